@@ -0,0 +1,202 @@
+package fantrax
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// AuctionBudgetSettings is the economy of a salary-cap (auction) draft
+type AuctionBudgetSettings struct {
+	Budget     float64 // Each team's draft budget
+	MinBid     float64 // Lowest allowed bid (default 1)
+	RosterSize int     // Players each team drafts
+}
+
+// AuctionBudgetSettingsFromLeagueInfo builds budget settings from the league's roster size
+//
+// Parameters:
+//   - info: League settings from GetLeagueInfo
+//   - budget: Each team's draft budget (league info does not include it)
+func AuctionBudgetSettingsFromLeagueInfo(info *LeagueInfo, budget float64) AuctionBudgetSettings {
+	return AuctionBudgetSettings{Budget: budget, MinBid: 1, RosterSize: info.RosterInfo.MaxTotalPlayers}
+}
+
+// TeamBudget is one team's position in an auction draft
+type TeamBudget struct {
+	TeamID    string  `json:"teamId"`
+	Spent     float64 `json:"spent"`
+	Remaining float64 `json:"remaining"`
+	Players   int     `json:"players"`   // Players won so far
+	SlotsLeft int     `json:"slotsLeft"` // Roster spots still to fill
+	MaxBid    float64 `json:"maxBid"`    // Most the team can bid and still fill its roster at the minimum bid
+}
+
+// auctionSale is a player won in the draft
+type auctionSale struct {
+	teamID string
+	price  float64
+}
+
+// BudgetBoard tracks each team's remaining budget, roster spots, and maximum bid during a
+// live auction draft. It is safe for concurrent use, so one goroutine can record sales from
+// the draft while others read the board.
+type BudgetBoard struct {
+	settings AuctionBudgetSettings
+
+	mu    sync.Mutex
+	order []string // Team IDs in the order they were added
+	sales map[string]auctionSale
+}
+
+// NewBudgetBoard creates a board for the given teams with nothing spent
+//
+// Parameters:
+//   - teamIDs: The league's teams, in the order the board lists them (e.g. DraftResults.DraftOrder)
+//   - settings: Budget, minimum bid, and roster size
+func NewBudgetBoard(teamIDs []string, settings AuctionBudgetSettings) *BudgetBoard {
+	if settings.MinBid <= 0 {
+		settings.MinBid = 1
+	}
+	return &BudgetBoard{
+		settings: settings,
+		order:    append([]string(nil), teamIDs...),
+		sales:    make(map[string]auctionSale),
+	}
+}
+
+// Record adds a won player to the board. Recording a player again replaces the earlier sale,
+// so a corrected price or winner can be entered the same way.
+//
+// Parameters:
+//   - teamID: The winning team
+//   - playerID: The player won
+//   - price: The winning bid
+//
+// Returns an error, without recording the sale, if the team can't afford the bid.
+func (b *BudgetBoard) Record(teamID, playerID string, price float64) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.hasTeam(teamID) {
+		b.order = append(b.order, teamID)
+	}
+	previous, resale := b.sales[playerID]
+	delete(b.sales, playerID)
+	if budget := b.teamBudget(teamID); price > budget.MaxBid {
+		if resale {
+			b.sales[playerID] = previous
+		}
+		return fmt.Errorf("team %s can bid at most $%g, not $%g for player %s", teamID, budget.MaxBid, price, playerID)
+	}
+	b.sales[playerID] = auctionSale{teamID: teamID, price: price}
+	return nil
+}
+
+// Sync records the draft's picks that aren't on the board yet
+//
+// Draft results say who won each player but not the price, so prices come from the caller,
+// e.g. bids entered from the draft room as players are won.
+//
+// Parameters:
+//   - results: The draft's results so far (from GetDraftResults)
+//   - prices: Winning bids by player ID
+//
+// Returns the picks left off the board because prices has no price for them.
+func (b *BudgetBoard) Sync(results *DraftResults, prices map[string]float64) ([]DraftPick, error) {
+	var unpriced []DraftPick
+	for _, pick := range results.DraftPicks {
+		if pick.PlayerID == "" || b.recorded(pick.TeamID, pick.PlayerID, prices) {
+			continue
+		}
+		price, ok := prices[pick.PlayerID]
+		if !ok {
+			unpriced = append(unpriced, pick)
+			continue
+		}
+		if err := b.Record(pick.TeamID, pick.PlayerID, price); err != nil {
+			return unpriced, err
+		}
+	}
+	return unpriced, nil
+}
+
+// recorded reports whether a pick is already on the board at its current price
+func (b *BudgetBoard) recorded(teamID, playerID string, prices map[string]float64) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	sale, ok := b.sales[playerID]
+	if !ok || sale.teamID != teamID {
+		return false
+	}
+	price, priced := prices[playerID]
+	return !priced || price == sale.price
+}
+
+// Team returns one team's budget
+func (b *BudgetBoard) Team(teamID string) (TeamBudget, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.hasTeam(teamID) {
+		return TeamBudget{}, false
+	}
+	return b.teamBudget(teamID), true
+}
+
+// Teams returns every team's budget in board order
+func (b *BudgetBoard) Teams() []TeamBudget {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	budgets := make([]TeamBudget, 0, len(b.order))
+	for _, teamID := range b.order {
+		budgets = append(budgets, b.teamBudget(teamID))
+	}
+	return budgets
+}
+
+// String formats the board as one line per team
+func (b *BudgetBoard) String() string {
+	var sb strings.Builder
+	for _, t := range b.Teams() {
+		fmt.Fprintf(&sb, "%s: $%g left, %d slots, max bid $%g\n", t.TeamID, t.Remaining, t.SlotsLeft, t.MaxBid)
+	}
+	return sb.String()
+}
+
+func (b *BudgetBoard) hasTeam(teamID string) bool {
+	for _, id := range b.order {
+		if id == teamID {
+			return true
+		}
+	}
+	return false
+}
+
+// teamBudget totals a team's sales; the caller holds the lock
+func (b *BudgetBoard) teamBudget(teamID string) TeamBudget {
+	budget := TeamBudget{TeamID: teamID}
+	for _, sale := range b.sales {
+		if sale.teamID == teamID {
+			budget.Spent += sale.price
+			budget.Players++
+		}
+	}
+	budget.Remaining = b.settings.Budget - budget.Spent
+	if b.settings.RosterSize > 0 {
+		budget.SlotsLeft = b.settings.RosterSize - budget.Players
+		if budget.SlotsLeft < 0 {
+			budget.SlotsLeft = 0
+		}
+	}
+	// Every slot after this one still needs at least the minimum bid
+	if budget.SlotsLeft > 0 || b.settings.RosterSize == 0 {
+		budget.MaxBid = budget.Remaining
+		if budget.SlotsLeft > 1 {
+			budget.MaxBid -= b.settings.MinBid * float64(budget.SlotsLeft-1)
+		}
+		if budget.MaxBid < 0 {
+			budget.MaxBid = 0
+		}
+	}
+	return budget
+}
@@ -0,0 +1,39 @@
+package fantrax
+
+import "testing"
+
+func TestBudgetBoard(t *testing.T) {
+	board := NewBudgetBoard([]string{"a", "b"}, AuctionBudgetSettings{Budget: 100, RosterSize: 3})
+
+	if err := board.Record("a", "p1", 60); err != nil {
+		t.Fatal(err)
+	}
+	a, _ := board.Team("a")
+	if a.Remaining != 40 || a.SlotsLeft != 2 || a.MaxBid != 39 {
+		t.Errorf("after one sale a = %+v, want $40 left, 2 slots, max bid $39", a)
+	}
+	if err := board.Record("a", "p2", 40); err == nil {
+		t.Error("a bid leaving no money for the last slot was accepted")
+	}
+
+	results := &DraftResults{DraftPicks: []DraftPick{
+		{TeamID: "a", PlayerID: "p1"},
+		{TeamID: "b", PlayerID: "p3"},
+		{TeamID: "b", PlayerID: "p4"},
+	}}
+	unpriced, err := board.Sync(results, map[string]float64{"p3": 25})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unpriced) != 1 || unpriced[0].PlayerID != "p4" {
+		t.Errorf("unpriced = %+v, want p4", unpriced)
+	}
+	b, _ := board.Team("b")
+	if b.Spent != 25 || b.Players != 1 || b.MaxBid != 74 {
+		t.Errorf("b = %+v, want $25 spent on one player and a $74 max bid", b)
+	}
+	a, _ = board.Team("a")
+	if a.Spent != 60 {
+		t.Errorf("syncing changed a's recorded sale: %+v", a)
+	}
+}
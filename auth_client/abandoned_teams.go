@@ -0,0 +1,137 @@
+package auth_client
+
+import (
+	"fmt"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// Signals that a team may be abandoned
+const (
+	SignalNoLineupChanges  = "NO_LINEUP_CHANGES" // No lineup change in the last IdlePeriods periods
+	SignalInactiveStarters = "INACTIVE_STARTERS" // Injured, suspended, inactive, or minor league players in active slots
+	SignalNoTransactions   = "NO_TRANSACTIONS"   // No claims, drops, or trades all season
+)
+
+// DefaultIdlePeriods is how many periods without a lineup change count as idle
+const DefaultIdlePeriods = 3
+
+// AbandonedTeamOptions configures FindAbandonedTeams
+type AbandonedTeamOptions struct {
+	CurrentPeriod int // The current scoring period; GetAbandonedTeams fills it in when 0
+	IdlePeriods   int // Periods without a lineup change before a team counts as idle; 0 = DefaultIdlePeriods
+	MinSignals    int // Signals a team needs to be flagged; 0 = 2
+}
+
+// AbandonedTeam is a team that shows signs its manager has stopped playing
+type AbandonedTeam struct {
+	TeamID           string   `json:"teamId"`
+	TeamName         string   `json:"teamName"`
+	Signals          []string `json:"signals"`
+	LastLineupChange int      `json:"lastLineupChange"` // Period of the latest lineup change; 0 for none
+	InactiveStarters []string `json:"inactiveStarters,omitempty"`
+	Transactions     int      `json:"transactions"`
+}
+
+// GetAbandonedTeams checks every team's current roster, transactions, and lineup change history
+// for signs of an abandoned team
+func (c *Client) GetAbandonedTeams(opts AbandonedTeamOptions) ([]AbandonedTeam, error) {
+	if opts.CurrentPeriod == 0 {
+		period, err := c.GetCurrentPeriod()
+		if err != nil {
+			return nil, err
+		}
+		opts.CurrentPeriod = period
+	}
+
+	rosters, teams, err := c.GetAllTeamRosters("")
+	if err != nil {
+		return nil, err
+	}
+	txs, err := c.GetAllTransactionsIncludingTrades()
+	if err != nil {
+		return nil, err
+	}
+	lineups, err := c.getLineupChanges()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lineup changes: %w", err)
+	}
+	return FindAbandonedTeams(teams, rosters, append(txs, lineups...), opts), nil
+}
+
+// FindAbandonedTeams flags teams showing at least opts.MinSignals of: no lineup change in the
+// last opts.IdlePeriods periods, injured or inactive players left in active slots, and no
+// transactions all season
+//
+// Parameters:
+//   - teams: The league's teams
+//   - rosters: Current rosters keyed by team ID; a team without one isn't checked for inactive starters
+//   - txs: The season's transactions including lineup changes
+//   - opts: The current period and thresholds
+//
+// Returns the flagged teams in league order.
+func FindAbandonedTeams(teams []models.FantasyTeam, rosters map[string]*models.TeamRoster, txs []models.Transaction, opts AbandonedTeamOptions) []AbandonedTeam {
+	if opts.IdlePeriods <= 0 {
+		opts.IdlePeriods = DefaultIdlePeriods
+	}
+	if opts.MinSignals <= 0 {
+		opts.MinSignals = 2
+	}
+
+	lastLineup := make(map[string]int)
+	moves := make(map[string]int)
+	for _, tx := range txs {
+		if isLineupChange(tx) {
+			if tx.Period > lastLineup[tx.TeamID] {
+				lastLineup[tx.TeamID] = tx.Period
+			}
+			continue
+		}
+		for _, id := range []string{tx.TeamID, tx.FromTeamID, tx.ToTeamID} {
+			if id != "" {
+				moves[id]++
+			}
+		}
+	}
+
+	var flagged []AbandonedTeam
+	for _, team := range teams {
+		candidate := AbandonedTeam{
+			TeamID:           team.ID,
+			TeamName:         team.Name,
+			LastLineupChange: lastLineup[team.ID],
+			Transactions:     moves[team.ID],
+		}
+		if opts.CurrentPeriod-candidate.LastLineupChange >= opts.IdlePeriods {
+			candidate.Signals = append(candidate.Signals, SignalNoLineupChanges)
+		}
+		if roster := rosters[team.ID]; roster != nil {
+			for _, player := range roster.ActiveRoster {
+				if isInactiveStarter(player) {
+					candidate.InactiveStarters = append(candidate.InactiveStarters, player.Name)
+				}
+			}
+		}
+		if len(candidate.InactiveStarters) > 0 {
+			candidate.Signals = append(candidate.Signals, SignalInactiveStarters)
+		}
+		if candidate.Transactions == 0 {
+			candidate.Signals = append(candidate.Signals, SignalNoTransactions)
+		}
+		if len(candidate.Signals) >= opts.MinSignals {
+			flagged = append(flagged, candidate)
+		}
+	}
+	return flagged
+}
+
+// isInactiveStarter reports whether an active player can't play: on the Injured List, out
+// indefinitely, suspended, inactive, or in the minor leagues. Day-to-day players don't count.
+func isInactiveStarter(player models.RosterPlayer) bool {
+	for _, icon := range []string{models.IconInjuredList, models.IconOutIndefinitely, models.IconSuspended, models.IconInactive, models.IconMinorLeagues} {
+		if models.HasIcon(player.Icons, icon) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package auth_client
+
+import (
+	"testing"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestFindAbandonedTeams(t *testing.T) {
+	teams := []models.FantasyTeam{{ID: "a", Name: "Aces"}, {ID: "b", Name: "Bats"}, {ID: "c", Name: "Cats"}}
+	rosters := map[string]*models.TeamRoster{
+		"a": {ActiveRoster: []models.RosterPlayer{{Name: "Hurt", Icons: []models.PlayerIcon{{TypeID: models.IconInjuredList}}}}},
+		"b": {ActiveRoster: []models.RosterPlayer{{Name: "Sore", Icons: []models.PlayerIcon{{TypeID: models.IconDayToDay}}}}},
+	}
+	txs := []models.Transaction{
+		{Type: "LINEUP_CHANGE", TeamID: "a", Period: 2},
+		{Type: "LINEUP_CHANGE", TeamID: "b", Period: 9},
+		{Type: "LINEUP_CHANGE", TeamID: "c", Period: 9},
+		{Type: "TRADE", FromTeamID: "c", ToTeamID: "b"},
+	}
+
+	flagged := FindAbandonedTeams(teams, rosters, txs, AbandonedTeamOptions{CurrentPeriod: 10})
+	if len(flagged) != 1 || flagged[0].TeamID != "a" {
+		t.Fatalf("expected only a to be flagged, got %+v", flagged)
+	}
+	if len(flagged[0].Signals) != 3 || flagged[0].LastLineupChange != 2 || flagged[0].InactiveStarters[0] != "Hurt" {
+		t.Errorf("unexpected signals: %+v", flagged[0])
+	}
+}
@@ -0,0 +1,198 @@
+package auth_client
+
+import (
+	"fmt"
+	"sort"
+)
+
+// AlternativeStandingsTeam is one team's record under the normal head-to-head schedule and
+// under the all-play and median formats some leagues track alongside it
+type AlternativeStandingsTeam struct {
+	TeamID    string  `json:"teamId"`
+	Name      string  `json:"name"`
+	PointsFor float64 `json:"pointsFor"`
+
+	// Head-to-head record from the actual schedule, and its rank
+	Rank   int `json:"rank"`
+	Wins   int `json:"wins"`
+	Losses int `json:"losses"`
+	Ties   int `json:"ties"`
+
+	// All-play: each period, the team's score is compared with every other team's score
+	AllPlayRank   int     `json:"allPlayRank"`
+	AllPlayWins   int     `json:"allPlayWins"`
+	AllPlayLosses int     `json:"allPlayLosses"`
+	AllPlayTies   int     `json:"allPlayTies"`
+	AllPlayPct    float64 `json:"allPlayPct"`
+
+	// Median: one extra game per period, won by scoring above the league median
+	MedianWins   int `json:"medianWins"`
+	MedianLosses int `json:"medianLosses"`
+	MedianTies   int `json:"medianTies"`
+
+	// Head-to-head and median games together, as in leagues that play the median each week
+	CombinedRank   int `json:"combinedRank"`
+	CombinedWins   int `json:"combinedWins"`
+	CombinedLosses int `json:"combinedLosses"`
+	CombinedTies   int `json:"combinedTies"`
+}
+
+// AlternativeStandings holds the head-to-head, all-play, and median tables for a season
+type AlternativeStandings struct {
+	CompletedThrough int                        `json:"completedThrough"`
+	Teams            []AlternativeStandingsTeam `json:"teams"` // Ordered by head-to-head rank
+}
+
+// ByAllPlay returns the teams ordered by all-play rank
+func (s *AlternativeStandings) ByAllPlay() []AlternativeStandingsTeam {
+	teams := append([]AlternativeStandingsTeam(nil), s.Teams...)
+	sort.SliceStable(teams, func(i, j int) bool { return teams[i].AllPlayRank < teams[j].AllPlayRank })
+	return teams
+}
+
+// ByCombined returns the teams ordered by combined head-to-head and median rank
+func (s *AlternativeStandings) ByCombined() []AlternativeStandingsTeam {
+	teams := append([]AlternativeStandingsTeam(nil), s.Teams...)
+	sort.SliceStable(teams, func(i, j int) bool { return teams[i].CombinedRank < teams[j].CombinedRank })
+	return teams
+}
+
+// ComputeAlternativeStandings computes all-play and median records from each period's
+// scores, next to the head-to-head record from the actual schedule
+//
+// All formats rank by win percentage (ties count as half a win), then by points for. In H2H
+// category leagues a period's score is the number of categories won.
+//
+// Parameters:
+//   - result: The season's matchups (e.g. from GetAllMatchups)
+//   - completedThrough: The last scoring period whose results count (0 = every period with scores)
+func ComputeAlternativeStandings(result *AllMatchupsResult, completedThrough int) *AlternativeStandings {
+	completed := completedMatchups(result.Matchups, completedThrough)
+
+	standings := &AlternativeStandings{CompletedThrough: completedThrough}
+	index := make(map[string]int)
+	for _, t := range rankMatchupStandings(result, completed) {
+		index[t.TeamID] = len(standings.Teams)
+		standings.Teams = append(standings.Teams, AlternativeStandingsTeam{
+			TeamID:    t.TeamID,
+			Name:      t.Name,
+			PointsFor: t.PointsFor,
+			Rank:      t.Rank,
+			Wins:      t.Wins,
+			Losses:    t.Losses,
+			Ties:      t.Ties,
+		})
+	}
+
+	type score struct {
+		teamID string
+		total  float64
+	}
+	byPeriod := make(map[int][]score)
+	for _, m := range completed {
+		byPeriod[m.ScoringPeriod] = append(byPeriod[m.ScoringPeriod],
+			score{m.AwayTeam.TeamID, m.AwayTeam.Total}, score{m.HomeTeam.TeamID, m.HomeTeam.Total})
+	}
+
+	for _, scores := range byPeriod {
+		totals := make([]float64, len(scores))
+		for i, s := range scores {
+			totals[i] = s.total
+		}
+		median := medianOf(totals)
+
+		for _, s := range scores {
+			team := &standings.Teams[index[s.teamID]]
+			for _, other := range scores {
+				if other.teamID == s.teamID {
+					continue
+				}
+				switch compareTotals(s.total, other.total) {
+				case 1:
+					team.AllPlayWins++
+				case -1:
+					team.AllPlayLosses++
+				default:
+					team.AllPlayTies++
+				}
+			}
+			switch compareTotals(s.total, median) {
+			case 1:
+				team.MedianWins++
+			case -1:
+				team.MedianLosses++
+			default:
+				team.MedianTies++
+			}
+		}
+	}
+
+	for i := range standings.Teams {
+		t := &standings.Teams[i]
+		t.AllPlayPct = winPct(t.AllPlayWins, t.AllPlayLosses, t.AllPlayTies)
+		t.CombinedWins = t.Wins + t.MedianWins
+		t.CombinedLosses = t.Losses + t.MedianLosses
+		t.CombinedTies = t.Ties + t.MedianTies
+	}
+	rankAlternative(standings.Teams, func(t *AlternativeStandingsTeam) float64 { return t.AllPlayPct },
+		func(t *AlternativeStandingsTeam, rank int) { t.AllPlayRank = rank })
+	rankAlternative(standings.Teams, func(t *AlternativeStandingsTeam) float64 {
+		return winPct(t.CombinedWins, t.CombinedLosses, t.CombinedTies)
+	}, func(t *AlternativeStandingsTeam, rank int) { t.CombinedRank = rank })
+
+	return standings
+}
+
+// GetAlternativeStandings fetches the schedule and computes all-play and median standings
+// through the last completed period
+func (c *Client) GetAlternativeStandings() (*AlternativeStandings, error) {
+	matchups, err := c.GetAllMatchups()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get matchups: %w", err)
+	}
+	currentPeriod, err := c.GetCurrentPeriod()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current period: %w", err)
+	}
+	if currentPeriod <= 1 {
+		return ComputeAlternativeStandings(&AllMatchupsResult{Teams: matchups.Teams}, 0), nil
+	}
+	return ComputeAlternativeStandings(matchups, currentPeriod-1), nil
+}
+
+// rankAlternative assigns ranks by pct, then points for, without reordering teams
+func rankAlternative(teams []AlternativeStandingsTeam, pct func(*AlternativeStandingsTeam) float64, set func(*AlternativeStandingsTeam, int)) {
+	order := make([]int, len(teams))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := &teams[order[i]], &teams[order[j]]
+		if pa, pb := pct(a), pct(b); pa != pb {
+			return pa > pb
+		}
+		return a.PointsFor > b.PointsFor
+	})
+	for rank, i := range order {
+		set(&teams[i], rank+1)
+	}
+}
+
+// winPct returns a win percentage with ties counted as half a win
+func winPct(wins, losses, ties int) float64 {
+	return standingPct(TeamStanding{Wins: wins, Losses: losses, Ties: ties})
+}
+
+// medianOf returns the median of values, averaging the middle two for an even count
+func medianOf(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
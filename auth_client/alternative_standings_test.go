@@ -0,0 +1,44 @@
+package auth_client
+
+import "testing"
+
+func TestComputeAlternativeStandings(t *testing.T) {
+	result := &AllMatchupsResult{
+		Matchups: []Matchup{
+			// Period 1 scores: a 100, b 90, c 80, d 70 (median 85)
+			matchup(1, "a", 100, "b", 90),
+			matchup(1, "c", 80, "d", 70),
+			// Period 2 scores: b 120, c 110, a 60, d 50 (median 85)
+			matchup(2, "a", 60, "c", 110),
+			matchup(2, "b", 120, "d", 50),
+		},
+		Teams: map[string]FantasyTeam{"a": {Name: "A"}, "b": {Name: "B"}, "c": {Name: "C"}, "d": {Name: "D"}},
+	}
+
+	standings := ComputeAlternativeStandings(result, 2)
+
+	teams := make(map[string]AlternativeStandingsTeam)
+	for _, team := range standings.Teams {
+		teams[team.TeamID] = team
+	}
+
+	b := teams["b"]
+	if b.Wins != 1 || b.Losses != 1 {
+		t.Errorf("b head-to-head: got %d-%d, want 1-1", b.Wins, b.Losses)
+	}
+	if b.AllPlayWins != 5 || b.AllPlayLosses != 1 || b.AllPlayRank != 1 {
+		t.Errorf("b all-play: got %d-%d rank %d, want 5-1 rank 1", b.AllPlayWins, b.AllPlayLosses, b.AllPlayRank)
+	}
+	if b.MedianWins != 2 || b.CombinedWins != 3 || b.CombinedLosses != 1 {
+		t.Errorf("b median: got %d median wins, %d-%d combined", b.MedianWins, b.CombinedWins, b.CombinedLosses)
+	}
+
+	a := teams["a"]
+	if a.AllPlayWins != 4 || a.MedianWins != 1 || a.MedianLosses != 1 {
+		t.Errorf("a: got all-play wins %d, median %d-%d", a.AllPlayWins, a.MedianWins, a.MedianLosses)
+	}
+
+	if got := standings.ByAllPlay()[3].TeamID; got != "d" {
+		t.Errorf("last in all-play: got %s, want d", got)
+	}
+}
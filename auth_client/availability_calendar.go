@@ -0,0 +1,162 @@
+package auth_client
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// PlayerAvailability is the days one rostered player has games in a period
+type PlayerAvailability struct {
+	Player   models.RosterPlayer `json:"player"`
+	TeamID   string              `json:"teamId"` // Fantasy team
+	TeamName string              `json:"teamName"`
+	GameDays []time.Time         `json:"gameDays"` // Midnight UTC of each game's calendar date; a double-header appears twice
+
+	// Unknown is true when the schedule has no entry for the player's MLB team, so games
+	// could not be counted
+	Unknown bool `json:"unknown,omitempty"`
+}
+
+// Games returns the number of games the player has in the period
+func (p PlayerAvailability) Games() int {
+	return len(p.GameDays)
+}
+
+// Dead reports whether the player has no games in the period, making their roster spot dead
+func (p PlayerAvailability) Dead() bool {
+	return !p.Unknown && len(p.GameDays) == 0
+}
+
+// PlaysOn reports whether the player has a game on day's calendar date
+func (p PlayerAvailability) PlaysOn(day time.Time) bool {
+	day = truncateDay(day)
+	for _, d := range p.GameDays {
+		if d.Equal(day) {
+			return true
+		}
+	}
+	return false
+}
+
+// AvailabilityCalendar is every rostered player's game days in a period
+type AvailabilityCalendar struct {
+	Start   time.Time            `json:"start"`
+	End     time.Time            `json:"end"`
+	Days    []time.Time          `json:"days"`    // Each day of the period
+	Players []PlayerAvailability `json:"players"` // Ordered by fantasy team, then fewest games
+}
+
+// DeadRosterSpots returns the players with no games in the period
+func (c *AvailabilityCalendar) DeadRosterSpots() []PlayerAvailability {
+	var dead []PlayerAvailability
+	for _, p := range c.Players {
+		if p.Dead() {
+			dead = append(dead, p)
+		}
+	}
+	return dead
+}
+
+// ForTeam returns a fantasy team's players
+func (c *AvailabilityCalendar) ForTeam(teamID string) []PlayerAvailability {
+	var players []PlayerAvailability
+	for _, p := range c.Players {
+		if p.TeamID == teamID {
+			players = append(players, p)
+		}
+	}
+	return players
+}
+
+// BuildAvailabilityCalendar lists the days each active and reserve player has games between
+// start and end (inclusive), and which players have none
+//
+// IR and minors players are left out, as they don't take an active or reserve spot.
+//
+// Parameters:
+//   - rosters: Rosters keyed by fantasy team ID (e.g. from GetAllTeamRosters)
+//   - teams: The league's teams, for names and ordering; may be nil
+//   - schedule: Game dates of each MLB team, keyed by team abbreviation (from an MLB
+//     schedule; Fantrax does not publish one). Players whose team is missing are marked
+//     Unknown rather than dead, while players with no MLB team have no games.
+//   - start, end: The period's first and last days
+func BuildAvailabilityCalendar(rosters map[string]*models.TeamRoster, teams []models.FantasyTeam, schedule map[string][]time.Time, start, end time.Time) *AvailabilityCalendar {
+	first, last := truncateDay(start), truncateDay(end)
+	calendar := &AvailabilityCalendar{Start: first, End: last}
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		calendar.Days = append(calendar.Days, day)
+	}
+
+	names := make(map[string]string)
+	order := make(map[string]int)
+	for i, team := range teams {
+		names[team.ID] = team.Name
+		order[team.ID] = i
+	}
+
+	for teamID, roster := range rosters {
+		name := names[teamID]
+		players := append(append([]models.RosterPlayer(nil), roster.ActiveRoster...), roster.ReserveRoster...)
+		for _, player := range players {
+			entry := PlayerAvailability{Player: player, TeamID: teamID, TeamName: name}
+			dates, ok := schedule[player.TeamShortName]
+			if !ok && player.TeamShortName != "" {
+				entry.Unknown = true
+			}
+			for _, date := range dates {
+				if day := truncateDay(date); !day.Before(first) && !day.After(last) {
+					entry.GameDays = append(entry.GameDays, day)
+				}
+			}
+			sort.Slice(entry.GameDays, func(i, j int) bool { return entry.GameDays[i].Before(entry.GameDays[j]) })
+			calendar.Players = append(calendar.Players, entry)
+		}
+	}
+
+	sort.Slice(calendar.Players, func(i, j int) bool {
+		a, b := calendar.Players[i], calendar.Players[j]
+		if a.TeamID != b.TeamID {
+			oa, okA := order[a.TeamID]
+			ob, okB := order[b.TeamID]
+			if okA && okB {
+				return oa < ob
+			}
+			if okA != okB {
+				return okA
+			}
+			return a.TeamID < b.TeamID
+		}
+		if a.Games() != b.Games() {
+			return a.Games() < b.Games()
+		}
+		return a.Player.Name < b.Player.Name
+	})
+	return calendar
+}
+
+// GetAvailabilityCalendar fetches every team's roster for a scoring period and lists the days
+// each player has games, flagging dead roster spots
+//
+// Parameters:
+//   - period: The scoring period
+//   - schedule: Game dates of each MLB team, keyed by team abbreviation
+func (c *Client) GetAvailabilityCalendar(period int, schedule map[string][]time.Time) (*AvailabilityCalendar, error) {
+	matchups, err := c.GetAllMatchups()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule: %w", err)
+	}
+	start, end, err := PeriodDateRange(matchups.Matchups, period)
+	if err != nil {
+		return nil, err
+	}
+
+	rosters, teams, err := c.GetAllTeamRosters(strconv.Itoa(period))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rosters: %w", err)
+	}
+	return BuildAvailabilityCalendar(rosters, teams, schedule, start, end), nil
+}
@@ -0,0 +1,51 @@
+package auth_client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestBuildAvailabilityCalendar(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, time.July, d, 19, 5, 0, 0, time.UTC) }
+	rosters := map[string]*models.TeamRoster{
+		"t1": {
+			ActiveRoster:   []models.RosterPlayer{{PlayerID: "p1", Name: "Slugger", TeamShortName: "NYY"}},
+			ReserveRoster:  []models.RosterPlayer{{PlayerID: "p2", Name: "Benchwarmer", TeamShortName: "SEA"}},
+			InjuredReserve: []models.RosterPlayer{{PlayerID: "p3", Name: "Hurt", TeamShortName: "NYY"}},
+		},
+		"t2": {
+			ActiveRoster: []models.RosterPlayer{{PlayerID: "p4", Name: "Traveler", TeamShortName: "XYZ"}},
+		},
+	}
+	schedule := map[string][]time.Time{
+		"NYY": {day(13), day(14), day(14), day(21)}, // The 21st falls after the period
+		"SEA": {day(10)},                            // All-Star break during the period
+	}
+	teams := []models.FantasyTeam{{ID: "t2", Name: "Two"}, {ID: "t1", Name: "One"}}
+
+	calendar := BuildAvailabilityCalendar(rosters, teams, schedule, day(14), day(20))
+	if len(calendar.Days) != 7 {
+		t.Fatalf("expected 7 days, got %d", len(calendar.Days))
+	}
+	if len(calendar.Players) != 3 {
+		t.Fatalf("expected IR players to be left out, got %d players", len(calendar.Players))
+	}
+	if calendar.Players[0].TeamName != "Two" || !calendar.Players[0].Unknown || calendar.Players[0].Dead() {
+		t.Errorf("expected the unscheduled team first and not dead: %+v", calendar.Players[0])
+	}
+
+	team := calendar.ForTeam("t1")
+	if team[0].Player.PlayerID != "p2" || !team[0].Dead() {
+		t.Errorf("expected the player with no games first: %+v", team[0])
+	}
+	if team[1].Games() != 2 || !team[1].PlaysOn(day(14)) || team[1].PlaysOn(day(15)) {
+		t.Errorf("expected a double-header on the 14th only: %+v", team[1].GameDays)
+	}
+
+	dead := calendar.DeadRosterSpots()
+	if len(dead) != 1 || dead[0].Player.PlayerID != "p2" {
+		t.Errorf("unexpected dead roster spots: %+v", dead)
+	}
+}
@@ -0,0 +1,176 @@
+package auth_client
+
+import (
+	"regexp"
+	"sort"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// ghostTeamName matches the names leagues give a placeholder team
+var ghostTeamName = regexp.MustCompile(`(?i)^\s*(?:free\s+)?(?:agents?|bye|ghost|dummy|placeholder|tbd)(?:\s+team)?\s*$`)
+
+// GhostTeam is a placeholder team that exists to balance the schedule rather than for a
+// manager, such as a team named "Agents" that takes the bye each period
+type GhostTeam struct {
+	TeamID string `json:"teamId"`
+	Name   string `json:"name"`
+	Reason string `json:"reason"` // How it was recognized
+}
+
+// FindGhostTeam looks for the league's placeholder team in the setup
+//
+// A team is taken as the ghost team, in this order, if it is the only team with no owner, the
+// only team with a placeholder name such as "Agents", "Bye", or "Ghost", or the only team with a
+// bye in more than half of the periods that have matchups.
+//
+// Returns false if no single team fits.
+func FindGhostTeam(setup *models.LeagueSetupMatchups) (*GhostTeam, bool) {
+	var unowned, named []models.LeagueSetupTeam
+	for _, team := range setup.Teams {
+		if !hasOwner(team) {
+			unowned = append(unowned, team)
+		}
+		if ghostTeamName.MatchString(team.Name) {
+			named = append(named, team)
+		}
+	}
+	if len(unowned) == 1 {
+		return &GhostTeam{TeamID: unowned[0].TeamID, Name: unowned[0].Name, Reason: "no owner"}, true
+	}
+	if len(named) == 1 {
+		return &GhostTeam{TeamID: named[0].TeamID, Name: named[0].Name, Reason: "placeholder name"}, true
+	}
+
+	byes := make(map[string]int)
+	periods := 0
+	for _, pairs := range setup.Matchups {
+		if len(pairs) == 0 {
+			continue
+		}
+		periods++
+		for _, pair := range pairs {
+			if IsBye(pair) {
+				byes[pair.AwayTeamID]++
+			}
+		}
+	}
+	var frequent []string
+	for teamID, n := range byes {
+		if n*2 > periods {
+			frequent = append(frequent, teamID)
+		}
+	}
+	if len(frequent) == 1 {
+		if team := GetTeamByID(setup, frequent[0]); team != nil {
+			return &GhostTeam{TeamID: team.TeamID, Name: team.Name, Reason: "bye in most periods"}, true
+		}
+	}
+	return nil, false
+}
+
+// IsBye reports whether a matchup is a bye
+func IsBye(pair models.MatchupPair) bool {
+	return pair.HomeTeamID == ByeTeamID
+}
+
+// ByeMatchup returns the matchup giving a team a bye
+func ByeMatchup(teamID string) models.MatchupPair {
+	return models.MatchupPair{AwayTeamID: teamID, HomeTeamID: ByeTeamID}
+}
+
+// FillByes adds a bye for every league team that has no matchup in pairs, so a period's
+// matchups cover the whole league
+//
+// Returns the matchups with the byes appended, in setup team order.
+func FillByes(setup *models.LeagueSetupMatchups, pairs []models.MatchupPair) []models.MatchupPair {
+	scheduled := make(map[string]bool)
+	for _, pair := range pairs {
+		scheduled[pair.AwayTeamID] = true
+		scheduled[pair.HomeTeamID] = true
+	}
+	filled := append([]models.MatchupPair(nil), pairs...)
+	for _, team := range setup.Teams {
+		if !scheduled[team.TeamID] {
+			filled = append(filled, ByeMatchup(team.TeamID))
+		}
+	}
+	return filled
+}
+
+// TeamByeSummary is how byes and the ghost team fall on one team's schedule
+type TeamByeSummary struct {
+	TeamID       string `json:"teamId"`
+	TeamName     string `json:"teamName"`
+	Games        int    `json:"games"`                  // Matchups against real teams
+	ByePeriods   []int  `json:"byePeriods,omitempty"`   // Periods with a bye
+	GhostPeriods []int  `json:"ghostPeriods,omitempty"` // Periods against the ghost team
+}
+
+// ByeReport is the effect of byes and a ghost team on the league's schedule
+type ByeReport struct {
+	GhostTeamID string           `json:"ghostTeamId,omitempty"`
+	Teams       []TeamByeSummary `json:"teams"`  // Real teams, in setup order
+	Uneven      bool             `json:"uneven"` // Real teams' game counts differ by more than one
+}
+
+// ReportByes summarizes each real team's byes, games against the ghost team, and games
+// against real teams across the setup's matchups. A matchup against the ghost team counts as a
+// bye for scheduling purposes, since neither manager plays a real opponent.
+//
+// Parameters:
+//   - setup: The league setup from GetLeagueSetupMatchups
+//   - ghostTeamID: The placeholder team, e.g. from FindGhostTeam; empty if the league has none
+func ReportByes(setup *models.LeagueSetupMatchups, ghostTeamID string) *ByeReport {
+	report := &ByeReport{GhostTeamID: ghostTeamID}
+	index := make(map[string]int)
+	for _, team := range setup.Teams {
+		if team.TeamID == ghostTeamID {
+			continue
+		}
+		index[team.TeamID] = len(report.Teams)
+		report.Teams = append(report.Teams, TeamByeSummary{TeamID: team.TeamID, TeamName: team.Name})
+	}
+
+	periods := make([]int, 0, len(setup.Matchups))
+	for period := range setup.Matchups {
+		periods = append(periods, period)
+	}
+	sort.Ints(periods)
+	for _, period := range periods {
+		for _, pair := range setup.Matchups[period] {
+			away, awayOK := index[pair.AwayTeamID]
+			home, homeOK := index[pair.HomeTeamID]
+			switch {
+			case IsBye(pair) && awayOK:
+				report.Teams[away].ByePeriods = append(report.Teams[away].ByePeriods, period)
+			case ghostTeamID != "" && pair.HomeTeamID == ghostTeamID && awayOK:
+				report.Teams[away].GhostPeriods = append(report.Teams[away].GhostPeriods, period)
+			case ghostTeamID != "" && pair.AwayTeamID == ghostTeamID && homeOK:
+				report.Teams[home].GhostPeriods = append(report.Teams[home].GhostPeriods, period)
+			case awayOK && homeOK:
+				report.Teams[away].Games++
+				report.Teams[home].Games++
+			}
+		}
+	}
+
+	for i := range report.Teams {
+		for j := range report.Teams {
+			if report.Teams[i].Games-report.Teams[j].Games > 1 {
+				report.Uneven = true
+			}
+		}
+	}
+	return report
+}
+
+// hasOwner reports whether a team has an owner who has joined or been invited
+func hasOwner(team models.LeagueSetupTeam) bool {
+	for _, owner := range team.Owners {
+		if owner.JoinedLeague || owner.Email != "" {
+			return true
+		}
+	}
+	return false
+}
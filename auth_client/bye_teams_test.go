@@ -0,0 +1,45 @@
+package auth_client
+
+import (
+	"testing"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestByeHelpers(t *testing.T) {
+	joined := []models.TeamOwner{{JoinedLeague: true}}
+	setup := &models.LeagueSetupMatchups{
+		Teams: []models.LeagueSetupTeam{
+			{TeamID: "a", Name: "Aces", Owners: joined},
+			{TeamID: "b", Name: "Bats", Owners: joined},
+			{TeamID: "c", Name: "Cats", Owners: joined},
+			{TeamID: "g", Name: "Agents", Owners: joined},
+		},
+		Matchups: map[int][]models.MatchupPair{
+			1: {{AwayTeamID: "a", HomeTeamID: "b"}, {AwayTeamID: "c", HomeTeamID: "g"}},
+			2: {{AwayTeamID: "b", HomeTeamID: "c"}, ByeMatchup("a"), ByeMatchup("g")},
+		},
+	}
+
+	ghost, ok := FindGhostTeam(setup)
+	if !ok || ghost.TeamID != "g" || ghost.Reason != "placeholder name" {
+		t.Fatalf("unexpected ghost team: %+v", ghost)
+	}
+
+	report := ReportByes(setup, ghost.TeamID)
+	if len(report.Teams) != 3 || report.Uneven {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	aces, cats := report.Teams[0], report.Teams[2]
+	if aces.Games != 1 || len(aces.ByePeriods) != 1 || aces.ByePeriods[0] != 2 {
+		t.Errorf("unexpected summary for a: %+v", aces)
+	}
+	if cats.Games != 1 || len(cats.GhostPeriods) != 1 || cats.GhostPeriods[0] != 1 {
+		t.Errorf("unexpected summary for c: %+v", cats)
+	}
+
+	filled := FillByes(setup, []models.MatchupPair{{AwayTeamID: "a", HomeTeamID: "b"}})
+	if len(filled) != 3 || filled[1] != ByeMatchup("c") || filled[2] != ByeMatchup("g") {
+		t.Errorf("unexpected filled matchups: %+v", filled)
+	}
+}
@@ -0,0 +1,148 @@
+package auth_client
+
+import (
+	"strconv"
+	"strings"
+)
+
+// lowerIsBetterCategories lists common category short names where the smaller value wins the
+// category. It is only used for columns whose header carries no sort direction.
+var lowerIsBetterCategories = map[string]bool{
+	"ERA":  true,
+	"WHIP": true,
+	"GAA":  true,
+	"BB/9": true,
+	"H/9":  true,
+	"ER":   true,
+	"BS":   true,
+	"L":    true,
+	"TO":   true,
+}
+
+// matchupSummaryColumns are columns of a category matchup table that are not categories
+var matchupSummaryColumns = map[string]bool{
+	"score":  true,
+	"w-l-t":  true,
+	"record": true,
+	"pts":    true,
+}
+
+// scoringPeriodCaption reads the period number and first date from a matchup table caption
+// (e.g. "Scoring Period 4" with subCaption "(Mon Apr 14, 2025 - Sun Apr 20, 2025)"). ok is
+// false if the caption does not name a scoring period.
+func scoringPeriodCaption(table Table) (period int, date string, ok bool) {
+	if !strings.HasPrefix(table.Caption, "Scoring Period ") {
+		return 0, "", false
+	}
+	parts := strings.Split(table.Caption, " ")
+	if len(parts) >= 3 {
+		period, _ = strconv.Atoi(parts[2])
+	}
+	if len(table.SubCaption) > 2 {
+		date = strings.Trim(table.SubCaption, "()")
+		if idx := strings.Index(date, " - "); idx > 0 {
+			date = date[:idx]
+		}
+	}
+	return period, date, true
+}
+
+// categoryLowerIsBetter reports whether the smaller value wins a category column. Fantrax
+// gives stat columns the sort direction set for the category in the league, negative when
+// the smallest value ranks first; columns without one fall back to lowerIsBetterCategories.
+func categoryLowerIsBetter(header Cell, label string) bool {
+	if header.SortDirection != 0 {
+		return header.SortDirection < 0
+	}
+	return lowerIsBetterCategories[strings.ToUpper(label)]
+}
+
+// matchupSide is one team's half of a category matchup
+type matchupSide struct {
+	teamID string
+	labels []string
+	values map[string]float64
+}
+
+// parseCategoryMatchups reads an H2H category matchup table. Each team's category values
+// follow its team cell, labelled by the header cell in the same position. The two teams of a
+// matchup may share a row or sit on consecutive rows; either way the away team comes first.
+func parseCategoryMatchups(table Table, period int, date string) []Matchup {
+	var sides []matchupSide
+	lowerIsBetter := make(map[string]bool)
+	for _, row := range table.Rows {
+		var current *matchupSide
+		for i, cell := range row.Cells {
+			if cell.TeamID != "" {
+				sides = append(sides, matchupSide{teamID: cell.TeamID, values: make(map[string]float64)})
+				current = &sides[len(sides)-1]
+				continue
+			}
+			if current == nil || i >= len(table.Header.Cells) {
+				continue
+			}
+			header := table.Header.Cells[i]
+			label := columnKey(header)
+			if label == "" || matchupSummaryColumns[strings.ToLower(label)] {
+				continue
+			}
+			value, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(cell.Content), ",", ""), 64)
+			if err != nil {
+				continue
+			}
+			current.labels = append(current.labels, label)
+			current.values[label] = value
+			lowerIsBetter[label] = categoryLowerIsBetter(header, label)
+		}
+	}
+
+	matchups := make([]Matchup, 0, len(sides)/2)
+	for i := 0; i+1 < len(sides); i += 2 {
+		away, home := sides[i], sides[i+1]
+		matchup := Matchup{
+			ScoringPeriod: period,
+			Date:          date,
+			AwayTeam:      MatchTeam{TeamID: away.teamID},
+			HomeTeam:      MatchTeam{TeamID: home.teamID},
+		}
+
+		for _, label := range away.labels {
+			homeValue, ok := home.values[label]
+			if !ok {
+				continue
+			}
+			result := CategoryResult{
+				Category:      label,
+				AwayValue:     away.values[label],
+				HomeValue:     homeValue,
+				LowerIsBetter: lowerIsBetter[label],
+			}
+
+			awayAhead := result.AwayValue > result.HomeValue
+			if result.LowerIsBetter {
+				awayAhead = result.AwayValue < result.HomeValue
+			}
+			switch {
+			case result.AwayValue == result.HomeValue:
+				matchup.AwayTeam.CategoryTies++
+				matchup.HomeTeam.CategoryTies++
+			case awayAhead:
+				result.WinnerTeamID = away.teamID
+				matchup.AwayTeam.CategoryWins++
+				matchup.HomeTeam.CategoryLosses++
+			default:
+				result.WinnerTeamID = home.teamID
+				matchup.HomeTeam.CategoryWins++
+				matchup.AwayTeam.CategoryLosses++
+			}
+			matchup.Categories = append(matchup.Categories, result)
+		}
+
+		// Totals are category wins so matchup winners compare the same way as in points leagues
+		matchup.AwayTeam.Total = float64(matchup.AwayTeam.CategoryWins)
+		matchup.HomeTeam.Total = float64(matchup.HomeTeam.CategoryWins)
+
+		matchups = append(matchups, matchup)
+	}
+	return matchups
+}
@@ -0,0 +1,148 @@
+package auth_client
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/pmurley/go-fantrax"
+	"github.com/pmurley/go-fantrax/redact"
+)
+
+// ClientOption is a functional option for configuring NewClient
+type ClientOption func(*Client)
+
+// WithCookies authenticates with the given Cookie header (e.g. "FX_RM=...") instead of
+// looking up cookies through GetCookies
+func WithCookies(cookies string) ClientOption {
+	return func(c *Client) {
+		c.Cookies = cookies
+		redact.AddSecret(cookies)
+	}
+}
+
+// WithRateLimiter makes the client wait on limiter before every request to Fantrax. Pass the
+// same limiter to the public client to limit both together.
+func WithRateLimiter(limiter *fantrax.RateLimiter) ClientOption {
+	return func(c *Client) {
+		c.RateLimiter = limiter
+	}
+}
+
+// WithCacheEncryption encrypts the response and cookie caches with cacheCipher, in place of
+// the key from FANTRAX_CACHE_KEY or FANTRAX_CACHE_KEY_COMMAND
+func WithCacheEncryption(cacheCipher *fantrax.CacheCipher) ClientOption {
+	return func(c *Client) {
+		c.CacheCipher = cacheCipher
+	}
+}
+
+// WithTransport sends requests through transport instead of the transport shared with other
+// clients (fantrax.SharedTransport), e.g. to use a proxy or record traffic
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(c *Client) {
+		c.Client.Transport = transport
+	}
+}
+
+// WithRequestTimeout sets how long a request may take, including reading the response. Zero
+// means no limit. The default is DefaultRequestTimeout.
+func WithRequestTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.Client.Timeout = timeout
+	}
+}
+
+// WithMaxResponseBytes sets the largest fxpa response the client reads. Larger responses fail
+// with ErrResponseTooLarge; the player pool then retries with smaller pages.
+func WithMaxResponseBytes(n int64) ClientOption {
+	return func(c *Client) {
+		c.MaxResponseBytes = n
+	}
+}
+
+// WithUserAgent replaces the User-Agent sent with every request and by the login browser
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		c.UserAgent = userAgent
+	}
+}
+
+// WithAppID identifies the tool making requests by appending appID to the User-Agent
+func WithAppID(appID string) ClientOption {
+	return func(c *Client) {
+		c.AppID = appID
+	}
+}
+
+// WithTerminology shows status and position names in the league's own labels (see
+// Terminology)
+func WithTerminology(terminology *Terminology) ClientOption {
+	return func(c *Client) {
+		c.Terminology = terminology
+	}
+}
+
+// WithLeagueFormat parses standings and matchups as the league's scoring format, from its
+// settings, instead of recognizing it from the table types (see Client.LeagueFormat)
+func WithLeagueFormat(format LeagueFormat) ClientOption {
+	return func(c *Client) {
+		c.LeagueFormat = format
+	}
+}
+
+// WithLogRedaction masks cookies, credentials, and email addresses in everything logged
+// through the standard logrus logger, by installing redact.Hook on it. The hook applies to
+// the whole program, not just this client, so it is left to the program to ask for it.
+func WithLogRedaction() ClientOption {
+	return func(c *Client) {
+		redact.Install()
+	}
+}
+
+// userAgent returns the User-Agent header for requests
+func (c *Client) userAgent() string {
+	return fantrax.UserAgent(c.UserAgent, c.AppID)
+}
+
+// WithPageProgress sets a callback for each page fetched by the operations that read every
+// page of a history or the player pool, so tools can show progress on long fetches
+func WithPageProgress(progress func(PageProgress)) ClientOption {
+	return func(c *Client) {
+		c.OnPageProgress = progress
+	}
+}
+
+// noRedirectClient returns an HTTP client that shares the client's transport, and so its
+// connection pool, but returns redirects instead of following them. NewClient builds it once.
+func (c *Client) noRedirectClient() *http.Client {
+	if c.redirectless != nil {
+		return c.redirectless
+	}
+	return newNoRedirectClient(&c.Client)
+}
+
+// newNoRedirectClient derives a client from base that doesn't follow redirects
+func newNoRedirectClient(base *http.Client) *http.Client {
+	return &http.Client{
+		Transport: base.Transport,
+		Timeout:   base.Timeout,
+		Jar:       base.Jar,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// cookies returns the Cookie header for requests. The header is registered with the redact
+// package so it is masked if it ever reaches an error or a log.
+func (c *Client) cookies() (string, error) {
+	if c.Cookies != "" {
+		return c.Cookies, nil
+	}
+	cookies, err := getCookies(CacheFile, c.userAgent(), c.CacheCipher)
+	if err != nil {
+		return "", redact.Error(err)
+	}
+	redact.AddSecret(cookies)
+	return cookies, nil
+}
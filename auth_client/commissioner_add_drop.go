@@ -8,6 +8,8 @@ import (
 	"net/http"
 	"strings"
 	"time"
+
+	"github.com/pmurley/go-fantrax/redact"
 )
 
 // CreateClaimDropRequest represents the request payload for commissioner add/drop operations
@@ -221,7 +223,7 @@ func (c *Client) CommissionerAddToMinors(
 //   - positionID: The position slot ID (e.g., PosC, PosSS, PosUtil)
 //   - statusID: The status ID (e.g., StatusActive, StatusReserve)
 //
-// The transaction date/time is automatically set to the current time in the user's timezone.
+// The transaction date/time is automatically set to the current time in UTC (see commissionerTxDateTime).
 // The function uses hard-coded defaults for experimental/unknown fields.
 //
 // Returns the raw API response or an error if the request failed.
@@ -232,22 +234,17 @@ func (c *Client) CommissionerAdd(
 	positionID string,
 	statusID string,
 ) (*CreateClaimDropResponse, error) {
-
-	// Auto-generate transaction date/time in user's timezone
-	// Format: "2006-01-02 15:04:05" (MySQL datetime format)
-	var txDateTime string
-	if c.UserInfo != nil && c.UserInfo.Timezone != "" {
-		loc, err := time.LoadLocation(c.UserInfo.Timezone)
-		if err != nil {
-			// Fallback to UTC if timezone is invalid
-			loc = time.UTC
+	if err := c.checkCommissioner(); err != nil {
+		return nil, err
+	}
+	if c.Contracts != nil {
+		if err := c.Contracts.CheckClaim(teamID, playerID); err != nil {
+			return nil, err
 		}
-		txDateTime = time.Now().In(loc).Format("2006-01-02 15:04:05")
-	} else {
-		// Fallback to UTC if no user info
-		txDateTime = time.Now().UTC().Format("2006-01-02 15:04:05")
 	}
 
+	txDateTime := commissionerTxDateTime(time.Now())
+
 	// Build minimal request with hard-coded defaults for unknown fields
 	bidAmount := 0
 	requestPayload := CreateClaimDropRequest{
@@ -288,7 +285,7 @@ func (c *Client) CommissionerAdd(
 
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("add API returned non-200 status code: %d, body: %s", resp.StatusCode, string(bodyBytes))
+		return nil, fmt.Errorf("add API returned non-200 status code: %d, body: %s", resp.StatusCode, redact.String(string(bodyBytes)))
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -301,6 +298,10 @@ func (c *Client) CommissionerAdd(
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal add response: %w", err)
 	}
+	if c.Contracts != nil && response.IsSuccess() {
+		c.Contracts.ApplyClaim(teamID, playerID)
+		c.saveContracts()
+	}
 
 	return &response, nil
 }
@@ -360,7 +361,7 @@ func (c *Client) CommissionerDropToWaivers(
 //   - playerID: The player ID (scorerId) to drop
 //   - toWaivers: If true, player goes to waivers; if false, player becomes a free agent immediately
 //
-// The transaction date/time is automatically set to the current time in the user's timezone.
+// The transaction date/time is automatically set to the current time in UTC (see commissionerTxDateTime).
 // The function uses hard-coded defaults for experimental/unknown fields.
 //
 // Returns the raw API response or an error if the request failed.
@@ -370,19 +371,12 @@ func (c *Client) CommissionerDrop(
 	playerID string,
 	toWaivers bool,
 ) (*CreateClaimDropResponse, error) {
-
-	// Auto-generate transaction date/time in user's timezone
-	var txDateTime string
-	if c.UserInfo != nil && c.UserInfo.Timezone != "" {
-		loc, err := time.LoadLocation(c.UserInfo.Timezone)
-		if err != nil {
-			loc = time.UTC
-		}
-		txDateTime = time.Now().In(loc).Format("2006-01-02 15:04:05")
-	} else {
-		txDateTime = time.Now().UTC().Format("2006-01-02 15:04:05")
+	if err := c.checkCommissioner(); err != nil {
+		return nil, err
 	}
 
+	txDateTime := commissionerTxDateTime(time.Now())
+
 	// Determine drop destination status ID
 	dropStatusID := DropToFreeAgent
 	if toWaivers {
@@ -427,7 +421,7 @@ func (c *Client) CommissionerDrop(
 
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("drop API returned non-200 status code: %d, body: %s", resp.StatusCode, string(bodyBytes))
+		return nil, fmt.Errorf("drop API returned non-200 status code: %d, body: %s", resp.StatusCode, redact.String(string(bodyBytes)))
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -440,6 +434,10 @@ func (c *Client) CommissionerDrop(
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal drop response: %w", err)
 	}
+	if c.Contracts != nil && response.IsSuccess() {
+		c.Contracts.ReleasePlayer(playerID)
+		c.saveContracts()
+	}
 
 	return &response, nil
 }
@@ -0,0 +1,107 @@
+package auth_client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// RoleCommissioner is the role Fantrax lists in fxpa responses for the league's commissioners
+const RoleCommissioner = "COMMISSIONER"
+
+// ErrNotCommissioner is returned by commissioner-only operations when VerifyCommissioner is
+// set and the logged-in user is not a commissioner of the league
+var ErrNotCommissioner = errors.New("logged-in user is not a commissioner of this league")
+
+// leagueRoles holds the league roles from the most recent fxpa response that listed them.
+// A nil *leagueRoles records nothing.
+type leagueRoles struct {
+	mu    sync.Mutex
+	roles []string
+}
+
+func (r *leagueRoles) get() []string {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.roles...)
+}
+
+func (r *leagueRoles) set(roles []string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.roles = roles
+	r.mu.Unlock()
+}
+
+// recordRoles remembers the league roles listed in an fxpa response body. Responses
+// without a roles array leave the recorded roles unchanged.
+func (c *Client) recordRoles(body []byte) {
+	var envelope struct {
+		Roles []string `json:"roles"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil || envelope.Roles == nil {
+		return
+	}
+	c.roles.set(envelope.Roles)
+}
+
+// Roles returns the logged-in user's roles in the league (e.g. "LEAGUE_MEMBER",
+// "COMMISSIONER"), as reported by the most recent fxpa response. If no response has reported
+// roles yet, the standings are fetched to find them.
+func (c *Client) Roles() ([]string, error) {
+	if roles := c.roles.get(); len(roles) > 0 {
+		return roles, nil
+	}
+
+	if _, err := c.GetStandingsRaw(); err != nil {
+		return nil, fmt.Errorf("failed to fetch league roles: %w", err)
+	}
+	roles := c.roles.get()
+	if len(roles) == 0 {
+		return nil, fmt.Errorf("fantrax did not report league roles")
+	}
+	return roles, nil
+}
+
+// IsCommissioner reports whether the logged-in user is a commissioner (or co-commissioner)
+// of the league
+func (c *Client) IsCommissioner() (bool, error) {
+	roles, err := c.Roles()
+	if err != nil {
+		return false, err
+	}
+	for _, role := range roles {
+		if strings.Contains(strings.ToUpper(role), RoleCommissioner) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// RequireCommissioner returns ErrNotCommissioner if the logged-in user is not a
+// commissioner of the league, so tooling can stop before attempting commissioner actions
+func (c *Client) RequireCommissioner() error {
+	ok, err := c.IsCommissioner()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("league %s: %w", c.LeagueID, ErrNotCommissioner)
+	}
+	return nil
+}
+
+// checkCommissioner runs RequireCommissioner when VerifyCommissioner is enabled
+func (c *Client) checkCommissioner() error {
+	if !c.VerifyCommissioner {
+		return nil
+	}
+	return c.RequireCommissioner()
+}
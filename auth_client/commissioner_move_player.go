@@ -0,0 +1,107 @@
+package auth_client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// movePlayerVerifyAttempts is how many times the destination roster is re-fetched
+// while waiting for a moved player to show up
+const movePlayerVerifyAttempts = 3
+
+// movePlayerVerifyDelay is the pause between verification attempts
+const movePlayerVerifyDelay = 2 * time.Second
+
+// MovePlayerResult describes the outcome of a CommissionerMovePlayer call
+type MovePlayerResult struct {
+	Period   int                  // The period the move was applied to
+	Trade    *CreateTradeResponse // The raw response from the trade endpoint
+	Verified bool                 // True once the player was confirmed on the destination roster
+	Player   *models.RosterPlayer // The player as they now appear on the destination roster (nil if unverified)
+}
+
+// CommissionerMovePlayer moves a player from one team to another (commissioner mode only)
+//
+// This is the one-call fix for a claim that landed on the wrong team. The move is executed
+// as a single-item commissioner trade for the current period, so the player keeps their
+// eligibility and is not exposed to waivers the way a drop followed by an add would be.
+// After the trade executes, the destination roster is re-fetched (bypassing the cache)
+// until the player appears on it.
+//
+// Parameters:
+//   - fromTeamID: The fantasy team ID the player is currently on
+//   - toTeamID: The fantasy team ID the player should be moved to
+//   - playerID: The player ID (scorerId) to move
+//
+// Returns the move result, or an error if the trade was rejected or the player could not be
+// found on the destination roster after the trade executed.
+func (c *Client) CommissionerMovePlayer(fromTeamID, toTeamID, playerID string) (*MovePlayerResult, error) {
+	if fromTeamID == "" || toTeamID == "" || playerID == "" {
+		return nil, fmt.Errorf("fromTeamID, toTeamID and playerID are all required")
+	}
+	if fromTeamID == toTeamID {
+		return nil, fmt.Errorf("player %s is already assigned to team %s", playerID, toTeamID)
+	}
+
+	period, err := c.GetCurrentPeriod()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current period: %w", err)
+	}
+
+	items := []TradeItem{
+		{PlayerID: playerID, FromTeamID: fromTeamID, ToTeamID: toTeamID},
+	}
+	trade, err := c.CommissionerTrade(period, items, "", false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to move player: %w", err)
+	}
+
+	result := &MovePlayerResult{
+		Period: period,
+		Trade:  trade,
+	}
+
+	if !trade.IsSuccess() {
+		return result, fmt.Errorf("move was not executed: %s %v", trade.GenericMessage, trade.DetailMessages)
+	}
+
+	player, err := c.waitForPlayerOnTeam(toTeamID, playerID)
+	if err != nil {
+		return result, err
+	}
+
+	result.Verified = true
+	result.Player = player
+	return result, nil
+}
+
+// waitForPlayerOnTeam polls a team's roster until the given player appears on it
+func (c *Client) waitForPlayerOnTeam(teamID, playerID string) (*models.RosterPlayer, error) {
+	// Cached responses would reflect the roster from before the move
+	fresh := c.uncached()
+
+	var lastErr error
+	for attempt := 0; attempt < movePlayerVerifyAttempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(movePlayerVerifyDelay)
+		}
+
+		roster, err := fresh.GetTeamRosterInfo("", teamID)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if player := roster.FindPlayer(playerID); player != nil {
+			return player, nil
+		}
+		lastErr = nil
+	}
+
+	if lastErr != nil {
+		return nil, fmt.Errorf("failed to verify player %s on team %s: %w", playerID, teamID, lastErr)
+	}
+	return nil, fmt.Errorf("player %s was not found on team %s after the move", playerID, teamID)
+}
@@ -7,6 +7,8 @@ import (
 	"io"
 	"net/http"
 	"time"
+
+	"github.com/pmurley/go-fantrax/redact"
 )
 
 // TradeItem represents a single player movement in a trade
@@ -48,6 +50,17 @@ func (r *CreateTradeResponse) IsError() bool {
 	return r.Code == "ERROR"
 }
 
+// commissionerTxDateTime formats the txDateTime of a commissioner transaction made at now
+// ("2006-01-02 15:04:05", MySQL datetime format)
+//
+// The time is sent in UTC. Earlier versions meant to use the user's timezone but looked up
+// UserInfo.Timezone, an offset such as "-0500", as a zone name; that always failed, so every
+// transaction so far has been sent in UTC. Which zone Fantrax reads the field in hasn't been
+// confirmed, so the observed behavior is kept.
+func commissionerTxDateTime(now time.Time) string {
+	return now.UTC().Format("2006-01-02 15:04:05")
+}
+
 // CommissionerTrade executes a trade between teams (commissioner mode only)
 //
 // This function is for commissioners/administrators to execute trades between any teams.
@@ -58,7 +71,7 @@ func (r *CreateTradeResponse) IsError() bool {
 //   - items: A slice of TradeItem structs, each representing one player movement
 //   - message: Optional trade message/notes (can be empty string)
 //
-// The transaction date/time is automatically set to the current time in the user's timezone.
+// The transaction date/time is automatically set to the current time in UTC (see commissionerTxDateTime).
 //
 // Returns the API response or an error if the request failed.
 func (c *Client) CommissionerTrade(
@@ -67,22 +80,20 @@ func (c *Client) CommissionerTrade(
 	message string,
 	override bool,
 ) (*CreateTradeResponse, error) {
+	if err := c.checkCommissioner(); err != nil {
+		return nil, err
+	}
 	if len(items) == 0 {
 		return nil, fmt.Errorf("at least one trade item is required")
 	}
-
-	// Auto-generate transaction date/time in user's timezone
-	var txDateTime string
-	if c.UserInfo != nil && c.UserInfo.Timezone != "" {
-		loc, err := time.LoadLocation(c.UserInfo.Timezone)
-		if err != nil {
-			loc = time.UTC
+	if c.Contracts != nil {
+		if err := c.Contracts.CheckTrade(items); err != nil {
+			return nil, err
 		}
-		txDateTime = time.Now().In(loc).Format("2006-01-02 15:04:05")
-	} else {
-		txDateTime = time.Now().UTC().Format("2006-01-02 15:04:05")
 	}
 
+	txDateTime := commissionerTxDateTime(time.Now())
+
 	// Build transactions map
 	// Each entry format: "SC,playerID,fromTeamID,toTeamID,"
 	// SC = Scorer Change, trailing comma is for an optional descriptor (not required)
@@ -125,7 +136,7 @@ func (c *Client) CommissionerTrade(
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("trade API returned non-200 status code: %d, body: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("trade API returned non-200 status code: %d, body: %s", resp.StatusCode, redact.String(string(body)))
 	}
 
 	var response CreateTradeResponse
@@ -133,6 +144,10 @@ func (c *Client) CommissionerTrade(
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal trade response: %w", err)
 	}
+	if c.Contracts != nil && response.IsSuccess() {
+		c.Contracts.ApplyTrade(items)
+		c.saveContracts()
+	}
 
 	return &response, nil
 }
@@ -0,0 +1,17 @@
+package auth_client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestCommissionerTxDateTime(t *testing.T) {
+	// Transactions are stamped in UTC whatever the user's timezone
+	user := &models.UserInfo{Timezone: "-0500", TimezoneCode: "America/Chicago"}
+	now := time.Date(2025, 4, 1, 21, 30, 0, 0, user.Location())
+	if got := commissionerTxDateTime(now); got != "2025-04-02 02:30:00" {
+		t.Errorf("commissionerTxDateTime = %q, want 2025-04-02 02:30:00", got)
+	}
+}
@@ -0,0 +1,372 @@
+package auth_client
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Contract is a player's contract in a salary-cap league
+type Contract struct {
+	PlayerID   string  `json:"playerId"`
+	PlayerName string  `json:"playerName,omitempty"`
+	TeamID     string  `json:"teamId"`    // Team holding the contract; empty once released
+	Salary     float64 `json:"salary"`    // Salary in StartYear
+	StartYear  int     `json:"startYear"` // First season of the contract
+	Years      int     `json:"years"`     // Length in seasons
+
+	// Escalators raise the salary each season after the first: by Escalator percent of the
+	// previous season's salary (0.1 = 10%), then by the flat Raise
+	Escalator float64 `json:"escalator,omitempty"`
+	Raise     float64 `json:"raise,omitempty"`
+}
+
+// EndYear returns the last season of the contract
+func (c Contract) EndYear() int {
+	return c.StartYear + c.Years - 1
+}
+
+// SalaryIn returns the contract's salary in a season, with escalators applied, and false if
+// the contract does not cover the season
+func (c Contract) SalaryIn(year int) (float64, bool) {
+	if year < c.StartYear || year > c.EndYear() {
+		return 0, false
+	}
+	salary := c.Salary
+	for y := c.StartYear; y < year; y++ {
+		salary = math.Round((salary*(1+c.Escalator)+c.Raise)*100) / 100
+	}
+	return salary, true
+}
+
+// TeamCapSpace is a team's payroll against the cap in one season
+type TeamCapSpace struct {
+	TeamID    string  `json:"teamId"`
+	Year      int     `json:"year"`
+	Payroll   float64 `json:"payroll"`
+	Cap       float64 `json:"cap"`
+	Space     float64 `json:"space"` // Negative when the team is over the cap
+	Contracts int     `json:"contracts"`
+}
+
+// CapViolation is a team pushed over the cap by a trade or claim
+type CapViolation struct {
+	TeamID  string  `json:"teamId"`
+	Year    int     `json:"year"`
+	Payroll float64 `json:"payroll"` // Payroll after the move
+	Cap     float64 `json:"cap"`
+}
+
+// CapError is returned when a trade or claim would push a team over the salary cap
+type CapError struct {
+	Violations []CapViolation
+}
+
+func (e *CapError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = fmt.Sprintf("team %s payroll %.2f is over the %d cap of %.2f", v.TeamID, v.Payroll, v.Year, v.Cap)
+	}
+	return "salary cap exceeded: " + strings.Join(messages, "; ")
+}
+
+// ContractLedger tracks player contracts and each team's cap space in a salary-cap league
+//
+// Fantrax has no contract data, so the ledger is maintained by the commissioner and kept in
+// step with rosters by ApplyTrade and ApplyClaim (which the client calls after successful
+// commissioner trades and adds when the ledger is attached with WithContractLedger). It is
+// JSON-serializable with Save and LoadContractLedger, and safe for concurrent use.
+type ContractLedger struct {
+	Contracts map[string]Contract `json:"contracts"` // Keyed by player ID
+	Cap       float64             `json:"cap"`
+	CapByYear map[int]float64     `json:"capByYear,omitempty"` // Per-season caps overriding Cap
+
+	// Season is the season trades and claims are checked against (0 = the current year)
+	Season int `json:"season,omitempty"`
+
+	// MinimumSalary is the one-season contract given to a player claimed without a contract
+	MinimumSalary float64 `json:"minimumSalary,omitempty"`
+
+	mu sync.Mutex
+}
+
+// NewContractLedger creates an empty ledger with a salary cap
+func NewContractLedger(salaryCap float64) *ContractLedger {
+	return &ContractLedger{
+		Contracts: make(map[string]Contract),
+		Cap:       salaryCap,
+		CapByYear: make(map[int]float64),
+	}
+}
+
+// LoadContractLedger reads a ledger previously written with Save
+func LoadContractLedger(path string) (*ContractLedger, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read contract ledger: %w", err)
+	}
+
+	ledger := NewContractLedger(0)
+	if err := json.Unmarshal(data, ledger); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal contract ledger: %w", err)
+	}
+	if ledger.Contracts == nil {
+		ledger.Contracts = make(map[string]Contract)
+	}
+	if ledger.CapByYear == nil {
+		ledger.CapByYear = make(map[int]float64)
+	}
+	return ledger, nil
+}
+
+// Save writes the ledger to a JSON file
+func (l *ContractLedger) Save(path string) error {
+	l.mu.Lock()
+	data, err := json.MarshalIndent(l, "", "  ")
+	l.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to marshal contract ledger: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write contract ledger: %w", err)
+	}
+	return nil
+}
+
+// SetContract adds or replaces a player's contract
+func (l *ContractLedger) SetContract(contract Contract) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.Contracts[contract.PlayerID] = contract
+}
+
+// ReleasePlayer removes a player's contract from its team; the contract is kept so a
+// re-signed player can be given a new one with SetContract
+func (l *ContractLedger) ReleasePlayer(playerID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if contract, ok := l.Contracts[playerID]; ok {
+		contract.TeamID = ""
+		l.Contracts[playerID] = contract
+	}
+}
+
+// CapFor returns the salary cap in a season
+func (l *ContractLedger) CapFor(year int) float64 {
+	if salaryCap, ok := l.CapByYear[year]; ok {
+		return salaryCap
+	}
+	return l.Cap
+}
+
+// CapSpace returns every team's payroll and cap space in a season, ordered by space, least first
+func (l *ContractLedger) CapSpace(year int) []TeamCapSpace {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	byTeam := make(map[string]*TeamCapSpace)
+	for _, contract := range l.Contracts {
+		if contract.TeamID == "" {
+			continue
+		}
+		salary, ok := contract.SalaryIn(year)
+		if !ok {
+			continue
+		}
+		team, ok := byTeam[contract.TeamID]
+		if !ok {
+			team = &TeamCapSpace{TeamID: contract.TeamID, Year: year, Cap: l.CapFor(year)}
+			byTeam[contract.TeamID] = team
+		}
+		team.Payroll += salary
+		team.Contracts++
+	}
+
+	spaces := make([]TeamCapSpace, 0, len(byTeam))
+	for _, team := range byTeam {
+		team.Space = team.Cap - team.Payroll
+		spaces = append(spaces, *team)
+	}
+	sort.Slice(spaces, func(i, j int) bool {
+		if spaces[i].Space != spaces[j].Space {
+			return spaces[i].Space < spaces[j].Space
+		}
+		return spaces[i].TeamID < spaces[j].TeamID
+	})
+	return spaces
+}
+
+// Payroll returns a team's total salary in a season
+func (l *ContractLedger) Payroll(teamID string, year int) float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.payroll(l.Contracts, teamID, year)
+}
+
+// CheckTrade returns a *CapError if the trade would leave a team over the cap in the
+// ledger's season with a higher payroll than before. Players without contracts count as zero.
+func (l *ContractLedger) CheckTrade(items []TradeItem) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	after := l.copyContracts()
+	for _, item := range items {
+		if contract, ok := after[item.PlayerID]; ok {
+			contract.TeamID = item.ToTeamID
+			after[item.PlayerID] = contract
+		}
+	}
+	teams := make(map[string]bool)
+	for _, item := range items {
+		teams[item.ToTeamID] = true
+	}
+	return l.checkCap(after, teams)
+}
+
+// CheckClaim returns a *CapError if adding a player (after dropping dropPlayerIDs) would leave
+// the team over the cap in the ledger's season with a higher payroll than before. A player
+// without a contract costs MinimumSalary.
+func (l *ContractLedger) CheckClaim(teamID, playerID string, dropPlayerIDs ...string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	after := l.copyContracts()
+	for _, dropID := range dropPlayerIDs {
+		if contract, ok := after[dropID]; ok && contract.TeamID == teamID {
+			contract.TeamID = ""
+			after[dropID] = contract
+		}
+	}
+	after[playerID] = l.claimedContract(playerID, teamID)
+	return l.checkCap(after, map[string]bool{teamID: true})
+}
+
+// ApplyTrade moves the traded players' contracts to their new teams
+func (l *ContractLedger) ApplyTrade(items []TradeItem) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, item := range items {
+		if contract, ok := l.Contracts[item.PlayerID]; ok {
+			contract.TeamID = item.ToTeamID
+			l.Contracts[item.PlayerID] = contract
+		}
+	}
+}
+
+// ApplyClaim gives a claimed player's contract to the team, creating a one-season contract at
+// MinimumSalary if the player has none covering the ledger's season
+func (l *ContractLedger) ApplyClaim(teamID, playerID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.Contracts[playerID] = l.claimedContract(playerID, teamID)
+}
+
+// SalaryCapRule returns a roster rule enforcing the cap in a season from the ledger's
+// salaries, for use with WithRosterRules
+func (l *ContractLedger) SalaryCapRule(year int) SalaryCap {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	salaries := make(map[string]float64, len(l.Contracts))
+	for playerID, contract := range l.Contracts {
+		if salary, ok := contract.SalaryIn(year); ok {
+			salaries[playerID] = salary
+		}
+	}
+	return SalaryCap{Cap: l.CapFor(year), Salaries: salaries}
+}
+
+// season returns the season trades and claims are checked against
+func (l *ContractLedger) season() int {
+	if l.Season != 0 {
+		return l.Season
+	}
+	return time.Now().Year()
+}
+
+// claimedContract returns the contract a claimed player joins the team with. The caller must
+// hold l.mu.
+func (l *ContractLedger) claimedContract(playerID, teamID string) Contract {
+	contract, ok := l.Contracts[playerID]
+	if _, covered := contract.SalaryIn(l.season()); !ok || !covered {
+		contract = Contract{PlayerID: playerID, PlayerName: contract.PlayerName, Salary: l.MinimumSalary, StartYear: l.season(), Years: 1}
+	}
+	contract.TeamID = teamID
+	return contract
+}
+
+// checkCap compares the teams' payrolls before and after a move. The caller must hold l.mu.
+func (l *ContractLedger) checkCap(after map[string]Contract, teams map[string]bool) error {
+	year := l.season()
+	salaryCap := l.CapFor(year)
+
+	teamIDs := make([]string, 0, len(teams))
+	for teamID := range teams {
+		teamIDs = append(teamIDs, teamID)
+	}
+	sort.Strings(teamIDs)
+
+	var violations []CapViolation
+	for _, teamID := range teamIDs {
+		before := l.payroll(l.Contracts, teamID, year)
+		payroll := l.payroll(after, teamID, year)
+		if payroll > salaryCap && payroll > before {
+			violations = append(violations, CapViolation{TeamID: teamID, Year: year, Payroll: payroll, Cap: salaryCap})
+		}
+	}
+	if len(violations) > 0 {
+		return &CapError{Violations: violations}
+	}
+	return nil
+}
+
+// payroll totals a team's salaries in a season. The caller must hold l.mu.
+func (l *ContractLedger) payroll(contracts map[string]Contract, teamID string, year int) float64 {
+	total := 0.0
+	for _, contract := range contracts {
+		if contract.TeamID != teamID {
+			continue
+		}
+		if salary, ok := contract.SalaryIn(year); ok {
+			total += salary
+		}
+	}
+	return total
+}
+
+// copyContracts copies the contracts map. The caller must hold l.mu.
+func (l *ContractLedger) copyContracts() map[string]Contract {
+	contracts := make(map[string]Contract, len(l.Contracts))
+	for playerID, contract := range l.Contracts {
+		contracts[playerID] = contract
+	}
+	return contracts
+}
+
+// WithContractLedger checks commissioner trades and adds against the ledger's salary cap
+// before sending them, and updates the ledger after they succeed, saving it to path when
+// path is not empty
+func WithContractLedger(ledger *ContractLedger, path string) ClientOption {
+	return func(c *Client) {
+		c.Contracts = ledger
+		c.ContractLedgerPath = path
+	}
+}
+
+// saveContracts saves the client's contract ledger after a change. Failing to save is logged
+// rather than failing a transaction Fantrax has already executed.
+func (c *Client) saveContracts() {
+	if c.ContractLedgerPath == "" {
+		return
+	}
+	if err := c.Contracts.Save(c.ContractLedgerPath); err != nil {
+		log.Warn("failed to save contract ledger: ", err)
+	}
+}
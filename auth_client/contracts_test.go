@@ -0,0 +1,50 @@
+package auth_client
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestContractLedger(t *testing.T) {
+	ledger := NewContractLedger(100)
+	ledger.Season = 2026
+	ledger.MinimumSalary = 1
+	ledger.SetContract(Contract{PlayerID: "p1", TeamID: "a", Salary: 40, StartYear: 2025, Years: 3, Escalator: 0.1})
+	ledger.SetContract(Contract{PlayerID: "p2", TeamID: "a", Salary: 50, StartYear: 2026, Years: 1})
+	ledger.SetContract(Contract{PlayerID: "p3", TeamID: "b", Salary: 20, StartYear: 2026, Years: 2, Raise: 5})
+
+	if salary, ok := ledger.Contracts["p1"].SalaryIn(2027); !ok || salary != 48.4 {
+		t.Errorf("expected an escalated 2027 salary of 48.4, got %v", salary)
+	}
+	if _, ok := ledger.Contracts["p1"].SalaryIn(2028); ok {
+		t.Error("expected the contract to have ended")
+	}
+
+	spaces := ledger.CapSpace(2026)
+	if spaces[0].TeamID != "a" || spaces[0].Payroll != 94 || spaces[0].Space != 6 {
+		t.Errorf("unexpected cap space for a: %+v", spaces[0])
+	}
+
+	// a taking on p3 goes over the cap; swapping p2 for p3 does not
+	var capErr *CapError
+	if err := ledger.CheckTrade([]TradeItem{{PlayerID: "p3", FromTeamID: "b", ToTeamID: "a"}}); !errors.As(err, &capErr) || capErr.Violations[0].Payroll != 114 {
+		t.Errorf("expected a cap error, got %v", err)
+	}
+	swap := []TradeItem{{PlayerID: "p3", FromTeamID: "b", ToTeamID: "a"}, {PlayerID: "p2", FromTeamID: "a", ToTeamID: "b"}}
+	if err := ledger.CheckTrade(swap); err != nil {
+		t.Errorf("expected the swap to fit under the cap, got %v", err)
+	}
+	ledger.ApplyTrade(swap)
+	if got := ledger.Payroll("b", 2026); got != 50 {
+		t.Errorf("expected b's payroll to be 50 after the trade, got %v", got)
+	}
+
+	// Claims without a contract cost the minimum salary
+	ledger.Cap = 64.5
+	if err := ledger.CheckClaim("a", "p4"); err == nil {
+		t.Error("expected a minimum-salary claim to go over the cap")
+	}
+	if err := ledger.CheckClaim("a", "p4", "p3"); err != nil {
+		t.Errorf("expected the claim with a drop to fit, got %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+package auth_client
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pmurley/go-fantrax/models"
+	"github.com/pmurley/go-fantrax/redact"
+	log "github.com/sirupsen/logrus"
+)
+
+// GetDivisions fetches the league's divisions with their standings tab IDs and teams
+//
+// When the logged-in user is the commissioner, the league setup page is read as well to
+// add each division's setup form ID.
+func (c *Client) GetDivisions() ([]models.Division, error) {
+	standings, err := c.GetStandings()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get standings: %w", err)
+	}
+	home, err := c.GetLeagueHomeInfo()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league home info: %w", err)
+	}
+
+	var setupDivisions []models.LeagueSetupDivision
+	if err := c.RequireCommissioner(); err == nil {
+		setup, err := c.GetLeagueSetupMatchups()
+		if err != nil {
+			log.Warn("failed to get league setup, leaving out division setup IDs: ", redact.Error(err))
+		} else {
+			setupDivisions = setup.Divisions
+		}
+	} else if !errors.Is(err, ErrNotCommissioner) {
+		return nil, err
+	}
+
+	return MergeDivisions(standings.Divisions, home.Standings, setupDivisions), nil
+}
+
+// MergeDivisions joins the divisions reported by the standings tabs, the league home
+// standings, and the league setup page by name
+//
+// Divisions are returned in setup order, then standings tab order. Team IDs come from the
+// setup page, or the home standings when setup data isn't given. A league without
+// divisions has no division tabs and a single home standings table, and gets no divisions.
+//
+// Parameters:
+//   - standings: Divisions from GetStandings; may be nil
+//   - home: Standings from GetLeagueHomeInfo; may be nil
+//   - setup: Divisions from GetLeagueSetupMatchups; may be nil
+func MergeDivisions(standings []Division, home []DivisionStandings, setup []models.LeagueSetupDivision) []models.Division {
+	var divisions []models.Division
+	byName := make(map[string]int)
+	find := func(name string) *models.Division {
+		key := divisionKey(name)
+		if i, ok := byName[key]; ok {
+			return &divisions[i]
+		}
+		byName[key] = len(divisions)
+		divisions = append(divisions, models.Division{Name: strings.TrimSpace(name)})
+		return &divisions[len(divisions)-1]
+	}
+
+	for _, div := range setup {
+		d := find(div.Name)
+		d.SetupID = div.DivisionID
+		d.TeamIDs = append([]string(nil), div.TeamIDs...)
+	}
+	for _, div := range standings {
+		find(div.Name).StandingsTabID = div.ID
+	}
+	for _, table := range home {
+		if _, ok := byName[divisionKey(table.DivisionName)]; !ok && len(home) < 2 {
+			continue
+		}
+		d := find(table.DivisionName)
+		if len(setup) > 0 {
+			continue
+		}
+		for _, row := range table.Teams {
+			if !d.HasTeam(row.TeamID) {
+				d.TeamIDs = append(d.TeamIDs, row.TeamID)
+			}
+		}
+	}
+
+	for i := range divisions {
+		if divisions[i].TeamIDs == nil {
+			divisions[i].TeamIDs = []string{}
+		}
+	}
+	return divisions
+}
+
+// DivisionsByTeam maps each team ID to its division
+func DivisionsByTeam(divisions []models.Division) map[string]models.Division {
+	byTeam := make(map[string]models.Division)
+	for _, div := range divisions {
+		for _, teamID := range div.TeamIDs {
+			byTeam[teamID] = div
+		}
+	}
+	return byTeam
+}
+
+// IsDivisionMatchup reports whether both teams of a matchup are in the same division
+func IsDivisionMatchup(byTeam map[string]models.Division, m Matchup) bool {
+	away, ok := byTeam[m.AwayTeam.TeamID]
+	if !ok {
+		return false
+	}
+	home, ok := byTeam[m.HomeTeam.TeamID]
+	return ok && divisionKey(away.Name) == divisionKey(home.Name)
+}
+
+// divisionKey normalizes a division name for matching across pages
+func divisionKey(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
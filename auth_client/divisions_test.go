@@ -0,0 +1,42 @@
+package auth_client
+
+import (
+	"testing"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestMergeDivisions(t *testing.T) {
+	standings := []Division{{ID: "tab1", Name: "East"}, {ID: "tab2", Name: "West "}}
+	home := []DivisionStandings{
+		{DivisionName: "East", Teams: []TeamStandingRow{{TeamID: "a"}, {TeamID: "b"}}},
+		{DivisionName: "west", Teams: []TeamStandingRow{{TeamID: "c"}, {TeamID: "d"}}},
+	}
+
+	divisions := MergeDivisions(standings, home, nil)
+	if len(divisions) != 2 {
+		t.Fatalf("expected 2 divisions, got %+v", divisions)
+	}
+	if d := divisions[1]; d.Name != "West" || d.StandingsTabID != "tab2" || len(d.TeamIDs) != 2 || !d.HasTeam("c") {
+		t.Errorf("unexpected west division: %+v", d)
+	}
+
+	setup := []models.LeagueSetupDivision{{DivisionID: "d9", Name: "West", TeamIDs: []string{"c", "d"}}, {DivisionID: "d8", Name: "East", TeamIDs: []string{"a", "b"}}}
+	divisions = MergeDivisions(standings, home, setup)
+	if d := divisions[0]; d.Name != "West" || d.SetupID != "d9" || d.StandingsTabID != "tab2" {
+		t.Errorf("expected setup order with both IDs: %+v", d)
+	}
+
+	byTeam := DivisionsByTeam(divisions)
+	if !IsDivisionMatchup(byTeam, Matchup{AwayTeam: MatchTeam{TeamID: "a"}, HomeTeam: MatchTeam{TeamID: "b"}}) {
+		t.Error("expected a and b to share a division")
+	}
+	if IsDivisionMatchup(byTeam, Matchup{AwayTeam: MatchTeam{TeamID: "a"}, HomeTeam: MatchTeam{TeamID: "c"}}) {
+		t.Error("expected a and c to be in different divisions")
+	}
+
+	single := []DivisionStandings{{DivisionName: "Standings", Teams: []TeamStandingRow{{TeamID: "a"}}}}
+	if divisions := MergeDivisions(nil, single, nil); len(divisions) != 0 {
+		t.Errorf("expected no divisions without division tabs, got %+v", divisions)
+	}
+}
@@ -0,0 +1,296 @@
+package auth_client
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pmurley/go-fantrax/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// DraftPickTransfer records a draft pick changing hands in a trade
+type DraftPickTransfer struct {
+	TradeID    string    `json:"tradeId"`
+	FromTeamID string    `json:"fromTeamId"`
+	ToTeamID   string    `json:"toTeamId"`
+	Date       time.Time `json:"date"`
+}
+
+// DraftPickAsset is a future draft pick and who owns it
+type DraftPickAsset struct {
+	Year             int                 `json:"year"`
+	Round            int                 `json:"round"`
+	OriginalTeamID   string              `json:"originalTeamId"`
+	OriginalTeamName string              `json:"originalTeamName"`
+	OwnerTeamID      string              `json:"ownerTeamId"`
+	Conditions       string              `json:"conditions,omitempty"` // Free text, e.g. "top-3 protected"
+	History          []DraftPickTransfer `json:"history,omitempty"`
+}
+
+// DraftPickLedger tracks future draft pick ownership for a league
+//
+// Fantrax only shows the picks a team currently owns, so the ledger keeps its own record:
+// seed it with AddTeamPicks, then feed it trades with ApplyTrades (or attach it to a client
+// with WithDraftPickLedger so every fetched trade is applied). Trades are only applied once,
+// so re-running over the full trade history is always safe. The ledger is JSON-serializable
+// with Save and LoadDraftPickLedger, and safe for concurrent use.
+type DraftPickLedger struct {
+	Picks         []DraftPickAsset  `json:"picks"`
+	TeamNames     map[string]string `json:"teamNames"`     // Keyed by team ID
+	AppliedTrades map[string]bool   `json:"appliedTrades"` // Keyed by trade ID and pick
+	UpdatedAt     time.Time         `json:"updatedAt"`
+
+	mu sync.Mutex
+}
+
+// NewDraftPickLedger creates an empty ledger
+func NewDraftPickLedger() *DraftPickLedger {
+	return &DraftPickLedger{
+		TeamNames:     make(map[string]string),
+		AppliedTrades: make(map[string]bool),
+	}
+}
+
+// LoadDraftPickLedger reads a ledger previously written with Save
+func LoadDraftPickLedger(path string) (*DraftPickLedger, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read draft pick ledger: %w", err)
+	}
+
+	ledger := NewDraftPickLedger()
+	if err := json.Unmarshal(data, ledger); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal draft pick ledger: %w", err)
+	}
+	if ledger.TeamNames == nil {
+		ledger.TeamNames = make(map[string]string)
+	}
+	if ledger.AppliedTrades == nil {
+		ledger.AppliedTrades = make(map[string]bool)
+	}
+	return ledger, nil
+}
+
+// Save writes the ledger to a JSON file
+func (l *DraftPickLedger) Save(path string) error {
+	l.mu.Lock()
+	data, err := json.MarshalIndent(l, "", "  ")
+	l.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to marshal draft pick ledger: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write draft pick ledger: %w", err)
+	}
+	return nil
+}
+
+// AddTeamPicks gives a team its own picks for a draft year, skipping picks already in the ledger
+//
+// Parameters:
+//   - teamID: The team's ID
+//   - teamName: The team's name, used to match picks Fantrax names by team
+//   - year: The draft year
+//   - rounds: The number of rounds in the draft
+func (l *DraftPickLedger) AddTeamPicks(teamID, teamName string, year, rounds int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if teamName != "" {
+		l.TeamNames[teamID] = teamName
+	}
+	for round := 1; round <= rounds; round++ {
+		if l.find(year, round, teamID, teamName) != nil {
+			continue
+		}
+		l.Picks = append(l.Picks, DraftPickAsset{
+			Year:             year,
+			Round:            round,
+			OriginalTeamID:   teamID,
+			OriginalTeamName: teamName,
+			OwnerTeamID:      teamID,
+		})
+	}
+}
+
+// SetConditions records conditions attached to a pick, such as protections agreed in a trade
+func (l *DraftPickLedger) SetConditions(year, round int, originalTeamID, conditions string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	pick := l.find(year, round, originalTeamID, "")
+	if pick == nil {
+		return fmt.Errorf("no %d round %d pick originally owned by team %s", year, round, originalTeamID)
+	}
+	pick.Conditions = conditions
+	return nil
+}
+
+// GetPick returns a pick by year, round, and original owner
+func (l *DraftPickLedger) GetPick(year, round int, originalTeamID string) (DraftPickAsset, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if pick := l.find(year, round, originalTeamID, ""); pick != nil {
+		return *pick, true
+	}
+	return DraftPickAsset{}, false
+}
+
+// GetPicksOwnedBy returns the picks a team currently owns, ordered by year, round, and
+// original team
+//
+// Parameters:
+//   - teamID: The owning team's ID
+//   - year: The draft year (0 = every year)
+func (l *DraftPickLedger) GetPicksOwnedBy(teamID string, year int) []DraftPickAsset {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var picks []DraftPickAsset
+	for _, pick := range l.Picks {
+		if pick.OwnerTeamID == teamID && (year == 0 || pick.Year == year) {
+			picks = append(picks, pick)
+		}
+	}
+	sort.Slice(picks, func(i, j int) bool {
+		if picks[i].Year != picks[j].Year {
+			return picks[i].Year < picks[j].Year
+		}
+		if picks[i].Round != picks[j].Round {
+			return picks[i].Round < picks[j].Round
+		}
+		return picks[i].OriginalTeamID < picks[j].OriginalTeamID
+	})
+	return picks
+}
+
+// ApplyTrades moves traded picks to their new owners, oldest trade first. Rows that are not
+// draft picks, and pick moves already applied, are skipped. Picks not yet in the ledger are
+// added. Returns the number of pick moves applied.
+func (l *DraftPickLedger) ApplyTrades(trades []models.Transaction) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var rows []models.Transaction
+	for _, tx := range trades {
+		if tx.Type == "TRADE" && tx.DraftPick != nil {
+			rows = append(rows, tx)
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].ProcessedDate.Before(rows[j].ProcessedDate) })
+
+	applied := 0
+	for _, tx := range rows {
+		if tx.FromTeamName != "" {
+			l.TeamNames[tx.FromTeamID] = tx.FromTeamName
+		}
+		if tx.ToTeamName != "" {
+			l.TeamNames[tx.ToTeamID] = tx.ToTeamName
+		}
+
+		originalID, originalName := l.resolveOriginalTeam(tx)
+		key := fmt.Sprintf("%s|%d|%d|%s", tx.TradeGroupID, tx.DraftPick.Year, tx.DraftPick.Round, originalID+"/"+originalName)
+		if l.AppliedTrades[key] {
+			continue
+		}
+
+		pick := l.find(tx.DraftPick.Year, tx.DraftPick.Round, originalID, originalName)
+		if pick == nil {
+			l.Picks = append(l.Picks, DraftPickAsset{
+				Year:             tx.DraftPick.Year,
+				Round:            tx.DraftPick.Round,
+				OriginalTeamID:   originalID,
+				OriginalTeamName: originalName,
+				OwnerTeamID:      tx.FromTeamID,
+			})
+			pick = &l.Picks[len(l.Picks)-1]
+		}
+		if pick.OwnerTeamID != tx.FromTeamID {
+			log.Warnf("draft pick ledger: %s traded by team %s but owned by team %s", tx.DraftPick, tx.FromTeamID, pick.OwnerTeamID)
+		}
+		pick.OwnerTeamID = tx.ToTeamID
+		pick.History = append(pick.History, DraftPickTransfer{
+			TradeID:    tx.TradeGroupID,
+			FromTeamID: tx.FromTeamID,
+			ToTeamID:   tx.ToTeamID,
+			Date:       tx.ProcessedDate,
+		})
+		l.AppliedTrades[key] = true
+		applied++
+	}
+	if applied > 0 {
+		l.UpdatedAt = time.Now()
+	}
+	return applied
+}
+
+// resolveOriginalTeam works out which team a traded pick originally belonged to. Fantrax names
+// the original team; without a name the pick is assumed to be the trading team's own.
+func (l *DraftPickLedger) resolveOriginalTeam(tx models.Transaction) (string, string) {
+	name := tx.DraftPick.OriginalTeamName
+	if name == "" {
+		return tx.FromTeamID, l.TeamNames[tx.FromTeamID]
+	}
+	for id, teamName := range l.TeamNames {
+		if strings.EqualFold(teamName, name) {
+			return id, teamName
+		}
+	}
+	return "", name
+}
+
+// find returns the pick with the given year, round, and original team, matched by ID when
+// known and by name otherwise. The caller must hold l.mu.
+func (l *DraftPickLedger) find(year, round int, originalTeamID, originalTeamName string) *DraftPickAsset {
+	for i := range l.Picks {
+		pick := &l.Picks[i]
+		if pick.Year != year || pick.Round != round {
+			continue
+		}
+		if originalTeamID != "" && pick.OriginalTeamID == originalTeamID {
+			return pick
+		}
+		if originalTeamID == "" && originalTeamName != "" && strings.EqualFold(pick.OriginalTeamName, originalTeamName) {
+			return pick
+		}
+	}
+	return nil
+}
+
+// WithDraftPickLedger applies the draft picks in every trade fetched by GetTrades and
+// GetAllTrades to the ledger, saving it to path afterwards when path is not empty
+func WithDraftPickLedger(ledger *DraftPickLedger, path string) ClientOption {
+	return func(c *Client) {
+		c.DraftPicks = ledger
+		c.DraftPickLedgerPath = path
+	}
+}
+
+// recordDraftPickTrades applies fetched trades to the client's draft pick ledger. Failing to
+// save the ledger is logged rather than failing the trade fetch.
+func (c *Client) recordDraftPickTrades(trades []models.Transaction) {
+	if c.DraftPicks == nil {
+		return
+	}
+	if c.DraftPicks.ApplyTrades(trades) == 0 || c.DraftPickLedgerPath == "" {
+		return
+	}
+	if err := c.DraftPicks.Save(c.DraftPickLedgerPath); err != nil {
+		log.Warn("failed to save draft pick ledger: ", err)
+	}
+}
+
+// UpdateDraftPickLedger applies the league's full trade history to a ledger
+func (c *Client) UpdateDraftPickLedger(ledger *DraftPickLedger) (int, error) {
+	trades, err := c.GetAllTrades()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get trades: %w", err)
+	}
+	return ledger.ApplyTrades(trades), nil
+}
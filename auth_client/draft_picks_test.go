@@ -0,0 +1,72 @@
+package auth_client
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pmurley/go-fantrax/auth_client/parser"
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestParseDraftPick(t *testing.T) {
+	cases := map[string]models.DraftPick{
+		"2026 Round 1 (Bombers)":         {Year: 2026, Round: 1, OriginalTeamName: "Bombers"},
+		"2027 Draft Pick, Round 3":       {Year: 2027, Round: 3},
+		"2026 2nd Round Pick (The Nine)": {Year: 2026, Round: 2, OriginalTeamName: "The Nine"},
+	}
+	for name, want := range cases {
+		got, ok := parser.ParseDraftPick(name)
+		if !ok || *got != want {
+			t.Errorf("%q: expected %+v, got %+v", name, want, got)
+		}
+	}
+	if _, ok := parser.ParseDraftPick("Shohei Ohtani"); ok {
+		t.Error("expected a player name not to parse as a pick")
+	}
+}
+
+func TestDraftPickLedger(t *testing.T) {
+	ledger := NewDraftPickLedger()
+	ledger.AddTeamPicks("a", "Bombers", 2026, 2)
+	ledger.AddTeamPicks("b", "Sluggers", 2026, 2)
+
+	day := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	trades := []models.Transaction{
+		// b flips a's first back to c a week later; listed newest first, as Fantrax does
+		{Type: "TRADE", TradeGroupID: "t2", FromTeamID: "b", ToTeamID: "c", ToTeamName: "Aces", ProcessedDate: day.AddDate(0, 0, 7),
+			DraftPick: &models.DraftPick{Year: 2026, Round: 1, OriginalTeamName: "Bombers"}},
+		{Type: "TRADE", TradeGroupID: "t1", FromTeamID: "a", ToTeamID: "b", ProcessedDate: day,
+			DraftPick: &models.DraftPick{Year: 2026, Round: 1}},
+		{Type: "TRADE", TradeGroupID: "t1", FromTeamID: "b", ToTeamID: "a", PlayerID: "p1", ProcessedDate: day},
+	}
+	if n := ledger.ApplyTrades(trades); n != 2 {
+		t.Fatalf("expected 2 pick moves, got %d", n)
+	}
+	if n := ledger.ApplyTrades(trades); n != 0 {
+		t.Fatalf("expected trades to apply once, got %d more moves", n)
+	}
+
+	owned := ledger.GetPicksOwnedBy("c", 2026)
+	if len(owned) != 1 || owned[0].OriginalTeamID != "a" || len(owned[0].History) != 2 {
+		t.Fatalf("unexpected picks for c: %+v", owned)
+	}
+	if got := len(ledger.GetPicksOwnedBy("a", 0)); got != 1 {
+		t.Errorf("expected a to keep 1 pick, got %d", got)
+	}
+
+	if err := ledger.SetConditions(2026, 1, "a", "top-3 protected"); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "picks.json")
+	if err := ledger.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadDraftPickLedger(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pick, ok := loaded.GetPick(2026, 1, "a"); !ok || pick.Conditions != "top-3 protected" || pick.OwnerTeamID != "c" {
+		t.Errorf("unexpected loaded pick: %+v", pick)
+	}
+}
@@ -1,12 +1,12 @@
 package auth_client
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
+	"sort"
+	"time"
 
+	"github.com/pmurley/go-fantrax/auth_client/parser"
 	"github.com/pmurley/go-fantrax/models"
 )
 
@@ -105,50 +105,13 @@ func (c *Client) ConfirmOrExecuteTeamRosterChangesRaw(
 		},
 	}
 
-	// Build the reference URL
-	refUrl := fmt.Sprintf("https://www.fantrax.com/fantasy/league/%s/team/roster#league-team-roster-confirm-dialog", c.LeagueID)
-
-	// Get timezone from UserInfo if available, otherwise default to UTC
-	timezone := "UTC"
-	if c.UserInfo != nil && c.UserInfo.Timezone != "" {
-		timezone = c.UserInfo.Timezone
-	}
-
-	// Build the full request with metadata
-	fullRequest := map[string]interface{}{
-		"msgs":   requestPayload.Msgs,
-		"uiv":    3,
-		"refUrl": refUrl,
-		"dt":     1,
-		"at":     0,
-		"av":     "0.0",
-		"tz":     timezone,
-		"v":      "179.0.1",
-	}
-
-	jsonStr, err := json.Marshal(fullRequest)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %w", err)
-	}
-
-	req, err := http.NewRequest("POST", "https://www.fantrax.com/fxpa/req?leagueId="+c.LeagueID, bytes.NewBuffer(jsonStr))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := c.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned non-200 status code: %d", resp.StatusCode)
-	}
-
-	body, err := io.ReadAll(resp.Body)
+	// Sent from the roster confirm dialog, hence dt=1
+	body, err := c.postFxpa(fxpaRequest{
+		Msgs:        requestPayload.Msgs,
+		DisplayType: 1,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, err
 	}
 
 	var apiResponse models.RosterChangeResponse
@@ -205,6 +168,7 @@ func (c *Client) ConfirmOrExecuteTeamRosterChanges(
 		result.Success = false
 		result.ErrorMessage = "Change not allowed by league rules"
 		result.Warnings = responseData.TextArray.Model.IllegalRosterMsgs
+		result.IllegalMessages = models.ParseIllegalRosterMessages(result.Warnings)
 		return result, nil
 	}
 
@@ -213,6 +177,7 @@ func (c *Client) ConfirmOrExecuteTeamRosterChanges(
 		result.Success = false
 		result.ErrorMessage = "API indicated error via showConfirmWindow"
 		result.Warnings = responseData.TextArray.Model.IllegalRosterMsgs
+		result.IllegalMessages = models.ParseIllegalRosterMessages(result.Warnings)
 		return result, nil
 	}
 
@@ -221,6 +186,13 @@ func (c *Client) ConfirmOrExecuteTeamRosterChanges(
 	result.Changes = responseData.TextArray.Model.RosterAdjustmentInfo.LineupChanges
 	result.Warnings = responseData.TextArray.Model.IllegalRosterMsgs
 	result.TotalFee = responseData.TextArray.Model.RosterAdjustmentInfo.TotalFee
+	result.Fees = responseData.TextArray.Model.RosterAdjustmentInfo.Fees()
+	result.IllegalMessages = models.ParseIllegalRosterMessages(result.Warnings)
+	for _, change := range result.Changes {
+		if lineupChange, ok := models.ParseLineupChangeSummary(change); ok {
+			result.LineupChanges = append(result.LineupChanges, lineupChange)
+		}
+	}
 
 	return result, nil
 }
@@ -289,8 +261,14 @@ type RosterEditor struct {
 	daily       bool
 	rawRoster   *models.TeamRosterResponse
 	fieldMap    map[string]RosterPosition
-	playerNames map[string]string // playerID -> name (for helpful error messages)
-	changesMade []string          // track what we've changed for logging
+	original    map[string]RosterPosition // fieldMap as loaded, used to report per-player changes
+	playerNames map[string]string         // playerID -> name (for helpful error messages)
+	changesMade []string                  // track what we've changed for logging
+
+	gameStarts map[string]time.Time // playerID -> start of next game, for lock checks
+	lockPolicy LockPolicy
+	warnings   []string
+	now        func() time.Time
 }
 
 // PlayerInfo represents basic information about a player on the roster
@@ -307,7 +285,7 @@ type PlayerInfo struct {
 //
 // Parameters:
 //   - period: The roster period (week number). Pass 0 to auto-detect the current period.
-//   - teamID: The fantasy team ID to edit (empty string = authenticated user's team)
+//   - teamID: The fantasy team ID to edit (empty string = the user's team, see GetMyTeamID)
 //   - adminMode: true = commissioner editing another team, false = user editing own team
 //   - daily: true = daily league, false = weekly league
 //
@@ -328,16 +306,32 @@ func (c *Client) NewRosterEditor(period int, teamID string, adminMode bool, dail
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch current roster: %w", err)
 	}
+	// The roster fetch recorded the user's team, so this doesn't fetch again
+	teamID, err = c.teamOrMine(teamID)
+	if err != nil {
+		return nil, err
+	}
 
 	// Build initial fieldMap from current state
 	fieldMap := BuildFieldMapFromRoster(rawRoster)
+	original := make(map[string]RosterPosition, len(fieldMap))
+	for playerID, pos := range fieldMap {
+		original[playerID] = pos
+	}
 
-	// Build playerNames map for helpful error messages
+	// Build playerNames map for helpful error messages, and note game times for lock checks
 	playerNames := make(map[string]string)
+	gameStarts := make(map[string]time.Time)
+	loc := c.userLocation()
 	for _, table := range rawRoster.Responses[0].Data.Tables {
 		for _, row := range table.Rows {
 			if row.Scorer.ScorerID != "" {
 				playerNames[row.Scorer.ScorerID] = row.Scorer.Name
+				if game := parser.ExtractNextGame(row.Cells); game != nil {
+					if start, ok := parser.ParseGameTime(game.DateTime, time.Now().In(loc)); ok {
+						gameStarts[row.Scorer.ScorerID] = start
+					}
+				}
 			}
 		}
 	}
@@ -350,8 +344,12 @@ func (c *Client) NewRosterEditor(period int, teamID string, adminMode bool, dail
 		daily:       daily,
 		rawRoster:   rawRoster,
 		fieldMap:    fieldMap,
+		original:    original,
 		playerNames: playerNames,
 		changesMade: []string{},
+		gameStarts:  gameStarts,
+		lockPolicy:  LockPolicyWarn,
+		now:         time.Now,
 	}, nil
 }
 
@@ -371,6 +369,9 @@ func (e *RosterEditor) MoveToActive(playerID string, positionID string) error {
 	if !exists {
 		return fmt.Errorf("player %s not found on roster", playerID)
 	}
+	if err := e.checkLock(playerID); err != nil {
+		return err
+	}
 
 	oldStatus := pos.StID
 	oldPos := pos.PosID
@@ -381,9 +382,9 @@ func (e *RosterEditor) MoveToActive(playerID string, positionID string) error {
 
 	playerName := e.playerNames[playerID]
 	if oldStatus == StatusActive && oldPos != "" {
-		e.changesMade = append(e.changesMade, fmt.Sprintf("%s: %s → %s", playerName, positionName(oldPos), positionName(positionID)))
+		e.changesMade = append(e.changesMade, fmt.Sprintf("%s: %s → %s", playerName, e.client.positionName(oldPos), e.client.positionName(positionID)))
 	} else {
-		e.changesMade = append(e.changesMade, fmt.Sprintf("%s: %s → Active at %s", playerName, statusName(oldStatus), positionName(positionID)))
+		e.changesMade = append(e.changesMade, fmt.Sprintf("%s: %s → %s at %s", playerName, e.client.statusName(oldStatus), e.client.statusName(StatusActive), e.client.positionName(positionID)))
 	}
 
 	return nil
@@ -397,6 +398,9 @@ func (e *RosterEditor) MoveToReserve(playerID string) error {
 	if !exists {
 		return fmt.Errorf("player %s not found on roster", playerID)
 	}
+	if err := e.checkLock(playerID); err != nil {
+		return err
+	}
 
 	oldStatus := pos.StID
 	pos.StID = StatusReserve
@@ -404,7 +408,7 @@ func (e *RosterEditor) MoveToReserve(playerID string) error {
 	e.fieldMap[playerID] = pos
 
 	playerName := e.playerNames[playerID]
-	e.changesMade = append(e.changesMade, fmt.Sprintf("%s: %s → Reserve", playerName, statusName(oldStatus)))
+	e.changesMade = append(e.changesMade, fmt.Sprintf("%s: %s → %s", playerName, e.client.statusName(oldStatus), e.client.statusName(StatusReserve)))
 	return nil
 }
 
@@ -416,6 +420,9 @@ func (e *RosterEditor) MoveToMinors(playerID string) error {
 	if !exists {
 		return fmt.Errorf("player %s not found on roster", playerID)
 	}
+	if err := e.checkLock(playerID); err != nil {
+		return err
+	}
 
 	oldStatus := pos.StID
 	pos.StID = StatusMinors
@@ -423,7 +430,7 @@ func (e *RosterEditor) MoveToMinors(playerID string) error {
 	e.fieldMap[playerID] = pos
 
 	playerName := e.playerNames[playerID]
-	e.changesMade = append(e.changesMade, fmt.Sprintf("%s: %s → Minors", playerName, statusName(oldStatus)))
+	e.changesMade = append(e.changesMade, fmt.Sprintf("%s: %s → %s", playerName, e.client.statusName(oldStatus), e.client.statusName(StatusMinors)))
 	return nil
 }
 
@@ -435,6 +442,9 @@ func (e *RosterEditor) MoveToIR(playerID string) error {
 	if !exists {
 		return fmt.Errorf("player %s not found on roster", playerID)
 	}
+	if err := e.checkLock(playerID); err != nil {
+		return err
+	}
 
 	oldStatus := pos.StID
 	pos.StID = StatusIR
@@ -442,7 +452,7 @@ func (e *RosterEditor) MoveToIR(playerID string) error {
 	e.fieldMap[playerID] = pos
 
 	playerName := e.playerNames[playerID]
-	e.changesMade = append(e.changesMade, fmt.Sprintf("%s: %s → IR", playerName, statusName(oldStatus)))
+	e.changesMade = append(e.changesMade, fmt.Sprintf("%s: %s → %s", playerName, e.client.statusName(oldStatus), e.client.statusName(StatusIR)))
 	return nil
 }
 
@@ -492,9 +502,17 @@ func (e *RosterEditor) GetPendingChanges() []string {
 // Parameters:
 //   - applyToFuturePeriods: true = apply to current and future periods, false = current period only
 //
+// The edited roster is first checked against the client's RosterRules; if the changes would
+// break a rule the roster does not already break, Apply sends nothing and returns a
+// *RosterRuleError.
+//
 // Returns the result of the roster change operation, or an error if the request failed.
 func (e *RosterEditor) Apply(applyToFuturePeriods bool) (*models.RosterChangeResult, error) {
-	return e.client.ConfirmOrExecuteTeamRosterChanges(
+	if err := e.checkRules(); err != nil {
+		return nil, err
+	}
+
+	result, err := e.client.ConfirmOrExecuteTeamRosterChanges(
 		e.period,
 		e.teamID,
 		e.fieldMap,
@@ -502,6 +520,46 @@ func (e *RosterEditor) Apply(applyToFuturePeriods bool) (*models.RosterChangeRes
 		e.daily,
 		e.adminMode,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	// The editor knows exactly which players moved, which the API summary does not say
+	if result.Success {
+		result.LineupChanges = e.GetLineupChanges()
+	}
+	return result, nil
+}
+
+// GetLineupChanges returns the per-player changes between the roster as loaded and the
+// roster as currently edited, ordered by player name
+func (e *RosterEditor) GetLineupChanges() []models.LineupChange {
+	var changes []models.LineupChange
+	for playerID, pos := range e.fieldMap {
+		was := e.original[playerID]
+		if was.StID == pos.StID && (pos.StID != StatusActive || was.PosID == pos.PosID) {
+			continue
+		}
+
+		change := models.LineupChange{
+			PlayerID:   playerID,
+			PlayerName: e.playerNames[playerID],
+			FromStatus: e.client.statusName(was.StID),
+			ToStatus:   e.client.statusName(pos.StID),
+		}
+		if was.StID == StatusActive {
+			change.FromPosition = e.client.positionName(was.PosID)
+		}
+		if pos.StID == StatusActive {
+			change.ToPosition = e.client.positionName(pos.PosID)
+		}
+		changes = append(changes, change)
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].PlayerName < changes[j].PlayerName
+	})
+	return changes
 }
 
 // statusName converts a status ID to a human-readable name
@@ -10,14 +10,19 @@ import (
 	"net/http"
 	"os"
 	"path"
+	"time"
 
 	"github.com/pmurley/go-fantrax"
 	"github.com/pmurley/go-fantrax/models"
+	"github.com/pmurley/go-fantrax/redact"
 	log "github.com/sirupsen/logrus"
 )
 
 const CacheDir string = "./.fantrax-cache"
 
+// DefaultRequestTimeout bounds a whole request to Fantrax, including reading the response
+const DefaultRequestTimeout = 2 * time.Minute
+
 type FantraxRequest struct {
 	Msgs []FantraxMessage `json:"msgs"`
 }
@@ -30,33 +35,160 @@ type FantraxMessage struct {
 type Client struct {
 	http.Client
 	LeagueID string
-	UseCache bool
 	UserInfo *models.UserInfo
+
+	// UseCache stores responses in CacheDir and answers repeated requests from it. Responses
+	// are only cached encrypted, so nothing is cached while CacheCipher is nil.
+	UseCache bool
+
+	// AppVersion overrides the Fantrax web app version sent with requests
+	// (defaults to DefaultAppVersion)
+	AppVersion string
+
+	// OnParseWarning, if set, receives every row or cell the parsers could not read. When nil,
+	// warnings are logged. It may be called from several goroutines at once (GetPlayerPool
+	// fetches pages concurrently).
+	OnParseWarning func(models.ParseWarning)
+
+	// OnPageProgress, if set, is called after each page of GetAllTransactions, GetAllTrades,
+	// GetPendingTransactions, and the player pool is fetched and parsed. Calls come from the
+	// goroutine running the operation, in page order.
+	OnPageProgress func(PageProgress)
+
+	// ReconcileTransactionPages makes GetAllTransactions and GetAllTrades merge pages by
+	// transaction ID and fetch pages again when the result count Fantrax reports changes
+	// mid-fetch (e.g. during a waiver run), instead of trusting the count from each page.
+	// Progress reports may then repeat pages.
+	ReconcileTransactionPages bool
+
+	// ValidateSchema compares fxpa responses against the types they decode into and reports
+	// unknown fields, missing fields, and type mismatches to OnSchemaDrift (or logs them when
+	// OnSchemaDrift is nil). Like OnParseWarning, the handler may be called concurrently.
+	ValidateSchema bool
+	OnSchemaDrift  func(fantrax.SchemaDrift)
+
+	// VerifyCommissioner makes commissioner-only operations (CommissionerAdd, CommissionerDrop,
+	// CommissionerTrade, SetPeriodMatchups, minors eligibility overrides) check the user's league
+	// roles first and fail with ErrNotCommissioner instead of sending the request
+	VerifyCommissioner bool
+
+	// Cookies, when set, is the Cookie header sent with every request in place of the cookies
+	// found by GetCookies
+	Cookies string
+
+	// DisableRequestDedup sends every request. By default, concurrent identical read requests
+	// (fxpa "get" methods and the league setup and illegal roster pages) share one request to
+	// Fantrax and its result.
+	DisableRequestDedup bool
+
+	// UserAgent replaces fantrax.DefaultUserAgent, and AppID is appended to it to identify the
+	// tool making requests. Both also apply to the browser GetCookies logs in with.
+	UserAgent string
+	AppID     string
+
+	// RateLimiter, when set, spaces out requests to Fantrax. It may be shared with the public
+	// client.
+	RateLimiter *fantrax.RateLimiter
+
+	// CacheCipher, when set, encrypts the response cache and the cookie cache. NewClient
+	// sets it once from FANTRAX_CACHE_KEY or FANTRAX_CACHE_KEY_COMMAND, or, when UseCache is
+	// set and neither is, from fantrax.LocalCacheCipher.
+	CacheCipher *fantrax.CacheCipher
+
+	// Terminology, when set, replaces the generic roster status and position names in lineup
+	// changes, lineup issues, trade blocks, and roster editor messages with the league's own
+	// labels. LoadLeagueTerminology fills in the position labels Fantrax shows.
+	Terminology *Terminology
+
+	// LeagueFormat, when set from the league's scoring settings, is the format GetStandings and
+	// GetAllMatchups parse every table as. Otherwise it is recognized from Fantrax's table types,
+	// of which only the H2H points ones have been checked against captured responses.
+	LeagueFormat LeagueFormat
+
+	// RosterRules are custom league constraints checked by the roster compliance sweep and
+	// before RosterEditor.Apply
+	RosterRules []RosterRule
+
+	// DraftPicks, when set, is updated with the draft picks in every trade GetTrades and
+	// GetAllTrades fetch, and saved to DraftPickLedgerPath if that is set
+	DraftPicks          *DraftPickLedger
+	DraftPickLedgerPath string
+
+	// Contracts, when set, is the salary cap checked before commissioner trades and adds and
+	// updated after them, and saved to ContractLedgerPath if that is set
+	Contracts          *ContractLedger
+	ContractLedgerPath string
+
+	// PeriodCacheDir holds the results and box scores of completed scoring periods, which are
+	// never fetched again once stored (see GetScoringPeriodResults). Empty turns it off.
+	PeriodCacheDir string
+
+	// MaxResponseBytes is the largest fxpa response read before failing with
+	// ErrResponseTooLarge. 0 means DefaultMaxResponseBytes.
+	MaxResponseBytes int64
+
+	roles        *leagueRoles
+	myTeam       *myTeam       // See GetMyTeamID
+	redirectless *http.Client  // See noRedirectClient
+	inFlight     *requestGroup // Read requests in flight; see DisableRequestDedup
 }
 
 // NewClient creates a new instance of the auth_client and fetches user info
-func NewClient(leagueId string, useCache bool) (*Client, error) {
+func NewClient(leagueId string, useCache bool, opts ...ClientOption) (*Client, error) {
 	client := &Client{
-		Client:   http.Client{},
-		LeagueID: leagueId,
-		UseCache: useCache,
+		Client:         http.Client{Transport: fantrax.SharedTransport(), Timeout: DefaultRequestTimeout},
+		LeagueID:       leagueId,
+		UseCache:       useCache,
+		PeriodCacheDir: DefaultPeriodCacheDir,
+		roles:          &leagueRoles{},
+		myTeam:         &myTeam{},
+		inFlight:       &requestGroup{},
+	}
+	for _, opt := range opts {
+		opt(client)
+	}
+	client.redirectless = newNoRedirectClient(&client.Client)
+	// Resolved once: FANTRAX_CACHE_KEY_COMMAND runs a program, and the cookie cache is read
+	// whenever cookies are needed
+	if client.CacheCipher == nil {
+		cacheCipher, err := fantrax.CacheCipherFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		if cacheCipher == nil && useCache {
+			// The response cache holds the user's leagues, rosters, and trades
+			if cacheCipher, err = fantrax.LocalCacheCipher(); err != nil {
+				return nil, err
+			}
+		}
+		client.CacheCipher = cacheCipher
 	}
 
 	// Fetch user info including timezone data
 	err := client.Login()
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch user info during client initialization: %w", err)
+		return nil, fmt.Errorf("failed to fetch user info during client initialization: %w", redact.Error(err))
 	}
 
 	return client, nil
 }
 
+// uncached returns a copy of the client that skips the response cache, for reads that must
+// see a change just made. Its requests aren't shared with cached reads in flight.
+func (c *Client) uncached() *Client {
+	clone := *c
+	clone.UseCache = false
+	clone.inFlight = &requestGroup{}
+	return &clone
+}
+
 // Do sends an HTTP request and returns an HTTP response
 func (c *Client) Do(req *http.Request) (*http.Response, error) {
 	var cacheKey string
 	var newBody io.ReadCloser
 	var err error
-	if c.UseCache {
+	caching := c.UseCache && c.CacheCipher != nil
+	if caching {
 		cacheKey, newBody, err = hashReadCloser(req.Body)
 		if err != nil {
 			return nil, err
@@ -80,30 +212,36 @@ func (c *Client) Do(req *http.Request) (*http.Response, error) {
 			}
 			cachedResponse.Close()
 
-			// Create a new reader from the data
-			response := &http.Response{
-				StatusCode: http.StatusOK,
-				Body:       io.NopCloser(bytes.NewBuffer(cachedData)),
+			// A file encrypted with another key is refetched and overwritten
+			if cachedData, err = c.CacheCipher.Open(cachedData); err == nil {
+				// Create a new reader from the data
+				response := &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewBuffer(cachedData)),
+				}
+				log.Info("cache hit")
+				return response, nil
 			}
-			log.Info("cache hit")
-			return response, nil
+			log.Warn("unreadable cache file: ", err)
 		}
 		log.Info("cache miss")
 	}
 
-	cookiesString, err := GetCookies()
+	cookiesString, err := c.cookies()
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Cookie", cookiesString)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.userAgent())
+	c.RateLimiter.Wait()
 	resp, err := c.Client.Do(req)
 	if err != nil {
 		return nil, err
 	}
 
-	if c.UseCache {
+	if caching {
 		// Read the entire response body
 		respData, err := io.ReadAll(resp.Body)
 		if err != nil {
@@ -112,12 +250,16 @@ func (c *Client) Do(req *http.Request) (*http.Response, error) {
 		resp.Body.Close()
 
 		// Write to cache file
-		err = os.MkdirAll(CacheDir, 0755)
+		err = os.MkdirAll(CacheDir, 0700)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create cache directory: %w", err)
 		}
 
-		err = os.WriteFile(path.Join(CacheDir, cacheKey), respData, 0644)
+		cacheData, err := c.CacheCipher.Seal(respData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt cache file: %w", err)
+		}
+		err = os.WriteFile(path.Join(CacheDir, cacheKey), cacheData, 0600)
 		if err != nil {
 			return nil, fmt.Errorf("failed to write cache file: %w", err)
 		}
@@ -129,6 +271,17 @@ func (c *Client) Do(req *http.Request) (*http.Response, error) {
 	return resp, nil
 }
 
+// reportParseWarnings passes parser warnings to OnParseWarning, or logs them
+func (c *Client) reportParseWarnings(warnings []models.ParseWarning) {
+	for _, w := range warnings {
+		if c.OnParseWarning != nil {
+			c.OnParseWarning(w)
+		} else {
+			log.Warn("fantrax parse warning: ", w.String())
+		}
+	}
+}
+
 func hashReadCloser(rc io.ReadCloser) (string, io.ReadCloser, error) {
 	// Read all bytes from the reader
 	body, err := io.ReadAll(rc)
@@ -150,53 +303,27 @@ func hashReadCloser(rc io.ReadCloser) (string, io.ReadCloser, error) {
 type LoginResponse struct {
 	Responses []struct {
 		Data struct {
-			UserInfo models.UserInfo `json:"userInfo"`
+			UserInfo models.UserInfo     `json:"userInfo"`
+			Leagues  []models.UserLeague `json:"leagues,omitempty"`
 		} `json:"data"`
 	} `json:"responses"`
 }
 
 // Login calls the login endpoint and stores user info including timezone data
 func (c *Client) Login() error {
-	// Build the request
-	fullRequest := map[string]interface{}{
-		"msgs": []FantraxMessage{
+	// Never cached: the response holds the user's email address and every league they're in
+	body, err := c.uncached().postFxpa(fxpaRequest{
+		Msgs: []FantraxMessage{
 			{
 				Method: "login",
 				Data:   map[string]interface{}{},
 			},
 		},
-		"uiv":    3,
-		"refUrl": fmt.Sprintf("https://www.fantrax.com/newui/fantasy/miscellaneous.go?leagueId=%s", c.LeagueID),
-		"dt":     0,
-		"at":     0,
-		"av":     "0.0",
-		"tz":     "UTC",
-		"v":      "179.0.1",
-	}
-
-	jsonStr, err := json.Marshal(fullRequest)
+		RefURL:   fmt.Sprintf("https://www.fantrax.com/newui/fantasy/miscellaneous.go?leagueId=%s", c.LeagueID),
+		Timezone: "UTC",
+	})
 	if err != nil {
-		return fmt.Errorf("failed to marshal login request: %w", err)
-	}
-
-	req, err := http.NewRequest("POST", "https://www.fantrax.com/fxpa/req?leagueId="+c.LeagueID, bytes.NewBuffer(jsonStr))
-	if err != nil {
-		return fmt.Errorf("failed to create login request: %w", err)
-	}
-
-	resp, err := c.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to send login request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("login API returned non-200 status code: %d", resp.StatusCode)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read login response body: %w", err)
+		return fmt.Errorf("login request failed: %w", err)
 	}
 
 	var loginResponse LoginResponse
@@ -204,6 +331,7 @@ func (c *Client) Login() error {
 	if err != nil {
 		return fmt.Errorf("failed to unmarshal login response: %w", err)
 	}
+	c.checkSchema("login", body, &loginResponse)
 
 	if len(loginResponse.Responses) == 0 {
 		return fmt.Errorf("no responses in login response")
@@ -211,6 +339,9 @@ func (c *Client) Login() error {
 
 	// Store the user info in the client
 	c.UserInfo = &loginResponse.Responses[0].Data.UserInfo
+	if len(c.UserInfo.Leagues) == 0 {
+		c.UserInfo.Leagues = loginResponse.Responses[0].Data.Leagues
+	}
 
 	// Verify authentication succeeded by checking for user data
 	// When auth fails, Fantrax returns HTTP 200 but with no userInfo data
@@ -8,8 +8,9 @@ import (
 	"github.com/chromedp/cdproto/network"
 	"github.com/chromedp/cdproto/storage"
 	"github.com/chromedp/chromedp"
+	"github.com/pmurley/go-fantrax"
+	"github.com/pmurley/go-fantrax/redact"
 	log "github.com/sirupsen/logrus"
-	"io"
 	"os"
 	"strings"
 	"time"
@@ -21,7 +22,23 @@ var relevantCookies = map[string]bool{
 
 const CacheFile string = CacheDir + "/" + ".fantrax_cookie_cache.json"
 
+// GetCookies finds the Cookie header for Fantrax: FANTRAX_COOKIES, then the cookie cache, then
+// a browser login with FANTRAX_USERNAME and FANTRAX_PASSWORD. The cookie cache is encrypted
+// when FANTRAX_CACHE_KEY or FANTRAX_CACHE_KEY_COMMAND is set.
 func GetCookies() (string, error) {
+	cacheCipher, err := fantrax.CacheCipherFromEnv()
+	if err != nil {
+		return "", err
+	}
+	return getCookies(CacheFile, fantrax.DefaultUserAgent, cacheCipher)
+}
+
+// getCookies is GetCookies with the cookie cache file, the User-Agent the login browser
+// presents, and the cipher for the cookie cache
+//
+// A cache file that exists but can't be read (e.g. one encrypted with a different key) is an
+// error rather than a reason to log in again, which would overwrite it.
+func getCookies(cacheFile, userAgent string, cacheCipher *fantrax.CacheCipher) (string, error) {
 	// First try environment variable
 	if envCookies := os.Getenv("FANTRAX_COOKIES"); envCookies != "" {
 		log.Debug("Found cookies from environment variable")
@@ -29,15 +46,18 @@ func GetCookies() (string, error) {
 	}
 
 	// Then try cache file
-	cookies, err := getCookiesFromCache(CacheFile)
+	cookies, err := getCookiesFromCache(cacheFile, cacheCipher)
 	if err == nil {
 		log.Debug("Found cookies from cache")
 		return convertCookiesToString(cookies)
 	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("failed to read cookie cache %s (delete it to log in again): %w", cacheFile, err)
+	}
 
 	// Finally fall back to browser
 	log.Info("Fetching cookies with browser")
-	cookies, err = GetCookiesWithBrowser(CacheFile)
+	cookies, err = getCookiesWithBrowser(cacheFile, userAgent, cacheCipher)
 	if err != nil {
 		return "", err
 	}
@@ -65,14 +85,12 @@ func convertCookiesToString(cookies []*network.Cookie) (string, error) {
 	}
 }
 
-func getCookiesFromCache(cacheFile string) ([]*network.Cookie, error) {
-	f, err := os.Open(cacheFile)
+func getCookiesFromCache(cacheFile string, cacheCipher *fantrax.CacheCipher) ([]*network.Cookie, error) {
+	data, err := os.ReadFile(cacheFile)
 	if err != nil {
 		return nil, err
 	}
-
-	data, err := io.ReadAll(f)
-	if err != nil {
+	if data, err = cacheCipher.Open(data); err != nil {
 		return nil, err
 	}
 
@@ -85,7 +103,16 @@ func getCookiesFromCache(cacheFile string) ([]*network.Cookie, error) {
 	return cookies, nil
 }
 
+// GetCookiesWithBrowser logs in to Fantrax in a headless browser and saves the cookies to cacheFile
 func GetCookiesWithBrowser(cacheFile string) ([]*network.Cookie, error) {
+	cacheCipher, err := fantrax.CacheCipherFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return getCookiesWithBrowser(cacheFile, fantrax.DefaultUserAgent, cacheCipher)
+}
+
+func getCookiesWithBrowser(cacheFile, userAgent string, cacheCipher *fantrax.CacheCipher) ([]*network.Cookie, error) {
 	// Get credentials from environment variables or command line
 	username := os.Getenv("FANTRAX_USERNAME")
 	password := os.Getenv("FANTRAX_PASSWORD")
@@ -93,6 +120,7 @@ func GetCookiesWithBrowser(cacheFile string) ([]*network.Cookie, error) {
 		return nil, errors.New("unable to fetch cookies from Fantrax." +
 			"FANTRAX_USERNAME and FANTRAX_PASSWORD must be set as environment variables")
 	}
+	redact.AddSecret(username, password)
 
 	// Create a new Chrome instance in headless mode
 	opts := append(chromedp.DefaultExecAllocatorOptions[:],
@@ -101,14 +129,14 @@ func GetCookiesWithBrowser(cacheFile string) ([]*network.Cookie, error) {
 		chromedp.Flag("disable-gpu", true),
 		chromedp.Flag("disable-dev-shm-usage", true),
 		chromedp.Flag("window-size", "1920,1080"),
-		chromedp.UserAgent("Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/97.0.4692.71 Safari/537.36"),
+		chromedp.UserAgent(userAgent),
 	)
 
 	allocCtx, cancel := chromedp.NewExecAllocator(context.Background(), opts...)
 	defer cancel()
 
 	// Create a new browser context with logging
-	ctx, cancel := chromedp.NewContext(allocCtx, chromedp.WithLogf(log.Printf))
+	ctx, cancel := chromedp.NewContext(allocCtx, chromedp.WithLogf(redact.Logf(log.Printf)))
 	defer cancel()
 
 	// Set a timeout for the entire operation
@@ -146,7 +174,7 @@ func GetCookiesWithBrowser(cacheFile string) ([]*network.Cookie, error) {
 		chromedp.Sleep(5*time.Second),
 	)
 	if err != nil {
-		log.Fatalf("Login error: %v", err)
+		return nil, fmt.Errorf("login error: %w", redact.Error(err))
 	}
 
 	fmt.Println("Login successful. Getting auth_client...")
@@ -160,9 +188,13 @@ func GetCookiesWithBrowser(cacheFile string) ([]*network.Cookie, error) {
 
 		return nil
 	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cookies after login: %w", redact.Error(err))
+	}
 
-	// Write our cookies to cache
-	f, err := os.Create(cacheFile)
+	// Write our cookies to cache, readable only by the current user and encrypted when a
+	// cache key is configured
+	f, err := os.OpenFile(cacheFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
 	if err != nil {
 		return nil, err
 	}
@@ -172,6 +204,9 @@ func GetCookiesWithBrowser(cacheFile string) ([]*network.Cookie, error) {
 	if err != nil {
 		return nil, err
 	}
+	if cookieBytes, err = cacheCipher.Seal(cookieBytes); err != nil {
+		return nil, err
+	}
 
 	_, err = f.Write(cookieBytes)
 	if err != nil {
@@ -0,0 +1,39 @@
+package auth_client
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pmurley/go-fantrax"
+)
+
+func TestGetCookiesFromCache(t *testing.T) {
+	t.Setenv("FANTRAX_COOKIES", "")
+	cacheFile := filepath.Join(t.TempDir(), "cookies.json")
+	key, _ := fantrax.NewCacheCipher("right key")
+	sealed, err := key.Seal([]byte(`[{"name":"FX_RM","value":"secret"}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(cacheFile, sealed, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cookies, err := getCookies(cacheFile, "test", key)
+	if err != nil || cookies != "FX_RM=secret" {
+		t.Errorf("getCookies() = %q, %v, want FX_RM=secret", cookies, err)
+	}
+
+	// A wrong or missing key is an error, and the cache is left for the right key
+	wrong, _ := fantrax.NewCacheCipher("wrong key")
+	for _, cacheCipher := range []*fantrax.CacheCipher{wrong, nil} {
+		if _, err := getCookies(cacheFile, "test", cacheCipher); err == nil {
+			t.Error("getCookies() with the wrong key succeeded")
+		}
+	}
+	if data, _ := os.ReadFile(cacheFile); !bytes.Equal(data, sealed) {
+		t.Error("cookie cache was overwritten")
+	}
+}
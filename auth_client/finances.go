@@ -0,0 +1,186 @@
+package auth_client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+)
+
+// LedgerEntryType identifies what a finance ledger entry records
+type LedgerEntryType string
+
+// Finance ledger entry types
+const (
+	LedgerDues    LedgerEntryType = "DUES"    // Amount a team owes (entry fee, penalties)
+	LedgerPayment LedgerEntryType = "PAYMENT" // Amount a team has paid
+)
+
+// LedgerEntry is a single manually recorded charge or payment
+type LedgerEntry struct {
+	TeamID string          `json:"teamId"`
+	Type   LedgerEntryType `json:"type"`
+	Amount float64         `json:"amount"`
+	Note   string          `json:"note,omitempty"`
+	Date   time.Time       `json:"date"`
+}
+
+// TeamBalance is one team's financial position
+type TeamBalance struct {
+	TeamID      string  `json:"teamId"`
+	TeamName    string  `json:"teamName"`
+	Dues        float64 `json:"dues"`        // Manually recorded dues
+	FeesAccrued float64 `json:"feesAccrued"` // Transaction fees computed from league activity
+	Paid        float64 `json:"paid"`
+	Balance     float64 `json:"balance"` // Dues plus fees minus payments; positive means the team owes money
+}
+
+// FinanceLedger tracks dues, payments and accrued transaction fees for each team
+//
+// Dues and payments are recorded by the commissioner; transaction fees are replaced wholesale
+// each time UpdateFees or UpdateFinanceLedger is run, so re-running is always safe. The ledger
+// is JSON-serializable with Save and LoadFinanceLedger.
+type FinanceLedger struct {
+	Entries       []LedgerEntry      `json:"entries"`
+	FeesAccrued   map[string]float64 `json:"feesAccrued"` // Keyed by team ID
+	TeamNames     map[string]string  `json:"teamNames"`   // Keyed by team ID
+	FeesUpdatedAt time.Time          `json:"feesUpdatedAt"`
+}
+
+// NewFinanceLedger creates an empty ledger
+func NewFinanceLedger() *FinanceLedger {
+	return &FinanceLedger{
+		FeesAccrued: make(map[string]float64),
+		TeamNames:   make(map[string]string),
+	}
+}
+
+// LoadFinanceLedger reads a ledger previously written with Save
+func LoadFinanceLedger(path string) (*FinanceLedger, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read finance ledger: %w", err)
+	}
+
+	ledger := NewFinanceLedger()
+	if err := json.Unmarshal(data, ledger); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal finance ledger: %w", err)
+	}
+	if ledger.FeesAccrued == nil {
+		ledger.FeesAccrued = make(map[string]float64)
+	}
+	if ledger.TeamNames == nil {
+		ledger.TeamNames = make(map[string]string)
+	}
+	return ledger, nil
+}
+
+// Save writes the ledger to a JSON file
+func (l *FinanceLedger) Save(path string) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal finance ledger: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write finance ledger: %w", err)
+	}
+	return nil
+}
+
+// RecordDues records an amount owed by a team
+func (l *FinanceLedger) RecordDues(teamID string, amount float64, note string) {
+	l.Entries = append(l.Entries, LedgerEntry{TeamID: teamID, Type: LedgerDues, Amount: amount, Note: note, Date: time.Now()})
+}
+
+// RecordPayment records an amount paid by a team
+func (l *FinanceLedger) RecordPayment(teamID string, amount float64, note string) {
+	l.Entries = append(l.Entries, LedgerEntry{TeamID: teamID, Type: LedgerPayment, Amount: amount, Note: note, Date: time.Now()})
+}
+
+// UpdateFees replaces the accrued transaction fees with the given per-team totals
+func (l *FinanceLedger) UpdateFees(summaries []TeamFeeSummary) {
+	l.FeesAccrued = make(map[string]float64, len(summaries))
+	for _, summary := range summaries {
+		l.FeesAccrued[summary.TeamID] = summary.Total
+		if summary.TeamName != "" {
+			l.TeamNames[summary.TeamID] = summary.TeamName
+		}
+	}
+	l.FeesUpdatedAt = time.Now()
+}
+
+// Balances returns every team's financial position, ordered by balance owed, highest first
+func (l *FinanceLedger) Balances() []TeamBalance {
+	byTeam := make(map[string]*TeamBalance)
+	get := func(teamID string) *TeamBalance {
+		if b, ok := byTeam[teamID]; ok {
+			return b
+		}
+		b := &TeamBalance{TeamID: teamID, TeamName: l.TeamNames[teamID]}
+		byTeam[teamID] = b
+		return b
+	}
+
+	for _, entry := range l.Entries {
+		switch entry.Type {
+		case LedgerDues:
+			get(entry.TeamID).Dues += entry.Amount
+		case LedgerPayment:
+			get(entry.TeamID).Paid += entry.Amount
+		}
+	}
+	for teamID, fees := range l.FeesAccrued {
+		get(teamID).FeesAccrued += fees
+	}
+
+	balances := make([]TeamBalance, 0, len(byTeam))
+	for _, b := range byTeam {
+		b.Balance = b.Dues + b.FeesAccrued - b.Paid
+		balances = append(balances, *b)
+	}
+	sort.Slice(balances, func(i, j int) bool {
+		if balances[i].Balance != balances[j].Balance {
+			return balances[i].Balance > balances[j].Balance
+		}
+		return balances[i].TeamName < balances[j].TeamName
+	})
+	return balances
+}
+
+// WriteMarkdown renders the team balances as a Markdown table for inclusion in a league report
+func (l *FinanceLedger) WriteMarkdown(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "| Team | Dues | Fees | Paid | Balance |\n|---|---:|---:|---:|---:|\n"); err != nil {
+		return fmt.Errorf("failed to write finance table: %w", err)
+	}
+	for _, b := range l.Balances() {
+		name := b.TeamName
+		if name == "" {
+			name = b.TeamID
+		}
+		if _, err := fmt.Fprintf(w, "| %s | %.2f | %.2f | %.2f | %.2f |\n", name, b.Dues, b.FeesAccrued, b.Paid, b.Balance); err != nil {
+			return fmt.Errorf("failed to write finance table: %w", err)
+		}
+	}
+	return nil
+}
+
+// UpdateFinanceLedger recomputes the ledger's accrued transaction fees from league activity
+// and refreshes team names from the league's team list
+func (c *Client) UpdateFinanceLedger(ledger *FinanceLedger) error {
+	summaries, err := c.GetTeamFeeSummary()
+	if err != nil {
+		return err
+	}
+	ledger.UpdateFees(summaries)
+
+	matchups, err := c.GetAllMatchups()
+	if err != nil {
+		return fmt.Errorf("failed to get teams: %w", err)
+	}
+	for id, team := range matchups.Teams {
+		ledger.TeamNames[id] = team.Name
+	}
+	return nil
+}
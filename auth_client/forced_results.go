@@ -0,0 +1,189 @@
+package auth_client
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultForcedWinMargin is how far ahead a forced winner is put when no margin is given
+const DefaultForcedWinMargin = 1.0
+
+// ForcedResult describes a matchup result set by the commissioner
+type ForcedResult struct {
+	Period       int                `json:"period"`
+	WinnerTeamID string             `json:"winnerTeamId"`
+	LoserTeamID  string             `json:"loserTeamId"`
+	Forfeit      bool               `json:"forfeit"`
+	Adjustments  map[string]float64 `json:"adjustments"`       // Team ID -> the score adjustment to enter; empty if the result already stands
+	Matchup      *Matchup           `json:"matchup,omitempty"` // The matchup as last fetched
+}
+
+// PlanMatchupWin works out the score adjustment that makes a team win its matchup in a period
+// (commissioner only)
+//
+// The package doesn't save score adjustments: the fxpa request the commissioner score
+// adjustment dialog sends hasn't been captured. Enter the planned adjustments in the "Adj"
+// column of the commissioner's schedule page, then call ConfirmForcedResult. Points leagues
+// only.
+//
+// Parameters:
+//   - period: The scoring period
+//   - winnerTeamID: The team to win
+//   - margin: How far ahead the winner ends up; 0 for DefaultForcedWinMargin
+func (c *Client) PlanMatchupWin(period int, winnerTeamID string, margin float64) (*ForcedResult, error) {
+	return c.planForcedResult(period, winnerTeamID, false, margin)
+}
+
+// PlanMatchupForfeit works out the score adjustments for a forfeit: the forfeiting team's
+// score goes to zero and its opponent wins (commissioner only). As with PlanMatchupWin, the
+// adjustments are entered by hand and checked with ConfirmForcedResult.
+//
+// Parameters:
+//   - period: The scoring period
+//   - forfeitTeamID: The team forfeiting the matchup
+//   - margin: The opponent's minimum score; 0 for DefaultForcedWinMargin
+func (c *Client) PlanMatchupForfeit(period int, forfeitTeamID string, margin float64) (*ForcedResult, error) {
+	return c.planForcedResult(period, forfeitTeamID, true, margin)
+}
+
+// planForcedResult fetches a team's matchup and plans a forced win or a forfeit
+func (c *Client) planForcedResult(period int, teamID string, forfeit bool, margin float64) (*ForcedResult, error) {
+	if err := c.checkCommissioner(); err != nil {
+		return nil, err
+	}
+	matchups, err := c.freshMatchups()
+	if err != nil {
+		return nil, err
+	}
+	m, err := findPeriodMatchup(matchups, period, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *ForcedResult
+	if forfeit {
+		result, err = PlanForfeit(*m, teamID, margin)
+	} else {
+		result, err = PlanForcedWin(*m, teamID, margin)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.Matchup = m
+	return result, nil
+}
+
+// ConfirmForcedResult checks that a planned result stands once its adjustments have been
+// entered. The period's matchups are fetched without the response cache, and the period
+// cache is cleared so later standings and results fetches see the new scores.
+func (c *Client) ConfirmForcedResult(result *ForcedResult) error {
+	if err := c.InvalidatePeriod(result.Period); err != nil {
+		log.Warn("failed to clear period cache: ", err)
+	}
+	matchups, err := c.freshMatchups()
+	if err != nil {
+		return fmt.Errorf("failed to confirm result: %w", err)
+	}
+	m, err := findPeriodMatchup(matchups, result.Period, result.WinnerTeamID)
+	if err != nil {
+		return fmt.Errorf("failed to confirm result: %w", err)
+	}
+	result.Matchup = m
+	if winner := m.Winner(); winner != result.WinnerTeamID {
+		return fmt.Errorf("matchup in period %d has winner %q, want %s", result.Period, winner, result.WinnerTeamID)
+	}
+	return nil
+}
+
+// freshMatchups fetches the season's matchups without the response cache, which would hold
+// the scores from before an adjustment
+func (c *Client) freshMatchups() ([]Matchup, error) {
+	result, err := c.uncached().GetAllMatchups()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get matchups: %w", err)
+	}
+	return result.Matchups, nil
+}
+
+// PlanForcedWin works out the score adjustment that makes a team win a matchup by margin
+//
+// Parameters:
+//   - m: The matchup
+//   - winnerTeamID: The team to win
+//   - margin: How far ahead the winner ends up; 0 for DefaultForcedWinMargin
+func PlanForcedWin(m Matchup, winnerTeamID string, margin float64) (*ForcedResult, error) {
+	winner, loser, err := forcedResultSides(m, winnerTeamID)
+	if err != nil {
+		return nil, err
+	}
+	if margin <= 0 {
+		margin = DefaultForcedWinMargin
+	}
+
+	result := &ForcedResult{
+		Period:       m.ScoringPeriod,
+		WinnerTeamID: winner.TeamID,
+		LoserTeamID:  loser.TeamID,
+		Adjustments:  make(map[string]float64),
+	}
+	if winner.Total < loser.Total+margin {
+		result.Adjustments[winner.TeamID] = loser.Total + margin - winner.Points
+	}
+	return result, nil
+}
+
+// PlanForfeit works out the score adjustments for a forfeit: the forfeiting team's score goes
+// to zero, and its opponent's is raised to margin if it is below that
+//
+// Parameters:
+//   - m: The matchup
+//   - forfeitTeamID: The team forfeiting
+//   - margin: The opponent's minimum score; 0 for DefaultForcedWinMargin
+func PlanForfeit(m Matchup, forfeitTeamID string, margin float64) (*ForcedResult, error) {
+	loser, winner, err := forcedResultSides(m, forfeitTeamID)
+	if err != nil {
+		return nil, err
+	}
+	if margin <= 0 {
+		margin = DefaultForcedWinMargin
+	}
+
+	result := &ForcedResult{
+		Period:       m.ScoringPeriod,
+		WinnerTeamID: winner.TeamID,
+		LoserTeamID:  loser.TeamID,
+		Forfeit:      true,
+		Adjustments:  make(map[string]float64),
+	}
+	if loser.Total != 0 {
+		result.Adjustments[loser.TeamID] = -loser.Points
+	}
+	if winner.Total < margin {
+		result.Adjustments[winner.TeamID] = margin - winner.Points
+	}
+	return result, nil
+}
+
+// forcedResultSides returns the team's side of a points matchup and its opponent's
+func forcedResultSides(m Matchup, teamID string) (MatchTeam, MatchTeam, error) {
+	if len(m.Categories) > 0 {
+		return MatchTeam{}, MatchTeam{}, fmt.Errorf("period %d is a category matchup; results can only be forced in points leagues", m.ScoringPeriod)
+	}
+	if m.AwayTeam.TeamID != teamID && m.HomeTeam.TeamID != teamID {
+		return MatchTeam{}, MatchTeam{}, fmt.Errorf("team %s is not in the matchup", teamID)
+	}
+	team, opponent := matchSides(m, teamID)
+	return team, opponent, nil
+}
+
+// findPeriodMatchup finds a team's matchup in a scoring period
+func findPeriodMatchup(matchups []Matchup, period int, teamID string) (*Matchup, error) {
+	for i := range matchups {
+		m := &matchups[i]
+		if m.ScoringPeriod == period && (m.AwayTeam.TeamID == teamID || m.HomeTeam.TeamID == teamID) {
+			return m, nil
+		}
+	}
+	return nil, fmt.Errorf("team %s has no matchup in period %d", teamID, period)
+}
@@ -0,0 +1,35 @@
+package auth_client
+
+import "testing"
+
+func TestPlanForcedResults(t *testing.T) {
+	m := Matchup{
+		ScoringPeriod: 5,
+		AwayTeam:      MatchTeam{TeamID: "a", Points: 90, Adjustment: 2, Total: 92},
+		HomeTeam:      MatchTeam{TeamID: "b", Points: 100, Total: 100},
+	}
+
+	win, err := PlanForcedWin(m, "a", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a needs 101 total: 90 points + 11
+	if win.WinnerTeamID != "a" || win.LoserTeamID != "b" || win.Adjustments["a"] != 11 || len(win.Adjustments) != 1 {
+		t.Errorf("unexpected forced win: %+v", win)
+	}
+	if win, _ := PlanForcedWin(m, "b", 0); len(win.Adjustments) != 0 {
+		t.Errorf("expected no adjustment for a team already winning, got %+v", win.Adjustments)
+	}
+
+	forfeit, err := PlanForfeit(m, "b", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !forfeit.Forfeit || forfeit.WinnerTeamID != "a" || forfeit.Adjustments["b"] != -100 || len(forfeit.Adjustments) != 1 {
+		t.Errorf("unexpected forfeit: %+v", forfeit)
+	}
+
+	if _, err := PlanForcedWin(m, "c", 0); err == nil {
+		t.Error("expected an error for a team not in the matchup")
+	}
+}
@@ -0,0 +1,124 @@
+package auth_client
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// FreeAgentSort is the order GetFreeAgents ranks players in
+type FreeAgentSort string
+
+const (
+	SortByFantasyPoints   FreeAgentSort = "FPTS"    // Total fantasy points, highest first
+	SortByFantasyPointsPG FreeAgentSort = "FPG"     // Fantasy points per game, highest first
+	SortByRank            FreeAgentSort = "RANK"    // Fantrax rank, best first
+	SortByPercentRostered FreeAgentSort = "ROST"    // % of leagues rostering, highest first
+	SortByRosterChange    FreeAgentSort = "ROST_CH" // Change in roster %, biggest riser first
+	SortByADP             FreeAgentSort = "ADP"     // Average draft position, earliest first
+)
+
+// GetFreeAgents fetches the free agents and waiver players eligible at a position, ranked
+//
+// Only available players are requested (StatusFilterAvailable), and the position is matched
+// against each player's eligible positions on this side, since the player pool request has no
+// position filter.
+//
+// Parameters:
+//   - position: A position short name such as "SS" or "SP"; empty for any position
+//   - sortBy: The ranking; empty for SortByFantasyPoints
+//   - limit: The most players to return; 0 for all
+func (c *Client) GetFreeAgents(position string, sortBy FreeAgentSort, limit int) ([]models.PoolPlayer, error) {
+	if _, err := freeAgentLess(sortBy); err != nil {
+		return nil, err
+	}
+
+	var matching []models.PoolPlayer
+	err := c.GetPlayerPoolStream(func(page []models.PoolPlayer) error {
+		for _, player := range page {
+			if player.FantasyTeamID == "" && eligibleAt(player, position) {
+				matching = append(matching, player)
+			}
+		}
+		return nil
+	}, WithStatusFilter(StatusFilterAvailable))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get free agents: %w", err)
+	}
+	return RankFreeAgents(matching, position, sortBy, limit)
+}
+
+// RankFreeAgents picks the unrostered players eligible at a position out of a player pool and
+// ranks them. Ties are broken by name.
+//
+// Parameters:
+//   - players: The player pool, e.g. from GetPlayerPool
+//   - position: A position short name such as "SS" or "SP"; empty for any position
+//   - sortBy: The ranking; empty for SortByFantasyPoints
+//   - limit: The most players to return; 0 for all
+func RankFreeAgents(players []models.PoolPlayer, position string, sortBy FreeAgentSort, limit int) ([]models.PoolPlayer, error) {
+	less, err := freeAgentLess(sortBy)
+	if err != nil {
+		return nil, err
+	}
+
+	var ranked []models.PoolPlayer
+	for _, player := range players {
+		if player.FantasyTeamID == "" && eligibleAt(player, position) {
+			ranked = append(ranked, player)
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if less(ranked[i], ranked[j]) {
+			return true
+		}
+		if less(ranked[j], ranked[i]) {
+			return false
+		}
+		return ranked[i].Name < ranked[j].Name
+	})
+	if limit > 0 && len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	return ranked, nil
+}
+
+// freeAgentLess returns the comparison for a ranking
+func freeAgentLess(sortBy FreeAgentSort) (func(a, b models.PoolPlayer) bool, error) {
+	switch sortBy {
+	case "", SortByFantasyPoints:
+		return func(a, b models.PoolPlayer) bool { return a.FantasyPoints > b.FantasyPoints }, nil
+	case SortByFantasyPointsPG:
+		return func(a, b models.PoolPlayer) bool { return a.FantasyPointsPerG > b.FantasyPointsPerG }, nil
+	case SortByPercentRostered:
+		return func(a, b models.PoolPlayer) bool { return a.PercentRostered > b.PercentRostered }, nil
+	case SortByRosterChange:
+		return func(a, b models.PoolPlayer) bool { return a.RosterChange > b.RosterChange }, nil
+	case SortByRank:
+		// Unranked players (0) go last
+		return func(a, b models.PoolPlayer) bool {
+			return a.Rank > 0 && (b.Rank == 0 || a.Rank < b.Rank)
+		}, nil
+	case SortByADP:
+		return func(a, b models.PoolPlayer) bool {
+			return a.ADP > 0 && (b.ADP == 0 || a.ADP < b.ADP)
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown free agent sort %q", sortBy)
+}
+
+// eligibleAt reports whether a player is eligible at a position short name. Utility slots
+// such as "UT" match only when asked for by name.
+func eligibleAt(player models.PoolPlayer, position string) bool {
+	if position == "" {
+		return true
+	}
+	for _, pos := range strings.Split(stripHTML(player.PosShortNames), ",") {
+		if strings.EqualFold(strings.TrimSpace(pos), position) {
+			return true
+		}
+	}
+	return false
+}
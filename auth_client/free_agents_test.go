@@ -0,0 +1,34 @@
+package auth_client
+
+import (
+	"testing"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestRankFreeAgents(t *testing.T) {
+	players := []models.PoolPlayer{
+		{Name: "Able", PosShortNames: "<b>SS</b>,2B", FantasyPoints: 50, Rank: 0},
+		{Name: "Baker", PosShortNames: "SS,UT", FantasyPoints: 80, Rank: 40},
+		{Name: "Cole", PosShortNames: "SS", FantasyPoints: 90, FantasyTeamID: "team01", Rank: 5},
+		{Name: "Dunn", PosShortNames: "SP", FantasyPoints: 120, Rank: 10},
+		{Name: "Eads", PosShortNames: "2B,SS", FantasyPoints: 80, Rank: 30},
+	}
+
+	ranked, err := RankFreeAgents(players, "ss", "", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ranked) != 2 || ranked[0].Name != "Baker" || ranked[1].Name != "Eads" {
+		t.Errorf("unexpected points ranking: %+v", ranked)
+	}
+
+	ranked, _ = RankFreeAgents(players, "SS", SortByRank, 0)
+	if len(ranked) != 3 || ranked[0].Name != "Eads" || ranked[2].Name != "Able" {
+		t.Errorf("unexpected rank ranking: %+v", ranked)
+	}
+
+	if _, err := RankFreeAgents(players, "", "BOGUS", 0); err == nil {
+		t.Error("expected an error for an unknown sort")
+	}
+}
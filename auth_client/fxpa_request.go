@@ -0,0 +1,146 @@
+package auth_client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// DefaultAppVersion is the Fantrax web app version sent with fxpa requests when
+// Client.AppVersion is empty. Bump it (or set AppVersion) when Fantrax starts rejecting
+// requests from older app versions.
+const DefaultAppVersion = "179.0.1"
+
+// DefaultMaxResponseBytes is the largest fxpa response read when Client.MaxResponseBytes is 0
+const DefaultMaxResponseBytes int64 = 256 << 20
+
+// ErrResponseTooLarge is returned when an fxpa response is larger than the client's maximum
+// response size
+var ErrResponseTooLarge = errors.New("response exceeds the maximum size")
+
+// StatusError is returned when Fantrax answers an fxpa request with a status other than 200
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("API returned non-200 status code: %d", e.StatusCode)
+}
+
+// fxpaRefPaths maps each fxpa method to the league page the Fantrax web app calls it from
+var fxpaRefPaths = map[string]string{
+	"confirmOrExecuteTeamRosterChanges": "/team/roster#league-team-roster-confirm-dialog",
+	"getLeaguePolls":                    "/polls",
+	"getLeagueHomeInfo":                 "/home",
+	"getPlayerStats":                    "/players",
+	"getStandings":                      "/standings",
+	"getTeamRosterInfo":                 "/team/roster",
+	"getTeamServiceTime":                "/team/service-time",
+	"getTradeBlocks":                    "/trade-block",
+	"getTransactionDetailsHistory":      "/transactions/history",
+}
+
+// fxpaRequest describes one POST to the fxpa/req endpoint
+type fxpaRequest struct {
+	Msgs []FantraxMessage
+
+	// RefURL is the page the request claims to come from. Defaults to fxpaRefURL of the
+	// first message's method.
+	RefURL string
+
+	// DisplayType is the "dt" field; the web app sends 1 for requests made from a dialog
+	DisplayType int
+
+	// Timezone is the "tz" field. Defaults to the user's timezone (see getTimezone).
+	Timezone string
+}
+
+// appVersion returns the app version to send in the "v" field
+func (c *Client) appVersion() string {
+	if c.AppVersion != "" {
+		return c.AppVersion
+	}
+	return DefaultAppVersion
+}
+
+// fxpaRefURL returns the default refUrl for a method, falling back to the league home page
+func (c *Client) fxpaRefURL(method string) string {
+	refPath, ok := fxpaRefPaths[method]
+	if !ok {
+		refPath = "/home"
+	}
+	return fmt.Sprintf("https://www.fantrax.com/fantasy/league/%s%s", c.LeagueID, refPath)
+}
+
+// fxpaEnvelope builds the full request body with the metadata fields the web app sends
+func (c *Client) fxpaEnvelope(r fxpaRequest) map[string]interface{} {
+	refURL := r.RefURL
+	if refURL == "" && len(r.Msgs) > 0 {
+		refURL = c.fxpaRefURL(r.Msgs[0].Method)
+	}
+	timezone := r.Timezone
+	if timezone == "" {
+		timezone = c.getTimezone()
+	}
+
+	return map[string]interface{}{
+		"msgs":   r.Msgs,
+		"uiv":    3,
+		"refUrl": refURL,
+		"dt":     r.DisplayType,
+		"at":     0,
+		"av":     "0.0",
+		"tz":     timezone,
+		"v":      c.appVersion(),
+	}
+}
+
+// postFxpa sends a request to the fxpa/req endpoint and returns the response body
+func (c *Client) postFxpa(r fxpaRequest) ([]byte, error) {
+	jsonStr, err := json.Marshal(c.fxpaEnvelope(r))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request payload: %w", err)
+	}
+
+	if isReadOnlyFxpa(r) {
+		return c.dedupRead("fxpa\n"+string(jsonStr), func() ([]byte, error) { return c.sendFxpa(jsonStr) })
+	}
+	return c.sendFxpa(jsonStr)
+}
+
+// sendFxpa posts an encoded request body to the fxpa/req endpoint
+func (c *Client) sendFxpa(jsonStr []byte) ([]byte, error) {
+	req, err := http.NewRequest("POST", "https://www.fantrax.com/fxpa/req?leagueId="+c.LeagueID, bytes.NewBuffer(jsonStr))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Check for non-200 status code
+	if resp.StatusCode != http.StatusOK {
+		return nil, &StatusError{StatusCode: resp.StatusCode}
+	}
+
+	limit := c.MaxResponseBytes
+	if limit <= 0 {
+		limit = DefaultMaxResponseBytes
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if int64(len(body)) > limit {
+		return nil, fmt.Errorf("%w of %d bytes", ErrResponseTooLarge, limit)
+	}
+	c.recordRoles(body)
+
+	return body, nil
+}
@@ -0,0 +1,27 @@
+package auth_client
+
+import "testing"
+
+func TestFxpaEnvelope(t *testing.T) {
+	c := &Client{LeagueID: "abc"}
+	env := c.fxpaEnvelope(fxpaRequest{Msgs: []FantraxMessage{{Method: "getStandings"}}})
+
+	if env["refUrl"] != "https://www.fantrax.com/fantasy/league/abc/standings" {
+		t.Errorf("unexpected refUrl %v", env["refUrl"])
+	}
+	if env["v"] != DefaultAppVersion {
+		t.Errorf("expected default app version, got %v", env["v"])
+	}
+	if env["tz"] != "UTC" {
+		t.Errorf("expected UTC without user info, got %v", env["tz"])
+	}
+
+	c.AppVersion = "180.0.0"
+	env = c.fxpaEnvelope(fxpaRequest{Msgs: []FantraxMessage{{Method: "unknownMethod"}}, DisplayType: 1})
+	if env["v"] != "180.0.0" || env["dt"] != 1 {
+		t.Errorf("overrides not applied: v=%v dt=%v", env["v"], env["dt"])
+	}
+	if env["refUrl"] != "https://www.fantrax.com/fantasy/league/abc/home" {
+		t.Errorf("unexpected fallback refUrl %v", env["refUrl"])
+	}
+}
@@ -0,0 +1,287 @@
+package auth_client
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pmurley/go-fantrax"
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// LineupSlot is one active hitter slot and the player assigned to it
+type LineupSlot struct {
+	Slot   string               `json:"slot"`   // Position short name (e.g. "SS", "UT")
+	Player *models.RosterPlayer `json:"player"` // Nil if no eligible hitter is left for the slot
+	Games  int                  `json:"games"`
+}
+
+// GamesPlayedLineup is the active hitter lineup that plays the most games in a period
+type GamesPlayedLineup struct {
+	Slots        []LineupSlot          `json:"slots"`
+	TotalGames   int                   `json:"totalGames"`
+	CurrentGames int                   `json:"currentGames"` // Games the current active hitters play
+	Start        []models.RosterPlayer `json:"start"`        // Reserve hitters to activate
+	Bench        []models.RosterPlayer `json:"bench"`        // Active hitters to move to reserve
+}
+
+// OptimizeGamesPlayed assigns a team's hitters to its active slots to maximize games played
+// in a weekly period, and lists the lineup changes that gets there
+//
+// Ties in games go to the better FP/G hitter, then to whoever is already active, so the
+// suggestion only moves players when it gains games. Pitcher slots and pitchers are left out;
+// IR and minors players are not considered.
+//
+// Parameters:
+//   - roster: The team's roster for the period
+//   - slots: Active slots per position short name (e.g. RosterLimits.MaxActiveByPosition);
+//     "UT"/"Util" slots take any hitter, "MI" 2B or SS, "CI" 1B or 3B, "IF" any infielder,
+//     and "OF" any outfielder
+//   - games: Games each MLB team plays in the period, keyed by team abbreviation (from an
+//     MLB schedule; Fantrax does not publish team game counts)
+//   - fpg: Optional FP/G by player ID for breaking ties; may be nil
+func OptimizeGamesPlayed(roster *models.TeamRoster, slots map[string]int, games map[string]int, fpg map[string]float64) *GamesPlayedLineup {
+	var seats []string
+	names := make([]string, 0, len(slots))
+	for slot := range slots {
+		names = append(names, slot)
+	}
+	sort.Strings(names)
+	for _, slot := range names {
+		if isPitcherSlot(slot) {
+			continue
+		}
+		for i := 0; i < slots[slot]; i++ {
+			seats = append(seats, slot)
+		}
+	}
+
+	active := make(map[string]bool)
+	var hitters []models.RosterPlayer
+	for _, group := range [][]models.RosterPlayer{roster.ActiveRoster, roster.ReserveRoster} {
+		for _, player := range group {
+			if isRosterPitcher(player) {
+				continue
+			}
+			hitters = append(hitters, player)
+		}
+	}
+	for _, player := range roster.ActiveRoster {
+		active[player.PlayerID] = true
+	}
+
+	lineup := &GamesPlayedLineup{}
+	for _, player := range roster.ActiveRoster {
+		if !isRosterPitcher(player) {
+			lineup.CurrentGames += games[player.TeamShortName]
+		}
+	}
+
+	// Maximize games first, then FP/G, then keeping active players active
+	weight := func(player models.RosterPlayer) float64 {
+		w := float64(games[player.TeamShortName])*1e6 + math.Min(fpg[player.PlayerID], 999)*1e3
+		if active[player.PlayerID] {
+			w++
+		}
+		return w
+	}
+	cost := make([][]float64, len(seats))
+	for i, seat := range seats {
+		cost[i] = make([]float64, len(hitters)+len(seats)) // Extra columns leave a seat empty
+		for j, player := range hitters {
+			if slotAccepts(seat, rosterPlayerPositions(player)) {
+				cost[i][j] = -weight(player)
+			} else {
+				cost[i][j] = math.Inf(1)
+			}
+		}
+	}
+
+	chosen := make(map[string]bool)
+	for i, j := range assignMinCost(cost) {
+		slot := LineupSlot{Slot: seats[i]}
+		if j < len(hitters) && !math.IsInf(cost[i][j], 1) {
+			player := hitters[j]
+			slot.Player = &player
+			slot.Games = games[player.TeamShortName]
+			chosen[player.PlayerID] = true
+		}
+		lineup.TotalGames += slot.Games
+		lineup.Slots = append(lineup.Slots, slot)
+	}
+
+	for _, player := range hitters {
+		switch {
+		case chosen[player.PlayerID] && !active[player.PlayerID]:
+			lineup.Start = append(lineup.Start, player)
+		case !chosen[player.PlayerID] && active[player.PlayerID]:
+			lineup.Bench = append(lineup.Bench, player)
+		}
+	}
+	return lineup
+}
+
+// OptimizeGamesPlayed fetches a team's roster for a period and the league's active slots,
+// and suggests the hitter lineup that plays the most games
+//
+// Parameters:
+//   - teamID: The fantasy team ID (empty string = the user's team, see GetMyTeamID)
+//   - period: The scoring period
+//   - games: Games each MLB team plays in the period, keyed by team abbreviation
+func (c *Client) OptimizeGamesPlayed(teamID string, period int, games map[string]int) (*GamesPlayedLineup, error) {
+	teamID, err := c.teamOrMine(teamID)
+	if err != nil {
+		return nil, err
+	}
+	roster, err := c.GetTeamRosterInfo(strconv.Itoa(period), teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roster for team %s: %w", teamID, err)
+	}
+
+	publicClient, err := fantrax.NewClient(c.LeagueID, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create public client: %w", err)
+	}
+	info, err := publicClient.GetLeagueInfo(c.LeagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league roster settings: %w", err)
+	}
+
+	fpg := make(map[string]float64)
+	for _, player := range roster.AllPlayers() {
+		if player.Stats != nil && player.Stats.Batting != nil && player.Stats.Batting.FantasyPointsPerGame != nil {
+			fpg[player.PlayerID] = *player.Stats.Batting.FantasyPointsPerGame
+		}
+	}
+	return OptimizeGamesPlayed(roster, RosterLimitsFromLeagueInfo(info).MaxActiveByPosition, games, fpg), nil
+}
+
+// slotAccepts reports whether a player with the given positions can fill a slot
+func slotAccepts(slot string, positions []string) bool {
+	slot = strings.ToUpper(slot)
+	for _, pos := range positions {
+		pos = strings.ToUpper(pos)
+		if pos == slot {
+			return true
+		}
+		switch slot {
+		case "UT", "UTIL":
+			if pos != "SP" && pos != "RP" && pos != "P" {
+				return true
+			}
+		case "MI":
+			if pos == "2B" || pos == "SS" {
+				return true
+			}
+		case "CI":
+			if pos == "1B" || pos == "3B" {
+				return true
+			}
+		case "IF":
+			if pos == "1B" || pos == "2B" || pos == "3B" || pos == "SS" {
+				return true
+			}
+		case "OF":
+			if pos == "LF" || pos == "CF" || pos == "RF" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isPitcherSlot(slot string) bool {
+	switch strings.ToUpper(slot) {
+	case "SP", "RP", "P":
+		return true
+	}
+	return false
+}
+
+// isRosterPitcher reports whether a roster player is eligible only as a pitcher
+func isRosterPitcher(player models.RosterPlayer) bool {
+	positions := rosterPlayerPositions(player)
+	for _, pos := range positions {
+		if !isPitcherSlot(pos) {
+			return false
+		}
+	}
+	return len(positions) > 0
+}
+
+// assignMinCost solves the assignment problem for an n×m cost matrix (n ≤ m) with the
+// Hungarian algorithm, returning the column assigned to each row. Infinite costs mark
+// forbidden pairs; the caller supplies enough finite columns for every row.
+func assignMinCost(cost [][]float64) []int {
+	n := len(cost)
+	if n == 0 {
+		return nil
+	}
+	m := len(cost[0])
+	const forbidden = 1e15
+	at := func(i, j int) float64 {
+		if math.IsInf(cost[i][j], 1) {
+			return forbidden
+		}
+		return cost[i][j]
+	}
+
+	// Potentials and matching are 1-indexed, with row/column 0 as the sentinel
+	u := make([]float64, n+1)
+	v := make([]float64, m+1)
+	match := make([]int, m+1) // match[j] = row assigned to column j
+	way := make([]int, m+1)
+	for i := 1; i <= n; i++ {
+		match[0] = i
+		j0 := 0
+		minv := make([]float64, m+1)
+		used := make([]bool, m+1)
+		for j := range minv {
+			minv[j] = math.Inf(1)
+		}
+		for {
+			used[j0] = true
+			i0, delta, j1 := match[j0], math.Inf(1), 0
+			for j := 1; j <= m; j++ {
+				if used[j] {
+					continue
+				}
+				if cur := at(i0-1, j-1) - u[i0] - v[j]; cur < minv[j] {
+					minv[j] = cur
+					way[j] = j0
+				}
+				if minv[j] < delta {
+					delta = minv[j]
+					j1 = j
+				}
+			}
+			for j := 0; j <= m; j++ {
+				if used[j] {
+					u[match[j]] += delta
+					v[j] -= delta
+				} else {
+					minv[j] -= delta
+				}
+			}
+			j0 = j1
+			if match[j0] == 0 {
+				break
+			}
+		}
+		for j0 != 0 {
+			j1 := way[j0]
+			match[j0] = match[j1]
+			j0 = j1
+		}
+	}
+
+	assignment := make([]int, n)
+	for j := 1; j <= m; j++ {
+		if match[j] != 0 {
+			assignment[match[j]-1] = j - 1
+		}
+	}
+	return assignment
+}
@@ -0,0 +1,41 @@
+package auth_client
+
+import (
+	"testing"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestOptimizeGamesPlayed(t *testing.T) {
+	hitter := func(id, positions, team string) models.RosterPlayer {
+		return models.RosterPlayer{PlayerID: id, Name: id, PosShortNames: positions, TeamShortName: team}
+	}
+	roster := &models.TeamRoster{
+		ActiveRoster: []models.RosterPlayer{
+			hitter("ss5", "SS", "SF"),
+			hitter("of5", "OF", "SF"),
+			hitter("c6", "C", "NYY"),
+			hitter("sp", "SP", "SF"),
+		},
+		ReserveRoster: []models.RosterPlayer{
+			hitter("2b7", "2B,SS", "LAD"),
+			hitter("of7", "LF,UT", "LAD"),
+			hitter("of6", "OF", "NYY"),
+		},
+	}
+	games := map[string]int{"SF": 5, "LAD": 7, "NYY": 6}
+	slots := map[string]int{"C": 1, "SS": 1, "OF": 1, "UT": 1, "SP": 2}
+
+	lineup := OptimizeGamesPlayed(roster, slots, games, nil)
+	if lineup.CurrentGames != 16 || lineup.TotalGames != 26 {
+		t.Fatalf("expected 16 games now and 26 optimized, got %d and %d", lineup.CurrentGames, lineup.TotalGames)
+	}
+	if len(lineup.Start) != 3 || len(lineup.Bench) != 2 {
+		t.Errorf("expected 3 starts and 2 benchings, got %v and %v", lineup.Start, lineup.Bench)
+	}
+	for _, slot := range lineup.Slots {
+		if slot.Slot == "SS" && (slot.Player == nil || slot.Player.PlayerID != "2b7") {
+			t.Errorf("expected 2b7 at SS, got %+v", slot.Player)
+		}
+	}
+}
@@ -1,11 +1,8 @@
 package auth_client
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"strconv"
 	"strings"
 )
@@ -16,47 +13,31 @@ type AllMatchupsResult struct {
 	Teams    map[string]FantasyTeam `json:"teams"` // keyed by teamId
 }
 
+// GetAllMatchupsRaw fetches the standings SCHEDULE view without parsing it
+func (c *Client) GetAllMatchupsRaw() (json.RawMessage, error) {
+	return c.GetStandingsRaw(WithStandingsView(StandingsViewSchedule))
+}
+
 // GetAllMatchups returns all matchups for the season using the SCHEDULE view
 func (c *Client) GetAllMatchups() (*AllMatchupsResult, error) {
-	var requestPayload = FantraxRequest{
-		Msgs: []FantraxMessage{
-			{
-				Method: "getStandings",
-				Data: map[string]string{
-					"leagueId": c.LeagueID,
-					"view":     "SCHEDULE",
-				},
-			},
-		},
-	}
-
-	jsonStr, err := json.Marshal(requestPayload)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request payload: %w", err)
-	}
-
-	req, err := http.NewRequest("POST", "https://www.fantrax.com/fxpa/req?leagueId="+c.LeagueID, bytes.NewBuffer(jsonStr))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := c.Do(req)
+	body, err := c.GetAllMatchupsRaw()
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned non-200 status code: %d", resp.StatusCode)
+		return nil, err
 	}
+	return ParseAllMatchupsAs(body, c.LeagueFormat)
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
+// ParseAllMatchups parses a standings SCHEDULE view response (as returned by
+// GetAllMatchupsRaw) into the season's matchups
+func ParseAllMatchups(body []byte) (*AllMatchupsResult, error) {
+	return ParseAllMatchupsAs(body, "")
+}
 
+// ParseAllMatchupsAs is ParseAllMatchups for a league whose format is known from its
+// settings (see Client.LeagueFormat)
+func ParseAllMatchupsAs(body []byte, league LeagueFormat) (*AllMatchupsResult, error) {
 	var response StandingsResponse
-	err = json.Unmarshal(body, &response)
+	err := json.Unmarshal(body, &response)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
@@ -76,6 +57,12 @@ func (c *Client) GetAllMatchups() (*AllMatchupsResult, error) {
 	// Completed matchups use H2hPointsBased3 with 8 cells (pts/adj/total split out).
 	// Future/unplayed matchups use H2hPointsBased2 with 4 cells (team/score pairs).
 	for _, table := range responseData.TableList {
+		if tableFormat(table, league) == LeagueFormatH2HCategories {
+			if period, date, ok := scoringPeriodCaption(table); ok {
+				result.Matchups = append(result.Matchups, parseCategoryMatchups(table, period, date)...)
+			}
+			continue
+		}
 		if table.TableType != "H2hPointsBased3" && table.TableType != "H2hPointsBased2" {
 			continue
 		}
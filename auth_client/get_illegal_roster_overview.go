@@ -2,8 +2,6 @@ package auth_client
 
 import (
 	"fmt"
-	"io"
-	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
@@ -24,37 +22,25 @@ func (c *Client) GetIllegalRosterOverview() (*models.IllegalRosterOverview, erro
 	return parseIllegalRosterOverview(html)
 }
 
-// fetchIllegalRosterHTML makes a GET request to the illegal roster override admin page.
-func (c *Client) fetchIllegalRosterHTML() (string, error) {
-	url := fmt.Sprintf("https://www.fantrax.com/newui/fantasy/illegalRosterOverrideAdmin.go?leagueId=%s", c.LeagueID)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
-	}
-
-	cookiesString, err := GetCookies()
-	if err != nil {
-		return "", fmt.Errorf("failed to get cookies: %w", err)
-	}
-	req.Header.Set("Cookie", cookiesString)
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko)")
-
-	resp, err := c.Client.Do(req)
+// GetIllegalRosterOverviewRaw fetches the illegal roster override page HTML without parsing it
+func (c *Client) GetIllegalRosterOverviewRaw() ([]byte, error) {
+	html, err := c.fetchIllegalRosterHTML()
 	if err != nil {
-		return "", fmt.Errorf("failed to send request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, fmt.Errorf("failed to fetch illegal roster page: %w", err)
 	}
+	return []byte(html), nil
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %w", err)
-	}
+// ParseIllegalRosterOverview parses illegal roster override page HTML (as returned by
+// GetIllegalRosterOverviewRaw)
+func ParseIllegalRosterOverview(html string) (*models.IllegalRosterOverview, error) {
+	return parseIllegalRosterOverview(html)
+}
 
-	return string(body), nil
+// fetchIllegalRosterHTML makes a GET request to the illegal roster override admin page.
+func (c *Client) fetchIllegalRosterHTML() (string, error) {
+	url := fmt.Sprintf("https://www.fantrax.com/newui/fantasy/illegalRosterOverrideAdmin.go?leagueId=%s", c.LeagueID)
+	return c.fetchPageHTML(url)
 }
 
 // parseIllegalRosterOverview parses the HTML from the illegal roster override admin page.
@@ -0,0 +1,150 @@
+package auth_client
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// StandingsViewPlayoffs returns the playoff bracket matchups. It is the ID of the playoffs
+// tab the standings page lists beside its other views; GetStandingsRaw fails with
+// ErrStandingsViewNotShown if Fantrax answers with another view.
+const StandingsViewPlayoffs StandingsView = "PLAYOFFS"
+
+// SeasonLeague identifies the Fantrax league for one season
+//
+// Fantrax creates a new league ID each time a league is renewed, and none of the responses
+// this package reads link a league to its earlier seasons, so the league IDs have to come
+// from the caller.
+type SeasonLeague struct {
+	Year     int // 0 takes the year the season's standings start in
+	LeagueID string
+}
+
+// LeagueHistory contains the archived results of past seasons, oldest first
+type LeagueHistory struct {
+	Seasons []SeasonHistory `json:"seasons"`
+}
+
+// SeasonHistory contains the final standings and playoff results for one season
+type SeasonHistory struct {
+	Year            int              `json:"year"`
+	LeagueID        string           `json:"leagueId"`
+	LeagueName      string           `json:"leagueName"`
+	Standings       *LeagueStandings `json:"standings"`
+	PlayoffMatchups []Matchup        `json:"playoffMatchups"`
+	ChampionTeamID  string           `json:"championTeamId,omitempty"` // Empty if the champion could not be determined
+	ChampionName    string           `json:"championName,omitempty"`
+}
+
+// Winner returns the team ID of the matchup winner, or an empty string for a tie
+func (m Matchup) Winner() string {
+	switch compareTotals(m.AwayTeam.Total, m.HomeTeam.Total) {
+	case 1:
+		return m.AwayTeam.TeamID
+	case -1:
+		return m.HomeTeam.TeamID
+	default:
+		return ""
+	}
+}
+
+// GetLeagueHistory fetches final standings and playoff results for past seasons
+//
+// Each season is fetched using its own league ID with the current credentials, so the
+// authenticated user must have access to every league listed.
+//
+// Parameters:
+//   - seasons: The league ID for each season to include, with its year if known
+//
+// Returns the seasons ordered oldest first, or an error if any season could not be fetched.
+func (c *Client) GetLeagueHistory(seasons []SeasonLeague) (*LeagueHistory, error) {
+	history := &LeagueHistory{
+		Seasons: make([]SeasonHistory, 0, len(seasons)),
+	}
+
+	for _, season := range seasons {
+		seasonHistory, err := c.forLeague(season.LeagueID).getSeasonHistory(season.Year)
+		if err != nil {
+			if season.Year == 0 {
+				return nil, fmt.Errorf("failed to get history for league %s: %w", season.LeagueID, err)
+			}
+			return nil, fmt.Errorf("failed to get history for %d season: %w", season.Year, err)
+		}
+		history.Seasons = append(history.Seasons, *seasonHistory)
+	}
+
+	sort.SliceStable(history.Seasons, func(i, j int) bool {
+		return history.Seasons[i].Year < history.Seasons[j].Year
+	})
+
+	return history, nil
+}
+
+// getSeasonHistory fetches the archived results for the client's league
+func (c *Client) getSeasonHistory(year int) (*SeasonHistory, error) {
+	standings, err := c.GetStandings(WithStandingsView(StandingsViewAll))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get final standings: %w", err)
+	}
+
+	playoffs, err := c.GetStandings(WithStandingsView(StandingsViewPlayoffs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get playoff results: %w", err)
+	}
+
+	if year == 0 && standings.SeasonDates.StartDate > 0 {
+		year = time.UnixMilli(standings.SeasonDates.StartDate).UTC().Year()
+	}
+
+	season := &SeasonHistory{
+		Year:            year,
+		LeagueID:        c.LeagueID,
+		LeagueName:      standings.LeagueName,
+		Standings:       standings,
+		PlayoffMatchups: playoffs.Matchups,
+	}
+
+	season.ChampionTeamID = championFromPlayoffs(playoffs.Matchups)
+	for _, team := range standings.Teams {
+		if team.TeamID == season.ChampionTeamID {
+			season.ChampionName = team.Name
+			break
+		}
+	}
+
+	return season, nil
+}
+
+// championFromPlayoffs returns the winner of the final playoff period when that period
+// contains a single matchup. Brackets that also play a consolation game in the final
+// period are ambiguous, so an empty string is returned for them.
+func championFromPlayoffs(matchups []Matchup) string {
+	finalPeriod := 0
+	for _, m := range matchups {
+		if m.ScoringPeriod > finalPeriod {
+			finalPeriod = m.ScoringPeriod
+		}
+	}
+
+	var finals []Matchup
+	for _, m := range matchups {
+		if m.ScoringPeriod == finalPeriod {
+			finals = append(finals, m)
+		}
+	}
+
+	if len(finals) != 1 {
+		return ""
+	}
+	return finals[0].Winner()
+}
+
+// forLeague returns a copy of the client that targets a different league with the same
+// credentials and settings
+func (c *Client) forLeague(leagueID string) *Client {
+	clone := *c
+	clone.LeagueID = leagueID
+	clone.roles = &leagueRoles{}
+	return &clone
+}
@@ -1,13 +1,10 @@
 package auth_client
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
+	"time"
 )
-
 // ============================================================
 // Raw API Response Types
 // ============================================================
@@ -28,6 +25,10 @@ type LeagueHomeInfoRawData struct {
 	FantasyTeams []LeagueHomeInfoRawTeam      `json:"fantasyTeams"`
 	Standings    LeagueHomeInfoRawStandings   `json:"standings"`
 	Matchups     LeagueHomeInfoRawMatchups    `json:"matchups"`
+	PendingTrades []LeagueHomeInfoRawPendingTrade `json:"pendingTrades"`
+	Messages      LeagueHomeInfoRawMessages       `json:"messages"`
+	Alerts        []LeagueHomeInfoRawAlert        `json:"alerts"`
+	Announcements []LeagueHomeInfoRawAnnouncement `json:"announcements"`
 }
 
 // LeagueHomeInfoRawSettings contains league settings
@@ -93,6 +94,48 @@ type LeagueHomeInfoRawGame struct {
 	HomeTeamScore string `json:"homeTeamScore"`
 }
 
+// LeagueHomeInfoRawPendingTrade contains a trade awaiting a response or processing
+type LeagueHomeInfoRawPendingTrade struct {
+	TxSetID        string `json:"txSetId"`
+	FromTeamID     string `json:"fromTeamId"`
+	FromTeamName   string `json:"fromTeamName"`
+	ToTeamID       string `json:"toTeamId"`
+	ToTeamName     string `json:"toTeamName"`
+	Status         string `json:"status"`
+	Date           int64  `json:"date"` // Milliseconds since the epoch
+	ActionRequired bool   `json:"actionRequired"`
+}
+
+// LeagueHomeInfoRawMessages contains the league message board summary
+type LeagueHomeInfoRawMessages struct {
+	UnreadCount int                        `json:"unreadCount"`
+	Unread      []LeagueHomeInfoRawMessage `json:"unread"`
+}
+
+// LeagueHomeInfoRawMessage contains a single league message
+type LeagueHomeInfoRawMessage struct {
+	ID         string `json:"id"`
+	Subject    string `json:"subject"`
+	FromTeamID string `json:"fromTeamId"`
+	FromName   string `json:"fromName"`
+	Date       int64  `json:"date"` // Milliseconds since the epoch
+}
+
+// LeagueHomeInfoRawAlert contains a banner shown at the top of the league home page
+type LeagueHomeInfoRawAlert struct {
+	Type     string `json:"type"`
+	Msg      string `json:"msg"`
+	Deadline int64  `json:"deadline"` // Milliseconds since the epoch; 0 if the banner has no deadline
+}
+
+// LeagueHomeInfoRawAnnouncement contains a commissioner note
+type LeagueHomeInfoRawAnnouncement struct {
+	Title      string `json:"title"`
+	Body       string `json:"body"` // HTML
+	AuthorName string `json:"authorName"`
+	Date       int64  `json:"date"` // Milliseconds since the epoch
+}
+
 // ============================================================
 // Processed Types (clean, easy to use)
 // ============================================================
@@ -103,6 +146,7 @@ type LeagueHomeInfo struct {
 	Teams        []LeagueTeam           `json:"teams"`
 	Standings    []DivisionStandings    `json:"standings"`
 	Matchups     LeagueMatchups         `json:"matchups"`
+	PendingItems LeaguePendingItems     `json:"pendingItems"`
 }
 
 // LeagueSettings contains league configuration
@@ -162,6 +206,51 @@ type MatchupGame struct {
 	HomeTeamScore string `json:"homeTeamScore"`
 }
 
+// LeaguePendingItems contains the items on the league home page waiting for attention
+type LeaguePendingItems struct {
+	Trades         []PendingTradeItem   `json:"trades"`
+	UnreadMessages int                  `json:"unreadMessages"` // May exceed len(Messages), which lists only the newest
+	Messages       []LeagueMessage      `json:"messages"`
+	Deadlines      []DeadlineBanner     `json:"deadlines"`
+	Announcements  []LeagueAnnouncement `json:"announcements"`
+}
+
+// PendingTradeItem contains a pending trade shown on the league home page
+type PendingTradeItem struct {
+	TxSetID        string    `json:"txSetId"`
+	FromTeamID     string    `json:"fromTeamId"`
+	FromTeamName   string    `json:"fromTeamName"`
+	ToTeamID       string    `json:"toTeamId"`
+	ToTeamName     string    `json:"toTeamName"`
+	Status         string    `json:"status"`
+	Date           time.Time `json:"date"`
+	ActionRequired bool      `json:"actionRequired"` // Whether the user's team has to accept or reject it
+}
+
+// LeagueMessage contains an unread league message
+type LeagueMessage struct {
+	ID         string    `json:"id"`
+	Subject    string    `json:"subject"`
+	FromTeamID string    `json:"fromTeamId,omitempty"`
+	FromName   string    `json:"fromName"`
+	Date       time.Time `json:"date"`
+}
+
+// DeadlineBanner contains an upcoming deadline banner, e.g. the trade deadline
+type DeadlineBanner struct {
+	Type     string     `json:"type"`
+	Message  string     `json:"message"`
+	Deadline *time.Time `json:"deadline,omitempty"`
+}
+
+// LeagueAnnouncement contains a commissioner announcement
+type LeagueAnnouncement struct {
+	Title  string    `json:"title"`
+	Body   string    `json:"body"` // Plain text
+	Author string    `json:"author"`
+	Date   time.Time `json:"date"`
+}
+
 // ============================================================
 // API Functions
 // ============================================================
@@ -177,29 +266,9 @@ func (c *Client) GetLeagueHomeInfoRaw() ([]byte, error) {
 		},
 	}
 
-	jsonStr, err := json.Marshal(requestPayload)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request payload: %w", err)
-	}
-
-	req, err := http.NewRequest("POST", "https://www.fantrax.com/fxpa/req?leagueId="+c.LeagueID, bytes.NewBuffer(jsonStr))
+	body, err := c.postFxpa(fxpaRequest{Msgs: requestPayload.Msgs})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := c.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned non-200 status code: %d", resp.StatusCode)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, err
 	}
 
 	return body, nil
@@ -216,6 +285,7 @@ func (c *Client) GetLeagueHomeInfo() (*LeagueHomeInfo, error) {
 	if err := json.Unmarshal(rawBody, &rawResponse); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
+	c.checkSchema("getLeagueHomeInfo", rawBody, &rawResponse)
 
 	return processLeagueHomeInfo(&rawResponse)
 }
@@ -295,5 +365,61 @@ func processLeagueHomeInfo(raw *LeagueHomeInfoRawResponse) (*LeagueHomeInfo, err
 		})
 	}
 
+	result.PendingItems = processPendingItems(data)
+
 	return result, nil
+}
+
+// processPendingItems collects the pending trades, unread messages, deadline banners, and
+// commissioner announcements. Dates are converted to UTC.
+func processPendingItems(data LeagueHomeInfoRawData) LeaguePendingItems {
+	items := LeaguePendingItems{
+		Trades:         make([]PendingTradeItem, 0, len(data.PendingTrades)),
+		UnreadMessages: data.Messages.UnreadCount,
+		Messages:       make([]LeagueMessage, 0, len(data.Messages.Unread)),
+		Deadlines:      make([]DeadlineBanner, 0, len(data.Alerts)),
+		Announcements:  make([]LeagueAnnouncement, 0, len(data.Announcements)),
+	}
+	if items.UnreadMessages < len(data.Messages.Unread) {
+		items.UnreadMessages = len(data.Messages.Unread)
+	}
+
+	for _, trade := range data.PendingTrades {
+		items.Trades = append(items.Trades, PendingTradeItem{
+			TxSetID:        trade.TxSetID,
+			FromTeamID:     trade.FromTeamID,
+			FromTeamName:   trade.FromTeamName,
+			ToTeamID:       trade.ToTeamID,
+			ToTeamName:     trade.ToTeamName,
+			Status:         trade.Status,
+			Date:           time.UnixMilli(trade.Date).UTC(),
+			ActionRequired: trade.ActionRequired,
+		})
+	}
+	for _, msg := range data.Messages.Unread {
+		items.Messages = append(items.Messages, LeagueMessage{
+			ID:         msg.ID,
+			Subject:    stripHTML(msg.Subject),
+			FromTeamID: msg.FromTeamID,
+			FromName:   msg.FromName,
+			Date:       time.UnixMilli(msg.Date).UTC(),
+		})
+	}
+	for _, alert := range data.Alerts {
+		banner := DeadlineBanner{Type: alert.Type, Message: stripHTML(alert.Msg)}
+		if alert.Deadline > 0 {
+			deadline := time.UnixMilli(alert.Deadline).UTC()
+			banner.Deadline = &deadline
+		}
+		items.Deadlines = append(items.Deadlines, banner)
+	}
+	for _, note := range data.Announcements {
+		items.Announcements = append(items.Announcements, LeagueAnnouncement{
+			Title:  stripHTML(note.Title),
+			Body:   stripHTML(note.Body),
+			Author: note.AuthorName,
+			Date:   time.UnixMilli(note.Date).UTC(),
+		})
+	}
+	return items
 }
\ No newline at end of file
@@ -21,25 +21,42 @@ func (c *Client) GetLeagueSetupMatchups() (*models.LeagueSetupMatchups, error) {
 		return nil, fmt.Errorf("failed to fetch league setup page: %w", err)
 	}
 
-	matchups, err := parseMatchupMap(html)
+	return ParseLeagueSetupMatchups(html)
+}
+
+// GetLeagueSetupMatchupsRaw fetches the league setup page HTML without parsing it
+func (c *Client) GetLeagueSetupMatchupsRaw() ([]byte, error) {
+	html, err := c.fetchLeagueSetupHTML()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch league setup page: %w", err)
+	}
+	return []byte(html), nil
+}
+
+// ParseLeagueSetupMatchups parses league setup page HTML (as returned by
+// GetLeagueSetupMatchupsRaw) into matchups, teams, divisions, and form configuration
+func ParseLeagueSetupMatchups(html string) (*models.LeagueSetupMatchups, error) {
+	page, err := newSetupPage(html)
+	if err != nil {
+		return nil, err
+	}
+
+	matchups, err := page.matchupMap()
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse matchup map: %w", err)
 	}
 
-	teams, err := parseTeams(html)
+	teams, err := page.teams()
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse teams: %w", err)
 	}
 
-	divisions, err := parseDivisions(html)
+	divisions, err := page.divisions()
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse divisions: %w", err)
 	}
 
-	formConfig, err := parseFormConfig(html, teams, divisions)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse form config: %w", err)
-	}
+	formConfig := page.formConfig(teams, divisions)
 
 	return &models.LeagueSetupMatchups{
 		Teams:      teams,
@@ -53,39 +70,48 @@ func (c *Client) GetLeagueSetupMatchups() (*models.LeagueSetupMatchups, error) {
 // the raw HTML. This bypasses the standard Do() method which sets JSON headers.
 func (c *Client) fetchLeagueSetupHTML() (string, error) {
 	url := fmt.Sprintf("https://www.fantrax.com/newui/fantasy/createLeague.go?goto=1&leagueId=%s", c.LeagueID)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
-	}
+	return c.fetchPageHTML(url)
+}
 
-	cookiesString, err := GetCookies()
-	if err != nil {
-		return "", fmt.Errorf("failed to get cookies: %w", err)
-	}
-	req.Header.Set("Cookie", cookiesString)
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko)")
+// fetchPageHTML GETs a Fantrax page with the user's cookies and returns its HTML. Concurrent
+// fetches of the same page share one request.
+func (c *Client) fetchPageHTML(url string) (string, error) {
+	body, err := c.dedupRead("GET "+url, func() ([]byte, error) {
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
 
-	// Use the embedded http.Client directly to avoid JSON headers from Do()
-	resp, err := c.Client.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("failed to send request: %w", err)
-	}
-	defer resp.Body.Close()
+		cookiesString, err := c.cookies()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get cookies: %w", err)
+		}
+		req.Header.Set("Cookie", cookiesString)
+		req.Header.Set("User-Agent", c.userAgent())
 
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
+		// Use the embedded http.Client directly to avoid JSON headers from Do()
+		c.RateLimiter.Wait()
+		resp, err := c.Client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to send request: %w", err)
+		}
+		defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %w", err)
-	}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		}
 
-	return string(body), nil
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return body, nil
+	})
+	return string(body), err
 }
 
-// parseMatchupMap extracts the matchupMap JS variable from the HTML and parses
-// it into a map of period number -> matchup pairs.
+// matchupMap extracts the matchupMap JS variable from the page's scripts and
+// parses it into a map of period number -> matchup pairs.
 //
 // Source format:
 //
@@ -94,23 +120,23 @@ func (c *Client) fetchLeagueSetupHTML() (string, error) {
 //	  '2':['awayId_homeId',...],
 //	  ...
 //	};
-func parseMatchupMap(html string) (map[int][]models.MatchupPair, error) {
+func (p *setupPage) matchupMap() (map[int][]models.MatchupPair, error) {
 	// Extract the matchupMap block
 	outerRe := regexp.MustCompile(`var\s+matchupMap\s*=\s*\{([\s\S]*?)\};`)
-	outerMatch := outerRe.FindStringSubmatch(html)
+	outerMatch := outerRe.FindStringSubmatch(p.script)
 	if outerMatch == nil {
 		return nil, fmt.Errorf("matchupMap not found in HTML")
 	}
 	mapContent := outerMatch[1]
 
 	// Extract each period's matchup array
-	periodRe := regexp.MustCompile(`'(\d+)'\s*:\s*\[(.*?)\]`)
+	periodRe := regexp.MustCompile(`['"]?(\d+)['"]?\s*:\s*\[(.*?)\]`)
 	periodMatches := periodRe.FindAllStringSubmatch(mapContent, -1)
 	if len(periodMatches) == 0 {
 		return nil, fmt.Errorf("no periods found in matchupMap")
 	}
 
-	pairRe := regexp.MustCompile(`'([^']+)'`)
+	pairRe := regexp.MustCompile(`'([^']+)'|"([^"]+)"`)
 	result := make(map[int][]models.MatchupPair, len(periodMatches))
 
 	for _, pm := range periodMatches {
@@ -124,9 +150,10 @@ func parseMatchupMap(html string) (map[int][]models.MatchupPair, error) {
 
 		var pairs []models.MatchupPair
 		for _, pairMatch := range pairMatches {
-			parts := strings.SplitN(pairMatch[1], "_", 2)
+			pair := pairMatch[1] + pairMatch[2]
+			parts := strings.SplitN(pair, "_", 2)
 			if len(parts) != 2 {
-				return nil, fmt.Errorf("invalid matchup pair format: %q", pairMatch[1])
+				return nil, fmt.Errorf("invalid matchup pair format: %q", pair)
 			}
 			pairs = append(pairs, models.MatchupPair{
 				AwayTeamID: parts[0],
@@ -140,7 +167,17 @@ func parseMatchupMap(html string) (map[int][]models.MatchupPair, error) {
 	return result, nil
 }
 
-// parseTeams extracts team data and owner info from addTeam() JS calls in the HTML.
+// parseTeams extracts team data and owner info from the addTeam() JS calls in
+// the league setup page HTML.
+func parseTeams(html string) ([]models.LeagueSetupTeam, error) {
+	page, err := newSetupPage(html)
+	if err != nil {
+		return nil, err
+	}
+	return page.teams()
+}
+
+// teams extracts team data and owner info from addTeam() JS calls.
 // Teams with multiple owners appear multiple times; owners are collected per team.
 //
 // Source format:
@@ -149,26 +186,24 @@ func parseMatchupMap(html string) (map[int][]models.MatchupPair, error) {
 //
 // The JS function transforms userId='NULL' into 'NULL_N' with an incrementing
 // counter. We replicate that logic here so owner email form field keys match.
-func parseTeams(html string) ([]models.LeagueSetupTeam, error) {
-	re := regexp.MustCompile(`addTeam\('([^']*)',\s*'([^']*)',\s*'([^']*)',\s*'([^']*)',\s*'([^']*)',\s*(true|false),\s*(true|false)`)
-	matches := re.FindAllStringSubmatch(html, -1)
-	if len(matches) == 0 {
-		return nil, fmt.Errorf("no addTeam() calls found in HTML")
-	}
-
+func (p *setupPage) teams() ([]models.LeagueSetupTeam, error) {
 	// Track teams by ID to preserve order and collect owners
 	teamIndex := make(map[string]int) // teamID -> index in teams slice
 	var teams []models.LeagueSetupTeam
 	uniqueTempUserID := 0 // Mirrors JS var uniqueTempUserId
 
-	for _, m := range matches {
-		name := m[1]
-		shortName := m[2]
-		email := m[3]
-		teamID := m[4]
-		userID := m[5]
-		isCommissioner := m[6] == "true"
-		joinedLeague := m[7] == "true"
+	for _, args := range jsCalls(p.script, "addTeam") {
+		// Calls made with variables rather than literals build teams added in the browser
+		if len(args) < 7 || !allLiteral(args[:7]) {
+			continue
+		}
+		name := args[0].Value
+		shortName := args[1].Value
+		email := args[2].Value
+		teamID := args[3].Value
+		userID := args[4].Value
+		isCommissioner := args[5].Value == "true"
+		joinedLeague := args[6].Value == "true"
 
 		// Replicate JS logic: if userId is 'NULL', assign 'NULL_N'
 		if userID == "NULL" {
@@ -197,42 +232,43 @@ func parseTeams(html string) ([]models.LeagueSetupTeam, error) {
 			})
 		}
 	}
+	if len(teams) == 0 {
+		return nil, fmt.Errorf("no addTeam() calls found in HTML")
+	}
 
 	return teams, nil
 }
 
-// parseDivisions extracts division structure from divisionName_ inputs and
-// __removeTeamFromDivision() calls in the HTML.
-func parseDivisions(html string) ([]models.LeagueSetupDivision, error) {
-	// Extract division names from input elements.
-	// Use an alphanumeric-only ID pattern to skip JS template strings like
-	// divisionName_' + tempId + ' that appear in script blocks.
-	nameRe := regexp.MustCompile(`<input[^>]*name="divisionName_([a-zA-Z0-9]+)"[^>]*value="([^"]*)"`)
-	nameMatches := nameRe.FindAllStringSubmatch(html, -1)
-	if len(nameMatches) == 0 {
-		return nil, fmt.Errorf("no division names found in HTML")
-	}
-
+// divisions extracts division structure from divisionName_ inputs and
+// __removeTeamFromDivision() calls.
+func (p *setupPage) divisions() ([]models.LeagueSetupDivision, error) {
 	divMap := make(map[string]*models.LeagueSetupDivision)
 	var divOrder []string
-	for _, m := range nameMatches {
-		divID := m[1]
+	for _, input := range p.inputs {
+		divID, ok := strings.CutPrefix(input.Name, "divisionName_")
+		if !ok || divID == "" {
+			continue
+		}
 		if _, exists := divMap[divID]; !exists {
 			divMap[divID] = &models.LeagueSetupDivision{
 				DivisionID: divID,
-				Name:       m[2],
+				Name:       input.Value,
 			}
 			divOrder = append(divOrder, divID)
 		}
 	}
+	if len(divOrder) == 0 {
+		return nil, fmt.Errorf("no division names found in HTML")
+	}
 
 	// Extract team assignments from __removeTeamFromDivision() calls
 	// Pattern: __removeTeamFromDivision('tbl_{divId}', '{teamId}', false)
-	teamRe := regexp.MustCompile(`__removeTeamFromDivision\('tbl_(\w+)',\s*'(\w+)'`)
-	teamMatches := teamRe.FindAllStringSubmatch(html, -1)
-	for _, m := range teamMatches {
-		divID := m[1]
-		teamID := m[2]
+	for _, args := range jsCalls(p.script, "__removeTeamFromDivision") {
+		if len(args) < 2 || !allLiteral(args[:2]) {
+			continue
+		}
+		divID := strings.TrimPrefix(args[0].Value, "tbl_")
+		teamID := args[1].Value
 		if div, ok := divMap[divID]; ok {
 			// Avoid duplicates
 			found := false
@@ -258,8 +294,18 @@ func parseDivisions(html string) ([]models.LeagueSetupDivision, error) {
 }
 
 // parseFormConfig extracts all form field values needed to echo back when
-// POSTing matchup changes.
+// POSTing the league setup page HTML.
 func parseFormConfig(html string, teams []models.LeagueSetupTeam, divisions []models.LeagueSetupDivision) (*models.LeagueSetupFormConfig, error) {
+	page, err := newSetupPage(html)
+	if err != nil {
+		return nil, err
+	}
+	return page.formConfig(teams, divisions), nil
+}
+
+// formConfig extracts all form field values needed to echo back when
+// POSTing matchup changes.
+func (p *setupPage) formConfig(teams []models.LeagueSetupTeam, divisions []models.LeagueSetupDivision) *models.LeagueSetupFormConfig {
 	config := &models.LeagueSetupFormConfig{
 		HiddenFields:     make(map[string]string),
 		SelectFields:     make(map[string]string),
@@ -270,68 +316,36 @@ func parseFormConfig(html string, teams []models.LeagueSetupTeam, divisions []mo
 		DivisionNames:    make(map[string]string),
 	}
 
-	// Parse hidden input fields
-	// Handles both name="x" value="y" and value="y" name="x" orderings
-	hiddenRe := regexp.MustCompile(`<input[^>]*type="hidden"[^>]*>`)
-	hiddenMatches := hiddenRe.FindAllString(html, -1)
-	nameRe := regexp.MustCompile(`name="([^"]+)"`)
-	valueRe := regexp.MustCompile(`value="([^"]*)"`)
-
-	for _, tag := range hiddenMatches {
-		nameMatch := nameRe.FindStringSubmatch(tag)
-		valueMatch := valueRe.FindStringSubmatch(tag)
-		if nameMatch == nil {
-			continue
-		}
-		name := nameMatch[1]
-		value := ""
-		if valueMatch != nil {
-			value = valueMatch[1]
-		}
-
-		// Skip JS template strings from <script> blocks (contain ' + or ')
-		if strings.Contains(name, "'") || strings.Contains(value, "'") {
-			continue
-		}
-
-		// Categorize by field name prefix
-		if strings.HasPrefix(name, "_") {
-			config.CheckboxFields[name] = value
-		} else {
-			config.HiddenFields[name] = value
-		}
-	}
-
-	// Parse select fields with selected options
-	parseSelectFields(html, config)
-
-	// Parse text input fields for dates and other values
-	textInputRe := regexp.MustCompile(`<input[^>]*type="text"[^>]*>`)
-	textMatches := textInputRe.FindAllString(html, -1)
-	for _, tag := range textMatches {
-		nameMatch := nameRe.FindStringSubmatch(tag)
-		valueMatch := valueRe.FindStringSubmatch(tag)
-		if nameMatch == nil || valueMatch == nil {
-			continue
-		}
-		name := nameMatch[1]
-		// Only include form-relevant fields (startDate, endDate), not division names
-		if name == "startDate" || name == "endDate" {
-			config.HiddenFields[name] = valueMatch[1]
+	for _, input := range p.inputs {
+		switch input.Type {
+		case "hidden":
+			// Categorize by field name prefix
+			if strings.HasPrefix(input.Name, "_") {
+				config.CheckboxFields[input.Name] = input.Value
+			} else {
+				config.HiddenFields[input.Name] = input.Value
+			}
+		case "text":
+			// Only include form-relevant fields (startDate, endDate), not division names
+			if input.Name == "startDate" || input.Name == "endDate" {
+				config.HiddenFields[input.Name] = input.Value
+			}
+		case "checkbox":
+			// A checked box without a value submits "on", as in a browser
+			if input.Checked {
+				value := input.Value
+				if value == "" {
+					value = "on"
+				}
+				config.HiddenFields[input.Name] = value
+			}
 		}
 	}
 
-	// Parse checked checkboxes
-	checkboxRe := regexp.MustCompile(`<input[^>]*type="checkbox"[^>]*checked[^>]*>`)
-	checkboxMatches := checkboxRe.FindAllString(html, -1)
-	for _, tag := range checkboxMatches {
-		nameMatch := nameRe.FindStringSubmatch(tag)
-		if nameMatch == nil {
-			continue
-		}
-		vMatch := valueRe.FindStringSubmatch(tag)
-		if vMatch != nil {
-			config.HiddenFields[nameMatch[1]] = vMatch[1]
+	// Select fields with a selected option
+	for _, sel := range p.selects {
+		if sel.Selected {
+			config.SelectFields[sel.Name] = sel.Value
 		}
 	}
 
@@ -347,8 +361,7 @@ func parseFormConfig(html string, teams []models.LeagueSetupTeam, divisions []mo
 	for _, team := range teams {
 		for _, owner := range team.Owners {
 			if !owner.IsCommissioner && !owner.JoinedLeague {
-				key := fmt.Sprintf("teamOwnerEmail,%s,%s,%s", owner.Email, team.TeamID, owner.UserID)
-				config.OwnerEmailFields[key] = owner.Email
+				config.OwnerEmailFields[ownerEmailField(owner, team.TeamID)] = owner.Email
 			}
 		}
 	}
@@ -367,38 +380,17 @@ func parseFormConfig(html string, teams []models.LeagueSetupTeam, divisions []mo
 		}
 	}
 
-	return config, nil
+	return config
 }
 
-// parseSelectFields extracts select element names and their selected option values.
-func parseSelectFields(html string, config *models.LeagueSetupFormConfig) {
-	// Find all select elements with name attributes
-	// We use a non-greedy match to find each select...option...selected...value block
-	selectRe := regexp.MustCompile(`(?s)<select[^>]*name="([^"]+)"[^>]*>(.*?)</select>`)
-	selectMatches := selectRe.FindAllStringSubmatch(html, -1)
-
-	selectedValueRe := regexp.MustCompile(`<option[^>]*value="([^"]*)"[^>]*selected[^>]*>`)
-	selectedValueAltRe := regexp.MustCompile(`<option[^>]*selected[^>]*value="([^"]*)"[^>]*>`)
-
-	for _, sm := range selectMatches {
-		name := sm[1]
-		optionsHTML := sm[2]
-
-		// Try to find the selected option value
-		match := selectedValueRe.FindStringSubmatch(optionsHTML)
-		if match == nil {
-			match = selectedValueAltRe.FindStringSubmatch(optionsHTML)
-		}
-		// Also handle bare "selected" without ="selected" (e.g., <option value="DAILY" selected>)
-		if match == nil {
-			bareSelectedRe := regexp.MustCompile(`<option[^>]*value="([^"]*)"[^>]*\bselected\b`)
-			match = bareSelectedRe.FindStringSubmatch(optionsHTML)
-		}
-
-		if match != nil {
-			config.SelectFields[name] = match[1]
+// allLiteral reports whether every argument is a literal
+func allLiteral(args []jsArg) bool {
+	for _, arg := range args {
+		if !arg.Literal {
+			return false
 		}
 	}
+	return true
 }
 
 // GetMatchupsByPeriod returns the matchup pairs for a specific period from the
@@ -1,16 +1,20 @@
 package auth_client
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
+	"net"
 	"net/http"
+	"os"
 	"regexp"
 	"strconv"
 	"strings"
 
 	"github.com/pmurley/go-fantrax/models"
+	"github.com/pmurley/go-fantrax/redact"
+	log "github.com/sirupsen/logrus"
 )
 
 var htmlTagRegex = regexp.MustCompile(`<[^>]*>`)
@@ -19,6 +23,14 @@ const (
 	// MaxPlayersPerPage is the maximum number of players Fantrax returns per page
 	MaxPlayersPerPage = 5000
 
+	// DefaultPlayersPerPage is the page size a player pool fetch starts with. It is
+	// MinPlayersPerPage times a power of two, so failed pages halve evenly all the way down.
+	DefaultPlayersPerPage = 4000
+
+	// MinPlayersPerPage is the smallest page a player pool fetch is downgraded to when large
+	// pages time out
+	MinPlayersPerPage = 250
+
 	// StatusFilterAll includes all players (rostered and available)
 	StatusFilterAll = "ALL"
 
@@ -38,8 +50,13 @@ type PlayerPoolOption func(*playerPoolConfig)
 
 type playerPoolConfig struct {
 	statusFilter string
+	concurrency  int
+	pageSize     int
 }
 
+// DefaultPlayerPoolConcurrency is the number of player pool pages fetched at once after the first
+const DefaultPlayerPoolConcurrency = 3
+
 // WithStatusFilter sets the status filter for the player pool query
 // Use StatusFilterAll for all players or StatusFilterAvailable for only available players
 func WithStatusFilter(filter string) PlayerPoolOption {
@@ -48,101 +65,239 @@ func WithStatusFilter(filter string) PlayerPoolOption {
 	}
 }
 
+// WithConcurrency sets how many player pool pages may be fetched at the same time once the
+// first page has revealed the page count. Use 1 to fetch pages one after another.
+func WithConcurrency(n int) PlayerPoolOption {
+	return func(c *playerPoolConfig) {
+		if n > 0 {
+			c.concurrency = n
+		}
+	}
+}
+
+// WithPageSize sets how many players are requested per page, up to MaxPlayersPerPage (the
+// default is DefaultPlayersPerPage). Pages that time out or exceed the client's maximum
+// response size are retried at half the size, down to MinPlayersPerPage, so this is only
+// needed to skip the failed attempts in a league known to need small pages. Pages after the
+// first are only split while the size is even, so only MinPlayersPerPage times a power of two
+// (250, 500, 1000, 2000 or 4000) halves all the way down; other sizes stop at their first odd
+// half.
+func WithPageSize(n int) PlayerPoolOption {
+	return func(c *playerPoolConfig) {
+		if n >= MinPlayersPerPage && n <= MaxPlayersPerPage {
+			c.pageSize = n
+		}
+	}
+}
+
+// ErrStopStream can be returned by a GetPlayerPoolStream handler to stop fetching further
+// pages without GetPlayerPoolStream reporting an error
+var ErrStopStream = errors.New("stop player pool stream")
+
 // GetPlayerPool fetches all players in the league's player pool
 // By default, fetches ALL players (including rostered). Use WithStatusFilter(StatusFilterAvailable)
 // to get only free agents and waiver players.
-// This handles pagination automatically to retrieve all players.
+// This handles pagination automatically to retrieve all players. If a later page fails, the
+// players from the pages already fetched are returned along with the error.
 func (c *Client) GetPlayerPool(opts ...PlayerPoolOption) ([]models.PoolPlayer, error) {
+	var allPlayers []models.PoolPlayer
+	err := c.GetPlayerPoolStream(func(page []models.PoolPlayer) error {
+		allPlayers = append(allPlayers, page...)
+		return nil
+	}, opts...)
+	return allPlayers, err
+}
+
+// GetPlayerPoolStream fetches the player pool page by page, calling handler with each page's
+// players as soon as the page arrives
+//
+// The first page is fetched alone to learn the page count; the remaining pages are fetched
+// concurrently (see WithConcurrency) but are always handed to handler in page order. The
+// handler can return ErrStopStream to stop early, in which case GetPlayerPoolStream returns
+// nil. Any other handler error stops fetching and is returned wrapped.
+//
+// Pages that time out or exceed the client's maximum response size are fetched again as
+// smaller pages (see WithPageSize), so large leagues load without extra configuration.
+//
+// Parameters:
+//   - handler: Called once per page, in page order
+//   - opts: Same options as GetPlayerPool
+func (c *Client) GetPlayerPoolStream(handler func(page []models.PoolPlayer) error, opts ...PlayerPoolOption) error {
 	// Apply options
 	config := &playerPoolConfig{
 		statusFilter: StatusFilterAll, // Default to all players
+		concurrency:  DefaultPlayerPoolConcurrency,
+		pageSize:     DefaultPlayersPerPage,
 	}
 	for _, opt := range opts {
 		opt(config)
 	}
 
-	var allPlayers []models.PoolPlayer
-	pageNumber := 1
-	totalPages := 1 // Will be updated after first request
+	// The first page sets the page size for the rest, so downgrade it until it loads
+	var players []models.PoolPlayer
+	var totalPages int
+	var err error
+	for {
+		players, totalPages, err = c.fetchPlayerPoolPage(config.statusFilter, 1, config.pageSize)
+		if err == nil {
+			break
+		}
+		if !isPageTooLarge(err) || config.pageSize/2 < MinPlayersPerPage {
+			return err
+		}
+		config.pageSize /= 2
+		log.Warnf("player pool page failed (%v); retrying with %d players per page", redact.Error(err), config.pageSize)
+	}
+	pages := &models.PaginatedResultSet{TotalNumPages: totalPages}
+	rows := len(players)
+	c.reportPageProgress(ProgressPlayerPool, 1, len(players), rows, pages)
+	if stop, err := handlePlayerPoolPage(handler, players, 1); stop || err != nil {
+		return err
+	}
+	if totalPages <= 1 {
+		return nil
+	}
+
+	type pageResult struct {
+		players []models.PoolPlayer
+		err     error
+	}
+
+	// Each remaining page gets a buffered slot so workers never block once they hold the semaphore
+	results := make([]chan pageResult, totalPages+1)
+	sem := make(chan struct{}, config.concurrency)
+	stop := make(chan struct{})
+	defer close(stop)
+
+	for pageNumber := 2; pageNumber <= totalPages; pageNumber++ {
+		results[pageNumber] = make(chan pageResult, 1)
+		go func(pageNumber int) {
+			select {
+			case sem <- struct{}{}:
+			case <-stop:
+				return
+			}
+			defer func() { <-sem }()
+
+			players, err := c.fetchPlayerPoolRange(config.statusFilter, pageNumber, config.pageSize)
+			results[pageNumber] <- pageResult{players: players, err: err}
+		}(pageNumber)
+	}
 
-	for pageNumber <= totalPages {
-		response, err := c.getPlayerPoolPage(config.statusFilter, pageNumber)
-		if err != nil {
-			return nil, fmt.Errorf("failed to fetch page %d: %w", pageNumber, err)
+	for pageNumber := 2; pageNumber <= totalPages; pageNumber++ {
+		result := <-results[pageNumber]
+		if result.err != nil {
+			return result.err
+		}
+		rows += len(result.players)
+		c.reportPageProgress(ProgressPlayerPool, pageNumber, len(result.players), rows, pages)
+		if stop, err := handlePlayerPoolPage(handler, result.players, pageNumber); stop || err != nil {
+			return err
 		}
+	}
+
+	return nil
+}
 
-		if len(response.Responses) == 0 {
-			return nil, fmt.Errorf("no responses in player pool response for page %d", pageNumber)
+// handlePlayerPoolPage calls the stream handler and reports whether streaming should stop
+func handlePlayerPoolPage(handler func(page []models.PoolPlayer) error, players []models.PoolPlayer, pageNumber int) (bool, error) {
+	if err := handler(players); err != nil {
+		if errors.Is(err, ErrStopStream) {
+			return true, nil
 		}
+		return true, fmt.Errorf("player pool handler failed on page %d: %w", pageNumber, err)
+	}
+	return false, nil
+}
+
+// fetchPlayerPoolRange fetches the players of one page. If the page times out or is too large,
+// the same players are fetched as two pages of half the size, recursively down to
+// MinPlayersPerPage. Only an even page size splits into two pages covering the same rows, so
+// an odd size fails with the page's error.
+func (c *Client) fetchPlayerPoolRange(statusFilter string, pageNumber, pageSize int) ([]models.PoolPlayer, error) {
+	players, _, err := c.fetchPlayerPoolPage(statusFilter, pageNumber, pageSize)
+	if err == nil || !isPageTooLarge(err) || pageSize%2 != 0 || pageSize/2 < MinPlayersPerPage {
+		return players, err
+	}
 
-		data := response.Responses[0].Data
-		totalPages = data.PaginatedResultSet.TotalNumPages
+	log.Warnf("player pool page %d failed (%v); retrying it as two pages of %d players", pageNumber, redact.Error(err), pageSize/2)
+	first, err := c.fetchPlayerPoolRange(statusFilter, 2*pageNumber-1, pageSize/2)
+	if err != nil {
+		return nil, err
+	}
+	second, err := c.fetchPlayerPoolRange(statusFilter, 2*pageNumber, pageSize/2)
+	if err != nil {
+		return nil, err
+	}
+	return append(first, second...), nil
+}
 
-		// Parse players from this page
-		players, err := parseStatsTable(data.StatsTable, buildColumnIndex(data.TableHeader))
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse players on page %d: %w", pageNumber, err)
+// isPageTooLarge reports whether a page request failed in a way a smaller page may avoid: a
+// timeout, a gateway error, or a response over the size limit
+func isPageTooLarge(err error) bool {
+	if errors.Is(err, ErrResponseTooLarge) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, os.ErrDeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		switch statusErr.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
 		}
+	}
+	return false
+}
 
-		allPlayers = append(allPlayers, players...)
-		pageNumber++
+// fetchPlayerPoolPage fetches and parses one page, returning its players and the total page count
+func (c *Client) fetchPlayerPoolPage(statusFilter string, pageNumber, pageSize int) ([]models.PoolPlayer, int, error) {
+	response, err := c.getPlayerPoolPage(statusFilter, pageNumber, pageSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch page %d: %w", pageNumber, err)
 	}
 
-	return allPlayers, nil
+	if len(response.Responses) == 0 {
+		return nil, 0, fmt.Errorf("no responses in player pool response for page %d", pageNumber)
+	}
+
+	data := response.Responses[0].Data
+
+	// Parse players from this page
+	players, warnings := parseStatsTable(data.StatsTable, buildColumnIndex(data.TableHeader))
+	for i := range warnings {
+		warnings[i].Message = fmt.Sprintf("page %d: %s", pageNumber, warnings[i].Message)
+	}
+	c.reportParseWarnings(warnings)
+
+	return players, data.PaginatedResultSet.TotalNumPages, nil
 }
 
 // GetPlayerPoolRaw fetches a single page of the raw player pool response without parsing
 func (c *Client) GetPlayerPoolRaw(statusFilter string, pageNumber int) (*models.PlayerPoolResponse, error) {
-	return c.getPlayerPoolPage(statusFilter, pageNumber)
+	return c.getPlayerPoolPage(statusFilter, pageNumber, MaxPlayersPerPage)
 }
 
 // getPlayerPoolPage fetches a single page of the player pool
-func (c *Client) getPlayerPoolPage(statusFilter string, pageNumber int) (*models.PlayerPoolResponse, error) {
+func (c *Client) getPlayerPoolPage(statusFilter string, pageNumber, pageSize int) (*models.PlayerPoolResponse, error) {
 	requestData := GetPlayerPoolRequest{
 		StatusOrTeamFilter: statusFilter,
-		MaxResultsPerPage:  MaxPlayersPerPage,
+		MaxResultsPerPage:  pageSize,
 		PageNumber:         strconv.Itoa(pageNumber),
 	}
 
-	fullRequest := map[string]interface{}{
-		"msgs": []FantraxMessage{
+	body, err := c.postFxpa(fxpaRequest{
+		Msgs: []FantraxMessage{
 			{
 				Method: "getPlayerStats",
 				Data:   requestData,
 			},
 		},
-		"uiv":    3,
-		"refUrl": fmt.Sprintf("https://www.fantrax.com/fantasy/league/%s/players", c.LeagueID),
-		"dt":     0,
-		"at":     0,
-		"av":     "0.0",
-		"tz":     c.getTimezone(),
-		"v":      "179.0.1",
-	}
-
-	jsonStr, err := json.Marshal(fullRequest)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request payload: %w", err)
-	}
-
-	req, err := http.NewRequest("POST", "https://www.fantrax.com/fxpa/req?leagueId="+c.LeagueID, bytes.NewBuffer(jsonStr))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := c.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned non-200 status code: %d", resp.StatusCode)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, err
 	}
 
 	var response models.PlayerPoolResponse
@@ -150,6 +305,7 @@ func (c *Client) getPlayerPoolPage(statusFilter string, pageNumber int) (*models
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
+	c.checkSchema("getPlayerStats", body, &response)
 
 	return &response, nil
 }
@@ -195,26 +351,39 @@ func (c columnIndex) find(ids ...string) int {
 	return -1
 }
 
-// parseStatsTable converts raw stats table entries to PoolPlayer structs
-func parseStatsTable(entries []models.StatsTableEntry, cols columnIndex) ([]models.PoolPlayer, error) {
+// parseStatsTable converts raw stats table entries to PoolPlayer structs, along with warnings
+// for entries and cells that could not be read
+func parseStatsTable(entries []models.StatsTableEntry, cols columnIndex) ([]models.PoolPlayer, []models.ParseWarning) {
 	players := make([]models.PoolPlayer, 0, len(entries))
-
-	for _, entry := range entries {
-		player, err := parseStatsTableEntry(entry, cols)
-		if err != nil {
-			// Log warning but continue with other players
-			continue
+	var warnings []models.ParseWarning
+
+	for i, entry := range entries {
+		if entry.Scorer.ScorerID == "" {
+			warnings = append(warnings, models.ParseWarning{
+				Source:  "playerPool",
+				Row:     i,
+				Field:   "scorer",
+				Value:   entry.Scorer.Name,
+				Message: "entry has no player ID",
+			})
+		}
+		player, entryWarnings := parseStatsTableEntry(entry, cols)
+		for _, w := range entryWarnings {
+			w.Row = i
+			warnings = append(warnings, w)
 		}
 		players = append(players, player)
 	}
 
-	return players, nil
+	return players, warnings
 }
 
-// parseStatsTableEntry converts a single stats table entry to a PoolPlayer
-func parseStatsTableEntry(entry models.StatsTableEntry, cols columnIndex) (models.PoolPlayer, error) {
+// parseStatsTableEntry converts a single stats table entry to a PoolPlayer, along with
+// warnings for numeric cells that could not be read (the caller fills in the row index)
+func parseStatsTableEntry(entry models.StatsTableEntry, cols columnIndex) (models.PoolPlayer, []models.ParseWarning) {
 	scorer := entry.Scorer
 	cells := entry.Cells
+	var warnings []models.ParseWarning
 
 	player := models.PoolPlayer{
 		// Core identification
@@ -268,6 +437,31 @@ func parseStatsTableEntry(entry models.StatsTableEntry, cols columnIndex) (model
 		return cells[i], true
 	}
 
+	// number parses a numeric cell, recording a warning when it holds something other than a number
+	number := func(field string, c models.StatsTableCell, percentage bool) float64 {
+		text := strings.TrimSpace(c.Content)
+		if text == "" || text == "-" {
+			return 0
+		}
+		check := text
+		if percentage {
+			check = strings.TrimPrefix(strings.TrimSuffix(check, "%"), "+")
+		}
+		if _, err := strconv.ParseFloat(check, 64); err != nil {
+			warnings = append(warnings, models.ParseWarning{
+				Source:  "playerPool",
+				Field:   field,
+				Value:   c.Content,
+				Message: "value is not a number",
+			})
+			return 0
+		}
+		if percentage {
+			return parsePercentage(text)
+		}
+		return parseFloat(text)
+	}
+
 	// Rank already comes from scorer.Rank.
 
 	// Status - FA, W, or team abbreviation
@@ -279,9 +473,7 @@ func parseStatsTableEntry(entry models.StatsTableEntry, cols columnIndex) (model
 
 	// Age
 	if c, ok := cell("age", "AGE", "Age"); ok {
-		if age, err := strconv.Atoi(strings.TrimSpace(c.Content)); err == nil {
-			player.Age = age
-		}
+		player.Age = int(number("age", c, false))
 	}
 
 	// Next opponent (may contain HTML like "@SF<br/>Wed 8:05PM")
@@ -291,35 +483,35 @@ func parseStatsTableEntry(entry models.StatsTableEntry, cols columnIndex) (model
 
 	// Fantasy Points
 	if c, ok := cell("fpts", "SCORE", "FPts"); ok {
-		player.FantasyPoints = parseFloat(c.Content)
+		player.FantasyPoints = number("fpts", c, false)
 	}
 
 	// Fantasy Points Per Game
 	if c, ok := cell("fptsPerGame", "FPTS_PER_GAME", "FP/G"); ok {
-		player.FantasyPointsPerG = parseFloat(c.Content)
+		player.FantasyPointsPerG = number("fptsPerGame", c, false)
 	}
 
-	// % Drafted (absent outside draft season)
+	// % Drafted (absent outside draft season; may have % suffix)
 	if c, ok := cell("PERCENT_DRAFTED", "%D"); ok {
-		player.PercentDrafted = parseFloat(c.Content)
+		player.PercentDrafted = number("PERCENT_DRAFTED", c, true)
 	}
 
 	// ADP (absent outside draft season)
 	if c, ok := cell("ADP"); ok {
-		player.ADP = parseFloat(c.Content)
+		player.ADP = number("ADP", c, false)
 	}
 
 	// % Rostered (may have % suffix)
 	if c, ok := cell("OVERVIEW_PERCENT_OWNED_2", "Ros"); ok {
-		player.PercentRostered = parsePercentage(c.Content)
+		player.PercentRostered = number("OVERVIEW_PERCENT_OWNED_2", c, true)
 	}
 
 	// Roster Change (may have +/- prefix and % suffix)
 	if c, ok := cell("OVERVIEW_PLUS_MINUS_PERCENT_OWNED_2", "+/-"); ok {
-		player.RosterChange = parsePercentage(c.Content)
+		player.RosterChange = number("OVERVIEW_PLUS_MINUS_PERCENT_OWNED_2", c, true)
 	}
 
-	return player, nil
+	return player, warnings
 }
 
 // parseFloat parses a string to float64, returning 0 on error
@@ -44,9 +44,9 @@ func TestParseStatsTableEntry_EightColumnLayout(t *testing.T) {
 		},
 	}
 
-	player, err := parseStatsTableEntry(entry, cols)
-	if err != nil {
-		t.Fatalf("unexpected error: %v", err)
+	player, warnings := parseStatsTableEntry(entry, cols)
+	if len(warnings) != 0 {
+		t.Fatalf("unexpected warnings: %v", warnings)
 	}
 	if player.Age != 21 {
 		t.Errorf("Age = %d, want 21", player.Age)
@@ -86,9 +86,9 @@ func TestParseStatsTableEntry_TenColumnLayout(t *testing.T) {
 		},
 	}
 
-	player, err := parseStatsTableEntry(entry, cols)
-	if err != nil {
-		t.Fatalf("unexpected error: %v", err)
+	player, warnings := parseStatsTableEntry(entry, cols)
+	if len(warnings) != 0 {
+		t.Fatalf("unexpected warnings: %v", warnings)
 	}
 	if player.Age != 30 {
 		t.Errorf("Age = %d, want 30", player.Age)
@@ -97,3 +97,35 @@ func TestParseStatsTableEntry_TenColumnLayout(t *testing.T) {
 		t.Errorf("PercentRostered = %v, want 97", player.PercentRostered)
 	}
 }
+
+// TestParseStatsTable_ReportsUnreadableCells checks that a cell which is not a number is
+// reported instead of silently becoming zero, while the rest of the row is still parsed.
+func TestParseStatsTable_ReportsUnreadableCells(t *testing.T) {
+	cols := buildColumnIndex(header8())
+	entries := []models.StatsTableEntry{
+		{
+			Scorer: models.PoolScorer{ScorerID: "075zj", Name: "Augusto Mendieta"},
+			Cells: []models.StatsTableCell{
+				{Content: "5914"},
+				{Content: "FA"},
+				{Content: "21"},
+				{Content: "BOS"},
+				{Content: "1,204.5"},
+				{Content: "-"},
+				{Content: "0%"},
+				{Content: "0%"},
+			},
+		},
+	}
+
+	players, warnings := parseStatsTable(entries, cols)
+	if len(players) != 1 || players[0].Age != 21 {
+		t.Fatalf("players = %+v, want one player aged 21", players)
+	}
+	if len(warnings) != 1 {
+		t.Fatalf("warnings = %v, want 1", warnings)
+	}
+	if w := warnings[0]; w.Row != 0 || w.Field != "fpts" || w.Value != "1,204.5" {
+		t.Errorf("warning = %+v, want row 0 fpts=\"1,204.5\"", w)
+	}
+}
@@ -1,11 +1,10 @@
 package auth_client
 
 import (
-	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
-	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -169,10 +168,21 @@ type StandingsTeam struct {
 // LeagueStandings represents the processed standings data in an intuitive format
 type LeagueStandings struct {
 	LeagueName  string         `json:"leagueName"`
+	Format      LeagueFormat   `json:"format"`
 	Teams       []TeamStanding `json:"teams"`
 	Divisions   []Division     `json:"divisions"`
 	Matchups    []Matchup      `json:"matchups"`
 	SeasonDates DateRange      `json:"seasonDates"`
+
+	// Timeframe is the period range the standings cover
+	Timeframe StandingsTimeframe `json:"timeframe"`
+}
+
+// StandingsTimeframe is the timeframe selection the standings were computed for
+type StandingsTimeframe struct {
+	Period        int                `json:"period,omitempty"`        // The selected scoring period
+	TimeframeType string             `json:"timeframeType,omitempty"` // e.g. "YEAR_TO_DATE" or "BY_PERIOD"
+	TimeStart     StandingsTimeStart `json:"timeStart,omitempty"`     // Whether a period's standings are cumulative
 }
 
 // TeamStanding represents a single team's standing information
@@ -192,6 +202,10 @@ type TeamStanding struct {
 	PointsFor     float64 `json:"pointsFor"`
 	PointsAgainst float64 `json:"pointsAgainst"`
 	Streak        string  `json:"streak"`
+
+	// Rotisserie leagues only
+	TotalPoints    float64            `json:"totalPoints,omitempty"`    // Standings points summed across categories
+	CategoryPoints map[string]float64 `json:"categoryPoints,omitempty"` // Standings points per category, keyed by column short name
 }
 
 // Division represents a division in the league
@@ -206,6 +220,8 @@ type Matchup struct {
 	Date          string    `json:"date"`
 	AwayTeam      MatchTeam `json:"awayTeam"`
 	HomeTeam      MatchTeam `json:"homeTeam"`
+
+	Categories []CategoryResult `json:"categories,omitempty"` // H2H category leagues only
 }
 
 // MatchTeam represents a team in a matchup with score
@@ -214,6 +230,20 @@ type MatchTeam struct {
 	Points     float64 `json:"points"`
 	Adjustment float64 `json:"adjustment"`
 	Total      float64 `json:"total"`
+
+	// H2H category leagues only: categories won, lost, and tied in the matchup
+	CategoryWins   int `json:"categoryWins,omitempty"`
+	CategoryLosses int `json:"categoryLosses,omitempty"`
+	CategoryTies   int `json:"categoryTies,omitempty"`
+}
+
+// CategoryResult is one category of an H2H category matchup
+type CategoryResult struct {
+	Category      string  `json:"category"` // Column short name, e.g. "HR"
+	AwayValue     float64 `json:"awayValue"`
+	HomeValue     float64 `json:"homeValue"`
+	LowerIsBetter bool    `json:"lowerIsBetter,omitempty"`
+	WinnerTeamID  string  `json:"winnerTeamId,omitempty"` // Empty when the category is tied or unscored
 }
 
 // DateRange represents a time period with start and end dates
@@ -224,6 +254,12 @@ type DateRange struct {
 
 // ProcessStandings converts the raw API response into a more intuitive structure
 func ProcessStandings(response *StandingsResponse) (*LeagueStandings, error) {
+	return ProcessStandingsAs(response, "")
+}
+
+// ProcessStandingsAs is ProcessStandings for a league whose format is known from its
+// settings (see Client.LeagueFormat). An empty format recognizes it from the table types.
+func ProcessStandingsAs(response *StandingsResponse, league LeagueFormat) (*LeagueStandings, error) {
 	if len(response.Responses) == 0 {
 		return nil, fmt.Errorf("no response data found")
 	}
@@ -240,6 +276,11 @@ func ProcessStandings(response *StandingsResponse) (*LeagueStandings, error) {
 			StartDate: responseData.MiscData.DisplayedMinDate,
 			EndDate:   responseData.MiscData.DisplayedMaxDate,
 		},
+		Timeframe: StandingsTimeframe{
+			Period:        responseData.DisplayedSelections.Period,
+			TimeframeType: responseData.DisplayedSelections.TimeframeType,
+			TimeStart:     StandingsTimeStart(responseData.DisplayedSelections.TimeStartType),
+		},
 	}
 
 	// Process divisions from tabs
@@ -259,7 +300,19 @@ func ProcessStandings(response *StandingsResponse) (*LeagueStandings, error) {
 
 	// Process teams and standings table
 	for _, table := range responseData.TableList {
-		if table.TableType == "H2hPointsBased1" {
+		format := tableFormat(table, league)
+		if format == LeagueFormatH2HCategories {
+			standings.Format = format
+			if period, date, ok := scoringPeriodCaption(table); ok {
+				standings.Matchups = append(standings.Matchups, parseCategoryMatchups(table, period, date)...)
+			} else {
+				standings.Teams = append(standings.Teams, parseStandingsTable(table, responseData.FantasyTeamInfo, format)...)
+			}
+		} else if format == LeagueFormatRotisserie || format == LeagueFormatPoints {
+			standings.Format = format
+			standings.Teams = append(standings.Teams, parseStandingsTable(table, responseData.FantasyTeamInfo, format)...)
+		} else if table.TableType == "H2hPointsBased1" {
+			standings.Format = LeagueFormatH2HPoints
 			// This is the standings table
 			for _, row := range table.Rows {
 				if len(row.Cells) < 10 || len(row.FixedCells) < 2 {
@@ -306,6 +359,7 @@ func ProcessStandings(response *StandingsResponse) (*LeagueStandings, error) {
 			}
 		} else if table.TableType == "H2hPointsBased2" || table.TableType == "H2hPointsBased3" {
 			// These are the matchup tables (H2hPointsBased2 for COMBINED view, H2hPointsBased3 for SCHEDULE view)
+			standings.Format = LeagueFormatH2HPoints
 			period := 0
 			date := ""
 
@@ -359,10 +413,28 @@ func ProcessStandings(response *StandingsResponse) (*LeagueStandings, error) {
 		}
 	}
 
-	// Add divisions from the map to the result
+	// Fall back to the first table listing teams when no table type was recognized
+	if standings.Format == "" {
+		for _, table := range responseData.TableList {
+			if !hasTeamRows(table) {
+				continue
+			}
+			standings.Format = inferStandingsFormat(table)
+			standings.Teams = parseStandingsTable(table, responseData.FantasyTeamInfo, standings.Format)
+			break
+		}
+	}
+	if standings.Format == "" {
+		standings.Format = LeagueFormatUnknown
+	}
+
+	// Add divisions from the map to the result, in a stable order
 	for _, div := range divisionMap {
 		standings.Divisions = append(standings.Divisions, div)
 	}
+	sort.Slice(standings.Divisions, func(i, j int) bool {
+		return standings.Divisions[i].ID < standings.Divisions[j].ID
+	})
 
 	return standings, nil
 }
@@ -385,9 +457,23 @@ const (
 type StandingsOption func(*standingsOptions)
 
 type standingsOptions struct {
-	view StandingsView
+	view      StandingsView
+	period    int
+	timeStart StandingsTimeStart
 }
 
+// StandingsTimeStart is the start of the timeframe selector on the standings page: whether
+// a period's standings cover the period alone or the season up to it
+type StandingsTimeStart string
+
+const (
+	// StandingsPeriodOnly counts only the selected period's results
+	StandingsPeriodOnly StandingsTimeStart = "PERIOD_ONLY"
+	// StandingsFromSeasonStart counts results from the start of the season through the
+	// selected period
+	StandingsFromSeasonStart StandingsTimeStart = "FROM_SEASON_START"
+)
+
 // WithStandingsView sets the view parameter for the standings request
 func WithStandingsView(view StandingsView) StandingsOption {
 	return func(o *standingsOptions) {
@@ -395,61 +481,113 @@ func WithStandingsView(view StandingsView) StandingsOption {
 	}
 }
 
-func (c *Client) GetStandings(opts ...StandingsOption) (*LeagueStandings, error) {
-	// Default options
-	options := &standingsOptions{
-		view: StandingsViewCombined,
+// WithStandingsThroughPeriod returns the standings as they stood after a scoring period,
+// counting every result from the start of the season through it
+func WithStandingsThroughPeriod(period int) StandingsOption {
+	return func(o *standingsOptions) {
+		o.period = period
+		o.timeStart = StandingsFromSeasonStart
 	}
+}
 
-	// Apply provided options
-	for _, opt := range opts {
-		opt(options)
+// WithStandingsPeriodOnly returns standings for a single scoring period, counting only its
+// results, e.g. for a weekly record
+func WithStandingsPeriodOnly(period int) StandingsOption {
+	return func(o *standingsOptions) {
+		o.period = period
+		o.timeStart = StandingsPeriodOnly
 	}
+}
+
+// GetStandingsRaw fetches the getStandings response without parsing it
+func (c *Client) GetStandingsRaw(opts ...StandingsOption) (json.RawMessage, error) {
+	data := standingsRequestData(c.LeagueID, opts...)
 
 	var requestPayload = FantraxRequest{
 		Msgs: []FantraxMessage{
 			{
 				Method: "getStandings",
-				Data: map[string]string{
-					"leagueId": c.LeagueID,
-					"view":     string(options.view),
-				},
+				Data:   data,
 			},
 		},
 	}
 
-	jsonStr, err := json.Marshal(requestPayload)
+	body, err := c.postFxpa(fxpaRequest{Msgs: requestPayload.Msgs})
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request payload: %w", err)
+		return nil, err
 	}
+	c.checkSchema("getStandings", body, &StandingsResponse{})
+	if err := checkStandingsView(body, StandingsView(data["view"])); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
 
-	req, err := http.NewRequest("POST", "https://www.fantrax.com/fxpa/req?leagueId="+c.LeagueID, bytes.NewBuffer(jsonStr))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+// ErrStandingsViewNotShown is returned when Fantrax answers a standings request with a
+// different view than the one asked for, e.g. one the league doesn't have
+var ErrStandingsViewNotShown = errors.New("fantrax did not return the requested standings view")
+
+// checkStandingsView fails if the response shows a view other than the one requested, so its
+// tables aren't parsed as the wrong view's
+func checkStandingsView(body []byte, view StandingsView) error {
+	var response struct {
+		Responses []struct {
+			Data struct {
+				DisplayedSelections struct {
+					View string `json:"view"`
+				} `json:"displayedSelections"`
+			} `json:"data"`
+		} `json:"responses"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil || len(response.Responses) == 0 {
+		// Left to the parser to report
+		return nil
+	}
+	if shown := response.Responses[0].Data.DisplayedSelections.View; shown != "" && shown != string(view) {
+		return fmt.Errorf("%w: asked for %s, got %s", ErrStandingsViewNotShown, view, shown)
+	}
+	return nil
+}
+
+// standingsRequestData builds the getStandings request data from the options
+func standingsRequestData(leagueID string, opts ...StandingsOption) map[string]string {
+	// Default options
+	options := &standingsOptions{
+		view: StandingsViewCombined,
 	}
 
-	resp, err := c.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+	// Apply provided options
+	for _, opt := range opts {
+		opt(options)
 	}
-	defer resp.Body.Close()
 
-	// Check for non-200 status code
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned non-200 status code: %d", resp.StatusCode)
+	data := map[string]string{
+		"leagueId": leagueID,
+		"view":     string(options.view),
+	}
+	if options.period > 0 {
+		// The timeframe selector's values, as the standings page sends them
+		data["period"] = strconv.Itoa(options.period)
+		data["timeframeType"] = "BY_PERIOD"
+		data["timeStartType"] = string(options.timeStart)
 	}
+	return data
+}
 
-	body, err := io.ReadAll(resp.Body)
+// GetStandings fetches and processes the league standings
+func (c *Client) GetStandings(opts ...StandingsOption) (*LeagueStandings, error) {
+	body, err := c.GetStandingsRaw(opts...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, err
 	}
+
 	var response StandingsResponse
 	err = json.Unmarshal(body, &response)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
-	standings, err := ProcessStandings(&response)
+	standings, err := ProcessStandingsAs(&response, c.LeagueFormat)
 	if err != nil {
 		return nil, fmt.Errorf("failed to process standings: %w", err)
 	}
@@ -0,0 +1,93 @@
+package auth_client
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestStandingsRequestData(t *testing.T) {
+	data := standingsRequestData("abc")
+	if data["view"] != string(StandingsViewCombined) || data["period"] != "" {
+		t.Errorf("unexpected default request: %v", data)
+	}
+
+	data = standingsRequestData("abc", WithStandingsPeriodOnly(7))
+	if data["period"] != "7" || data["timeframeType"] != "BY_PERIOD" || data["timeStartType"] != string(StandingsPeriodOnly) {
+		t.Errorf("unexpected period-only request: %v", data)
+	}
+
+	data = standingsRequestData("abc", WithStandingsThroughPeriod(7), WithStandingsView(StandingsViewAll))
+	if data["view"] != "ALL" || data["timeStartType"] != string(StandingsFromSeasonStart) {
+		t.Errorf("unexpected through-period request: %v", data)
+	}
+}
+
+func TestProcessStandingsAsLeagueFormat(t *testing.T) {
+	// A table type the parser doesn't recognize, with too few category columns to be inferred
+	// as rotisserie
+	response := &StandingsResponse{Responses: []Response{{Data: ResponseData{
+		TableList: []Table{{
+			TableType: "Standings7",
+			Header:    HeaderData{Cells: []Cell{{ShortName: "Team"}, {ShortName: "HR"}, {ShortName: "Pts"}}},
+			Rows: []Row{{
+				FixedCells: []Cell{{Content: "1"}},
+				Cells:      []Cell{{Content: "Aces", TeamID: "t1"}, {Content: "10"}, {Content: "42.5"}},
+			}},
+		}},
+	}}}}
+
+	standings, err := ProcessStandings(response)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if standings.Format != LeagueFormatPoints {
+		t.Fatalf("format = %s, want points when inferred", standings.Format)
+	}
+
+	standings, err = ProcessStandingsAs(response, LeagueFormatRotisserie)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if standings.Format != LeagueFormatRotisserie || len(standings.Teams) != 1 {
+		t.Fatalf("got %s with %d teams, want one rotisserie team", standings.Format, len(standings.Teams))
+	}
+	team := standings.Teams[0]
+	if team.TotalPoints != 42.5 || team.CategoryPoints["HR"] != 10 || team.Rank != 1 {
+		t.Errorf("unexpected team: %+v", team)
+	}
+}
+
+func TestCategoryMatchupSortDirection(t *testing.T) {
+	// SV is marked ascending by its header and ERA has no sort direction, so both fall to
+	// the lower value while HR goes to the higher one
+	table := Table{
+		Header: HeaderData{Cells: []Cell{{ShortName: "Team"}, {ShortName: "HR", SortDirection: 1}, {ShortName: "SV", SortDirection: -1}, {ShortName: "ERA"}}},
+		Rows: []Row{
+			{Cells: []Cell{{TeamID: "away"}, {Content: "5"}, {Content: "2"}, {Content: "3.10"}}},
+			{Cells: []Cell{{TeamID: "home"}, {Content: "3"}, {Content: "4"}, {Content: "4.20"}}},
+		},
+	}
+
+	matchups := parseCategoryMatchups(table, 1, "")
+	if len(matchups) != 1 {
+		t.Fatalf("got %d matchups, want 1", len(matchups))
+	}
+	for _, result := range matchups[0].Categories {
+		if result.WinnerTeamID != "away" {
+			t.Errorf("%s won by %q, want away", result.Category, result.WinnerTeamID)
+		}
+		if result.LowerIsBetter != (result.Category != "HR") {
+			t.Errorf("%s lowerIsBetter = %v", result.Category, result.LowerIsBetter)
+		}
+	}
+}
+
+func TestCheckStandingsView(t *testing.T) {
+	body := []byte(`{"responses":[{"data":{"displayedSelections":{"view":"COMBINED"}}}]}`)
+	if err := checkStandingsView(body, StandingsViewCombined); err != nil {
+		t.Errorf("matching view: %v", err)
+	}
+	if err := checkStandingsView(body, StandingsViewPlayoffs); !errors.Is(err, ErrStandingsViewNotShown) {
+		t.Errorf("got %v, want ErrStandingsViewNotShown", err)
+	}
+}
@@ -1,11 +1,9 @@
 package auth_client
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
+	"time"
 
 	"github.com/pmurley/go-fantrax/auth_client/parser"
 	"github.com/pmurley/go-fantrax/models"
@@ -17,69 +15,80 @@ type GetTeamRosterInfoRequest struct {
 	Reload   string `json:"reload"`
 	Period   string `json:"period"`
 	TeamID   string `json:"teamId,omitempty"`
+
+	// TimeframeTypeCode selects the stats shown alongside each player (see RosterStatsView)
+	TimeframeTypeCode string `json:"timeframeTypeCode,omitempty"`
+}
+
+// RosterStatsView selects which stats the roster page shows for each player. The values are
+// the timeframe codes of the roster page's stats selector.
+type RosterStatsView string
+
+const (
+	RosterStatsSeason    RosterStatsView = "YEAR_TO_DATE" // Season to date (the page default)
+	RosterStatsLast7     RosterStatsView = "LAST_7_DAYS"
+	RosterStatsLast14    RosterStatsView = "LAST_14_DAYS"
+	RosterStatsLast30    RosterStatsView = "LAST_30_DAYS"
+	RosterStatsProjected RosterStatsView = "PROJECTED"
+	RosterStatsPeriod    RosterStatsView = "BY_PERIOD" // Stats for the requested period only
+)
+
+// RosterOption is a functional option for configuring GetTeamRosterInfo
+type RosterOption func(*rosterOptions)
+
+type rosterOptions struct {
+	statsView RosterStatsView
+}
+
+// WithRosterStatsView selects the stats timeframe returned with each player
+func WithRosterStatsView(view RosterStatsView) RosterOption {
+	return func(o *rosterOptions) {
+		o.statsView = view
+	}
 }
 
-// GetTeamRosterInfoRaw fetches the raw team roster response without parsing
-func (c *Client) GetTeamRosterInfoRaw(period string, teamID string) (*models.TeamRosterResponse, error) {
+// GetTeamRosterInfoRaw fetches the raw team roster response without parsing. An empty teamID
+// fetches the user's team.
+func (c *Client) GetTeamRosterInfoRaw(period string, teamID string, opts ...RosterOption) (*models.TeamRosterResponse, error) {
+	options := &rosterOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
 	requestPayload := FantraxRequest{
 		Msgs: []FantraxMessage{
 			{
 				Method: "getTeamRosterInfo",
 				Data: GetTeamRosterInfoRequest{
-					LeagueID: c.LeagueID,
-					Reload:   "1",
-					Period:   period,
-					TeamID:   teamID,
+					LeagueID:          c.LeagueID,
+					Reload:            "1",
+					Period:            period,
+					TeamID:            teamID,
+					TimeframeTypeCode: string(options.statsView),
 				},
 			},
 		},
 	}
 
 	// Build refUrl with optional parameters
-	refUrl := fmt.Sprintf("https://www.fantrax.com/fantasy/league/%s/team/roster;reload=1", c.LeagueID)
+	refUrl := c.fxpaRefURL("getTeamRosterInfo") + ";reload=1"
 	if period != "" {
 		refUrl += fmt.Sprintf(";period=%s", period)
 	}
 	if teamID != "" {
 		refUrl += fmt.Sprintf(";teamId=%s", teamID)
 	}
-
-	// Add common Fantrax request fields
-	fullRequest := map[string]interface{}{
-		"msgs":   requestPayload.Msgs,
-		"uiv":    3,
-		"refUrl": refUrl,
-		"dt":     0,
-		"at":     0,
-		"av":     "0.0",
-		"tz":     "UTC",
-		"v":      "179.0.1",
-	}
-
-	jsonStr, err := json.Marshal(fullRequest)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request payload: %w", err)
-	}
-
-	req, err := http.NewRequest("POST", "https://www.fantrax.com/fxpa/req?leagueId="+c.LeagueID, bytes.NewBuffer(jsonStr))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	if options.statsView != "" {
+		refUrl += fmt.Sprintf(";timeframeTypeCode=%s", options.statsView)
 	}
 
-	resp, err := c.Do(req)
+	body, err := c.postFxpa(fxpaRequest{
+		Msgs:     requestPayload.Msgs,
+		RefURL:   refUrl,
+		Timezone: "UTC",
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Check for non-200 status code
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned non-200 status code: %d", resp.StatusCode)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, err
 	}
 
 	var response models.TeamRosterResponse
@@ -87,14 +96,17 @@ func (c *Client) GetTeamRosterInfoRaw(period string, teamID string) (*models.Tea
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
+	c.checkSchema("getTeamRosterInfo", body, &response)
+	c.recordMyTeamID(&response)
 
 	return &response, nil
 }
 
-// GetTeamRosterInfo fetches and parses the team roster into a simplified structure
-func (c *Client) GetTeamRosterInfo(period string, teamID string) (*models.TeamRoster, error) {
+// GetTeamRosterInfo fetches and parses the team roster into a simplified structure.
+// Pass WithRosterStatsView to request an alternate stats timeframe (last 7 days, projected, ...).
+func (c *Client) GetTeamRosterInfo(period string, teamID string, opts ...RosterOption) (*models.TeamRoster, error) {
 	// Get the raw response
-	rawResponse, err := c.GetTeamRosterInfoRaw(period, teamID)
+	rawResponse, err := c.GetTeamRosterInfoRaw(period, teamID, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get raw team roster info: %w", err)
 	}
@@ -111,6 +123,10 @@ func (c *Client) GetTeamRosterInfo(period string, teamID string) (*models.TeamRo
 		return nil, fmt.Errorf("failed to parse team roster response: %w", err)
 	}
 
+	c.reportParseWarnings(roster.ParseWarnings)
+	c.resolveGameTimes(roster, time.Now())
+	roster.StatsView = rosterStatsView(rawResponse, opts)
+
 	return roster, nil
 }
 
@@ -137,3 +153,49 @@ func (c *Client) GetMyTeamRosterInfoRaw(period string) (*models.TeamRosterRespon
 	// Empty string for teamID will get the user's own team
 	return c.GetTeamRosterInfoRaw(period, "")
 }
+
+// GetAllTeamRosters fetches the roster of every team in the league for a period
+//
+// The league's team list is taken from the authenticated user's roster response, then each
+// team's roster is fetched in turn. The returned map is keyed by fantasy team ID, and each
+// roster's TeamInfo.TeamID is set to that key.
+//
+// Parameters:
+//   - period: The roster period as a string (empty string = current period)
+//
+// Returns the rosters keyed by team ID, the league's teams in display order, or an error if
+// any roster could not be fetched.
+func (c *Client) GetAllTeamRosters(period string) (map[string]*models.TeamRoster, []models.FantasyTeam, error) {
+	myRoster, err := c.GetTeamRosterInfo(period, "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get league team list: %w", err)
+	}
+
+	rosters := make(map[string]*models.TeamRoster, len(myRoster.LeagueTeams))
+	for _, team := range myRoster.LeagueTeams {
+		roster, err := c.GetTeamRosterInfo(period, team.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get roster for team %s: %w", team.ID, err)
+		}
+		// TeamInfo.TeamID is filled from myTeamIds, so set it to the team actually fetched
+		roster.TeamInfo.TeamID = team.ID
+		rosters[team.ID] = roster
+	}
+
+	return rosters, myRoster.LeagueTeams, nil
+}
+
+// rosterStatsView reports the stats timeframe of a roster response, preferring the selection
+// echoed back by Fantrax and falling back to the requested view
+func rosterStatsView(resp *models.TeamRosterResponse, opts []RosterOption) string {
+	if resp != nil && len(resp.Responses) > 0 {
+		if code, ok := resp.Responses[0].Data.DisplayedSelections["timeframeTypeCode"].(string); ok && code != "" {
+			return code
+		}
+	}
+	options := &rosterOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return string(options.statsView)
+}
@@ -1,11 +1,8 @@
 package auth_client
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"strconv"
 
 	"github.com/pmurley/go-fantrax/models"
@@ -25,8 +22,14 @@ type GetTeamServiceTimeRequest struct {
 	TeamID string `json:"teamId"`
 }
 
-// GetTeamServiceTimeRaw fetches the raw team service time response
+// GetTeamServiceTimeRaw fetches the raw team service time response. An empty teamID fetches
+// the user's team (see GetMyTeamID).
 func (c *Client) GetTeamServiceTimeRaw(teamID string) (*models.ServiceTimeResponse, error) {
+	teamID, err := c.teamOrMine(teamID)
+	if err != nil {
+		return nil, err
+	}
+
 	requestPayload := FantraxRequest{
 		Msgs: []FantraxMessage{
 			{
@@ -38,44 +41,12 @@ func (c *Client) GetTeamServiceTimeRaw(teamID string) (*models.ServiceTimeRespon
 		},
 	}
 
-	// Build refUrl
-	refUrl := fmt.Sprintf("https://www.fantrax.com/fantasy/league/%s/team/service-time;teamId=%s", c.LeagueID, teamID)
-
-	// Add common Fantrax request fields
-	fullRequest := map[string]interface{}{
-		"msgs":   requestPayload.Msgs,
-		"uiv":    3,
-		"refUrl": refUrl,
-		"dt":     0,
-		"at":     0,
-		"av":     "0.0",
-		"tz":     "America/Chicago",
-		"v":      "179.0.1",
-	}
-
-	jsonStr, err := json.Marshal(fullRequest)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request payload: %w", err)
-	}
-
-	req, err := http.NewRequest("POST", "https://www.fantrax.com/fxpa/req?leagueId="+c.LeagueID, bytes.NewBuffer(jsonStr))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := c.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned non-200 status code: %d", resp.StatusCode)
-	}
-
-	body, err := io.ReadAll(resp.Body)
+	body, err := c.postFxpa(fxpaRequest{
+		Msgs:   requestPayload.Msgs,
+		RefURL: c.fxpaRefURL("getTeamServiceTime") + ";teamId=" + teamID,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, err
 	}
 
 	var response models.ServiceTimeResponse
@@ -83,6 +54,7 @@ func (c *Client) GetTeamServiceTimeRaw(teamID string) (*models.ServiceTimeRespon
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
+	c.checkSchema("getTeamServiceTime", body, &response)
 
 	return &response, nil
 }
@@ -1,11 +1,8 @@
 package auth_client
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 
 	"github.com/pmurley/go-fantrax/auth_client/parser"
 
@@ -18,15 +15,22 @@ type GetTransactionDetailsHistoryRequest struct {
 	MaxResultsPerPage string `json:"maxResultsPerPage"`
 	ExecutedOnly      bool   `json:"executedOnly,omitempty"`
 	IncludeDeleted    bool   `json:"includeDeleted,omitempty"`
-	View              string `json:"view,omitempty"` // "CLAIM_DROP" or "TRADE"
+	View              string `json:"view,omitempty"` // "CLAIM_DROP", "TRADE" or "PENDING"
 	PageNumber        string `json:"pageNumber,omitempty"`
 }
 
+// Transaction history views
+const (
+	TransactionViewClaimDrop = "CLAIM_DROP"
+	TransactionViewTrade     = "TRADE"
+	TransactionViewPending   = "PENDING" // Claims awaiting processing and pending trades
+	TransactionViewLineup    = "LINEUP_CHANGE"
+)
+
 // GetTransactionDetailsHistoryRaw fetches the raw transaction history response without parsing
 func (c *Client) GetTransactionDetailsHistoryRaw(maxResultsPerPage string) (json.RawMessage, error) {
-	// Build the request payload matching the example
-	fullRequest := map[string]interface{}{
-		"msgs": []FantraxMessage{
+	body, err := c.postFxpa(fxpaRequest{
+		Msgs: []FantraxMessage{
 			{
 				Method: "getTransactionDetailsHistory",
 				Data: GetTransactionDetailsHistoryRequest{
@@ -35,40 +39,13 @@ func (c *Client) GetTransactionDetailsHistoryRaw(maxResultsPerPage string) (json
 				},
 			},
 		},
-		"uiv":    3,
-		"refUrl": fmt.Sprintf("https://www.fantrax.com/fantasy/league/%s/transactions/history;maxResultsPerPage=%s", c.LeagueID, maxResultsPerPage),
-		"dt":     0,
-		"at":     0,
-		"av":     "0.0",
-		"tz":     "UTC",
-		"v":      "179.0.1",
-	}
-
-	jsonStr, err := json.Marshal(fullRequest)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request payload: %w", err)
-	}
-
-	req, err := http.NewRequest("POST", "https://www.fantrax.com/fxpa/req?leagueId="+c.LeagueID, bytes.NewBuffer(jsonStr))
+		RefURL:   c.fxpaRefURL("getTransactionDetailsHistory") + ";maxResultsPerPage=" + maxResultsPerPage,
+		Timezone: "UTC",
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := c.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Check for non-200 status code
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned non-200 status code: %d", resp.StatusCode)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, err
 	}
+	c.checkSchema("getTransactionDetailsHistory", body, &models.TransactionHistoryResponse{})
 
 	return json.RawMessage(body), nil
 }
@@ -97,78 +74,25 @@ func (c *Client) GetTransactionHistory(maxResultsPerPage string) ([]models.Trans
 	if c.UserInfo != nil {
 		userTimezone = c.UserInfo.Timezone
 	}
-	transactions, err := parser.ParseTransactions(historyResponse, userTimezone)
+	transactions, warnings, err := parser.ParseTransactions(historyResponse, userTimezone)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse transactions: %w", err)
 	}
+	c.reportParseWarnings(warnings)
 
 	return transactions, nil
 }
 
-// GetAllTransactions fetches all claim/drop transactions across all pages
+// GetAllTransactions fetches all claim/drop transactions across all pages. Set
+// ReconcileTransactionPages to keep rows from shifting between pages during the fetch.
 func (c *Client) GetAllTransactions() ([]models.Transaction, error) {
-	var allTransactions []models.Transaction
-	pageNumber := 1
-
-	for {
-		// Build request for this page
-		req := GetTransactionDetailsHistoryRequest{
-			LeagueID:          c.LeagueID,
-			MaxResultsPerPage: "250",
-			ExecutedOnly:      true,
-			IncludeDeleted:    false,
-			View:              "CLAIM_DROP",
-			PageNumber:        fmt.Sprintf("%d", pageNumber),
-		}
-
-		// Get raw response
-		rawResponse, err := c.GetTransactionDetailsHistoryFullRaw(req)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get transaction history page %d: %w", pageNumber, err)
-		}
-
-		// Parse the response
-		historyResponse, err := parser.ParseTransactionHistoryResponse(rawResponse)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse transaction history response page %d: %w", pageNumber, err)
-		}
-
-		// Convert to simplified transactions
-		userTimezone := ""
-		if c.UserInfo != nil {
-			userTimezone = c.UserInfo.Timezone
-		}
-		transactions, err := parser.ParseTransactions(historyResponse, userTimezone)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse transactions page %d: %w", pageNumber, err)
-		}
-
-		// Get pagination info
-		if len(historyResponse.Responses) > 0 {
-			pagination := historyResponse.Responses[0].Data.PaginatedResultSet
-
-			// Add all transactions from this page
-			allTransactions = append(allTransactions, transactions...)
-
-			// Check if we have more pages
-			if pageNumber >= pagination.TotalNumPages {
-				break
-			}
-		} else {
-			break // No response data
-		}
-
-		pageNumber++
-	}
-
-	return allTransactions, nil
+	return c.getAllTransactionPages(TransactionViewClaimDrop, ProgressTransactions, nil)
 }
 
 // GetTransactionDetailsHistoryFullRaw fetches the raw transaction history with all parameters
 func (c *Client) GetTransactionDetailsHistoryFullRaw(req GetTransactionDetailsHistoryRequest) (json.RawMessage, error) {
 	// Build refUrl with all parameters
-	refUrl := fmt.Sprintf("https://www.fantrax.com/fantasy/league/%s/transactions/history;maxResultsPerPage=%s",
-		c.LeagueID, req.MaxResultsPerPage)
+	refUrl := c.fxpaRefURL("getTransactionDetailsHistory") + ";maxResultsPerPage=" + req.MaxResultsPerPage
 
 	if req.View != "" {
 		refUrl += fmt.Sprintf(";view=%s", req.View)
@@ -179,48 +103,20 @@ func (c *Client) GetTransactionDetailsHistoryFullRaw(req GetTransactionDetailsHi
 	// Add executedOnly and includeDeleted to refUrl
 	refUrl += fmt.Sprintf(";executedOnly=%t;includeDeleted=%t", req.ExecutedOnly, req.IncludeDeleted)
 
-	// Build the request payload
-	fullRequest := map[string]interface{}{
-		"msgs": []FantraxMessage{
+	body, err := c.postFxpa(fxpaRequest{
+		Msgs: []FantraxMessage{
 			{
 				Method: "getTransactionDetailsHistory",
 				Data:   req,
 			},
 		},
-		"uiv":    3,
-		"refUrl": refUrl,
-		"dt":     0,
-		"at":     0,
-		"av":     "0.0",
-		"tz":     "UTC",
-		"v":      "179.0.1",
-	}
-
-	jsonStr, err := json.Marshal(fullRequest)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request payload: %w", err)
-	}
-
-	req2, err := http.NewRequest("POST", "https://www.fantrax.com/fxpa/req?leagueId="+c.LeagueID, bytes.NewBuffer(jsonStr))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := c.Do(req2)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Check for non-200 status code
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned non-200 status code: %d", resp.StatusCode)
-	}
-
-	body, err := io.ReadAll(resp.Body)
+		RefURL:   refUrl,
+		Timezone: "UTC",
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, err
 	}
+	c.checkSchema("getTransactionDetailsHistory", body, &models.TransactionHistoryResponse{})
 
 	return json.RawMessage(body), nil
 }
@@ -262,73 +158,20 @@ func (c *Client) GetTrades(maxResultsPerPage string, pageNumber string, executed
 	if c.UserInfo != nil {
 		userTimezone = c.UserInfo.Timezone
 	}
-	transactions, err := parser.ParseTransactions(historyResponse, userTimezone)
+	transactions, warnings, err := parser.ParseTransactions(historyResponse, userTimezone)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse trades: %w", err)
 	}
+	c.reportParseWarnings(warnings)
+	c.recordDraftPickTrades(transactions)
 
 	return transactions, nil
 }
 
-// GetAllTrades fetches all trade transactions across all pages
+// GetAllTrades fetches all trade transactions across all pages. totalNumResults counts
+// distinct trades, but each trade may have multiple player rows, so every parsed row is kept.
 func (c *Client) GetAllTrades() ([]models.Transaction, error) {
-	var allTrades []models.Transaction
-	pageNumber := 1
-
-	for {
-		// Build request for this page
-		req := GetTransactionDetailsHistoryRequest{
-			LeagueID:          c.LeagueID,
-			MaxResultsPerPage: "250",
-			ExecutedOnly:      true,
-			IncludeDeleted:    false,
-			View:              "TRADE",
-			PageNumber:        fmt.Sprintf("%d", pageNumber),
-		}
-
-		// Get raw response
-		rawResponse, err := c.GetTransactionDetailsHistoryFullRaw(req)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get trade history page %d: %w", pageNumber, err)
-		}
-
-		// Parse the response
-		historyResponse, err := parser.ParseTransactionHistoryResponse(rawResponse)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse trade history response page %d: %w", pageNumber, err)
-		}
-
-		// Convert to simplified transactions
-		userTimezone := ""
-		if c.UserInfo != nil {
-			userTimezone = c.UserInfo.Timezone
-		}
-		transactions, err := parser.ParseTransactions(historyResponse, userTimezone)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse trades page %d: %w", pageNumber, err)
-		}
-
-		// Get pagination info
-		if len(historyResponse.Responses) > 0 {
-			pagination := historyResponse.Responses[0].Data.PaginatedResultSet
-
-			// Add all transactions from this page
-			// Note: For trades, totalNumResults counts distinct trades, but each trade
-			// may have multiple player rows, so we add all parsed transactions
-			allTrades = append(allTrades, transactions...)
-
-			// Check if we have more pages
-			if pageNumber >= pagination.TotalNumPages {
-				break
-			}
-		} else {
-			break // No response data
-		}
-
-		pageNumber++
-	}
-
-	return allTrades, nil
+	return c.getAllTransactionPages(TransactionViewTrade, ProgressTrades, c.recordDraftPickTrades)
 }
 
 // GetAllTransactionsIncludingTrades fetches both claims/drops and trades across all pages
@@ -352,7 +195,13 @@ func (c *Client) GetAllTransactionsIncludingTrades() ([]models.Transaction, erro
 }
 
 // GetTransactionsPaginated fetches transactions with pagination info
+//
+// The PENDING view is rejected; its rows only make sense grouped, so use
+// GetPendingTransactionsPaginated for it.
 func (c *Client) GetTransactionsPaginated(view string, pageNumber int, maxResults int, executedOnly bool) ([]models.Transaction, *models.PaginatedResultSet, error) {
+	if view == TransactionViewPending {
+		return nil, nil, fmt.Errorf("view %s is not supported here, use GetPendingTransactionsPaginated", view)
+	}
 	req := GetTransactionDetailsHistoryRequest{
 		LeagueID:          c.LeagueID,
 		MaxResultsPerPage: fmt.Sprintf("%d", maxResults),
@@ -361,17 +210,23 @@ func (c *Client) GetTransactionsPaginated(view string, pageNumber int, maxResult
 		View:              view,
 		PageNumber:        fmt.Sprintf("%d", pageNumber),
 	}
+	return c.getTransactionPage(req)
+}
+
+// getTransactionPage fetches and parses one page of transaction history
+func (c *Client) getTransactionPage(req GetTransactionDetailsHistoryRequest) ([]models.Transaction, *models.PaginatedResultSet, error) {
+	pageNumber := req.PageNumber
 
 	// Get raw response
 	rawResponse, err := c.GetTransactionDetailsHistoryFullRaw(req)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get transaction history page %d: %w", pageNumber, err)
+		return nil, nil, fmt.Errorf("failed to get transaction history page %s: %w", pageNumber, err)
 	}
 
 	// Parse the response
 	historyResponse, err := parser.ParseTransactionHistoryResponse(rawResponse)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to parse transaction history response page %d: %w", pageNumber, err)
+		return nil, nil, fmt.Errorf("failed to parse transaction history response page %s: %w", pageNumber, err)
 	}
 
 	// Convert to simplified transactions
@@ -379,10 +234,11 @@ func (c *Client) GetTransactionsPaginated(view string, pageNumber int, maxResult
 	if c.UserInfo != nil {
 		userTimezone = c.UserInfo.Timezone
 	}
-	transactions, err := parser.ParseTransactions(historyResponse, userTimezone)
+	transactions, warnings, err := parser.ParseTransactions(historyResponse, userTimezone)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to parse transactions page %d: %w", pageNumber, err)
+		return nil, nil, fmt.Errorf("failed to parse transactions page %s: %w", pageNumber, err)
 	}
+	c.reportParseWarnings(warnings)
 
 	// Get pagination info
 	var pagination *models.PaginatedResultSet
@@ -392,3 +248,69 @@ func (c *Client) GetTransactionsPaginated(view string, pageNumber int, maxResult
 
 	return transactions, pagination, nil
 }
+
+// GetPendingTransactionsPaginated fetches one page of the PENDING view: claims awaiting
+// processing (with their conditional drops) and trades awaiting acceptance or review
+func (c *Client) GetPendingTransactionsPaginated(pageNumber int, maxResults int) ([]models.PendingTransaction, *models.PaginatedResultSet, error) {
+	req := GetTransactionDetailsHistoryRequest{
+		LeagueID:          c.LeagueID,
+		MaxResultsPerPage: fmt.Sprintf("%d", maxResults),
+		ExecutedOnly:      false,
+		IncludeDeleted:    false,
+		View:              TransactionViewPending,
+		PageNumber:        fmt.Sprintf("%d", pageNumber),
+	}
+
+	// Get raw response
+	rawResponse, err := c.GetTransactionDetailsHistoryFullRaw(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get pending transactions page %d: %w", pageNumber, err)
+	}
+
+	// Parse the response
+	historyResponse, err := parser.ParseTransactionHistoryResponse(rawResponse)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse pending transactions response page %d: %w", pageNumber, err)
+	}
+
+	userTimezone := ""
+	if c.UserInfo != nil {
+		userTimezone = c.UserInfo.Timezone
+	}
+	pending, warnings, err := parser.ParsePendingTransactions(historyResponse, userTimezone)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse pending transactions page %d: %w", pageNumber, err)
+	}
+	c.reportParseWarnings(warnings)
+
+	var pagination *models.PaginatedResultSet
+	if len(historyResponse.Responses) > 0 {
+		pagination = &historyResponse.Responses[0].Data.PaginatedResultSet
+	}
+
+	return pending, pagination, nil
+}
+
+// GetPendingTransactions fetches all pending claims and trades across all pages
+func (c *Client) GetPendingTransactions() ([]models.PendingTransaction, error) {
+	var allPending []models.PendingTransaction
+	for pageNumber := 1; ; pageNumber++ {
+		pending, pagination, err := c.GetPendingTransactionsPaginated(pageNumber, 250)
+		if err != nil {
+			return nil, err
+		}
+		allPending = append(allPending, pending...)
+		c.reportPageProgress(ProgressPending, pageNumber, len(pending), len(allPending), pagination)
+
+		if pagination == nil || pageNumber >= pagination.TotalNumPages {
+			break
+		}
+	}
+	return allPending, nil
+}
+
+// BucketTransactions groups transactions by the day, week, or month they were processed in,
+// with day boundaries in the user's Fantrax timezone (see parser.BucketTransactions)
+func (c *Client) BucketTransactions(transactions []models.Transaction, size parser.BucketSize) []parser.TransactionBucket {
+	return parser.BucketTransactions(transactions, size, c.userLocation())
+}
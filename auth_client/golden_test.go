@@ -0,0 +1,187 @@
+package auth_client
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pmurley/go-fantrax/auth_client/parser"
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// Run `go test ./auth_client -run TestGoldenFixtures -update` to rewrite the golden files
+// after an intentional parser change. See testdata/README.md for adding fixtures.
+var updateGolden = flag.Bool("update", false, "rewrite golden files from the current parser output")
+
+// goldenParsers maps each fixture directory under testdata/fixtures to the parser its files
+// are run through. Every fixture directory must have an entry.
+var goldenParsers = map[string]func(data []byte) (interface{}, error){
+	"getTeamRosterInfo": func(data []byte) (interface{}, error) {
+		return parser.ParseTeamRosterResponse(data)
+	},
+	"getTransactionDetailsHistory": func(data []byte) (interface{}, error) {
+		response, err := parser.ParseTransactionHistoryResponse(data)
+		if err != nil {
+			return nil, err
+		}
+		transactions, warnings, err := parser.ParseTransactions(response, "")
+		return goldenWithWarnings{Result: transactions, Warnings: warnings}, err
+	},
+	"getTransactionDetailsHistory-pending": func(data []byte) (interface{}, error) {
+		response, err := parser.ParseTransactionHistoryResponse(data)
+		if err != nil {
+			return nil, err
+		}
+		pending, warnings, err := parser.ParsePendingTransactions(response, "")
+		return goldenWithWarnings{Result: pending, Warnings: warnings}, err
+	},
+	"getStandings": func(data []byte) (interface{}, error) {
+		var response StandingsResponse
+		if err := json.Unmarshal(data, &response); err != nil {
+			return nil, err
+		}
+		return ProcessStandings(&response)
+	},
+	"getLeagueHomeInfo": func(data []byte) (interface{}, error) {
+		var response LeagueHomeInfoRawResponse
+		if err := json.Unmarshal(data, &response); err != nil {
+			return nil, err
+		}
+		return processLeagueHomeInfo(&response)
+	},
+	"getStandings-schedule": func(data []byte) (interface{}, error) {
+		return ParseAllMatchups(data)
+	},
+	"getStandings-playoffs": func(data []byte) (interface{}, error) {
+		return ParsePlayoffBracket(data)
+	},
+	"getPlayerStats": func(data []byte) (interface{}, error) {
+		var response models.PlayerPoolResponse
+		if err := json.Unmarshal(data, &response); err != nil {
+			return nil, err
+		}
+		if len(response.Responses) == 0 {
+			return nil, nil
+		}
+		d := response.Responses[0].Data
+		players, warnings := parseStatsTable(d.StatsTable, buildColumnIndex(d.TableHeader))
+		return goldenWithWarnings{Result: players, Warnings: warnings}, nil
+	},
+	"getTeamServiceTime": func(data []byte) (interface{}, error) {
+		var response models.ServiceTimeResponse
+		if err := json.Unmarshal(data, &response); err != nil {
+			return nil, err
+		}
+		if len(response.Responses) == 0 {
+			return nil, nil
+		}
+		return parseServiceTime(response.Responses[0].Data.ServiceTime)
+	},
+	"getLeaguePolls": func(data []byte) (interface{}, error) {
+		var response models.PollResponse
+		if err := json.Unmarshal(data, &response); err != nil {
+			return nil, err
+		}
+		if len(response.Responses) == 0 {
+			return nil, nil
+		}
+		return parsePolls(response.Responses[0].Data), nil
+	},
+	"getTradeBlocks": func(data []byte) (interface{}, error) {
+		var response models.TradeBlockResponse
+		if err := json.Unmarshal(data, &response); err != nil {
+			return nil, err
+		}
+		if len(response.Responses) == 0 {
+			return nil, nil
+		}
+		return parseTradeBlocks(response.Responses[0].Data, nil), nil
+	},
+	"illegalRosterOverrideAdmin": func(data []byte) (interface{}, error) {
+		return ParseIllegalRosterOverview(string(data))
+	},
+	"createLeague": func(data []byte) (interface{}, error) {
+		return ParseLeagueSetupMatchups(string(data))
+	},
+}
+
+// goldenWithWarnings records parse warnings alongside the result so that a fixture which
+// starts producing (or stops producing) warnings shows up as a golden diff
+type goldenWithWarnings struct {
+	Result   interface{}           `json:"result"`
+	Warnings []models.ParseWarning `json:"warnings,omitempty"`
+}
+
+// TestGoldenFixtures runs every parser against the fixture corpus and compares the result with
+// the checked-in golden output
+func TestGoldenFixtures(t *testing.T) {
+	dirs, err := os.ReadDir(filepath.Join("testdata", "fixtures"))
+	if err != nil {
+		t.Fatalf("failed to read fixture corpus: %v", err)
+	}
+
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		parse, ok := goldenParsers[dir.Name()]
+		if !ok {
+			t.Errorf("fixture directory %q has no parser registered in goldenParsers", dir.Name())
+			continue
+		}
+
+		files, err := os.ReadDir(filepath.Join("testdata", "fixtures", dir.Name()))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", dir.Name(), err)
+		}
+		for _, file := range files {
+			if file.IsDir() {
+				continue
+			}
+			name := dir.Name() + "/" + file.Name()
+			t.Run(name, func(t *testing.T) {
+				runGoldenFixture(t, dir.Name(), file.Name(), parse)
+			})
+		}
+	}
+}
+
+func runGoldenFixture(t *testing.T, dir, file string, parse func([]byte) (interface{}, error)) {
+	data, err := os.ReadFile(filepath.Join("testdata", "fixtures", dir, file))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := parse(data)
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	got, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		t.Fatalf("failed to marshal result: %v", err)
+	}
+	got = append(got, '\n')
+
+	goldenPath := filepath.Join("testdata", "golden", dir, strings.TrimSuffix(file, filepath.Ext(file))+".json")
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(goldenPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(goldenPath, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatalf("missing golden file (run with -update to create it): %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("parser output differs from %s (run with -update if the change is intended)\n got: %s", goldenPath, got)
+	}
+}
@@ -0,0 +1,100 @@
+package auth_client
+
+import (
+	"fmt"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// IsIREligible returns true if the player carries an Injured List designation
+//
+// Day-to-day and out-indefinitely icons do not qualify; only players Fantrax marks as being
+// on the Injured List (15-day, 60-day, etc.) are eligible for an IR slot.
+func IsIREligible(player models.RosterPlayer) bool {
+	return models.HasIcon(player.Icons, models.IconInjuredList)
+}
+
+// CheckIREligibility returns a violation for every player in an IR slot on the roster who
+// does not carry an Injured List designation
+func CheckIREligibility(teamID, teamName string, roster *models.TeamRoster) []models.RosterViolation {
+	var violations []models.RosterViolation
+	for _, player := range roster.InjuredReserve {
+		if IsIREligible(player) {
+			continue
+		}
+		violations = append(violations, models.RosterViolation{
+			Rule:       models.ViolationIRNotInjured,
+			TeamID:     teamID,
+			TeamName:   teamName,
+			PlayerID:   player.PlayerID,
+			PlayerName: player.Name,
+			Message:    fmt.Sprintf("%s is in an IR slot but is not on the Injured List", player.Name),
+		})
+	}
+	return violations
+}
+
+// CheckLeagueIREligibility checks every team's IR slots for players without an Injured List
+// designation
+//
+// Parameters:
+//   - period: The roster period as a string (empty string = current period)
+//
+// Returns one entry per team in league order, including teams with no violations.
+func (c *Client) CheckLeagueIREligibility(period string) ([]models.TeamRosterViolations, error) {
+	rosters, teams, err := c.GetAllTeamRosters(period)
+	if err != nil {
+		return nil, err
+	}
+
+	report := make([]models.TeamRosterViolations, 0, len(teams))
+	for _, team := range teams {
+		report = append(report, models.TeamRosterViolations{
+			TeamID:     team.ID,
+			TeamName:   team.Name,
+			Violations: CheckIREligibility(team.ID, team.Name, rosters[team.ID]),
+		})
+	}
+	return report, nil
+}
+
+// FixIRViolations moves every player flagged by an IR eligibility check to reserve
+// (commissioner mode only)
+//
+// Each team with at least one ViolationIRNotInjured violation gets a single roster change
+// for the given period. Violations for other rules are ignored.
+//
+// Parameters:
+//   - period: The roster period (week number). Pass 0 to auto-detect the current period.
+//   - report: The per-team report returned by CheckLeagueIREligibility
+//   - daily: true = daily league, false = weekly league
+//
+// Returns the roster change result for each team that was modified, keyed by team ID.
+// Processing stops at the first request error.
+func (c *Client) FixIRViolations(period int, report []models.TeamRosterViolations, daily bool) (map[string]*models.RosterChangeResult, error) {
+	results := make(map[string]*models.RosterChangeResult)
+	for _, team := range report {
+		violations := team.ViolationsByRule(models.ViolationIRNotInjured)
+		if len(violations) == 0 {
+			continue
+		}
+
+		editor, err := c.NewRosterEditor(period, team.TeamID, true, daily)
+		if err != nil {
+			return results, fmt.Errorf("failed to load roster for team %s: %w", team.TeamID, err)
+		}
+
+		for _, v := range violations {
+			if err := editor.MoveToReserve(v.PlayerID); err != nil {
+				return results, fmt.Errorf("failed to move %s to reserve: %w", v.PlayerName, err)
+			}
+		}
+
+		result, err := editor.Apply(false)
+		if err != nil {
+			return results, fmt.Errorf("failed to apply IR fixes for team %s: %w", team.TeamID, err)
+		}
+		results[team.TeamID] = result
+	}
+	return results, nil
+}
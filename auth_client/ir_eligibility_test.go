@@ -0,0 +1,49 @@
+package auth_client
+
+import (
+	"testing"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestCheckIREligibility(t *testing.T) {
+	roster := &models.TeamRoster{
+		InjuredReserve: []models.RosterPlayer{
+			{
+				PlayerID: "p1",
+				Name:     "On IL",
+				Icons:    []models.PlayerIcon{{TypeID: models.IconInjuredList, Tooltip: "Injured List - 15-day IL - Elbow"}},
+			},
+			{
+				PlayerID: "p2",
+				Name:     "Day To Day",
+				Icons:    []models.PlayerIcon{{TypeID: models.IconDayToDay, Tooltip: "Hamstring - Day-to-Day"}},
+			},
+			{
+				PlayerID: "p3",
+				Name:     "Healthy",
+			},
+		},
+		ActiveRoster: []models.RosterPlayer{
+			{PlayerID: "p4", Name: "Active Healthy"},
+		},
+	}
+
+	violations := CheckIREligibility("team1", "Team Alpha", roster)
+	if len(violations) != 2 {
+		t.Fatalf("expected 2 violations, got %d", len(violations))
+	}
+
+	want := []string{"p2", "p3"}
+	for i, v := range violations {
+		if v.PlayerID != want[i] {
+			t.Errorf("violation %d: expected player %s, got %s", i, want[i], v.PlayerID)
+		}
+		if v.Rule != models.ViolationIRNotInjured {
+			t.Errorf("violation %d: expected rule %s, got %s", i, models.ViolationIRNotInjured, v.Rule)
+		}
+		if v.TeamID != "team1" || v.TeamName != "Team Alpha" {
+			t.Errorf("violation %d: unexpected team %s/%s", i, v.TeamID, v.TeamName)
+		}
+	}
+}
@@ -0,0 +1,290 @@
+package auth_client
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// KeeperSelections maps a fantasy team ID to the players that team is keeping.
+// Players may be given by player ID (scorerId) or by full name as shown on the roster.
+type KeeperSelections map[string][]string
+
+// LoadKeeperSelectionsCSV reads keeper selections from CSV
+//
+// The first row must be a header containing a "team_id" column and a "player" (or
+// "player_id") column. Other columns are ignored, so an owner-facing spreadsheet can be
+// exported as-is. A row with a team ID and an empty player records that the team keeps
+// nobody.
+func LoadKeeperSelectionsCSV(r io.Reader) (KeeperSelections, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keeper CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("keeper CSV is empty")
+	}
+
+	teamCol, playerCol := -1, -1
+	for i, name := range records[0] {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "team_id", "teamid":
+			teamCol = i
+		case "player", "player_id", "playerid":
+			playerCol = i
+		}
+	}
+	if teamCol < 0 || playerCol < 0 {
+		return nil, fmt.Errorf("keeper CSV header must contain team_id and player columns")
+	}
+
+	selections := make(KeeperSelections)
+	for line, record := range records[1:] {
+		if teamCol >= len(record) || playerCol >= len(record) {
+			return nil, fmt.Errorf("keeper CSV line %d has too few columns", line+2)
+		}
+		teamID := strings.TrimSpace(record[teamCol])
+		player := strings.TrimSpace(record[playerCol])
+		if teamID == "" {
+			continue
+		}
+		if player == "" {
+			// A team row with no player keeps nobody
+			if _, ok := selections[teamID]; !ok {
+				selections[teamID] = []string{}
+			}
+			continue
+		}
+		selections[teamID] = append(selections[teamID], player)
+	}
+	return selections, nil
+}
+
+// LoadKeeperSelectionsJSON reads keeper selections from a JSON object of team ID to a list
+// of player IDs or names
+func LoadKeeperSelectionsJSON(r io.Reader) (KeeperSelections, error) {
+	var selections KeeperSelections
+	if err := json.NewDecoder(r).Decode(&selections); err != nil {
+		return nil, fmt.Errorf("failed to decode keeper JSON: %w", err)
+	}
+	return selections, nil
+}
+
+// KeeperOptions configures ProcessKeepers
+type KeeperOptions struct {
+	Period        int  // Roster period for the drops (0 = current period)
+	MaxKeepers    int  // Maximum keepers per team (0 = unlimited)
+	DryRun        bool // Validate and build the plan without dropping anyone
+	DropToWaivers bool // Drop released players to waivers instead of free agency
+
+	// Progress, if set, is called after each drop is attempted
+	Progress func(KeeperProgress)
+}
+
+// KeeperProgress reports the outcome of one keeper drop
+type KeeperProgress struct {
+	TeamID     string
+	PlayerID   string
+	PlayerName string
+	Done       int // Drops attempted so far, including this one
+	Total      int // Total drops in the plan
+	Response   *CreateClaimDropResponse
+	Err        error
+}
+
+// TeamKeeperPlan is the keeper outcome for one team
+type TeamKeeperPlan struct {
+	TeamID   string
+	TeamName string
+	Keep     []models.RosterPlayer
+	Drop     []models.RosterPlayer
+	Problems []string // Validation problems; a plan with problems is not executed
+}
+
+// KeeperPlan is the validated set of keeps and drops for the whole league
+type KeeperPlan struct {
+	Teams []TeamKeeperPlan
+}
+
+// Valid returns true if no team in the plan has validation problems
+func (p *KeeperPlan) Valid() bool {
+	for _, team := range p.Teams {
+		if len(team.Problems) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// TotalDrops returns the number of players the plan will release
+func (p *KeeperPlan) TotalDrops() int {
+	total := 0
+	for _, team := range p.Teams {
+		total += len(team.Drop)
+	}
+	return total
+}
+
+// ErrInvalidKeeperPlan is returned by ProcessKeepers when the selections fail validation
+var ErrInvalidKeeperPlan = errors.New("keeper selections failed validation")
+
+// BuildKeeperPlan validates keeper selections against current rosters and works out which
+// players each team releases
+//
+// Every team in teams gets a plan entry. A team missing from selections is reported as a
+// problem and gets no drops, so a forgotten team can't lose its whole roster; give it an
+// empty list to keep nobody. Selections for teams that are not in the league, players not
+// on the team's roster, ambiguous names and teams over maxKeepers are also problems.
+func BuildKeeperPlan(selections KeeperSelections, rosters map[string]*models.TeamRoster, teams []models.FantasyTeam, maxKeepers int) *KeeperPlan {
+	plan := &KeeperPlan{}
+
+	known := make(map[string]bool, len(teams))
+	for _, team := range teams {
+		known[team.ID] = true
+	}
+	for teamID := range selections {
+		if !known[teamID] {
+			plan.Teams = append(plan.Teams, TeamKeeperPlan{
+				TeamID:   teamID,
+				Problems: []string{fmt.Sprintf("team %s is not in this league", teamID)},
+			})
+		}
+	}
+
+	for _, team := range teams {
+		teamPlan := TeamKeeperPlan{TeamID: team.ID, TeamName: team.Name}
+		roster := rosters[team.ID]
+		if roster == nil {
+			teamPlan.Problems = append(teamPlan.Problems, "no roster available")
+			plan.Teams = append(plan.Teams, teamPlan)
+			continue
+		}
+
+		teamSelections, ok := selections[team.ID]
+		if !ok {
+			teamPlan.Problems = append(teamPlan.Problems, "no keeper selections")
+			plan.Teams = append(plan.Teams, teamPlan)
+			continue
+		}
+
+		players := roster.AllPlayers()
+		keep := make(map[string]bool)
+		for _, selection := range teamSelections {
+			playerID, problem := matchRosterPlayer(players, selection)
+			if problem != "" {
+				teamPlan.Problems = append(teamPlan.Problems, problem)
+				continue
+			}
+			keep[playerID] = true
+		}
+
+		for _, player := range players {
+			if keep[player.PlayerID] {
+				teamPlan.Keep = append(teamPlan.Keep, player)
+			} else {
+				teamPlan.Drop = append(teamPlan.Drop, player)
+			}
+		}
+
+		if maxKeepers > 0 && len(teamPlan.Keep) > maxKeepers {
+			teamPlan.Problems = append(teamPlan.Problems,
+				fmt.Sprintf("%d keepers selected, maximum is %d", len(teamPlan.Keep), maxKeepers))
+		}
+
+		plan.Teams = append(plan.Teams, teamPlan)
+	}
+
+	return plan
+}
+
+// matchRosterPlayer finds a roster player by ID or case-insensitive full name
+func matchRosterPlayer(players []models.RosterPlayer, selection string) (string, string) {
+	for _, p := range players {
+		if p.PlayerID == selection {
+			return p.PlayerID, ""
+		}
+	}
+
+	var matches []string
+	for _, p := range players {
+		if strings.EqualFold(p.Name, selection) {
+			matches = append(matches, p.PlayerID)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Sprintf("%q is not on the roster", selection)
+	case 1:
+		return matches[0], ""
+	default:
+		return "", fmt.Sprintf("%q matches %d players on the roster; use the player ID", selection, len(matches))
+	}
+}
+
+// ProcessKeepers validates keeper selections and releases every unkept player
+// (commissioner mode only)
+//
+// Rosters are fetched for the requested period and checked with BuildKeeperPlan. If any
+// team has problems nothing is dropped and the plan is returned with ErrInvalidKeeperPlan.
+// With DryRun set the validated plan is returned without making changes.
+//
+// Drops are executed one at a time; the first failed request stops processing and the error
+// is returned alongside the plan.
+func (c *Client) ProcessKeepers(selections KeeperSelections, opts KeeperOptions) (*KeeperPlan, error) {
+	period := opts.Period
+	if period == 0 {
+		currentPeriod, err := c.GetCurrentPeriod()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current period: %w", err)
+		}
+		period = currentPeriod
+	}
+
+	rosters, teams, err := c.GetAllTeamRosters(fmt.Sprintf("%d", period))
+	if err != nil {
+		return nil, err
+	}
+
+	plan := BuildKeeperPlan(selections, rosters, teams, opts.MaxKeepers)
+	if !plan.Valid() {
+		return plan, ErrInvalidKeeperPlan
+	}
+	if opts.DryRun {
+		return plan, nil
+	}
+
+	total := plan.TotalDrops()
+	done := 0
+	for _, team := range plan.Teams {
+		for _, player := range team.Drop {
+			done++
+			response, err := c.CommissionerDrop(period, team.TeamID, player.PlayerID, opts.DropToWaivers)
+			if err == nil && !response.IsSuccess() {
+				err = fmt.Errorf("drop was not executed: %s", response.GenericMessage)
+			}
+
+			if opts.Progress != nil {
+				opts.Progress(KeeperProgress{
+					TeamID:     team.TeamID,
+					PlayerID:   player.PlayerID,
+					PlayerName: player.Name,
+					Done:       done,
+					Total:      total,
+					Response:   response,
+					Err:        err,
+				})
+			}
+
+			if err != nil {
+				return plan, fmt.Errorf("failed to drop %s from team %s: %w", player.Name, team.TeamID, err)
+			}
+		}
+	}
+
+	return plan, nil
+}
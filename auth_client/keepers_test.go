@@ -0,0 +1,53 @@
+package auth_client
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestBuildKeeperPlan(t *testing.T) {
+	rosters := map[string]*models.TeamRoster{
+		"t1": {ActiveRoster: []models.RosterPlayer{{PlayerID: "a", Name: "Alpha"}, {PlayerID: "b", Name: "Beta"}}},
+		"t2": {ActiveRoster: []models.RosterPlayer{{PlayerID: "c", Name: "Gamma"}}},
+		"t3": {ActiveRoster: []models.RosterPlayer{{PlayerID: "d", Name: "Delta"}}},
+	}
+	teams := []models.FantasyTeam{{ID: "t1"}, {ID: "t2"}, {ID: "t3"}}
+	selections := KeeperSelections{"t1": {"alpha"}, "t3": {}}
+
+	plan := BuildKeeperPlan(selections, rosters, teams, 0)
+	byTeam := make(map[string]TeamKeeperPlan)
+	for _, team := range plan.Teams {
+		byTeam[team.TeamID] = team
+	}
+
+	if team := byTeam["t1"]; len(team.Keep) != 1 || len(team.Drop) != 1 || team.Drop[0].PlayerID != "b" {
+		t.Errorf("t1 plan = %+v, want keep a, drop b", team)
+	}
+	// A team with no entry is a problem, not a full-roster release
+	if team := byTeam["t2"]; len(team.Problems) != 1 || len(team.Drop) != 0 {
+		t.Errorf("t2 plan = %+v, want a problem and no drops", team)
+	}
+	// An empty entry keeps nobody
+	if team := byTeam["t3"]; len(team.Problems) != 0 || len(team.Drop) != 1 {
+		t.Errorf("t3 plan = %+v, want delta dropped", team)
+	}
+	if plan.Valid() {
+		t.Error("plan with a team missing selections is valid")
+	}
+}
+
+func TestLoadKeeperSelectionsCSV(t *testing.T) {
+	csv := "team_id,player\nt1,Alpha\nt1,b\nt3,\n"
+	selections, err := LoadKeeperSelectionsCSV(strings.NewReader(csv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(selections["t1"]) != 2 {
+		t.Errorf("t1 selections = %v, want 2", selections["t1"])
+	}
+	if keep, ok := selections["t3"]; !ok || len(keep) != 0 {
+		t.Errorf("t3 selections = %v, %v, want present and empty", keep, ok)
+	}
+}
@@ -0,0 +1,61 @@
+package auth_client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// GetLeagueOwners fetches every team's owners from the league setup page (commissioner only)
+//
+// Returns one entry per owner in league order, with co-owners of a team next to each other.
+func (c *Client) GetLeagueOwners() ([]models.LeagueOwner, error) {
+	if err := c.checkCommissioner(); err != nil {
+		return nil, err
+	}
+	html, err := c.fetchLeagueSetupHTML()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch league setup page: %w", err)
+	}
+	teams, err := parseTeams(html)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse teams: %w", err)
+	}
+	return LeagueOwnersFromSetup(teams), nil
+}
+
+// LeagueOwnersFromSetup lists the owners of the teams parsed from the league setup page
+//
+// The setup page stands in "NULL_N" user IDs for owners who haven't joined, so its form
+// fields stay unique; those are reported as an empty UserID.
+func LeagueOwnersFromSetup(teams []models.LeagueSetupTeam) []models.LeagueOwner {
+	var owners []models.LeagueOwner
+	for _, team := range teams {
+		for _, owner := range team.Owners {
+			userID := owner.UserID
+			if userID == "NULL" || strings.HasPrefix(userID, "NULL_") {
+				userID = ""
+			}
+			owners = append(owners, models.LeagueOwner{
+				TeamID:        team.TeamID,
+				TeamName:      team.Name,
+				TeamShortName: team.ShortName,
+				UserID:        userID,
+				Email:         owner.Email,
+				Commissioner:  owner.IsCommissioner,
+				Joined:        owner.JoinedLeague,
+			})
+		}
+	}
+	return owners
+}
+
+// OwnersByTeam groups owners by team ID
+func OwnersByTeam(owners []models.LeagueOwner) map[string][]models.LeagueOwner {
+	byTeam := make(map[string][]models.LeagueOwner)
+	for _, owner := range owners {
+		byTeam[owner.TeamID] = append(byTeam[owner.TeamID], owner)
+	}
+	return byTeam
+}
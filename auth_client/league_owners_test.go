@@ -0,0 +1,28 @@
+package auth_client
+
+import "testing"
+
+func TestLeagueOwnersFromSetup(t *testing.T) {
+	html := `
+addTeam('Aces', 'ACE', 'ace@example.com', 't1', 'u1', true, true, 0);
+addTeam('Aces', 'ACE', 'co@example.com', 't1', 'u2', false, true, 0);
+addTeam('Bats', 'BAT', 'invite@example.com', 't2', 'NULL', false, false, 0);`
+	teams, err := parseTeams(html)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	owners := LeagueOwnersFromSetup(teams)
+	if len(owners) != 3 {
+		t.Fatalf("expected 3 owners, got %d", len(owners))
+	}
+	if o := owners[0]; o.TeamName != "Aces" || o.UserID != "u1" || o.Email != "ace@example.com" || !o.Commissioner || !o.Joined {
+		t.Errorf("unexpected commissioner: %+v", o)
+	}
+	if o := owners[2]; o.UserID != "" || o.Joined {
+		t.Errorf("expected an invited owner without a user ID: %+v", o)
+	}
+	if byTeam := OwnersByTeam(owners); len(byTeam["t1"]) != 2 || len(byTeam["t2"]) != 1 {
+		t.Errorf("unexpected grouping: %v", byTeam)
+	}
+}
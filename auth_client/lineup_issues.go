@@ -0,0 +1,137 @@
+package auth_client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// Lineup issue kinds
+const (
+	LineupIssueInactiveStarter = "INACTIVE_STARTER" // Injured, suspended, inactive, or minor league player in an active slot
+	LineupIssueIdleStarter     = "IDLE_STARTER"     // Active player without a game while a reserve who fits the slot has one
+	LineupIssueIllegalRoster   = "ILLEGAL_ROSTER"   // Fantrax marks the roster illegal for the period
+)
+
+// LineupIssue is a problem with a team's active lineup
+type LineupIssue struct {
+	Kind        string `json:"kind"` // One of the LineupIssue constants
+	PlayerID    string `json:"playerId,omitempty"`
+	PlayerName  string `json:"playerName,omitempty"`
+	Slot        string `json:"slot,omitempty"`        // The active slot's short name (e.g. "SS")
+	Replacement string `json:"replacement,omitempty"` // A reserve player who could take the slot
+	Message     string `json:"message"`
+}
+
+// TeamLineupIssues lists one team's lineup issues
+type TeamLineupIssues struct {
+	TeamID   string        `json:"teamId"`
+	TeamName string        `json:"teamName"`
+	Issues   []LineupIssue `json:"issues"`
+}
+
+// GetOutstandingLineupIssues checks every team's lineup for a period
+//
+// Parameters:
+//   - period: The roster period as a string (empty string = current period)
+//
+// Returns one entry per team in league order, including teams with no issues.
+func (c *Client) GetOutstandingLineupIssues(period string) ([]TeamLineupIssues, error) {
+	rosters, teams, err := c.GetAllTeamRosters(period)
+	if err != nil {
+		return nil, err
+	}
+
+	report := make([]TeamLineupIssues, 0, len(teams))
+	for _, team := range teams {
+		report = append(report, TeamLineupIssues{
+			TeamID:   team.ID,
+			TeamName: team.Name,
+			Issues:   findLineupIssues(rosters[team.ID], c.Terminology),
+		})
+	}
+	return report, nil
+}
+
+// FindLineupIssues checks a roster's active players for ones who won't play
+//
+// Active players on the Injured List, out indefinitely, suspended, inactive, or in the minors
+// are always reported. An active player with no upcoming game is reported only when a reserve
+// player who fits the slot has a game, since otherwise there is nothing to change. Each
+// reserve player is suggested as a replacement at most once, preferring ones with a game.
+func FindLineupIssues(roster *models.TeamRoster) []LineupIssue {
+	return findLineupIssues(roster, nil)
+}
+
+// findLineupIssues is FindLineupIssues with slots named in a league's terminology
+func findLineupIssues(roster *models.TeamRoster, terminology *Terminology) []LineupIssue {
+	if roster == nil {
+		return nil
+	}
+
+	var issues []LineupIssue
+	if roster.IllegalRoster {
+		message := roster.IllegalRosterTitle
+		if len(roster.IllegalRosterMessages) > 0 {
+			message = strings.Join(roster.IllegalRosterMessages, " ")
+		}
+		if message == "" {
+			message = "The roster is illegal for this period"
+		}
+		issues = append(issues, LineupIssue{Kind: LineupIssueIllegalRoster, Message: message})
+	}
+
+	used := make(map[string]bool)
+	for _, player := range roster.ActiveRoster {
+		slot := positionName(player.RosterPosition)
+		issue := LineupIssue{PlayerID: player.PlayerID, PlayerName: player.Name, Slot: terminology.Position(slot)}
+		switch {
+		case isInactiveStarter(player):
+			issue.Kind = LineupIssueInactiveStarter
+			issue.Message = fmt.Sprintf("%s is active at %s but can't play", player.Name, issue.Slot)
+			if sub := lineupReplacement(roster, slot, used, false); sub != nil {
+				issue.Replacement = sub.Name
+			}
+		case player.NextGame == nil:
+			sub := lineupReplacement(roster, slot, used, true)
+			if sub == nil {
+				continue
+			}
+			issue.Kind = LineupIssueIdleStarter
+			issue.Message = fmt.Sprintf("%s has no game at %s", player.Name, issue.Slot)
+			issue.Replacement = sub.Name
+		default:
+			continue
+		}
+		if issue.Replacement != "" {
+			issue.Message += fmt.Sprintf("; %s could start instead", issue.Replacement)
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// lineupReplacement finds an unused reserve player who fits a slot and can play, preferring
+// one with a game. needGame excludes players without one.
+func lineupReplacement(roster *models.TeamRoster, slot string, used map[string]bool, needGame bool) *models.RosterPlayer {
+	var fallback *models.RosterPlayer
+	for i := range roster.ReserveRoster {
+		player := &roster.ReserveRoster[i]
+		if used[player.PlayerID] || isInactiveStarter(*player) || !slotAccepts(slot, rosterPlayerPositions(*player)) {
+			continue
+		}
+		if player.NextGame != nil {
+			used[player.PlayerID] = true
+			return player
+		}
+		if fallback == nil {
+			fallback = player
+		}
+	}
+	if needGame || fallback == nil {
+		return nil
+	}
+	used[fallback.PlayerID] = true
+	return fallback
+}
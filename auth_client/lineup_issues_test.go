@@ -0,0 +1,43 @@
+package auth_client
+
+import (
+	"testing"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestFindLineupIssues(t *testing.T) {
+	game := &models.GameInfo{Opponent: "@PIT"}
+	roster := &models.TeamRoster{
+		ActiveRoster: []models.RosterPlayer{
+			{PlayerID: "1", Name: "Hurt", RosterPosition: PosSS, PosShortNames: "SS",
+				Icons: []models.PlayerIcon{{TypeID: models.IconInjuredList}}, NextGame: game},
+			{PlayerID: "2", Name: "Off Day", RosterPosition: PosC, PosShortNames: "C"},
+			{PlayerID: "3", Name: "Idle", RosterPosition: Pos1B, PosShortNames: "1B"},
+			{PlayerID: "4", Name: "Playing", RosterPosition: PosOF, PosShortNames: "OF", NextGame: game},
+		},
+		ReserveRoster: []models.RosterPlayer{
+			{PlayerID: "5", Name: "Backup SS", PosShortNames: "2B,SS", NextGame: game},
+			{PlayerID: "6", Name: "Backup 1B", PosShortNames: "1B", NextGame: game},
+			{PlayerID: "7", Name: "Also Hurt", PosShortNames: "C",
+				Icons: []models.PlayerIcon{{TypeID: models.IconOutIndefinitely}}, NextGame: game},
+		},
+	}
+
+	issues := FindLineupIssues(roster)
+	if len(issues) != 2 {
+		t.Fatalf("got %d issues, want 2: %+v", len(issues), issues)
+	}
+	if issues[0].Kind != LineupIssueInactiveStarter || issues[0].PlayerName != "Hurt" || issues[0].Replacement != "Backup SS" {
+		t.Errorf("issues[0] = %+v", issues[0])
+	}
+	if issues[1].Kind != LineupIssueIdleStarter || issues[1].PlayerName != "Idle" || issues[1].Replacement != "Backup 1B" {
+		t.Errorf("issues[1] = %+v", issues[1])
+	}
+
+	roster.IllegalRoster = true
+	roster.IllegalRosterMessages = []string{"Too many active players."}
+	if issues := FindLineupIssues(roster); issues[0].Kind != LineupIssueIllegalRoster || issues[0].Message != "Too many active players." {
+		t.Errorf("illegal roster issue = %+v", issues[0])
+	}
+}
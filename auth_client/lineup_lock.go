@@ -0,0 +1,94 @@
+package auth_client
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pmurley/go-fantrax/auth_client/parser"
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// LockPolicy controls what RosterEditor does when asked to move a player whose game has
+// already started
+type LockPolicy int
+
+const (
+	// LockPolicyWarn queues the move and records a warning (see RosterEditor.Warnings)
+	LockPolicyWarn LockPolicy = iota
+	// LockPolicyRefuse rejects the move with an error
+	LockPolicyRefuse
+	// LockPolicyIgnore queues the move without checking
+	LockPolicyIgnore
+)
+
+// userLocation returns the authenticated user's timezone, which Fantrax uses for game times
+func (c *Client) userLocation() *time.Location {
+	return c.UserInfo.Location()
+}
+
+// resolveGameTimes fills in NextGame.StartTime for every player on the roster
+func (c *Client) resolveGameTimes(roster *models.TeamRoster, now time.Time) {
+	now = now.In(c.userLocation())
+	for _, players := range [][]models.RosterPlayer{roster.ActiveRoster, roster.ReserveRoster, roster.InjuredReserve, roster.MinorsRoster} {
+		for i := range players {
+			if game := players[i].NextGame; game != nil {
+				if start, ok := parser.ParseGameTime(game.DateTime, now); ok {
+					game.StartTime = start
+				}
+				if start, ok := parser.ParseGameTime(game.SecondDateTime, now); ok {
+					game.SecondStartTime = start
+				}
+			}
+		}
+	}
+}
+
+// SetLockPolicy sets how moves for players whose games have started are handled
+// (default LockPolicyWarn)
+func (e *RosterEditor) SetLockPolicy(policy LockPolicy) {
+	e.lockPolicy = policy
+}
+
+// IsLocked returns true if the player's game has started
+func (e *RosterEditor) IsLocked(playerID string) bool {
+	start, ok := e.gameStarts[playerID]
+	return ok && !e.now().Before(start)
+}
+
+// EarliestLockTime returns the earliest upcoming game start on the roster, after which
+// that player can no longer be moved. Returns false if no upcoming game time is known.
+func (e *RosterEditor) EarliestLockTime() (time.Time, bool) {
+	now := e.now()
+	var upcoming []time.Time
+	for _, start := range e.gameStarts {
+		if start.After(now) {
+			upcoming = append(upcoming, start)
+		}
+	}
+	if len(upcoming) == 0 {
+		return time.Time{}, false
+	}
+	sort.Slice(upcoming, func(i, j int) bool { return upcoming[i].Before(upcoming[j]) })
+	return upcoming[0], true
+}
+
+// Warnings returns the warnings recorded for queued moves (e.g. moves of locked players)
+func (e *RosterEditor) Warnings() []string {
+	return e.warnings
+}
+
+// checkLock applies the lock policy to a move of the given player
+func (e *RosterEditor) checkLock(playerID string) error {
+	if e.lockPolicy == LockPolicyIgnore || !e.IsLocked(playerID) {
+		return nil
+	}
+
+	msg := fmt.Sprintf("%s is locked: their game started at %s",
+		e.playerNames[playerID], e.gameStarts[playerID].Format("Mon 3:04PM MST"))
+	if e.lockPolicy == LockPolicyRefuse {
+		return fmt.Errorf("cannot move player: %s", msg)
+	}
+	e.warnings = append(e.warnings, msg)
+	return nil
+}
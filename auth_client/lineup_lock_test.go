@@ -0,0 +1,87 @@
+package auth_client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pmurley/go-fantrax/auth_client/parser"
+)
+
+func TestRosterEditorLockPolicy(t *testing.T) {
+	// Thursday 6:00PM
+	now := time.Date(2025, time.June, 12, 18, 0, 0, 0, time.UTC)
+
+	started, ok := parser.ParseGameTime("Thu 5:40PM", now)
+	if !ok || !started.Equal(time.Date(2025, time.June, 12, 17, 40, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected start time %v (ok=%v)", started, ok)
+	}
+	upcoming, _ := parser.ParseGameTime("Sat 1:05PM", now)
+
+	editor := &RosterEditor{
+		fieldMap:    map[string]RosterPosition{"a": {StID: StatusActive}, "b": {StID: StatusActive}},
+		playerNames: map[string]string{"a": "Player A", "b": "Player B"},
+		gameStarts:  map[string]time.Time{"a": started, "b": upcoming},
+		now:         func() time.Time { return now },
+	}
+
+	if err := editor.MoveToReserve("a"); err != nil || len(editor.Warnings()) != 1 {
+		t.Fatalf("expected a warning for locked player, got err=%v warnings=%v", err, editor.Warnings())
+	}
+
+	editor.SetLockPolicy(LockPolicyRefuse)
+	if err := editor.MoveToReserve("a"); err == nil {
+		t.Errorf("expected locked move to be refused")
+	}
+	if err := editor.MoveToReserve("b"); err != nil {
+		t.Errorf("unexpected error for unlocked player: %v", err)
+	}
+
+	if lock, ok := editor.EarliestLockTime(); !ok || !lock.Equal(upcoming) {
+		t.Errorf("expected earliest lock %v, got %v", upcoming, lock)
+	}
+}
+
+func TestParseGameContent(t *testing.T) {
+	away := parser.ParseGameContent("@PIT<br/>Thu 5:40PM")
+	if away.Home || away.OpponentShortName != "PIT" || away.Opponent != "@PIT" || away.DateTime != "Thu 5:40PM" || away.DoubleHeader {
+		t.Errorf("unexpected road game: %+v", away)
+	}
+
+	home := parser.ParseGameContent("TEX<br/>Fri 9:40PM")
+	if !home.Home || home.OpponentShortName != "TEX" {
+		t.Errorf("unexpected home game: %+v", home)
+	}
+
+	dh := parser.ParseGameContent("@NYY<br/>Sat 1:05PM, 7:05PM")
+	if !dh.DoubleHeader || dh.DateTime != "Sat 1:05PM" || dh.SecondDateTime != "Sat 7:05PM" {
+		t.Errorf("unexpected double-header: %+v", dh)
+	}
+	dh = parser.ParseGameContent("BOS<br/>Sat 1:05PM<br/>Sat 7:05PM")
+	if !dh.DoubleHeader || dh.DateTime != "Sat 1:05PM" || dh.SecondDateTime != "Sat 7:05PM" {
+		t.Errorf("unexpected double-header on separate lines: %+v", dh)
+	}
+}
+
+func TestParsePitcherPopOver(t *testing.T) {
+	// Hand-written in the label-then-value shape; replace with a captured popover once one is saved
+	info := parser.ParsePitcherPopOver("<b>RHP</b><br/><b>W-L</b> 5-3 <b>ERA</b> 3.45 <b>WHIP</b> 1.12 <b>SO</b> 78")
+	s := info.Season
+	if info.Throws != "R" {
+		t.Errorf("throws: expected R, got %q", info.Throws)
+	}
+	if s.Wins == nil || s.Losses == nil || *s.Wins != 5 || *s.Losses != 3 {
+		t.Errorf("record: expected 5-3, got %v-%v", s.Wins, s.Losses)
+	}
+	if s.ERA == nil || *s.ERA != 3.45 || s.WHIP == nil || *s.WHIP != 1.12 {
+		t.Errorf("expected ERA 3.45 and WHIP 1.12, got %v and %v", s.ERA, s.WHIP)
+	}
+	if s.Strikeouts == nil || *s.Strikeouts != 78 {
+		t.Errorf("strikeouts: expected 78, got %v", s.Strikeouts)
+	}
+
+	// A missing stat stays nil instead of taking its neighbour's value
+	info = parser.ParsePitcherPopOver("<b>LHP</b> <b>ERA</b> 3.45 <b>K</b> 78")
+	if info.Throws != "L" || info.Season.WHIP != nil || info.Season.Wins != nil || *info.Season.ERA != 3.45 || *info.Season.Strikeouts != 78 {
+		t.Errorf("unexpected partial line: %+v", info.Season)
+	}
+}
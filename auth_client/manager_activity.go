@@ -0,0 +1,188 @@
+package auth_client
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pmurley/go-fantrax/models"
+	"github.com/pmurley/go-fantrax/redact"
+	log "github.com/sirupsen/logrus"
+)
+
+// TeamActivity counts a team's roster moves over a season
+type TeamActivity struct {
+	TeamID        string           `json:"teamId"`
+	TeamName      string           `json:"teamName"`
+	Transactions  int              `json:"transactions"` // Claims + drops + trades
+	Claims        int              `json:"claims"`
+	Drops         int              `json:"drops"`
+	Trades        int              `json:"trades"` // Distinct trades the team was part of
+	LineupChanges int              `json:"lineupChanges"`
+	Periods       []PeriodActivity `json:"periods,omitempty"` // Periods with claims or drops, in order
+}
+
+// PeriodActivity is a team's adds and drops in one scoring period
+type PeriodActivity struct {
+	Period int `json:"period"`
+	Adds   int `json:"adds"`
+	Drops  int `json:"drops"`
+}
+
+// ActivityReport is the league's roster churn by team, with the season's most active managers
+type ActivityReport struct {
+	Teams             []TeamActivity `json:"teams"` // Most transactions first
+	MostActive        string         `json:"mostActive,omitempty"`
+	MostTrades        string         `json:"mostTrades,omitempty"`
+	MostLineupChanges string         `json:"mostLineupChanges,omitempty"`
+}
+
+// GetManagerActivity fetches the season's claims, drops, trades, and lineup changes and counts
+// them by team
+//
+// Lineup changes come from the LINEUP_CHANGE history view. If that view can't be read the
+// report is still built from the other transactions, with no lineup changes counted.
+func (c *Client) GetManagerActivity() (*ActivityReport, error) {
+	txs, err := c.GetAllTransactionsIncludingTrades()
+	if err != nil {
+		return nil, err
+	}
+
+	lineups, err := c.getLineupChanges()
+	if err != nil {
+		log.Warn("lineup changes not counted: ", redact.Error(err))
+	}
+	return ComputeManagerActivity(append(txs, lineups...)), nil
+}
+
+// getLineupChanges fetches every page of the lineup change history
+func (c *Client) getLineupChanges() ([]models.Transaction, error) {
+	var all []models.Transaction
+	for pageNumber := 1; ; pageNumber++ {
+		lineups, pagination, err := c.GetTransactionsPaginated(TransactionViewLineup, pageNumber, 250, true)
+		if err != nil {
+			return all, err
+		}
+		all = append(all, lineups...)
+		c.reportPageProgress(ProgressLineups, pageNumber, len(lineups), len(all), pagination)
+		if pagination == nil || pageNumber >= pagination.TotalNumPages {
+			return all, nil
+		}
+	}
+}
+
+// ComputeManagerActivity counts roster moves by team
+//
+// Claims and drops count for the transacting team, and each trade counts once for every team
+// in it however many players moved. Rows whose type mentions LINEUP count as lineup changes.
+// Ties for the most active managers go to the team listed first.
+//
+// Parameters:
+//   - txs: Transactions from the history, e.g. GetAllTransactionsIncludingTrades
+func ComputeManagerActivity(txs []models.Transaction) *ActivityReport {
+	byTeam := make(map[string]*TeamActivity)
+	periods := make(map[string]map[int]*PeriodActivity)
+	team := func(id, name string) *TeamActivity {
+		activity, ok := byTeam[id]
+		if !ok {
+			activity = &TeamActivity{TeamID: id, TeamName: name}
+			byTeam[id] = activity
+			periods[id] = make(map[int]*PeriodActivity)
+		}
+		if activity.TeamName == "" {
+			activity.TeamName = name
+		}
+		return activity
+	}
+	period := func(teamID string, n int) *PeriodActivity {
+		p, ok := periods[teamID][n]
+		if !ok {
+			p = &PeriodActivity{Period: n}
+			periods[teamID][n] = p
+		}
+		return p
+	}
+
+	trades := make(map[string]bool) // Team ID + trade group already counted
+	for _, tx := range txs {
+		switch {
+		case tx.Type == "CLAIM" && tx.TeamID != "":
+			team(tx.TeamID, tx.TeamName).Claims++
+			period(tx.TeamID, tx.Period).Adds++
+		case tx.Type == "DROP" && tx.TeamID != "":
+			team(tx.TeamID, tx.TeamName).Drops++
+			period(tx.TeamID, tx.Period).Drops++
+		case tx.Type == "TRADE":
+			group := tx.TradeGroupID
+			if group == "" {
+				group = tx.ID
+			}
+			for _, side := range [][2]string{{tx.FromTeamID, tx.FromTeamName}, {tx.ToTeamID, tx.ToTeamName}} {
+				if side[0] == "" || trades[side[0]+"/"+group] {
+					continue
+				}
+				trades[side[0]+"/"+group] = true
+				team(side[0], side[1]).Trades++
+			}
+		case isLineupChange(tx) && tx.TeamID != "":
+			team(tx.TeamID, tx.TeamName).LineupChanges++
+		}
+	}
+
+	report := &ActivityReport{Teams: make([]TeamActivity, 0, len(byTeam))}
+	for id, activity := range byTeam {
+		activity.Transactions = activity.Claims + activity.Drops + activity.Trades
+		for _, p := range periods[id] {
+			activity.Periods = append(activity.Periods, *p)
+		}
+		sort.Slice(activity.Periods, func(i, j int) bool { return activity.Periods[i].Period < activity.Periods[j].Period })
+		report.Teams = append(report.Teams, *activity)
+	}
+	sort.Slice(report.Teams, func(i, j int) bool {
+		if report.Teams[i].Transactions != report.Teams[j].Transactions {
+			return report.Teams[i].Transactions > report.Teams[j].Transactions
+		}
+		return report.Teams[i].TeamName < report.Teams[j].TeamName
+	})
+
+	report.MostActive = mostActive(report.Teams, func(t TeamActivity) int { return t.Transactions })
+	report.MostTrades = mostActive(report.Teams, func(t TeamActivity) int { return t.Trades })
+	report.MostLineupChanges = mostActive(report.Teams, func(t TeamActivity) int { return t.LineupChanges })
+	return report
+}
+
+// Team returns a team's activity, or nil if it made no moves
+func (r *ActivityReport) Team(teamID string) *TeamActivity {
+	for i := range r.Teams {
+		if r.Teams[i].TeamID == teamID {
+			return &r.Teams[i]
+		}
+	}
+	return nil
+}
+
+// String formats the report as a table, most active first
+func (r *ActivityReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-30s %6s %6s %6s %6s %7s\n", "Team", "Moves", "Adds", "Drops", "Trades", "Lineup")
+	for _, t := range r.Teams {
+		fmt.Fprintf(&b, "%-30s %6d %6d %6d %6d %7d\n", t.TeamName, t.Transactions, t.Claims, t.Drops, t.Trades, t.LineupChanges)
+	}
+	return b.String()
+}
+
+// isLineupChange reports whether a history row is a lineup change rather than a roster move
+func isLineupChange(tx models.Transaction) bool {
+	return strings.Contains(strings.ToUpper(tx.Type), "LINEUP")
+}
+
+// mostActive returns the team with the highest count, or "" if every count is zero
+func mostActive(teams []TeamActivity, count func(TeamActivity) int) string {
+	best, leader := 0, ""
+	for _, t := range teams {
+		if n := count(t); n > best {
+			best, leader = n, t.TeamID
+		}
+	}
+	return leader
+}
@@ -0,0 +1,37 @@
+package auth_client
+
+import (
+	"testing"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestComputeManagerActivity(t *testing.T) {
+	txs := []models.Transaction{
+		{Type: "CLAIM", TeamID: "a", TeamName: "Aces", Period: 1},
+		{Type: "DROP", TeamID: "a", TeamName: "Aces", Period: 1},
+		{Type: "CLAIM", TeamID: "a", TeamName: "Aces", Period: 3},
+		{Type: "CLAIM", TeamID: "b", TeamName: "Bats", Period: 2},
+		// One trade with two players moving
+		{Type: "TRADE", TradeGroupID: "t1", FromTeamID: "a", ToTeamID: "b", ToTeamName: "Bats"},
+		{Type: "TRADE", TradeGroupID: "t1", FromTeamID: "b", ToTeamID: "a", ToTeamName: "Aces"},
+		{Type: "TRADE", TradeGroupID: "t2", FromTeamID: "c", FromTeamName: "Cats", ToTeamID: "b"},
+		{Type: "LINEUP_CHANGE", TeamID: "c", TeamName: "Cats"},
+		{Type: "LINEUP_CHANGE", TeamID: "c", TeamName: "Cats"},
+	}
+
+	report := ComputeManagerActivity(txs)
+	if report.MostActive != "a" || report.MostTrades != "b" || report.MostLineupChanges != "c" {
+		t.Errorf("unexpected leaders: %+v", report)
+	}
+	aces := report.Team("a")
+	if aces == nil || aces.Transactions != 4 || aces.Trades != 1 || len(aces.Periods) != 2 {
+		t.Fatalf("unexpected activity for a: %+v", aces)
+	}
+	if p := aces.Periods[0]; p.Period != 1 || p.Adds != 1 || p.Drops != 1 {
+		t.Errorf("unexpected period 1: %+v", p)
+	}
+	if bats := report.Team("b"); bats.Trades != 2 || bats.Transactions != 3 {
+		t.Errorf("unexpected activity for b: %+v", bats)
+	}
+}
@@ -0,0 +1,164 @@
+package auth_client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// FormFieldDiff is a form field whose values differ between two POST bodies
+type FormFieldDiff struct {
+	Field     string   `json:"field"`
+	Generated []string `json:"generated"`
+	KnownGood []string `json:"knownGood"`
+}
+
+// MatchupFormDiff compares a generated matchup POST body with a known-good one
+type MatchupFormDiff struct {
+	Form    url.Values      `json:"form"`    // The generated POST body
+	Missing []string        `json:"missing"` // Fields only in the known-good body
+	Extra   []string        `json:"extra"`   // Fields only in the generated body
+	Changed []FormFieldDiff `json:"changed"` // Fields in both with different values
+}
+
+// Empty reports whether the generated body matches the known-good body
+func (d *MatchupFormDiff) Empty() bool {
+	return len(d.Missing) == 0 && len(d.Extra) == 0 && len(d.Changed) == 0
+}
+
+// String formats the differences one field per line
+func (d *MatchupFormDiff) String() string {
+	if d.Empty() {
+		return "no differences"
+	}
+	var b strings.Builder
+	for _, field := range d.Missing {
+		fmt.Fprintf(&b, "- %s\n", field)
+	}
+	for _, field := range d.Extra {
+		fmt.Fprintf(&b, "+ %s\n", field)
+	}
+	for _, change := range d.Changed {
+		fmt.Fprintf(&b, "~ %s: %s -> %s\n", change.Field, formatFormValues(change.KnownGood), formatFormValues(change.Generated))
+	}
+	return b.String()
+}
+
+// GenerateMatchupFormDiff builds the POST body SetPeriodMatchups would send for new
+// matchups in a period, without sending it, and compares it with a body captured from a
+// matchup save in the browser. A field Fantrax adds or renames shows up as missing or extra
+// before schedule automation relies on the generated body.
+//
+// Repeated fields such as matchups and ~~divisions are compared regardless of order. The
+// setup is not modified.
+//
+// Parameters:
+//   - setup: The league setup from GetLeagueSetupMatchups
+//   - period: The period being edited
+//   - newPairs: The period's new matchups
+//   - knownGood: A captured POST body (e.g. from ParseCapturedForm)
+func GenerateMatchupFormDiff(setup *models.LeagueSetupMatchups, period int, newPairs []models.MatchupPair, knownGood url.Values) (*MatchupFormDiff, error) {
+	if _, exists := setup.Matchups[period]; !exists {
+		return nil, fmt.Errorf("period %d not found in setup matchups", period)
+	}
+	if len(newPairs) == 0 {
+		return nil, fmt.Errorf("matchups must not be empty")
+	}
+
+	edited := *setup
+	edited.Matchups = make(map[int][]models.MatchupPair, len(setup.Matchups))
+	for p, pairs := range setup.Matchups {
+		edited.Matchups[p] = pairs
+	}
+	edited.Matchups[period] = newPairs
+
+	form := BuildFormBody(&edited, period)
+	diff := DiffForms(form, knownGood)
+	diff.Form = form
+	return diff, nil
+}
+
+// DiffForms compares a generated form body with a known-good one
+func DiffForms(generated, knownGood url.Values) *MatchupFormDiff {
+	diff := &MatchupFormDiff{Form: generated}
+	for field, want := range knownGood {
+		got, ok := generated[field]
+		if !ok {
+			diff.Missing = append(diff.Missing, field)
+			continue
+		}
+		if !sameFormValues(got, want) {
+			diff.Changed = append(diff.Changed, FormFieldDiff{Field: field, Generated: got, KnownGood: want})
+		}
+	}
+	for field := range generated {
+		if _, ok := knownGood[field]; !ok {
+			diff.Extra = append(diff.Extra, field)
+		}
+	}
+	sort.Strings(diff.Missing)
+	sort.Strings(diff.Extra)
+	sort.Slice(diff.Changed, func(i, j int) bool { return diff.Changed[i].Field < diff.Changed[j].Field })
+	return diff
+}
+
+// ParseCapturedForm reads a captured POST body, either URL-encoded as sent or as a JSON
+// object of field names to a value or list of values (as browser dev tools export it)
+func ParseCapturedForm(data []byte) (url.Values, error) {
+	trimmed := strings.TrimSpace(string(data))
+	if !strings.HasPrefix(trimmed, "{") {
+		form, err := url.ParseQuery(trimmed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse URL-encoded form: %w", err)
+		}
+		return form, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal form JSON: %w", err)
+	}
+	form := url.Values{}
+	for field, raw := range fields {
+		var value string
+		if err := json.Unmarshal(raw, &value); err == nil {
+			form.Set(field, value)
+			continue
+		}
+		var values []string
+		if err := json.Unmarshal(raw, &values); err != nil {
+			return nil, fmt.Errorf("field %s is neither a string nor a list of strings", field)
+		}
+		form[field] = values
+	}
+	return form, nil
+}
+
+// sameFormValues compares two value lists regardless of order
+func sameFormValues(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sa := append([]string(nil), a...)
+	sb := append([]string(nil), b...)
+	sort.Strings(sa)
+	sort.Strings(sb)
+	for i := range sa {
+		if sa[i] != sb[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// formatFormValues shows a field's value, or the count for a repeated field
+func formatFormValues(values []string) string {
+	if len(values) == 1 {
+		return fmt.Sprintf("%q", values[0])
+	}
+	return fmt.Sprintf("[%d values]", len(values))
+}
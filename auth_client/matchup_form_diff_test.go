@@ -0,0 +1,69 @@
+package auth_client
+
+import (
+	"os"
+	"testing"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestGenerateMatchupFormDiff(t *testing.T) {
+	page, err := os.ReadFile("testdata/fixtures/createLeague/handbuilt_h2h_two_divisions.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	setup, err := ParseLeagueSetupMatchups(string(page))
+	if err != nil {
+		t.Fatal(err)
+	}
+	newPairs := []models.MatchupPair{{AwayTeamID: "t3", HomeTeamID: "t2"}, {AwayTeamID: "t1", HomeTeamID: "t4"}}
+
+	diff, err := GenerateMatchupFormDiff(setup, 1, newPairs, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := setup.Matchups[1][0].AwayTeamID; got != "t1" {
+		t.Errorf("expected the setup to be left unchanged, got away team %s", got)
+	}
+
+	// A capture as the browser would send it: the same body, through a JSON export, with a
+	// field Fantrax added and one renamed
+	knownGood, err := ParseCapturedForm([]byte(`{
+		"matchups": ["3|t4_t1|t2_t3", "1|t3_t2|t1_t4", "2|t1_t3|t2_t4"],
+		"tabId": "Matchups",
+		"newField": "1"
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for field, values := range diff.Form {
+		if field != "matchups" && field != "tabId" && field != "sportId" {
+			knownGood[field] = values
+		}
+	}
+	knownGood.Set("sportIdentifier", "MLB")
+
+	diff, err = GenerateMatchupFormDiff(setup, 1, newPairs, knownGood)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diff.Changed) != 0 {
+		t.Errorf("expected matching values regardless of order, got %+v", diff.Changed)
+	}
+	if len(diff.Missing) != 2 || diff.Missing[0] != "newField" || diff.Missing[1] != "sportIdentifier" {
+		t.Errorf("unexpected missing fields: %v", diff.Missing)
+	}
+	if len(diff.Extra) != 1 || diff.Extra[0] != "sportId" {
+		t.Errorf("unexpected extra fields: %v", diff.Extra)
+	}
+
+	knownGood.Set("tabId", "Teams")
+	if diff := DiffForms(diff.Form, knownGood); len(diff.Changed) != 1 || diff.Changed[0].Field != "tabId" {
+		t.Errorf("expected tabId to differ: %+v", diff.Changed)
+	}
+
+	raw, err := ParseCapturedForm([]byte("tabId=Matchups&matchups=1%7Ct1_t2&matchups=2%7Ct2_t1"))
+	if err != nil || len(raw["matchups"]) != 2 {
+		t.Errorf("unexpected URL-encoded capture: %v %v", raw, err)
+	}
+}
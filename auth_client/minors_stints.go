@@ -0,0 +1,115 @@
+package auth_client
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// Minors option alert rules
+const (
+	MinorsAlertStints      = "minors stints"       // More stints than MaxStints
+	MinorsAlertStintLength = "minors stint length" // A stint longer than MaxStintPeriods
+	MinorsAlertTotal       = "minors total"        // More minors periods than MaxTotalPeriods
+)
+
+// MinorsOptionLimits are the thresholds a dynasty league sets on minors use, in the spirit of
+// option years. Zero fields are not checked.
+type MinorsOptionLimits struct {
+	MaxStints       int // Separate trips to the minors
+	MaxStintPeriods int // Scoring periods in a single stint
+	MaxTotalPeriods int // Scoring periods in the minors altogether
+}
+
+// MinorsOptionAlert is one threshold a player has crossed
+type MinorsOptionAlert struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// MinorsOptionUsage is one player's minors stints on a team
+type MinorsOptionUsage struct {
+	TeamID       string               `json:"teamId"`
+	TeamName     string               `json:"teamName"`
+	ScorerID     string               `json:"scorerId"`
+	Name         string               `json:"name"`
+	Stints       []models.MinorsStint `json:"stints"`
+	TotalPeriods int                  `json:"totalPeriods"`
+	Alerts       []MinorsOptionAlert  `json:"alerts,omitempty"`
+}
+
+// TrackMinorsOptions finds the minors stints of every player in a team's service time and
+// checks them against limits
+//
+// Parameters:
+//   - teamID: The team the service time belongs to
+//   - teamName: The team's name, for messages
+//   - serviceTime: The team's service time (e.g. from GetTeamServiceTime)
+//   - limits: Thresholds to alert on
+//
+// Returns players with at least one stint, ordered by name.
+func TrackMinorsOptions(teamID, teamName string, serviceTime models.TeamServiceTimeResult, limits MinorsOptionLimits) []MinorsOptionUsage {
+	var usage []MinorsOptionUsage
+	for _, player := range serviceTime {
+		stints := player.MinorsStints()
+		if len(stints) == 0 {
+			continue
+		}
+		u := MinorsOptionUsage{
+			TeamID:   teamID,
+			TeamName: teamName,
+			ScorerID: player.ScorerID,
+			Name:     player.Name,
+			Stints:   stints,
+		}
+		longest := 0
+		for _, s := range stints {
+			u.TotalPeriods += s.Periods
+			if s.Periods > longest {
+				longest = s.Periods
+			}
+		}
+
+		alert := func(rule, format string, args ...interface{}) {
+			u.Alerts = append(u.Alerts, MinorsOptionAlert{Rule: rule, Message: fmt.Sprintf(format, args...)})
+		}
+		if limits.MaxStints > 0 && len(stints) > limits.MaxStints {
+			alert(MinorsAlertStints, "%s has %d minors stints, maximum is %d", player.Name, len(stints), limits.MaxStints)
+		}
+		if limits.MaxStintPeriods > 0 && longest > limits.MaxStintPeriods {
+			alert(MinorsAlertStintLength, "%s spent %d straight periods in the minors, maximum is %d", player.Name, longest, limits.MaxStintPeriods)
+		}
+		if limits.MaxTotalPeriods > 0 && u.TotalPeriods > limits.MaxTotalPeriods {
+			alert(MinorsAlertTotal, "%s spent %d periods in the minors, maximum is %d", player.Name, u.TotalPeriods, limits.MaxTotalPeriods)
+		}
+		usage = append(usage, u)
+	}
+
+	sort.Slice(usage, func(i, j int) bool { return usage[i].Name < usage[j].Name })
+	return usage
+}
+
+// GetMinorsOptionUsage fetches service time for every team and reports each player's minors
+// stints, with alerts for players over limits
+//
+// Parameters:
+//   - limits: Thresholds to alert on
+//
+// Returns players in league team order, then by name.
+func (c *Client) GetMinorsOptionUsage(limits MinorsOptionLimits) ([]MinorsOptionUsage, error) {
+	myRoster, err := c.GetTeamRosterInfo("", "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league team list: %w", err)
+	}
+
+	var usage []MinorsOptionUsage
+	for _, team := range myRoster.LeagueTeams {
+		serviceTime, err := c.GetTeamServiceTime(team.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get service time for team %s: %w", team.ID, err)
+		}
+		usage = append(usage, TrackMinorsOptions(team.ID, team.Name, serviceTime, limits)...)
+	}
+	return usage, nil
+}
@@ -0,0 +1,41 @@
+package auth_client
+
+import (
+	"testing"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestTrackMinorsOptions(t *testing.T) {
+	history := func(statuses ...models.RosterStatus) map[int]models.PeriodStatus {
+		h := make(map[int]models.PeriodStatus)
+		for i, s := range statuses {
+			h[i+1] = models.PeriodStatus{Status: s}
+		}
+		return h
+	}
+	const (
+		a = models.StatusActive
+		m = models.StatusMinors
+	)
+	serviceTime := models.TeamServiceTimeResult{
+		"p1": {ScorerID: "p1", Name: "Prospect", PeriodHistory: history(m, m, a, m, a, m, m, m)},
+		"p2": {ScorerID: "p2", Name: "Veteran", PeriodHistory: history(a, a, a)},
+	}
+
+	usage := TrackMinorsOptions("t1", "Team", serviceTime, MinorsOptionLimits{MaxStints: 2, MaxStintPeriods: 3})
+	if len(usage) != 1 {
+		t.Fatalf("expected 1 player with stints, got %d", len(usage))
+	}
+	p := usage[0]
+	if len(p.Stints) != 3 || p.TotalPeriods != 6 {
+		t.Fatalf("unexpected stints: %+v", p.Stints)
+	}
+	last := p.Stints[2]
+	if last.StartPeriod != 6 || last.EndPeriod != 8 || last.Periods != 3 || !last.Ongoing || p.Stints[0].Ongoing {
+		t.Errorf("unexpected last stint: %+v", last)
+	}
+	if len(p.Alerts) != 1 || p.Alerts[0].Rule != MinorsAlertStints {
+		t.Errorf("expected a single stint-count alert, got %+v", p.Alerts)
+	}
+}
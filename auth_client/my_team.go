@@ -0,0 +1,82 @@
+package auth_client
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// ErrNoTeam is returned by GetMyTeamID when the logged-in user has no team in the league
+// (e.g. a commissioner who doesn't play)
+var ErrNoTeam = errors.New("logged-in user has no team in this league")
+
+// myTeam holds the logged-in user's team ID once it is known. A nil *myTeam records nothing.
+type myTeam struct {
+	mu sync.Mutex
+	id string
+}
+
+func (t *myTeam) get() string {
+	if t == nil {
+		return ""
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.id
+}
+
+func (t *myTeam) set(id string) {
+	if t == nil || id == "" {
+		return
+	}
+	t.mu.Lock()
+	t.id = id
+	t.mu.Unlock()
+}
+
+// recordMyTeamID remembers the user's team from the myTeamIds of a roster response
+func (c *Client) recordMyTeamID(resp *models.TeamRosterResponse) {
+	if len(resp.Responses) > 0 && len(resp.Responses[0].Data.MyTeamIDs) > 0 {
+		c.myTeam.set(resp.Responses[0].Data.MyTeamIDs[0])
+	}
+}
+
+// GetMyTeamID returns the logged-in user's team ID in the league. It is resolved once, from
+// the league list of the login response or the myTeamIds of any roster fetched so far, and
+// otherwise by fetching the user's roster.
+//
+// Methods that take a team ID use this team when passed an empty string.
+func (c *Client) GetMyTeamID() (string, error) {
+	if id := c.myTeam.get(); id != "" {
+		return id, nil
+	}
+	if c.UserInfo != nil {
+		for _, league := range c.UserInfo.Leagues {
+			if league.LeagueID == c.LeagueID && league.TeamID != "" {
+				c.myTeam.set(league.TeamID)
+				return league.TeamID, nil
+			}
+		}
+	}
+
+	resp, err := c.GetMyTeamRosterInfoRaw("")
+	if err != nil {
+		return "", fmt.Errorf("failed to get your roster: %w", err)
+	}
+	if len(resp.Responses) == 0 || len(resp.Responses[0].Data.MyTeamIDs) == 0 {
+		return "", ErrNoTeam
+	}
+	id := resp.Responses[0].Data.MyTeamIDs[0]
+	c.myTeam.set(id)
+	return id, nil
+}
+
+// teamOrMine returns teamID, or the user's team ID if teamID is empty
+func (c *Client) teamOrMine(teamID string) (string, error) {
+	if teamID != "" {
+		return teamID, nil
+	}
+	return c.GetMyTeamID()
+}
@@ -0,0 +1,75 @@
+package auth_client
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// rosterTransport answers every request with a roster response listing myTeamIds
+type rosterTransport struct {
+	myTeamIDs string
+	requests  int
+}
+
+func (t *rosterTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests++
+	body := `{"responses":[{"data":{"myTeamIds":` + t.myTeamIDs + `,"tables":[]}}]}`
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+}
+
+func TestGetMyTeamID(t *testing.T) {
+	t.Run("from login data", func(t *testing.T) {
+		transport := &rosterTransport{myTeamIDs: `["other"]`}
+		c := &Client{
+			Client:   http.Client{Transport: transport},
+			Cookies:  "test",
+			LeagueID: "league1",
+			UserInfo: &models.UserInfo{Leagues: []models.UserLeague{
+				{LeagueID: "league0", TeamID: "wrong"},
+				{LeagueID: "league1", TeamID: "mine"},
+			}},
+			myTeam: &myTeam{},
+		}
+		id, err := c.GetMyTeamID()
+		if err != nil || id != "mine" {
+			t.Errorf("GetMyTeamID() = %q, %v, want mine", id, err)
+		}
+		if transport.requests != 0 {
+			t.Errorf("sent %d requests, want none", transport.requests)
+		}
+	})
+
+	t.Run("from roster once", func(t *testing.T) {
+		transport := &rosterTransport{myTeamIDs: `["mine"]`}
+		c := &Client{Client: http.Client{Transport: transport}, Cookies: "test", myTeam: &myTeam{}}
+		for i := 0; i < 2; i++ {
+			id, err := c.GetMyTeamID()
+			if err != nil || id != "mine" {
+				t.Errorf("GetMyTeamID() = %q, %v, want mine", id, err)
+			}
+		}
+		if transport.requests != 1 {
+			t.Errorf("sent %d requests, want 1", transport.requests)
+		}
+
+		// Empty team IDs default to the user's team
+		if id, _ := c.teamOrMine(""); id != "mine" {
+			t.Errorf("teamOrMine(\"\") = %q, want mine", id)
+		}
+		if id, _ := c.teamOrMine("other"); id != "other" {
+			t.Errorf("teamOrMine(\"other\") = %q, want other", id)
+		}
+	})
+
+	t.Run("no team", func(t *testing.T) {
+		c := &Client{Client: http.Client{Transport: &rosterTransport{myTeamIDs: `[]`}}, Cookies: "test", myTeam: &myTeam{}}
+		if _, err := c.GetMyTeamID(); !errors.Is(err, ErrNoTeam) {
+			t.Errorf("GetMyTeamID() error = %v, want ErrNoTeam", err)
+		}
+	})
+}
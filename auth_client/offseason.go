@@ -0,0 +1,442 @@
+package auth_client
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// OffseasonAction is the kind of offseason contract decision in a request
+type OffseasonAction string
+
+const (
+	// OffseasonArbitration awards an expiring player a one-season contract at the team's bid
+	OffseasonArbitration OffseasonAction = "arbitration"
+	// OffseasonExtension signs an expiring player to a new multi-season contract
+	OffseasonExtension OffseasonAction = "extension"
+	// OffseasonRelease declines to re-sign an expiring player, dropping them from the roster
+	OffseasonRelease OffseasonAction = "release"
+)
+
+// OffseasonRequest is one team's decision on an expiring contract, read from a CSV or JSON
+// submission. The player may be given by ID or by name.
+type OffseasonRequest struct {
+	Line      int             `json:"-"` // Line number in the source CSV, for error messages
+	TeamID    string          `json:"teamId"`
+	Player    string          `json:"player"`
+	Action    OffseasonAction `json:"action"`
+	Salary    float64         `json:"salary,omitempty"`    // Arbitration bid or extension salary
+	Years     int             `json:"years,omitempty"`     // Extensions only
+	Escalator float64         `json:"escalator,omitempty"` // Extensions only
+}
+
+// LoadOffseasonRequestsCSV reads offseason requests from CSV
+//
+// The first row must be a header with "team_id", "player" and "action" columns. The optional
+// columns are "salary", "years" and "escalator". Other columns are ignored.
+func LoadOffseasonRequestsCSV(r io.Reader) ([]OffseasonRequest, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read offseason CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("offseason CSV is empty")
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case "teamid", "team":
+			name = "team_id"
+		case "player_id", "playerid":
+			name = "player"
+		case "bid":
+			name = "salary"
+		}
+		columns[name] = i
+	}
+	for _, required := range []string{"team_id", "player", "action"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("offseason CSV header must contain team_id, player and action columns")
+		}
+	}
+
+	var requests []OffseasonRequest
+	for i, record := range records[1:] {
+		field := func(name string) string {
+			col, ok := columns[name]
+			if !ok || col >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[col])
+		}
+		if field("player") == "" && field("action") == "" {
+			continue
+		}
+
+		request := OffseasonRequest{
+			Line:   i + 2,
+			TeamID: field("team_id"),
+			Player: field("player"),
+			Action: OffseasonAction(strings.ToLower(field("action"))),
+		}
+		if salary := field("salary"); salary != "" {
+			if request.Salary, err = strconv.ParseFloat(strings.TrimPrefix(salary, "$"), 64); err != nil {
+				return nil, fmt.Errorf("offseason CSV line %d: invalid salary %q", request.Line, salary)
+			}
+		}
+		if years := field("years"); years != "" {
+			if request.Years, err = strconv.Atoi(years); err != nil {
+				return nil, fmt.Errorf("offseason CSV line %d: invalid years %q", request.Line, years)
+			}
+		}
+		if escalator := field("escalator"); escalator != "" {
+			if request.Escalator, err = strconv.ParseFloat(escalator, 64); err != nil {
+				return nil, fmt.Errorf("offseason CSV line %d: invalid escalator %q", request.Line, escalator)
+			}
+		}
+		requests = append(requests, request)
+	}
+	return requests, nil
+}
+
+// LoadOffseasonRequestsJSON reads offseason requests from a JSON list
+func LoadOffseasonRequestsJSON(r io.Reader) ([]OffseasonRequest, error) {
+	var requests []OffseasonRequest
+	if err := json.NewDecoder(r).Decode(&requests); err != nil {
+		return nil, fmt.Errorf("failed to decode offseason JSON: %w", err)
+	}
+	return requests, nil
+}
+
+// OffseasonRules are the league's arbitration and extension rules
+type OffseasonRules struct {
+	Season              int     // The season the new contracts start in
+	ArbitrationMinRaise float64 // Minimum arbitration raise over the expiring salary (0.1 = 10%)
+	MaxExtensionYears   int     // 0 = no limit
+	ReleaseUnsigned     bool    // Release expiring players with no request
+}
+
+// OffseasonStep is one resolved offseason decision
+type OffseasonStep struct {
+	Line       int // Source line, or 0 for releases added by ReleaseUnsigned
+	TeamID     string
+	PlayerID   string
+	PlayerName string
+	Action     OffseasonAction
+	Previous   Contract // The expiring contract
+	Contract   Contract // The new contract (arbitration and extensions)
+
+	Problems []string // Validation problems; a plan with problems is not executed
+}
+
+// OffseasonPlan is the validated set of offseason decisions
+type OffseasonPlan struct {
+	Season int
+	Steps  []OffseasonStep
+}
+
+// Valid returns true if no step in the plan has validation problems
+func (p *OffseasonPlan) Valid() bool {
+	for _, step := range p.Steps {
+		if len(step.Problems) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Problems returns every validation problem in the plan, prefixed with its source line
+func (p *OffseasonPlan) Problems() []string {
+	var problems []string
+	for _, step := range p.Steps {
+		for _, problem := range step.Problems {
+			if step.Line > 0 {
+				problem = fmt.Sprintf("line %d: %s", step.Line, problem)
+			}
+			problems = append(problems, problem)
+		}
+	}
+	return problems
+}
+
+// ErrInvalidOffseasonPlan is returned by ProcessOffseason when the requests fail validation
+var ErrInvalidOffseasonPlan = errors.New("offseason requests failed validation")
+
+// BuildOffseasonPlan validates offseason requests against the contract ledger and current
+// rosters
+//
+// Each request must name a player on the team's roster whose contract in the ledger expires
+// before rules.Season, and a player may only have one request. Arbitration bids must meet the
+// minimum raise, and extensions need a salary and a length within the limit. Teams whose
+// payroll for the new season ends up over the cap get a problem on each of their signings.
+//
+// Parameters:
+//   - requests: The teams' decisions, e.g. from LoadOffseasonRequestsCSV
+//   - ledger: The league's contracts; it is not modified
+//   - rosters: Current rosters keyed by team ID
+//   - rules: The league's offseason rules
+func BuildOffseasonPlan(requests []OffseasonRequest, ledger *ContractLedger, rosters map[string]*models.TeamRoster, rules OffseasonRules) *OffseasonPlan {
+	ledger.mu.Lock()
+	contracts := ledger.copyContracts()
+	ledger.mu.Unlock()
+
+	plan := &OffseasonPlan{Season: rules.Season}
+	seen := make(map[string]int)
+	for _, request := range requests {
+		step := OffseasonStep{Line: request.Line, TeamID: request.TeamID, Action: request.Action}
+		problem := func(format string, args ...interface{}) {
+			step.Problems = append(step.Problems, fmt.Sprintf(format, args...))
+		}
+
+		roster := rosters[request.TeamID]
+		if roster == nil {
+			problem("team %s is not in this league", request.TeamID)
+			plan.Steps = append(plan.Steps, step)
+			continue
+		}
+		playerID, matchProblem := matchRosterPlayer(roster.AllPlayers(), request.Player)
+		if matchProblem != "" {
+			problem("%s", matchProblem)
+			plan.Steps = append(plan.Steps, step)
+			continue
+		}
+		step.PlayerID = playerID
+		for _, player := range roster.AllPlayers() {
+			if player.PlayerID == playerID {
+				step.PlayerName = player.Name
+			}
+		}
+
+		if line, ok := seen[playerID]; ok {
+			problem("%s already has a request on line %d", step.PlayerName, line)
+		}
+		seen[playerID] = request.Line
+
+		previous, ok := contracts[playerID]
+		switch {
+		case !ok || previous.TeamID != request.TeamID:
+			problem("%s has no contract with team %s", step.PlayerName, request.TeamID)
+		case previous.EndYear() >= rules.Season:
+			problem("%s is under contract through %d", step.PlayerName, previous.EndYear())
+		}
+		step.Previous = previous
+
+		switch request.Action {
+		case OffseasonArbitration:
+			last, _ := previous.SalaryIn(previous.EndYear())
+			if minimum := last * (1 + rules.ArbitrationMinRaise); request.Salary < minimum {
+				problem("arbitration bid %.2f is below the minimum of %.2f", request.Salary, minimum)
+			}
+			step.Contract = Contract{PlayerID: playerID, PlayerName: step.PlayerName, TeamID: request.TeamID,
+				Salary: request.Salary, StartYear: rules.Season, Years: 1}
+		case OffseasonExtension:
+			if request.Salary <= 0 || request.Years <= 0 {
+				problem("extension needs a salary and years")
+			}
+			if rules.MaxExtensionYears > 0 && request.Years > rules.MaxExtensionYears {
+				problem("extension of %d years is over the maximum of %d", request.Years, rules.MaxExtensionYears)
+			}
+			step.Contract = Contract{PlayerID: playerID, PlayerName: step.PlayerName, TeamID: request.TeamID,
+				Salary: request.Salary, StartYear: rules.Season, Years: request.Years, Escalator: request.Escalator}
+		case OffseasonRelease:
+		default:
+			problem("unknown action %q", request.Action)
+		}
+		plan.Steps = append(plan.Steps, step)
+	}
+
+	if rules.ReleaseUnsigned {
+		plan.Steps = append(plan.Steps, unsignedReleases(contracts, rosters, seen, rules.Season)...)
+	}
+
+	// Check each team's payroll for the new season with the plan applied
+	after := make(map[string]Contract, len(contracts))
+	for playerID, contract := range contracts {
+		after[playerID] = contract
+	}
+	for _, step := range plan.Steps {
+		if step.Action == OffseasonArbitration || step.Action == OffseasonExtension {
+			after[step.PlayerID] = step.Contract
+		}
+	}
+	salaryCap := ledger.CapFor(rules.Season)
+	for i, step := range plan.Steps {
+		if step.Action != OffseasonArbitration && step.Action != OffseasonExtension {
+			continue
+		}
+		if payroll := ledger.payroll(after, step.TeamID, rules.Season); salaryCap > 0 && payroll > salaryCap {
+			plan.Steps[i].Problems = append(plan.Steps[i].Problems,
+				fmt.Sprintf("team %s %d payroll would be %.2f, over the cap of %.2f", step.TeamID, rules.Season, payroll, salaryCap))
+		}
+	}
+	return plan
+}
+
+// unsignedReleases returns release steps for rostered players whose contracts expire before
+// season and who have no request
+func unsignedReleases(contracts map[string]Contract, rosters map[string]*models.TeamRoster, requested map[string]int, season int) []OffseasonStep {
+	var steps []OffseasonStep
+	for teamID, roster := range rosters {
+		for _, player := range roster.AllPlayers() {
+			contract, ok := contracts[player.PlayerID]
+			if !ok || contract.TeamID != teamID || contract.EndYear() >= season {
+				continue
+			}
+			if _, ok := requested[player.PlayerID]; ok {
+				continue
+			}
+			steps = append(steps, OffseasonStep{TeamID: teamID, PlayerID: player.PlayerID, PlayerName: player.Name,
+				Action: OffseasonRelease, Previous: contract})
+		}
+	}
+	sort.Slice(steps, func(i, j int) bool {
+		if steps[i].TeamID != steps[j].TeamID {
+			return steps[i].TeamID < steps[j].TeamID
+		}
+		return steps[i].PlayerName < steps[j].PlayerName
+	})
+	return steps
+}
+
+// OffseasonAuditEntry records one applied offseason decision
+type OffseasonAuditEntry struct {
+	Time       time.Time       `json:"time"`
+	Season     int             `json:"season"`
+	TeamID     string          `json:"teamId"`
+	PlayerID   string          `json:"playerId"`
+	PlayerName string          `json:"playerName"`
+	Action     OffseasonAction `json:"action"`
+	Previous   Contract        `json:"previous"`
+	Contract   *Contract       `json:"contract,omitempty"`
+	Error      string          `json:"error,omitempty"`
+}
+
+// OffseasonOptions configures ProcessOffseason
+type OffseasonOptions struct {
+	Rules         OffseasonRules
+	Period        int    // Roster period for releases (0 = current period)
+	DryRun        bool   // Validate and build the plan without changing anything
+	DropToWaivers bool   // Drop released players to waivers instead of free agency
+	AuditPath     string // File each applied step is appended to, as a JSON line (empty = no audit trail)
+
+	// Progress, if set, is called after each step is applied
+	Progress func(OffseasonAuditEntry)
+}
+
+// ProcessOffseason validates the teams' arbitration bids, extensions and releases against the
+// client's contract ledger (see WithContractLedger), then applies them (commissioner mode only)
+//
+// New contracts are written to the ledger; released players are dropped with CommissionerDrop.
+// If any request has problems nothing is changed and the plan is returned with
+// ErrInvalidOffseasonPlan. With DryRun set the validated plan is returned without making
+// changes. Steps run in order and the first failure stops processing; every applied or
+// failed step is appended to the audit trail.
+func (c *Client) ProcessOffseason(requests []OffseasonRequest, opts OffseasonOptions) (*OffseasonPlan, error) {
+	if c.Contracts == nil {
+		return nil, fmt.Errorf("offseason processing needs a contract ledger (WithContractLedger)")
+	}
+	if err := c.checkCommissioner(); err != nil {
+		return nil, err
+	}
+	period := opts.Period
+	if period == 0 {
+		currentPeriod, err := c.GetCurrentPeriod()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current period: %w", err)
+		}
+		period = currentPeriod
+	}
+	if opts.Rules.Season == 0 {
+		opts.Rules.Season = c.Contracts.season()
+	}
+
+	rosters, _, err := c.GetAllTeamRosters(fmt.Sprintf("%d", period))
+	if err != nil {
+		return nil, err
+	}
+
+	plan := BuildOffseasonPlan(requests, c.Contracts, rosters, opts.Rules)
+	if !plan.Valid() {
+		return plan, ErrInvalidOffseasonPlan
+	}
+	if opts.DryRun {
+		return plan, nil
+	}
+
+	for _, step := range plan.Steps {
+		entry := OffseasonAuditEntry{
+			Time:       time.Now(),
+			Season:     plan.Season,
+			TeamID:     step.TeamID,
+			PlayerID:   step.PlayerID,
+			PlayerName: step.PlayerName,
+			Action:     step.Action,
+			Previous:   step.Previous,
+		}
+		err := c.applyOffseasonStep(period, step, opts.DropToWaivers)
+		if err != nil {
+			entry.Error = err.Error()
+		} else if step.Action != OffseasonRelease {
+			contract := step.Contract
+			entry.Contract = &contract
+		}
+		if auditErr := appendOffseasonAudit(opts.AuditPath, entry); auditErr != nil && err == nil {
+			err = auditErr
+		}
+		if opts.Progress != nil {
+			opts.Progress(entry)
+		}
+		if err != nil {
+			return plan, fmt.Errorf("%s (%s): %w", step.PlayerName, step.Action, err)
+		}
+	}
+	return plan, nil
+}
+
+// applyOffseasonStep writes a new contract to the ledger or releases the player
+func (c *Client) applyOffseasonStep(period int, step OffseasonStep, toWaivers bool) error {
+	if step.Action != OffseasonRelease {
+		c.Contracts.SetContract(step.Contract)
+		c.saveContracts()
+		return nil
+	}
+	response, err := c.CommissionerDrop(period, step.TeamID, step.PlayerID, toWaivers)
+	if err != nil {
+		return fmt.Errorf("failed to drop %s: %w", step.PlayerName, err)
+	}
+	if !response.IsSuccess() {
+		return fmt.Errorf("drop of %s was not executed: %s", step.PlayerName, response.GenericMessage)
+	}
+	return nil
+}
+
+// appendOffseasonAudit appends an entry to the audit trail file as a JSON line
+func appendOffseasonAudit(path string, entry OffseasonAuditEntry) error {
+	if path == "" {
+		return nil
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal offseason audit entry: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open offseason audit trail: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write offseason audit trail: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package auth_client
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestBuildOffseasonPlan(t *testing.T) {
+	requests, err := LoadOffseasonRequestsCSV(strings.NewReader(
+		"team_id,player,action,salary,years\n" +
+			"a,Ace Pitcher,arbitration,12,\n" +
+			"a,p2,extension,30,3\n" +
+			"b,p3,arbitration,5,\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ledger := NewContractLedger(60)
+	ledger.SetContract(Contract{PlayerID: "p1", TeamID: "a", Salary: 10, StartYear: 2025, Years: 1})
+	ledger.SetContract(Contract{PlayerID: "p2", TeamID: "a", Salary: 20, StartYear: 2024, Years: 2})
+	ledger.SetContract(Contract{PlayerID: "p3", TeamID: "b", Salary: 5, StartYear: 2025, Years: 1})
+	ledger.SetContract(Contract{PlayerID: "p4", TeamID: "b", Salary: 8, StartYear: 2024, Years: 2})
+	rosters := map[string]*models.TeamRoster{
+		"a": {ActiveRoster: []models.RosterPlayer{{PlayerID: "p1", Name: "Ace Pitcher"}, {PlayerID: "p2", Name: "Second Base"}}},
+		"b": {ActiveRoster: []models.RosterPlayer{{PlayerID: "p3", Name: "Third Base"}, {PlayerID: "p4", Name: "Fourth Out"}}},
+	}
+	rules := OffseasonRules{Season: 2026, ArbitrationMinRaise: 0.1, ReleaseUnsigned: true}
+
+	plan := BuildOffseasonPlan(requests, ledger, rosters, rules)
+	problems := plan.Problems()
+	if len(problems) != 1 || !strings.HasPrefix(problems[0], "line 4: arbitration bid 5.00 is below") {
+		t.Fatalf("unexpected problems: %v", problems)
+	}
+	last := plan.Steps[len(plan.Steps)-1]
+	if last.Action != OffseasonRelease || last.PlayerID != "p4" {
+		t.Errorf("expected the unsigned p4 to be released, got %+v", last)
+	}
+	if plan.Steps[1].Contract.EndYear() != 2028 {
+		t.Errorf("expected the extension to run through 2028, got %+v", plan.Steps[1].Contract)
+	}
+
+	// Over the cap once both signings are counted
+	ledger.Cap = 40
+	requests[2].Salary = 6
+	plan = BuildOffseasonPlan(requests, ledger, rosters, rules)
+	if len(plan.Problems()) != 2 {
+		t.Errorf("expected cap problems on both of team a's signings, got %v", plan.Problems())
+	}
+}
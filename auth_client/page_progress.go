@@ -0,0 +1,50 @@
+package auth_client
+
+import "github.com/pmurley/go-fantrax/models"
+
+// Operations named in PageProgress
+const (
+	ProgressTransactions = "transactions"         // GetAllTransactions
+	ProgressTrades       = "trades"               // GetAllTrades
+	ProgressPending      = "pending transactions" // GetPendingTransactions
+	ProgressLineups      = "lineup changes"       // The lineup history read by GetManagerActivity
+	ProgressPlayerPool   = "player pool"          // GetPlayerPool and GetPlayerPoolStream
+)
+
+// PageProgress reports one page fetched by a multi-page operation
+type PageProgress struct {
+	Operation  string // One of the Progress constants
+	Page       int    // Pages fetched so far, counting this one
+	TotalPages int    // Pages Fantrax reports in all; 0 if unknown
+	Rows       int    // Rows parsed from this page
+	RowsSoFar  int    // Rows parsed by the operation so far
+
+	// TotalRows is the result count Fantrax reports, or 0 if unknown. Trade history counts
+	// each trade once but has a row per player moved, so RowsSoFar can pass it.
+	TotalRows int
+}
+
+// Done reports whether this was the operation's last page
+func (p PageProgress) Done() bool {
+	return p.TotalPages > 0 && p.Page >= p.TotalPages
+}
+
+// reportPageProgress passes a fetched page to OnPageProgress, if set
+//
+// Parameters:
+//   - operation: One of the Progress constants
+//   - page: The page number just fetched
+//   - rows: Rows parsed from the page
+//   - rowsSoFar: Rows parsed by the operation so far, including this page
+//   - pagination: The page's pagination info, or nil if there was none
+func (c *Client) reportPageProgress(operation string, page, rows, rowsSoFar int, pagination *models.PaginatedResultSet) {
+	if c.OnPageProgress == nil {
+		return
+	}
+	progress := PageProgress{Operation: operation, Page: page, Rows: rows, RowsSoFar: rowsSoFar}
+	if pagination != nil {
+		progress.TotalPages = pagination.TotalNumPages
+		progress.TotalRows = pagination.TotalNumResults
+	}
+	c.OnPageProgress(progress)
+}
@@ -6,11 +6,13 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pmurley/go-fantrax/models"
 )
 
-// ParseTeamRosterResponse parses the raw API response into a simplified TeamRoster
+// ParseTeamRosterResponse parses the raw API response into a simplified TeamRoster. Rows and
+// cells that cannot be read are skipped and listed in the roster's ParseWarnings.
 func ParseTeamRosterResponse(data []byte) (*models.TeamRoster, error) {
 	var response models.TeamRosterResponse
 	if err := json.Unmarshal(data, &response); err != nil {
@@ -44,8 +46,9 @@ func ParseTeamRosterResponse(data []byte) (*models.TeamRoster, error) {
 
 	// Parse all tables (position players and pitchers)
 	for _, table := range rosterData.Tables {
-		players := parseRosterTable(table)
+		players, warnings := parseRosterTable(table)
 		allPlayers = append(allPlayers, players...)
+		roster.ParseWarnings = append(roster.ParseWarnings, warnings...)
 	}
 
 	// Separate players by roster status based on statusId
@@ -59,6 +62,14 @@ func ParseTeamRosterResponse(data []byte) (*models.TeamRoster, error) {
 			roster.InjuredReserve = append(roster.InjuredReserve, player)
 		case "Minors":
 			roster.MinorsRoster = append(roster.MinorsRoster, player)
+		default:
+			roster.ParseWarnings = append(roster.ParseWarnings, models.ParseWarning{
+				Source:  "roster",
+				Row:     -1,
+				Field:   "statusId",
+				Value:   player.PlayerID,
+				Message: fmt.Sprintf("player %s has an unknown roster status and was not placed on the roster", player.Name),
+			})
 		}
 	}
 
@@ -101,12 +112,23 @@ func extractClaimBudget(miscData models.MiscData) float64 {
 	return 0
 }
 
-func parseRosterTable(table models.RosterTable) []models.RosterPlayer {
+func parseRosterTable(table models.RosterTable) ([]models.RosterPlayer, []models.ParseWarning) {
 	var players []models.RosterPlayer
+	var warnings []models.ParseWarning
 
-	for _, row := range table.Rows {
+	for i, row := range table.Rows {
 		// Skip empty roster slots
-		if row.IsEmptyRosterSlot || row.Scorer.Name == "" {
+		if row.IsEmptyRosterSlot {
+			continue
+		}
+		if row.Scorer.Name == "" {
+			warnings = append(warnings, models.ParseWarning{
+				Source:  "roster",
+				Row:     i,
+				Field:   "scorer",
+				Value:   row.Scorer.ScorerID,
+				Message: "row is not an empty slot but has no player name; skipped",
+			})
 			continue
 		}
 
@@ -135,19 +157,55 @@ func parseRosterTable(table models.RosterTable) []models.RosterPlayer {
 			age, err := strconv.Atoi(row.Cells[0].Content)
 			if err == nil {
 				player.Age = age
+			} else if row.Cells[0].Content != "" && row.Cells[0].Content != "-" {
+				warnings = append(warnings, models.ParseWarning{
+					Source:  "roster",
+					Row:     i,
+					Field:   "age",
+					Value:   row.Cells[0].Content,
+					Message: "age is not a number",
+				})
 			}
 		}
 
 		// Parse stats from cells
 		player.Stats = parsePlayerStats(row.Cells, table.Header.Cells, row.Scorer.PosIDs)
+		for _, w := range statWarnings(row.Cells, table.Header.Cells) {
+			w.Row = i
+			warnings = append(warnings, w)
+		}
 
 		// Extract next game info
-		player.NextGame = extractNextGame(row.Cells)
+		player.NextGame = ExtractNextGame(row.Cells)
 
 		players = append(players, player)
 	}
 
-	return players
+	return players, warnings
+}
+
+// statWarnings reports stat cells whose content is not a number. Only columns with Fantrax
+// stat keys ("10#0200#-1") and fantasy points per game are checked, since other columns hold text.
+func statWarnings(cells []models.Cell, columns []models.Column) []models.ParseWarning {
+	var warnings []models.ParseWarning
+	for i, cell := range cells {
+		if i >= len(columns) || cell.Content == "" || cell.Content == "-" {
+			continue
+		}
+		key := columns[i].Key
+		if key != "fptsPerGame" && !strings.Contains(key, "#") {
+			continue
+		}
+		if _, err := strconv.ParseFloat(cell.Content, 64); err != nil {
+			warnings = append(warnings, models.ParseWarning{
+				Source:  "roster",
+				Field:   key,
+				Value:   cell.Content,
+				Message: "stat value is not a number",
+			})
+		}
+	}
+	return warnings
 }
 
 func parsePlayerStats(cells []models.Cell, columns []models.Column, positionIDs []string) *models.PlayerStats {
@@ -328,30 +386,20 @@ func parsePitchingStatByKey(key, value string, stats *models.PitchingStats) {
 	}
 }
 
-func extractNextGame(cells []models.Cell) *models.GameInfo {
+// ExtractNextGame parses the next-game cell of a roster row. StartTime is left zero; resolve
+// it with ParseGameTime once the user's timezone is known.
+func ExtractNextGame(cells []models.Cell) *models.GameInfo {
 	// Usually the second cell contains game info
 	if len(cells) > 1 && cells[1].EventID != "" {
-		gameInfo := &models.GameInfo{
-			EventID: cells[1].EventID,
-		}
-
-		// Parse game content (e.g., "@PIT<br/>Thu 5:40PM")
-		content := cells[1].Content
-		parts := strings.Split(content, "<br/>")
-		if len(parts) > 0 {
-			gameInfo.Opponent = strings.TrimSpace(parts[0])
-		}
-		if len(parts) > 1 {
-			gameInfo.DateTime = strings.TrimSpace(parts[1])
-		}
+		gameInfo := ParseGameContent(cells[1].Content)
+		gameInfo.EventID = cells[1].EventID
+		gameInfo.OpponentTeamID = cells[1].TeamID
 
 		// Extract pitcher info from popover
 		if cells[1].PopOver != nil {
-			gameInfo.ProbablePitcher = &models.PitcherInfo{
-				Name:      cells[1].PopOver.Scorer.Name,
-				ShortName: cells[1].PopOver.Scorer.ShortName,
-				Stats:     parsePitcherStats(cells[1].PopOver.Content),
-			}
+			gameInfo.ProbablePitcher = ParsePitcherPopOver(cells[1].PopOver.Content)
+			gameInfo.ProbablePitcher.Name = cells[1].PopOver.Scorer.Name
+			gameInfo.ProbablePitcher.ShortName = cells[1].PopOver.Scorer.ShortName
 		}
 
 		return gameInfo
@@ -360,22 +408,128 @@ func extractNextGame(cells []models.Cell) *models.GameInfo {
 	return nil
 }
 
-func parsePitcherStats(content string) map[string]string {
-	stats := make(map[string]string)
+var (
+	lineBreak = regexp.MustCompile(`(?i)<br\s*/?>`)
+	gameClock = regexp.MustCompile(`(?i)\d{1,2}:\d{2}\s*[AP]M`)
+)
 
-	// Remove HTML tags
-	re := regexp.MustCompile(`<[^>]+>`)
-	cleanContent := re.ReplaceAllString(content, "")
+// ParseGameContent parses the next-game cell of a roster row, such as "@PIT<br/>Thu 5:40PM"
+// (a road game) or "TEX<br/>Fri 9:40PM" (a home game). Double-headers list two times, e.g.
+// "@NYY<br/>Sat 1:05PM, 7:05PM" or with each time on its own line. Start times are left
+// zero; resolve them with ParseGameTime once the user's timezone is known.
+func ParseGameContent(content string) *models.GameInfo {
+	game := &models.GameInfo{}
+	lines := lineBreak.Split(content, -1)
+
+	game.Opponent = strings.TrimSpace(stripHTMLTags(lines[0]))
+	game.OpponentShortName = strings.TrimPrefix(game.Opponent, "@")
+	game.OpponentShortName = strings.TrimSpace(strings.TrimPrefix(game.OpponentShortName, "vs"))
+	game.Home = game.Opponent != "" && !strings.HasPrefix(game.Opponent, "@")
+	if len(lines) < 2 {
+		return game
+	}
+
+	when := strings.TrimSpace(stripHTMLTags(strings.Join(lines[1:], " ")))
+	clocks := gameClock.FindAllStringIndex(when, -1)
+	if len(clocks) < 2 {
+		game.DateTime = when
+		return game
+	}
 
-	// Split by spaces and parse key-value pairs
-	parts := strings.Fields(cleanContent)
-	for i := 0; i < len(parts)-1; i += 2 {
-		key := strings.TrimSpace(parts[i])
-		if i+1 < len(parts) {
-			value := strings.TrimSpace(parts[i+1])
-			stats[key] = value
+	// Double-header: both times share the day in front of the first
+	day := strings.TrimSpace(when[:clocks[0][0]])
+	withDay := func(clock string) string {
+		if day == "" {
+			return clock
 		}
+		return day + " " + clock
 	}
+	game.DateTime = withDay(when[clocks[0][0]:clocks[0][1]])
+	game.SecondDateTime = withDay(when[clocks[1][0]:clocks[1][1]])
+	game.DoubleHeader = true
+	return game
+}
 
-	return stats
+// weekdays maps the abbreviated day names used in game times to weekdays
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// ParseGameTime resolves a roster game time such as "Thu 5:40PM" (or "5:40PM" for today)
+// to the next matching moment on or after the start of now's day, in now's location.
+// Returns false if the text is not a game time (e.g. a score for a game in progress).
+func ParseGameTime(text string, now time.Time) (time.Time, bool) {
+	fields := strings.Fields(stripHTMLTags(text))
+	if len(fields) == 0 {
+		return time.Time{}, false
+	}
+
+	clock, err := time.Parse("3:04PM", strings.ToUpper(fields[len(fields)-1]))
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	dayOffset := 0
+	if len(fields) > 1 {
+		weekday, ok := weekdays[strings.ToLower(fields[0])]
+		if !ok {
+			return time.Time{}, false
+		}
+		dayOffset = (int(weekday) - int(now.Weekday()) + 7) % 7
+	}
+
+	day := now.AddDate(0, 0, dayOffset)
+	return time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, now.Location()), true
+}
+
+var (
+	popOverStat   = regexp.MustCompile(`(?i)\b(W-L|ERA|WHIP|SO|K)\s*:?\s*(\d+-\d+|\d*\.\d+|\d+)\b`)
+	popOverRecord = regexp.MustCompile(`^(\d+)-(\d+)$`)
+	popOverTag    = regexp.MustCompile(`<[^>]+>`)
+	popOverThrows = regexp.MustCompile(`\b([RL])HP\b`)
+)
+
+// ParsePitcherPopOver parses the probable pitcher popover of a next-game cell into the
+// pitcher's handedness and season line
+//
+// The popover is read as the old parser read it, as stat labels each followed by their value
+// (e.g. "<b>W-L</b> 5-3 <b>ERA</b> 3.45"), with handedness given as "RHP" or "LHP". No
+// captured popover has confirmed this shape yet. Each label is matched to the value after it,
+// so stats missing from the popover stay nil instead of shifting the others.
+func ParsePitcherPopOver(content string) *models.PitcherInfo {
+	info := &models.PitcherInfo{Stats: make(map[string]string)}
+
+	// Tags become spaces so "<b>ERA</b>3.45" doesn't run words together
+	text := strings.Join(strings.Fields(popOverTag.ReplaceAllString(content, " ")), " ")
+	for _, m := range popOverStat.FindAllStringSubmatch(text, -1) {
+		addPopOverStat(info.Stats, m[1], m[2])
+	}
+	if m := popOverThrows.FindStringSubmatch(text); m != nil {
+		info.Throws = m[1]
+	}
+
+	if m := popOverRecord.FindStringSubmatch(info.Stats["W-L"]); m != nil {
+		wins, _ := strconv.Atoi(m[1])
+		losses, _ := strconv.Atoi(m[2])
+		info.Season.Wins, info.Season.Losses = &wins, &losses
+	}
+	info.Season.ERA = parseFloatStat(info.Stats["ERA"])
+	info.Season.WHIP = parseFloatStat(info.Stats["WHIP"])
+	if k, err := strconv.Atoi(info.Stats["K"]); err == nil {
+		info.Season.Strikeouts = &k
+	}
+	return info
+}
+
+// addPopOverStat records a popover stat unless an earlier match already gave it. Labels are
+// upper-cased and SO is stored as K.
+func addPopOverStat(stats map[string]string, label, value string) {
+	label = strings.ToUpper(label)
+	if label == "SO" {
+		label = "K"
+	}
+	if _, ok := stats[label]; !ok {
+		stats[label] = value
+	}
 }
@@ -3,7 +3,9 @@ package parser
 import (
 	"encoding/json"
 	"fmt"
+	"html"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -21,10 +23,12 @@ func ParseTransactionHistoryResponse(data []byte) (*models.TransactionHistoryRes
 	return &response, nil
 }
 
-// ParseTransactions converts the raw transaction response into a simplified list of transactions
-func ParseTransactions(response *models.TransactionHistoryResponse, userTimezoneOffset string) ([]models.Transaction, error) {
+// ParseTransactions converts the raw transaction response into a simplified list of transactions.
+// Cells that cannot be parsed (dates, periods, fees) are left at their zero value and reported
+// as warnings rather than failing the whole response.
+func ParseTransactions(response *models.TransactionHistoryResponse, userTimezoneOffset string) ([]models.Transaction, []models.ParseWarning, error) {
 	if len(response.Responses) == 0 {
-		return nil, fmt.Errorf("no responses found in transaction history")
+		return nil, nil, fmt.Errorf("no responses found in transaction history")
 	}
 
 	transactionData := response.Responses[0].Data
@@ -32,14 +36,16 @@ func ParseTransactions(response *models.TransactionHistoryResponse, userTimezone
 
 	transactions := make([]models.Transaction, 0, len(rows))
 
+	var warnings []models.ParseWarning
+
 	// Keep track of transactions with shared cells (rowspan > 1)
 	groupedTransactionData := make(map[string]*groupData)
 
-	for _, row := range rows {
-		tx, err := parseTransactionRow(row, userTimezoneOffset)
-		if err != nil {
-			// Log error but continue processing other transactions
-			continue
+	for i, row := range rows {
+		tx, rowWarnings := parseTransactionRow(row, userTimezoneOffset)
+		for _, w := range rowWarnings {
+			w.Row = i
+			warnings = append(warnings, w)
 		}
 
 		// First, check if this is the first transaction in a group (has rowspan > 1)
@@ -58,6 +64,7 @@ func ParseTransactions(response *models.TransactionHistoryResponse, userTimezone
 				teamID:     "",
 				date:       time.Time{},
 				executedBy: "",
+				note:       tx.Note,
 			}
 
 			// Extract data from cells with rowspan
@@ -88,12 +95,16 @@ func ParseTransactions(response *models.TransactionHistoryResponse, userTimezone
 				tx.ProcessedDate = gd.date
 				tx.ExecutedBy = gd.executedBy
 			}
+			// The note is shown once for the whole group
+			if tx.Note == "" {
+				tx.Note = gd.note
+			}
 		}
 
 		transactions = append(transactions, tx)
 	}
 
-	return transactions, nil
+	return transactions, warnings, nil
 }
 
 // groupData holds shared data for grouped transactions
@@ -102,10 +113,22 @@ type groupData struct {
 	teamID     string
 	date       time.Time
 	executedBy string
+	note       string
 }
 
-// parseTransactionRow converts a single transaction row into a Transaction
-func parseTransactionRow(row models.TransactionRow, userTimezoneOffset string) (models.Transaction, error) {
+// parseTransactionRow converts a single transaction row into a Transaction, along with warnings
+// for any cells it could not read (the caller fills in the row index)
+func parseTransactionRow(row models.TransactionRow, userTimezoneOffset string) (models.Transaction, []models.ParseWarning) {
+	var warnings []models.ParseWarning
+	warn := func(cell models.TableCell, message string) {
+		warnings = append(warnings, models.ParseWarning{
+			Source:  "transactions",
+			Field:   cell.Key,
+			Value:   cell.Content,
+			Message: message,
+		})
+	}
+
 	tx := models.Transaction{
 		ID:             row.TxSetID,
 		Type:           row.TransactionCode,
@@ -115,6 +138,7 @@ func parseTransactionRow(row models.TransactionRow, userTimezoneOffset string) (
 		PlayerTeam:     row.Scorer.TeamShortName,
 		PlayerPosition: stripHTMLTags(row.Scorer.PosShortNames),
 		Executed:       row.Executed,
+		FeesUsed:       row.FeesUsed,
 	}
 
 	// Check if this is a trade by looking for from/to cells
@@ -141,17 +165,36 @@ func parseTransactionRow(row models.TransactionRow, userTimezoneOffset string) (
 			tx.BidAmount = cell.Content
 		case "priority":
 			tx.Priority = cell.Content
+		case "fee":
+			if fee, ok := ParseFeeAmount(cell.Content); ok {
+				tx.Fee = fee
+			} else if strings.TrimSpace(stripHTMLTags(cell.Content)) != "" {
+				warn(cell, "unrecognized fee amount")
+			}
 		case "date":
 			date, executedBy := parseDateCell(cell, userTimezoneOffset)
 			tx.ProcessedDate = date
 			tx.ExecutedBy = executedBy
+			if date.IsZero() && strings.TrimSpace(cell.Content) != "" {
+				warn(cell, "unrecognized date format")
+			}
+		case "note", "notes", "msg", "message", "comment", "comments":
+			if note := cleanNote(cell.Content); note != "" {
+				tx.Note = note
+			}
 		case "week":
 			if period, err := strconv.Atoi(cell.Content); err == nil {
 				tx.Period = period
+			} else if strings.TrimSpace(cell.Content) != "" {
+				warn(cell, "period is not a number")
 			}
 		}
 	}
 
+	if tx.Note == "" {
+		tx.Note = ParseTransactionNote(row)
+	}
+
 	// If we found from/to fields, this is a trade
 	if hasFromTo {
 		tx.Type = "TRADE"
@@ -159,7 +202,112 @@ func parseTransactionRow(row models.TransactionRow, userTimezoneOffset string) (
 		tx.TradeGroupSize = row.NumInGroup
 	}
 
-	return tx, nil
+	if hasFromTo && tx.PlayerID == "" {
+		if pick, ok := ParseDraftPick(tx.PlayerName); ok {
+			tx.DraftPick = pick
+			return tx, warnings
+		}
+	}
+
+	if tx.PlayerID == "" {
+		warnings = append(warnings, models.ParseWarning{
+			Source:  "transactions",
+			Field:   "scorer",
+			Value:   tx.PlayerName,
+			Message: "row has no player ID",
+		})
+	}
+
+	return tx, warnings
+}
+
+var (
+	draftPickYear         = regexp.MustCompile(`\b(20\d\d)\b`)
+	draftPickRound        = regexp.MustCompile(`(?i)\bround\s*#?\s*(\d+)\b`)
+	draftPickOrdinalRound = regexp.MustCompile(`(?i)\b(\d+)(?:st|nd|rd|th)\s+round\b`)
+	draftPickOriginalTeam = regexp.MustCompile(`\(([^()]+)\)\s*$`)
+)
+
+// ParseDraftPick recognizes the name Fantrax shows for a traded draft pick, such as
+// "2026 Round 1 (Team Name)", "2026 Draft Pick, Round 2" or "2027 1st Round Pick (Team Name)".
+// The original team is taken from trailing parentheses when present.
+func ParseDraftPick(name string) (*models.DraftPick, bool) {
+	name = strings.TrimSpace(stripHTMLTags(name))
+	lower := strings.ToLower(name)
+	if !strings.Contains(lower, "round") && !strings.Contains(lower, "pick") {
+		return nil, false
+	}
+
+	year := draftPickYear.FindStringSubmatch(name)
+	if year == nil {
+		return nil, false
+	}
+	round := draftPickRound.FindStringSubmatch(name)
+	if round == nil {
+		round = draftPickOrdinalRound.FindStringSubmatch(name)
+	}
+	if round == nil {
+		return nil, false
+	}
+
+	pick := &models.DraftPick{}
+	pick.Year, _ = strconv.Atoi(year[1])
+	pick.Round, _ = strconv.Atoi(round[1])
+	if team := draftPickOriginalTeam.FindStringSubmatch(name); team != nil && !draftPickYear.MatchString(team[1]) {
+		pick.OriginalTeamName = strings.TrimSpace(team[1])
+	}
+	return pick, true
+}
+
+// ParseFeeAmount parses a fee as displayed by Fantrax (e.g. "$1.50", "1,000", "-$2.00").
+// Returns false if the text does not contain a number.
+func ParseFeeAmount(text string) (float64, bool) {
+	text = strings.TrimSpace(stripHTMLTags(text))
+	negative := strings.HasPrefix(text, "-") || strings.HasPrefix(text, "(")
+	text = strings.Trim(text, "-()$ ")
+	text = strings.ReplaceAll(text, ",", "")
+	if text == "" {
+		return 0, false
+	}
+	amount, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return 0, false
+	}
+	if negative {
+		amount = -amount
+	}
+	return amount, true
+}
+
+// noteLabel matches a labelled note in a cell tooltip, e.g. "<b>Note</b> Fixing a missed claim"
+var noteLabel = regexp.MustCompile(`(?is)<b>\s*(?:Commissioner\s+)?(?:Notes?|Message|Msg|Comments?|Reason)\s*:?\s*</b>\s*:?\s*(.*?)(?:<br\s*/?>|$)`)
+
+// ParseTransactionNote returns the free-text note attached to a transaction row: the
+// commissioner's note on a commissioner action, or the message sent with a trade. Fantrax
+// sends it on the row itself or as a labelled line in a cell's tooltip. Returns "" when the
+// row has no note.
+func ParseTransactionNote(row models.TransactionRow) string {
+	for _, text := range []string{row.Note, row.Msg} {
+		if note := cleanNote(text); note != "" {
+			return note
+		}
+	}
+	for _, cell := range row.Cells {
+		for _, tip := range []string{cell.ToolTip, cell.IconToolTip} {
+			if m := noteLabel.FindStringSubmatch(tip); m != nil {
+				if note := cleanNote(m[1]); note != "" {
+					return note
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// cleanNote strips markup and entities from a note and collapses whitespace
+func cleanNote(text string) string {
+	text = html.UnescapeString(stripHTMLTags(lineBreak.ReplaceAllString(text, " ")))
+	return strings.Join(strings.Fields(text), " ")
 }
 
 // parseDateCell extracts the date and execution information from a date cell
@@ -329,3 +477,158 @@ func GroupTradesByTradeID(transactions []models.Transaction) map[string][]models
 
 	return grouped
 }
+
+// BucketSize is the span of time transactions are grouped into
+type BucketSize int
+
+const (
+	BucketDay  BucketSize = iota
+	BucketWeek            // Weeks start on Monday
+	BucketMonth
+)
+
+// TransactionBucket is the transactions processed within one day, week, or month
+type TransactionBucket struct {
+	Key          string    // "2006-01-02" for a day or week (the week's Monday), "2006-01" for a month
+	Start        time.Time // Start of the bucket in the grouping location
+	Transactions []models.Transaction
+}
+
+// BucketTransactions groups transactions by the day, week, or month they were processed in,
+// with day boundaries taken in loc (the league's timezone, e.g. UserInfo.Location). A nil loc
+// means UTC. Transactions without a processed date are left out.
+//
+// Returns the buckets in chronological order, each holding its transactions in input order.
+func BucketTransactions(transactions []models.Transaction, size BucketSize, loc *time.Location) []TransactionBucket {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	index := make(map[string]int)
+	var buckets []TransactionBucket
+	for _, tx := range transactions {
+		if tx.ProcessedDate.IsZero() {
+			continue
+		}
+		start, key := bucketStart(tx.ProcessedDate.In(loc), size)
+		i, ok := index[key]
+		if !ok {
+			i = len(buckets)
+			index[key] = i
+			buckets = append(buckets, TransactionBucket{Key: key, Start: start})
+		}
+		buckets[i].Transactions = append(buckets[i].Transactions, tx)
+	}
+
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].Start.Before(buckets[j].Start) })
+	return buckets
+}
+
+// GroupTransactionsByDay groups transactions by processed day ("2006-01-02") in loc
+func GroupTransactionsByDay(transactions []models.Transaction, loc *time.Location) map[string][]models.Transaction {
+	return groupBuckets(BucketTransactions(transactions, BucketDay, loc))
+}
+
+// GroupTransactionsByWeek groups transactions by the Monday ("2006-01-02") starting their
+// processed week in loc
+func GroupTransactionsByWeek(transactions []models.Transaction, loc *time.Location) map[string][]models.Transaction {
+	return groupBuckets(BucketTransactions(transactions, BucketWeek, loc))
+}
+
+// GroupTransactionsByMonth groups transactions by processed month ("2006-01") in loc
+func GroupTransactionsByMonth(transactions []models.Transaction, loc *time.Location) map[string][]models.Transaction {
+	return groupBuckets(BucketTransactions(transactions, BucketMonth, loc))
+}
+
+// groupBuckets converts buckets to a map keyed by bucket key
+func groupBuckets(buckets []TransactionBucket) map[string][]models.Transaction {
+	grouped := make(map[string][]models.Transaction, len(buckets))
+	for _, bucket := range buckets {
+		grouped[bucket.Key] = bucket.Transactions
+	}
+	return grouped
+}
+
+// bucketStart returns the start of the day, week, or month containing t, in t's location,
+// and the bucket's key
+func bucketStart(t time.Time, size BucketSize) (time.Time, string) {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch size {
+	case BucketWeek:
+		offset := (int(day.Weekday()) + 6) % 7 // Days since Monday
+		day = day.AddDate(0, 0, -offset)
+		return day, day.Format("2006-01-02")
+	case BucketMonth:
+		month := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+		return month, month.Format("2006-01")
+	}
+	return day, day.Format("2006-01-02")
+}
+
+// ParsePendingTransactions converts a PENDING view transaction response into pending
+// transactions, combining rows that share a txSetId
+//
+// Within a claim, CLAIM rows are the players being added and DROP rows are the conditional
+// drops. Transactions are returned in the order their first row appears.
+func ParsePendingTransactions(response *models.TransactionHistoryResponse, userTimezoneOffset string) ([]models.PendingTransaction, []models.ParseWarning, error) {
+	rows, warnings, err := ParseTransactions(response, userTimezoneOffset)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var pending []models.PendingTransaction
+	index := make(map[string]int)
+	for _, tx := range rows {
+		i, exists := index[tx.ID]
+		if !exists {
+			pending = append(pending, models.PendingTransaction{ID: tx.ID, Type: tx.Type})
+			i = len(pending) - 1
+			index[tx.ID] = i
+		}
+		p := &pending[i]
+
+		if p.TeamID == "" && tx.Type != "TRADE" {
+			p.TeamID, p.TeamName = tx.TeamID, tx.TeamName
+		}
+		if p.ClaimType == "" {
+			p.ClaimType = tx.ClaimType
+		}
+		if p.BidAmount == "" {
+			p.BidAmount = tx.BidAmount
+		}
+		if p.Priority == "" {
+			p.Priority = tx.Priority
+		}
+		if p.ProcessTime.IsZero() {
+			p.ProcessTime = tx.ProcessedDate
+		}
+		if p.Period == 0 {
+			p.Period = tx.Period
+		}
+		if p.Note == "" {
+			p.Note = tx.Note
+		}
+
+		player := models.PendingTransactionPlayer{
+			PlayerID:       tx.PlayerID,
+			PlayerName:     tx.PlayerName,
+			PlayerTeam:     tx.PlayerTeam,
+			PlayerPosition: tx.PlayerPosition,
+		}
+		switch tx.Type {
+		case "TRADE":
+			p.Type = "TRADE"
+			player.FromTeamID, player.FromTeamName = tx.FromTeamID, tx.FromTeamName
+			player.ToTeamID, player.ToTeamName = tx.ToTeamID, tx.ToTeamName
+			p.TradePlayers = append(p.TradePlayers, player)
+		case "DROP":
+			p.ConditionalDrops = append(p.ConditionalDrops, player)
+		default:
+			// A claim with conditional drops is a CLAIM even if a DROP row came first
+			p.Type = tx.Type
+			p.Claims = append(p.Claims, player)
+		}
+	}
+
+	return pending, warnings, nil
+}
@@ -0,0 +1,47 @@
+package auth_client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pmurley/go-fantrax/auth_client/parser"
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestParsePendingTransactions(t *testing.T) {
+	processDate := models.TableCell{Key: "date", Content: "Thu Jun 12, 2025, 3:00AM", Rowspan: 2}
+	response := &models.TransactionHistoryResponse{Responses: []models.TransactionDataResponse{{Data: models.TransactionData{
+		Table: models.TransactionTable{Rows: []models.TransactionRow{
+			// The conditional drop is listed before the claim it belongs to
+			{TxSetID: "a", TransactionCode: "DROP", Scorer: models.TransactionPlayer{ScorerID: "p2", Name: "Lee Conditional"}, Cells: []models.TableCell{processDate}},
+			{TxSetID: "a", TransactionCode: "CLAIM", Scorer: models.TransactionPlayer{ScorerID: "p1", Name: "Rowan Target"}},
+			{TxSetID: "b", TransactionCode: "CLAIM", Scorer: models.TransactionPlayer{ScorerID: "p3"}},
+		}},
+	}}}}
+
+	pending, _, err := parser.ParsePendingTransactions(response, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 2 {
+		t.Fatalf("expected 2 pending transactions, got %d", len(pending))
+	}
+
+	claim := pending[0]
+	if claim.Type != "CLAIM" {
+		t.Errorf("expected a CLAIM, got %q", claim.Type)
+	}
+	if want := time.Date(2025, time.June, 12, 3, 0, 0, 0, time.UTC); !claim.ProcessTime.Equal(want) {
+		t.Errorf("expected process time %v, got %v", want, claim.ProcessTime)
+	}
+	if len(claim.Claims) != 1 || claim.Claims[0].PlayerID != "p1" {
+		t.Errorf("unexpected claims %+v", claim.Claims)
+	}
+	if len(claim.ConditionalDrops) != 1 || claim.ConditionalDrops[0].PlayerID != "p2" {
+		t.Errorf("unexpected conditional drops %+v", claim.ConditionalDrops)
+	}
+
+	if other := pending[1]; len(other.ConditionalDrops) != 0 || !other.ProcessTime.IsZero() {
+		t.Errorf("claim without a drop or date picked up %+v", other)
+	}
+}
@@ -0,0 +1,185 @@
+package auth_client
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/pmurley/go-fantrax/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultPeriodCacheDir is where results of completed scoring periods are kept
+var DefaultPeriodCacheDir = filepath.Join(CacheDir, "periods")
+
+// ScoringPeriodResults is the matchups of one scoring period
+type ScoringPeriodResults struct {
+	Period    int                    `json:"period"`
+	Date      string                 `json:"date"`
+	Completed bool                   `json:"completed"`
+	Matchups  []Matchup              `json:"matchups"`
+	Teams     map[string]FantasyTeam `json:"teams"` // keyed by teamId
+}
+
+// WithPeriodCache keeps the results of completed scoring periods in dir. An empty dir turns
+// the period cache off. The default is DefaultPeriodCacheDir.
+func WithPeriodCache(dir string) ClientOption {
+	return func(c *Client) {
+		c.PeriodCacheDir = dir
+	}
+}
+
+// GetScoringPeriodResults returns the matchups of a scoring period
+//
+// Results of completed periods (before the current period) never change, so they are kept in
+// the period cache and never fetched again, whether or not the response cache is enabled. A
+// single fetch of the schedule fills the cache for every completed period. After a
+// commissioner score correction, clear the period with InvalidatePeriod.
+//
+// Parameters:
+//   - period: The scoring period
+func (c *Client) GetScoringPeriodResults(period int) (*ScoringPeriodResults, error) {
+	var cached ScoringPeriodResults
+	if c.readPeriodCache(periodResultsFile(period), &cached) {
+		return &cached, nil
+	}
+
+	currentPeriod, err := c.GetCurrentPeriod()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current period: %w", err)
+	}
+	matchups, err := c.GetAllMatchups()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get matchups: %w", err)
+	}
+
+	byPeriod := make(map[int]*ScoringPeriodResults)
+	for _, m := range matchups.Matchups {
+		results, ok := byPeriod[m.ScoringPeriod]
+		if !ok {
+			results = &ScoringPeriodResults{
+				Period:    m.ScoringPeriod,
+				Date:      m.Date,
+				Completed: m.ScoringPeriod < currentPeriod,
+				Teams:     matchups.Teams,
+			}
+			byPeriod[m.ScoringPeriod] = results
+		}
+		results.Matchups = append(results.Matchups, m)
+	}
+	for p, results := range byPeriod {
+		if results.Completed {
+			c.writePeriodCache(periodResultsFile(p), results)
+		}
+	}
+
+	if results, ok := byPeriod[period]; ok {
+		return results, nil
+	}
+	return nil, fmt.Errorf("no matchups in scoring period %d", period)
+}
+
+// GetPeriodBoxScore returns a team's roster with its players' stats for a scoring period.
+// Box scores of completed periods are kept in the period cache like GetScoringPeriodResults.
+//
+// Parameters:
+//   - period: The scoring period
+//   - teamID: The fantasy team ID (empty string = the user's team, see GetMyTeamID)
+func (c *Client) GetPeriodBoxScore(period int, teamID string) (*models.TeamRoster, error) {
+	teamID, err := c.teamOrMine(teamID)
+	if err != nil {
+		return nil, err
+	}
+	file := periodBoxScoreFile(period, teamID)
+	var cached models.TeamRoster
+	if c.readPeriodCache(file, &cached) {
+		return &cached, nil
+	}
+
+	roster, err := c.GetTeamRosterInfo(strconv.Itoa(period), teamID)
+	if err != nil {
+		return nil, err
+	}
+	currentPeriod, err := c.GetCurrentPeriod()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current period: %w", err)
+	}
+	if period < currentPeriod {
+		c.writePeriodCache(file, roster)
+	}
+	return roster, nil
+}
+
+// InvalidatePeriod removes a scoring period's results and box scores from the period cache,
+// e.g. after a commissioner changes a completed period's scores
+func (c *Client) InvalidatePeriod(period int) error {
+	if c.PeriodCacheDir == "" {
+		return nil
+	}
+	files, err := filepath.Glob(filepath.Join(c.periodCacheLeagueDir(), fmt.Sprintf("period-%d-*.json", period)))
+	if err != nil {
+		return fmt.Errorf("failed to list period cache: %w", err)
+	}
+	for _, file := range files {
+		if err := os.Remove(file); err != nil {
+			return fmt.Errorf("failed to remove period cache file: %w", err)
+		}
+	}
+	return nil
+}
+
+func periodResultsFile(period int) string {
+	return fmt.Sprintf("period-%d-matchups.json", period)
+}
+
+func periodBoxScoreFile(period int, teamID string) string {
+	return fmt.Sprintf("period-%d-team-%s.json", period, teamID)
+}
+
+func (c *Client) periodCacheLeagueDir() string {
+	return filepath.Join(c.PeriodCacheDir, c.LeagueID)
+}
+
+// readPeriodCache decodes a period cache file into v, returning false on a miss. Unreadable
+// files count as misses and are overwritten by the next fetch.
+func (c *Client) readPeriodCache(name string, v interface{}) bool {
+	if c.PeriodCacheDir == "" {
+		return false
+	}
+	data, err := os.ReadFile(filepath.Join(c.periodCacheLeagueDir(), name))
+	if err != nil {
+		return false
+	}
+	if data, err = c.CacheCipher.Open(data); err != nil {
+		log.Warn("unreadable period cache file: ", err)
+		return false
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		log.Warn("unreadable period cache file: ", err)
+		return false
+	}
+	return true
+}
+
+// writePeriodCache stores v in the period cache. Failures are logged; the caller already has
+// the fetched data.
+func (c *Client) writePeriodCache(name string, v interface{}) {
+	if c.PeriodCacheDir == "" {
+		return
+	}
+	data, err := json.Marshal(v)
+	if err == nil {
+		data, err = c.CacheCipher.Seal(data)
+	}
+	if err == nil {
+		err = os.MkdirAll(c.periodCacheLeagueDir(), 0755)
+	}
+	if err == nil {
+		err = os.WriteFile(filepath.Join(c.periodCacheLeagueDir(), name), data, 0600)
+	}
+	if err != nil {
+		log.Warn("failed to write period cache: ", err)
+	}
+}
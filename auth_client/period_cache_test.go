@@ -0,0 +1,43 @@
+package auth_client
+
+import (
+	"testing"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestPeriodCache(t *testing.T) {
+	c := &Client{LeagueID: "league", PeriodCacheDir: t.TempDir()}
+	c.writePeriodCache(periodResultsFile(3), &ScoringPeriodResults{
+		Period:    3,
+		Completed: true,
+		Matchups:  []Matchup{matchup(3, "a", 100, "b", 90)},
+	})
+	c.writePeriodCache(periodBoxScoreFile(3, "a"), &models.TeamRoster{
+		ActiveRoster: []models.RosterPlayer{{PlayerID: "p1", Name: "Player One"}},
+	})
+
+	// Served from the cache without any request
+	results, err := c.GetScoringPeriodResults(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results.Matchups) != 1 || results.Matchups[0].AwayTeam.Total != 100 {
+		t.Errorf("unexpected cached results: %+v", results)
+	}
+	roster, err := c.GetPeriodBoxScore(3, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(roster.ActiveRoster) != 1 || roster.ActiveRoster[0].Name != "Player One" {
+		t.Errorf("unexpected cached box score: %+v", roster)
+	}
+
+	if err := c.InvalidatePeriod(3); err != nil {
+		t.Fatal(err)
+	}
+	var cached ScoringPeriodResults
+	if c.readPeriodCache(periodResultsFile(3), &cached) || c.readPeriodCache(periodBoxScoreFile(3, "a"), &models.TeamRoster{}) {
+		t.Error("expected the period to be cleared from the cache")
+	}
+}
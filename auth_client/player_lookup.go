@@ -0,0 +1,85 @@
+package auth_client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// GetPlayersByIDs finds the requested players by scanning the player pool
+//
+// Fantrax has no request for players by ID, so this is a scan of the full pool with an early
+// exit: pages are streamed in order and only the requested players are kept, and no further
+// pages are fetched once every player has been found. A player near the end of the pool still
+// costs every page before it.
+// When the players are known to be on one team, pass WithStatusFilter with the team ID: the
+// status filter also accepts a fantasy team ID, which limits the request to that roster.
+//
+// Parameters:
+//   - ids: Fantrax player (scorer) IDs; duplicates are ignored
+//   - opts: Same options as GetPlayerPool
+//
+// Returns the players in the order requested. IDs not found in the pool are reported in the
+// error alongside the players that were found.
+func (c *Client) GetPlayersByIDs(ids []string, opts ...PlayerPoolOption) ([]models.PoolPlayer, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	wanted := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	found := make(map[string]models.PoolPlayer, len(wanted))
+	err := c.GetPlayerPoolStream(func(page []models.PoolPlayer) error {
+		for _, player := range page {
+			if wanted[player.PlayerID] {
+				found[player.PlayerID] = player
+			}
+		}
+		if len(found) == len(wanted) {
+			return ErrStopStream
+		}
+		return nil
+	}, opts...)
+
+	players, missing := orderPlayersByIDs(found, ids)
+	if err != nil {
+		return players, err
+	}
+	if len(missing) > 0 {
+		return players, fmt.Errorf("players not found in the player pool: %s", strings.Join(missing, ", "))
+	}
+	return players, nil
+}
+
+// FilterPlayersByIDs picks the requested players out of an already fetched pool, in the order
+// requested. IDs not in the pool are skipped.
+func FilterPlayersByIDs(pool []models.PoolPlayer, ids []string) []models.PoolPlayer {
+	byID := make(map[string]models.PoolPlayer, len(ids))
+	for _, player := range pool {
+		byID[player.PlayerID] = player
+	}
+	players, _ := orderPlayersByIDs(byID, ids)
+	return players
+}
+
+// orderPlayersByIDs lists the players for ids in order, once each, and the IDs with no player
+func orderPlayersByIDs(byID map[string]models.PoolPlayer, ids []string) ([]models.PoolPlayer, []string) {
+	players := make([]models.PoolPlayer, 0, len(ids))
+	var missing []string
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if player, ok := byID[id]; ok {
+			players = append(players, player)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	return players, missing
+}
@@ -0,0 +1,21 @@
+package auth_client
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestFilterPlayersByIDs(t *testing.T) {
+	pool := []models.PoolPlayer{{PlayerID: "a"}, {PlayerID: "b"}, {PlayerID: "c"}}
+	players := FilterPlayersByIDs(pool, []string{"c", "x", "a", "c"})
+
+	var got []string
+	for _, p := range players {
+		got = append(got, p.PlayerID)
+	}
+	if want := []string{"c", "a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
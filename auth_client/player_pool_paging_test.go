@@ -0,0 +1,119 @@
+package auth_client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// poolPageTransport serves the first two players of each player pool page, failing with a
+// gateway timeout for pages larger than maxSize. Each player's ID is its row number across the
+// whole pool.
+type poolPageTransport struct {
+	maxSize  int
+	requests []string
+}
+
+func (t *poolPageTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var envelope struct {
+		Msgs []struct {
+			Data GetPlayerPoolRequest `json:"data"`
+		} `json:"msgs"`
+	}
+	if err := json.NewDecoder(req.Body).Decode(&envelope); err != nil {
+		return nil, err
+	}
+	data := envelope.Msgs[0].Data
+	page, _ := strconv.Atoi(data.PageNumber)
+	t.requests = append(t.requests, fmt.Sprintf("%d/%d", page, data.MaxResultsPerPage))
+	if data.MaxResultsPerPage > t.maxSize {
+		return &http.Response{StatusCode: http.StatusGatewayTimeout, Body: io.NopCloser(strings.NewReader(""))}, nil
+	}
+
+	var rows []string
+	for i := 0; i < 2; i++ {
+		rows = append(rows, fmt.Sprintf(`{"scorer":{"scorerId":"%d"},"cells":[]}`, (page-1)*data.MaxResultsPerPage+i))
+	}
+	body := fmt.Sprintf(`{"responses":[{"data":{"paginatedResultSet":{"totalNumPages":4},"statsTable":[%s]}}]}`, strings.Join(rows, ","))
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+}
+
+func TestPlayerPoolDowngradesPageSize(t *testing.T) {
+	transport := &poolPageTransport{maxSize: DefaultPlayersPerPage / 2}
+	c := &Client{Client: http.Client{Transport: transport}, Cookies: "test"}
+
+	players, err := c.fetchPlayerPoolRange(StatusFilterAll, 2, DefaultPlayersPerPage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"2/4000", "3/2000", "4/2000"}
+	if strings.Join(transport.requests, " ") != strings.Join(want, " ") {
+		t.Errorf("requests = %v, want %v", transport.requests, want)
+	}
+	var ids []string
+	for _, p := range players {
+		ids = append(ids, p.PlayerID)
+	}
+	if strings.Join(ids, ",") != "4000,4001,6000,6001" {
+		t.Errorf("players = %v, want the rows of page 2 at 4000 per page", ids)
+	}
+}
+
+func TestPlayerPoolDefaultPageSizeHalvesToMinimum(t *testing.T) {
+	transport := &poolPageTransport{maxSize: MinPlayersPerPage}
+	c := &Client{Client: http.Client{Transport: transport}, Cookies: "test"}
+
+	players, err := c.fetchPlayerPoolRange(StatusFilterAll, 1, DefaultPlayersPerPage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 4000 splits into 16 pages of 250, each serving two players
+	if len(players) != 2*DefaultPlayersPerPage/MinPlayersPerPage {
+		t.Errorf("got %d players from %v", len(players), transport.requests)
+	}
+}
+
+func TestPlayerPoolReportsPageProgress(t *testing.T) {
+	var progress []PageProgress
+	c := &Client{
+		Client:         http.Client{Transport: &poolPageTransport{maxSize: MaxPlayersPerPage}},
+		Cookies:        "test",
+		OnPageProgress: func(p PageProgress) { progress = append(progress, p) },
+	}
+
+	if err := c.GetPlayerPoolStream(func([]models.PoolPlayer) error { return nil }, WithConcurrency(1)); err != nil {
+		t.Fatal(err)
+	}
+	if len(progress) != 4 {
+		t.Fatalf("got %d progress reports, want one per page: %+v", len(progress), progress)
+	}
+	for i, p := range progress {
+		if p.Operation != ProgressPlayerPool || p.Page != i+1 || p.TotalPages != 4 || p.Rows != 2 || p.RowsSoFar != 2*(i+1) {
+			t.Errorf("progress[%d] = %+v", i, p)
+		}
+	}
+	if !progress[3].Done() || progress[2].Done() {
+		t.Errorf("only the last page should be done: %+v", progress)
+	}
+}
+
+func TestPlayerPoolOddPageSizeDoesNotSplit(t *testing.T) {
+	transport := &poolPageTransport{maxSize: 300}
+	c := &Client{Client: http.Client{Transport: transport}, Cookies: "test"}
+
+	// 1250 halves to 625, and page 2 at 625 per page can't be split into two pages that cover
+	// the same rows, so it fails instead of fetching the wrong players
+	if _, err := c.fetchPlayerPoolRange(StatusFilterAll, 2, 1250); err == nil {
+		t.Fatal("fetching an unsplittable page succeeded")
+	}
+	want := []string{"2/1250", "3/625"}
+	if strings.Join(transport.requests, " ") != strings.Join(want, " ") {
+		t.Errorf("requests = %v, want %v", transport.requests, want)
+	}
+}
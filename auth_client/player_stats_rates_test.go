@@ -0,0 +1,53 @@
+package auth_client
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func intPtr(n int) *int           { return &n }
+func floatPtr(f float64) *float64 { return &f }
+
+func TestStatRates(t *testing.T) {
+	near := func(name string, got float64, ok bool, want float64) {
+		t.Helper()
+		if !ok || math.Abs(got-want) > 0.0005 {
+			t.Errorf("%s: expected %.3f, got %.3f (ok=%v)", name, want, got, ok)
+		}
+	}
+
+	batting := &models.BattingStats{AtBats: intPtr(100), Hits: intPtr(30), Doubles: intPtr(5), Triples: intPtr(1),
+		HomeRuns: intPtr(4), Walks: intPtr(8), HitByPitch: intPtr(2), GamesPlayed: intPtr(25)}
+	avg, ok := batting.AVG()
+	near("AVG", avg, ok, 0.300)
+	obp, ok := batting.OBP()
+	near("OBP", obp, ok, 40.0/110)
+	slg, ok := batting.SLG()
+	near("SLG", slg, ok, 0.490)
+	hrPerGame, ok := batting.PerGame(batting.HomeRuns)
+	near("HR/G", hrPerGame, ok, 0.16)
+	if _, ok := (&models.BattingStats{Hits: intPtr(3)}).AVG(); ok {
+		t.Error("expected AVG without at-bats to be unavailable")
+	}
+
+	split := batting.Since(&models.BattingStats{AtBats: intPtr(80), Hits: intPtr(20), Doubles: intPtr(5), Triples: intPtr(1),
+		HomeRuns: intPtr(2), Walks: intPtr(8), HitByPitch: intPtr(2), GamesPlayed: intPtr(20)})
+	avg, ok = split.AVG()
+	near("split AVG", avg, ok, 0.500)
+
+	pitching := &models.PitchingStats{InningsPitched: floatPtr(6.1), HitsAllowed: intPtr(5), WalksAllowed: intPtr(2),
+		Strikeouts: intPtr(8), EarnedRuns: intPtr(2)}
+	whip, ok := pitching.WHIP()
+	near("WHIP", whip, ok, 7/(19.0/3))
+	k9, ok := pitching.KPer9()
+	near("K/9", k9, ok, 8*9/(19.0/3))
+	era, ok := pitching.EarnedRunAverage()
+	near("ERA", era, ok, 2*9/(19.0/3))
+
+	pitchingSplit := pitching.Since(&models.PitchingStats{InningsPitched: floatPtr(2.2)})
+	if pitchingSplit.InningsPitched == nil || math.Abs(*pitchingSplit.InningsPitched-3.2) > 1e-9 {
+		t.Errorf("expected 3.2 innings in the split, got %v", pitchingSplit.InningsPitched)
+	}
+}
@@ -0,0 +1,395 @@
+package auth_client
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PlayoffBracket is a season's playoffs: each round's series with seeds and results, and the
+// champion once the final is decided
+type PlayoffBracket struct {
+	Rounds         []PlayoffRound `json:"rounds"`
+	Seeds          map[string]int `json:"seeds,omitempty"`          // Team ID -> seed, where the tables show seeds
+	ChampionTeamID string         `json:"championTeamId,omitempty"` // Empty until the final is decided
+	RunnerUpTeamID string         `json:"runnerUpTeamId,omitempty"`
+}
+
+// PlayoffRound is one round of the playoffs, which may span several scoring periods
+type PlayoffRound struct {
+	Number  int             `json:"number"` // 1 for the first round
+	Name    string          `json:"name"`   // The table caption, e.g. "Semifinals"; "Period N" when uncaptioned
+	Periods []int           `json:"periods,omitempty"`
+	Series  []PlayoffSeries `json:"series"`
+}
+
+// PlayoffSeries is the meeting of two teams in a round, over one or more matchups
+type PlayoffSeries struct {
+	Teams        []PlayoffSeriesTeam `json:"teams"` // Two teams, or one for a bye
+	Games        []Matchup           `json:"games,omitempty"`
+	WinnerTeamID string              `json:"winnerTeamId,omitempty"` // Empty while undecided or tied
+	Bye          bool                `json:"bye,omitempty"`
+	Consolation  bool                `json:"consolation,omitempty"` // A consolation or placement game
+}
+
+// PlayoffSeriesTeam is one team's side of a series
+type PlayoffSeriesTeam struct {
+	TeamID string  `json:"teamId"`
+	Seed   int     `json:"seed,omitempty"`
+	Wins   int     `json:"wins"`  // Matchups won in the series
+	Score  float64 `json:"score"` // Total across the series' matchups
+}
+
+var (
+	playoffPeriod      = regexp.MustCompile(`(?i)\bperiod\s+(\d+)`)
+	playoffPeriodLabel = regexp.MustCompile(`(?i)\b(?:scoring\s+)?period\s+\d+`)
+	playoffSeedPrefix  = regexp.MustCompile(`^(?:\((\d{1,2})\)|#(\d{1,2})|(\d{1,2})\.)\s*`)
+	playoffSeedSuffix  = regexp.MustCompile(`\s+\(#?(\d{1,2})\)$`)
+	playoffConsolation = regexp.MustCompile(`(?i)consolation|3rd|third|5th|fifth|7th|place|toilet|loser`)
+	playoffRecord      = regexp.MustCompile(`^(\d+)-(\d+)(?:-(\d+))?$`)
+)
+
+// GetPlayoffBracket fetches the playoff view of the standings and parses it into a bracket
+func (c *Client) GetPlayoffBracket() (*PlayoffBracket, error) {
+	body, err := c.GetStandingsRaw(WithStandingsView(StandingsViewPlayoffs))
+	if err != nil {
+		return nil, err
+	}
+	return ParsePlayoffBracket(body)
+}
+
+// ParsePlayoffBracket parses a standings PLAYOFFS view response (as returned by
+// GetStandingsRaw with StandingsViewPlayoffs) into a bracket
+//
+// Playoff tables are captioned by round rather than by scoring period, and their table
+// types differ by league format, so each table is read by its cells: a row with two team
+// cells is one matchup, and single-team rows (as in category leagues) pair up in order. A
+// lone team left over is a bye. Tables with the same caption form one round, so a round
+// played over several periods becomes a series, and a placement table such as "3rd Place"
+// joins the round played in the same period as a consolation series. A seed is read from a team cell such as
+// "(1) Team" or "Team (1)", or from a Seed column.
+func ParsePlayoffBracket(body []byte) (*PlayoffBracket, error) {
+	var response StandingsResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if len(response.Responses) == 0 {
+		return nil, fmt.Errorf("no response data found")
+	}
+
+	bracket := &PlayoffBracket{Seeds: make(map[string]int)}
+	roundIndex := make(map[string]int)
+	for _, table := range response.Responses[0].Data.TableList {
+		period := 0
+		if m := playoffPeriod.FindStringSubmatch(table.Caption + " " + table.SubCaption); m != nil {
+			period, _ = strconv.Atoi(m[1])
+		}
+		name := playoffRoundName(table.Caption, period)
+		consolation := playoffConsolation.MatchString(table.Caption + " " + table.SubCaption)
+
+		games, byes := parsePlayoffTable(table, period, bracket.Seeds)
+		if len(games) == 0 && len(byes) == 0 {
+			continue
+		}
+
+		// A placement game played alongside a round belongs to that round
+		i, ok := roundIndex[name]
+		if consolation && !ok && period > 0 {
+			for r := range bracket.Rounds {
+				if containsInt(bracket.Rounds[r].Periods, period) {
+					i, ok = r, true
+				}
+			}
+		}
+		if !ok {
+			i = len(bracket.Rounds)
+			roundIndex[name] = i
+			bracket.Rounds = append(bracket.Rounds, PlayoffRound{Number: i + 1, Name: name})
+		}
+		round := &bracket.Rounds[i]
+		if period > 0 && !containsInt(round.Periods, period) {
+			round.Periods = append(round.Periods, period)
+		}
+		for _, game := range games {
+			round.addGame(game, consolation)
+		}
+		for _, teamID := range byes {
+			round.Series = append(round.Series, PlayoffSeries{Teams: []PlayoffSeriesTeam{{TeamID: teamID}}, WinnerTeamID: teamID, Bye: true})
+		}
+	}
+
+	for r := range bracket.Rounds {
+		for s := range bracket.Rounds[r].Series {
+			series := &bracket.Rounds[r].Series[s]
+			for t := range series.Teams {
+				series.Teams[t].Seed = bracket.Seeds[series.Teams[t].TeamID]
+			}
+			if !series.Bye {
+				series.WinnerTeamID = series.winner()
+			}
+		}
+	}
+	if len(bracket.Seeds) == 0 {
+		bracket.Seeds = nil
+	}
+	bracket.ChampionTeamID, bracket.RunnerUpTeamID = bracket.final()
+	return bracket, nil
+}
+
+// addGame adds a matchup to the series between its two teams, starting one if needed
+func (r *PlayoffRound) addGame(game Matchup, consolation bool) {
+	for i := range r.Series {
+		series := &r.Series[i]
+		if series.Bye || len(series.Teams) != 2 {
+			continue
+		}
+		a, b := series.Teams[0].TeamID, series.Teams[1].TeamID
+		if (a == game.AwayTeam.TeamID && b == game.HomeTeam.TeamID) || (a == game.HomeTeam.TeamID && b == game.AwayTeam.TeamID) {
+			series.addGame(game)
+			return
+		}
+	}
+	series := PlayoffSeries{
+		Teams:       []PlayoffSeriesTeam{{TeamID: game.AwayTeam.TeamID}, {TeamID: game.HomeTeam.TeamID}},
+		Consolation: consolation,
+	}
+	series.addGame(game)
+	r.Series = append(r.Series, series)
+}
+
+// addGame adds a matchup's result to the series totals
+func (s *PlayoffSeries) addGame(game Matchup) {
+	s.Games = append(s.Games, game)
+	winner := game.Winner()
+	for i := range s.Teams {
+		team := &s.Teams[i]
+		switch team.TeamID {
+		case game.AwayTeam.TeamID:
+			team.Score += game.AwayTeam.Total
+		case game.HomeTeam.TeamID:
+			team.Score += game.HomeTeam.Total
+		}
+		if winner == team.TeamID {
+			team.Wins++
+		}
+	}
+}
+
+// winner decides the series by matchups won, then by total score
+func (s *PlayoffSeries) winner() string {
+	if len(s.Teams) != 2 {
+		return ""
+	}
+	a, b := s.Teams[0], s.Teams[1]
+	if a.Wins != b.Wins {
+		if a.Wins > b.Wins {
+			return a.TeamID
+		}
+		return b.TeamID
+	}
+	switch compareTotals(a.Score, b.Score) {
+	case 1:
+		return a.TeamID
+	case -1:
+		return b.TeamID
+	}
+	return ""
+}
+
+// final finds the championship series in the last round and returns its winner and loser.
+// When the last round has several series, the final is the one between two teams that
+// advanced from the round before.
+func (b *PlayoffBracket) final() (champion, runnerUp string) {
+	lastIndex := len(b.Rounds) - 1
+	for lastIndex >= 0 && !b.Rounds[lastIndex].hasBracketSeries() {
+		lastIndex--
+	}
+	if lastIndex < 0 {
+		return "", ""
+	}
+	last := b.Rounds[lastIndex]
+	advanced := make(map[string]bool)
+	if lastIndex > 0 {
+		for _, series := range b.Rounds[lastIndex-1].Series {
+			if !series.Consolation && series.WinnerTeamID != "" {
+				advanced[series.WinnerTeamID] = true
+			}
+		}
+	}
+
+	var finals []PlayoffSeries
+	for _, series := range last.Series {
+		if series.Consolation || series.Bye || len(series.Teams) != 2 {
+			continue
+		}
+		if len(advanced) > 0 && (!advanced[series.Teams[0].TeamID] || !advanced[series.Teams[1].TeamID]) {
+			continue
+		}
+		finals = append(finals, series)
+	}
+	if len(finals) != 1 || finals[0].WinnerTeamID == "" {
+		return "", ""
+	}
+	champion = finals[0].WinnerTeamID
+	for _, team := range finals[0].Teams {
+		if team.TeamID != champion {
+			runnerUp = team.TeamID
+		}
+	}
+	return champion, runnerUp
+}
+
+// hasBracketSeries reports whether the round has a series that isn't a bye or a
+// consolation game
+func (r PlayoffRound) hasBracketSeries() bool {
+	for _, series := range r.Series {
+		if !series.Bye && !series.Consolation {
+			return true
+		}
+	}
+	return false
+}
+
+// parsePlayoffTable reads a playoff table's matchups and byes, recording any seeds shown
+func parsePlayoffTable(table Table, period int, seeds map[string]int) ([]Matchup, []string) {
+	seedCol := -1
+	for i, cell := range table.Header.Cells {
+		if strings.EqualFold(cell.ShortName, "seed") || strings.EqualFold(cell.Name, "seed") {
+			seedCol = i
+		}
+	}
+	date := ""
+	if sub := strings.Trim(table.SubCaption, "()"); !playoffPeriod.MatchString(sub) {
+		date = sub
+	}
+
+	var games []Matchup
+	var pending *MatchTeam
+	var byes []string
+	for _, row := range table.Rows {
+		cells := append(append([]Cell(nil), row.FixedCells...), row.Cells...)
+		var teamCols []int
+		for i, cell := range cells {
+			if cell.TeamID != "" {
+				teamCols = append(teamCols, i)
+			}
+		}
+		if len(teamCols) == 0 {
+			continue
+		}
+
+		var sides []MatchTeam
+		for n, col := range teamCols {
+			end := len(cells)
+			if n+1 < len(teamCols) {
+				end = teamCols[n+1]
+			}
+			side := playoffSide(cells[col:end])
+			if seed := playoffSeed(cells[col].Content); seed > 0 {
+				seeds[side.TeamID] = seed
+			} else if seedCol >= 0 && seedCol < len(cells) && len(teamCols) == 1 {
+				if seed, err := strconv.Atoi(strings.Trim(cells[seedCol].Content, "()#")); err == nil && seed > 0 {
+					seeds[side.TeamID] = seed
+				}
+			}
+			sides = append(sides, side)
+		}
+
+		if len(sides) >= 2 {
+			games = append(games, Matchup{ScoringPeriod: period, Date: date, AwayTeam: sides[0], HomeTeam: sides[1]})
+			continue
+		}
+		if isPlayoffBye(cells) {
+			byes = append(byes, sides[0].TeamID)
+			continue
+		}
+		if pending == nil {
+			side := sides[0]
+			pending = &side
+			continue
+		}
+		games = append(games, Matchup{ScoringPeriod: period, Date: date, AwayTeam: *pending, HomeTeam: sides[0]})
+		pending = nil
+	}
+	if pending != nil {
+		byes = append(byes, pending.TeamID)
+	}
+	return games, byes
+}
+
+// playoffSide reads a team's result from its team cell and the cells after it: points, an
+// optional adjustment, and the total, or a category record such as "5-3-1"
+func playoffSide(cells []Cell) MatchTeam {
+	side := MatchTeam{TeamID: cells[0].TeamID}
+	var numbers []float64
+	for _, cell := range cells[1:] {
+		content := strings.TrimSpace(cell.Content)
+		if m := playoffRecord.FindStringSubmatch(content); m != nil {
+			side.CategoryWins, _ = strconv.Atoi(m[1])
+			side.CategoryLosses, _ = strconv.Atoi(m[2])
+			side.CategoryTies, _ = strconv.Atoi(m[3])
+			side.Total = float64(side.CategoryWins)
+			return side
+		}
+		if value, err := strconv.ParseFloat(strings.ReplaceAll(content, ",", ""), 64); err == nil {
+			numbers = append(numbers, value)
+		}
+	}
+	switch len(numbers) {
+	case 0:
+	case 1:
+		side.Points, side.Total = numbers[0], numbers[0]
+	default:
+		side.Points, side.Total = numbers[0], numbers[len(numbers)-1]
+		if len(numbers) == 3 {
+			side.Adjustment = numbers[1]
+		}
+	}
+	return side
+}
+
+// playoffSeed reads a seed shown with the team name, or 0
+func playoffSeed(content string) int {
+	content = strings.TrimSpace(content)
+	m := playoffSeedPrefix.FindStringSubmatch(content)
+	if m == nil {
+		m = playoffSeedSuffix.FindStringSubmatch(content)
+	}
+	if m == nil {
+		return 0
+	}
+	seed, _ := strconv.Atoi(strings.Join(m[1:], ""))
+	return seed
+}
+
+// isPlayoffBye reports whether a single-team row is marked as a bye
+func isPlayoffBye(cells []Cell) bool {
+	for _, cell := range cells {
+		if cell.TeamID == "" && strings.EqualFold(strings.TrimSpace(cell.Content), "bye") {
+			return true
+		}
+	}
+	return false
+}
+
+// playoffRoundName names a round by its table caption without the period, falling back to
+// the period
+func playoffRoundName(caption string, period int) string {
+	name := strings.Trim(playoffPeriodLabel.ReplaceAllString(caption, ""), " -–:,()")
+	if name == "" {
+		return fmt.Sprintf("Period %d", period)
+	}
+	return name
+}
+
+// containsInt reports whether n is in values
+func containsInt(values []int, n int) bool {
+	for _, v := range values {
+		if v == n {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package auth_client
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParsePlayoffBracketCategorySeries(t *testing.T) {
+	team := func(id, name string) Cell { return Cell{TeamID: id, Content: name} }
+	response := StandingsResponse{Responses: []Response{{Data: ResponseData{TableList: []Table{
+		{Caption: "Semifinals - Period 20", Rows: []Row{
+			{Cells: []Cell{team("a", "(1) Aces"), {Content: "BYE"}}},
+			{Cells: []Cell{team("b", "(4) Bats"), {Content: "6-3-1"}}},
+			{Cells: []Cell{team("c", "(3) Cats"), {Content: "3-6-1"}}},
+		}},
+		{Caption: "Semifinals - Period 21", Rows: []Row{
+			{Cells: []Cell{team("b", "Bats"), {Content: "4-5-1"}}},
+			{Cells: []Cell{team("c", "Cats"), {Content: "5-4-1"}}},
+		}},
+		{Caption: "Finals - Period 22", Rows: []Row{
+			{Cells: []Cell{team("a", "Aces"), {Content: "7-2-1"}, team("b", "Bats"), {Content: "2-7-1"}}},
+		}},
+	}}}}}
+	body, err := json.Marshal(response)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bracket, err := ParsePlayoffBracket(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bracket.Rounds) != 2 {
+		t.Fatalf("expected 2 rounds, got %d", len(bracket.Rounds))
+	}
+	semis := bracket.Rounds[0]
+	if semis.Name != "Semifinals" || len(semis.Periods) != 2 || len(semis.Series) != 2 {
+		t.Fatalf("unexpected semifinal round: %+v", semis)
+	}
+	// Split 1-1, decided by category wins 10-8
+	series := semis.Series[0]
+	if len(series.Games) != 2 || series.WinnerTeamID != "b" || series.Teams[0].Seed != 4 {
+		t.Errorf("unexpected series: %+v", series)
+	}
+	if bye := semis.Series[1]; !bye.Bye || bye.WinnerTeamID != "a" {
+		t.Errorf("expected a bye for a, got %+v", bye)
+	}
+	if bracket.ChampionTeamID != "a" || bracket.RunnerUpTeamID != "b" {
+		t.Errorf("expected a over b, got %s over %s", bracket.ChampionTeamID, bracket.RunnerUpTeamID)
+	}
+}
@@ -0,0 +1,284 @@
+package auth_client
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PlayoffPictureTeam is one team's position in the playoff race
+type PlayoffPictureTeam struct {
+	TeamID         string  `json:"teamId"`
+	Name           string  `json:"name"`
+	Seed           int     `json:"seed"`
+	Wins           int     `json:"wins"`
+	Losses         int     `json:"losses"`
+	Ties           int     `json:"ties"`
+	PointsFor      float64 `json:"pointsFor"`
+	GamesRemaining int     `json:"gamesRemaining"`
+	InPosition     bool    `json:"inPosition"` // True if the team currently holds a playoff seed
+
+	// MagicNumber is the number of additional wins that guarantees a playoff spot even if
+	// every rival wins out, comparing final win percentages as seeding does. Zero once the
+	// team has clinched.
+	MagicNumber int  `json:"magicNumber"`
+	Clinched    bool `json:"clinched"`
+	Eliminated  bool `json:"eliminated"`
+
+	// Tiebreaker names the rule that separated this team from the team seeded directly
+	// above it, or is empty if their records differ
+	Tiebreaker string `json:"tiebreaker,omitempty"`
+}
+
+// PlayoffPicture is the current seeding and clinching status for every team
+type PlayoffPicture struct {
+	PlayoffTeams int                  `json:"playoffTeams"`
+	Teams        []PlayoffPictureTeam `json:"teams"` // Ordered by seed
+}
+
+// Tiebreaker rule names, in the order they are applied
+const (
+	TiebreakerHeadToHead = "head-to-head"
+	TiebreakerDivision   = "division record"
+	TiebreakerPointsFor  = "points for"
+)
+
+// ComputePlayoffPicture seeds teams and works out magic numbers from standings and the
+// season schedule
+//
+// Teams are ordered by win percentage (ties count as half a win), then by win percentage in
+// the games between all the teams tied with them, then by division record, then by points
+// for. Matchups in periods after completedThrough count as games remaining, and magic numbers
+// and elimination compare the win percentages teams can finish with.
+//
+// Parameters:
+//   - standings: Current standings for every team
+//   - matchups: Every matchup of the regular season (e.g. from GetAllMatchups)
+//   - playoffTeams: Number of playoff spots
+//   - completedThrough: The last scoring period whose results are final
+func ComputePlayoffPicture(standings []TeamStanding, matchups []Matchup, playoffTeams int, completedThrough int) *PlayoffPicture {
+	remaining := make(map[string]int)
+	headToHead := make(map[[2]string]float64) // [team, opponent] -> wins (ties count half)
+	for _, m := range matchups {
+		away, home := m.AwayTeam.TeamID, m.HomeTeam.TeamID
+		if away == "" || home == "" {
+			continue
+		}
+		if m.ScoringPeriod > completedThrough {
+			remaining[away]++
+			remaining[home]++
+			continue
+		}
+		switch m.Winner() {
+		case away:
+			headToHead[[2]string{away, home}]++
+		case home:
+			headToHead[[2]string{home, away}]++
+		default:
+			headToHead[[2]string{away, home}] += 0.5
+			headToHead[[2]string{home, away}] += 0.5
+		}
+	}
+
+	teams := make([]TeamStanding, len(standings))
+	copy(teams, standings)
+
+	sort.SliceStable(teams, func(i, j int) bool {
+		return standingPct(teams[i]) > standingPct(teams[j])
+	})
+
+	// Teams level on win percentage are ordered by keys worked out once per team, starting with
+	// its record against the other tied teams, so the order holds however many are tied
+	keys := make(map[string]tiebreakKeys)
+	for start := 0; start < len(teams); {
+		end := start + 1
+		for end < len(teams) && standingPct(teams[end]) == standingPct(teams[start]) {
+			end++
+		}
+		tied := teams[start:end]
+		for _, team := range tied {
+			var won, played float64
+			for _, other := range tied {
+				if other.TeamID == team.TeamID {
+					continue
+				}
+				wins := headToHead[[2]string{team.TeamID, other.TeamID}]
+				won += wins
+				played += wins + headToHead[[2]string{other.TeamID, team.TeamID}]
+			}
+			key := tiebreakKeys{headToHead: 0.5, division: recordPct(team.DivRecord), pointsFor: team.PointsFor}
+			if played > 0 {
+				key.headToHead = won / played
+			}
+			keys[team.TeamID] = key
+		}
+		sort.SliceStable(tied, func(i, j int) bool {
+			diff, _ := keys[tied[i].TeamID].compare(keys[tied[j].TeamID])
+			return diff > 0
+		})
+		start = end
+	}
+
+	picture := &PlayoffPicture{PlayoffTeams: playoffTeams}
+	for i, team := range teams {
+		entry := PlayoffPictureTeam{
+			TeamID:         team.TeamID,
+			Name:           team.Name,
+			Seed:           i + 1,
+			Wins:           team.Wins,
+			Losses:         team.Losses,
+			Ties:           team.Ties,
+			PointsFor:      team.PointsFor,
+			GamesRemaining: remaining[team.TeamID],
+			InPosition:     i < playoffTeams,
+		}
+		if i > 0 && standingPct(teams[i-1]) == standingPct(team) {
+			_, entry.Tiebreaker = keys[teams[i-1].TeamID].compare(keys[team.TeamID])
+		}
+
+		// Rivals' best and worst possible final win percentages, highest first
+		var rivalMax, rivalMin []finalPct
+		for _, other := range teams {
+			if other.TeamID == team.TeamID {
+				continue
+			}
+			rivalMax = append(rivalMax, newFinalPct(other, remaining[other.TeamID], remaining[other.TeamID]))
+			rivalMin = append(rivalMin, newFinalPct(other, remaining[other.TeamID], 0))
+		}
+		sort.Slice(rivalMax, func(i, j int) bool { return rivalMax[j].less(rivalMax[i]) })
+		sort.Slice(rivalMin, func(i, j int) bool { return rivalMin[j].less(rivalMin[i]) })
+
+		if playoffTeams > 0 && playoffTeams <= len(rivalMax) {
+			// Finishing above the playoffTeams-th best rival's ceiling guarantees a spot
+			entry.MagicNumber = newFinalPct(team, remaining[team.TeamID], 0).winsToPass(rivalMax[playoffTeams-1])
+			entry.Clinched = entry.MagicNumber == 0
+			entry.Eliminated = newFinalPct(team, remaining[team.TeamID], remaining[team.TeamID]).less(rivalMin[playoffTeams-1])
+		} else if playoffTeams > len(rivalMax) {
+			entry.Clinched = true
+		}
+
+		picture.Teams = append(picture.Teams, entry)
+	}
+
+	return picture
+}
+
+// GetPlayoffPicture fetches standings and the schedule and computes the current playoff
+// seeding, tiebreakers and magic numbers
+//
+// Periods before the current period are treated as final.
+//
+// Parameters:
+//   - playoffTeams: Number of teams that make the playoffs
+func (c *Client) GetPlayoffPicture(playoffTeams int) (*PlayoffPicture, error) {
+	standings, err := c.GetStandings(WithStandingsView(StandingsViewAll))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get standings: %w", err)
+	}
+
+	matchups, err := c.GetAllMatchups()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get matchups: %w", err)
+	}
+
+	currentPeriod, err := c.GetCurrentPeriod()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current period: %w", err)
+	}
+
+	return ComputePlayoffPicture(standings.Teams, matchups.Matchups, playoffTeams, currentPeriod-1), nil
+}
+
+// tiebreakKeys are the values that order teams tied on win percentage, in the order the
+// tiebreakers apply
+type tiebreakKeys struct {
+	headToHead float64 // Win percentage against the other tied teams; 0.5 if they never met
+	division   float64
+	pointsFor  float64
+}
+
+// compare returns the rule that separates k from other (a positive result favours k)
+func (k tiebreakKeys) compare(other tiebreakKeys) (float64, string) {
+	if diff := k.headToHead - other.headToHead; diff != 0 {
+		return diff, TiebreakerHeadToHead
+	}
+	if diff := k.division - other.division; diff != 0 {
+		return diff, TiebreakerDivision
+	}
+	if diff := k.pointsFor - other.pointsFor; diff != 0 {
+		return diff, TiebreakerPointsFor
+	}
+	return 0, ""
+}
+
+// finalPct is a win percentage at the end of the season, kept as a fraction of half-wins over
+// half-games so that percentages compare exactly
+type finalPct struct {
+	halfWins  int
+	halfGames int
+}
+
+// newFinalPct returns the team's final win percentage if it wins won of its remaining games
+// and loses the rest
+func newFinalPct(t TeamStanding, remaining, won int) finalPct {
+	return finalPct{
+		halfWins:  2*(t.Wins+won) + t.Ties,
+		halfGames: 2 * (t.Wins + t.Losses + t.Ties + remaining),
+	}
+}
+
+// less reports whether p is a lower win percentage than other; no games counts as zero
+func (p finalPct) less(other finalPct) bool {
+	if other.halfGames == 0 {
+		return false
+	}
+	if p.halfGames == 0 {
+		return other.halfWins > 0
+	}
+	return p.halfWins*other.halfGames < other.halfWins*p.halfGames
+}
+
+// winsToPass returns how many more wins take p strictly above other, with the season's games
+// fixed at p's total
+func (p finalPct) winsToPass(other finalPct) int {
+	if other.halfGames == 0 {
+		return 0
+	}
+	// Each win adds two half-wins: solve (halfWins + 2x) * other.halfGames > other.halfWins * halfGames
+	gap := other.halfWins*p.halfGames - p.halfWins*other.halfGames
+	if gap < 0 {
+		return 0
+	}
+	return gap/(2*other.halfGames) + 1
+}
+
+// standingPct returns a team's win percentage with ties counted as half a win
+func standingPct(t TeamStanding) float64 {
+	games := t.Wins + t.Losses + t.Ties
+	if games == 0 {
+		return 0
+	}
+	return (float64(t.Wins) + 0.5*float64(t.Ties)) / float64(games)
+}
+
+// recordPct parses a "W-L" or "W-L-T" record and returns its win percentage
+func recordPct(record string) float64 {
+	parts := strings.Split(strings.TrimSpace(record), "-")
+	if len(parts) < 2 {
+		return 0
+	}
+	var counts [3]float64
+	for i := 0; i < len(parts) && i < 3; i++ {
+		n, err := strconv.Atoi(strings.TrimSpace(parts[i]))
+		if err != nil {
+			return 0
+		}
+		counts[i] = float64(n)
+	}
+	games := counts[0] + counts[1] + counts[2]
+	if games == 0 {
+		return 0
+	}
+	return (counts[0] + 0.5*counts[2]) / games
+}
@@ -0,0 +1,102 @@
+package auth_client
+
+import "testing"
+
+func TestComputePlayoffPicture(t *testing.T) {
+	standings := []TeamStanding{
+		{TeamID: "a", Name: "A", Wins: 8, Losses: 2, PointsFor: 1000},
+		{TeamID: "b", Name: "B", Wins: 6, Losses: 4, PointsFor: 900, DivRecord: "2-2"},
+		{TeamID: "c", Name: "C", Wins: 6, Losses: 4, PointsFor: 950, DivRecord: "3-1"},
+		{TeamID: "d", Name: "D", Wins: 0, Losses: 10, PointsFor: 700},
+	}
+	matchups := []Matchup{
+		// Completed: b and c split their meetings, so division record decides
+		matchup(1, "b", 100, "c", 90),
+		matchup(2, "c", 110, "b", 80),
+		// Two periods remaining
+		matchup(11, "a", 0, "b", 0),
+		matchup(11, "c", 0, "d", 0),
+		matchup(12, "a", 0, "c", 0),
+		matchup(12, "b", 0, "d", 0),
+	}
+
+	picture := ComputePlayoffPicture(standings, matchups, 2, 10)
+
+	wantOrder := []string{"a", "c", "b", "d"}
+	for i, id := range wantOrder {
+		if picture.Teams[i].TeamID != id {
+			t.Fatalf("seed %d: expected %s, got %s", i+1, id, picture.Teams[i].TeamID)
+		}
+	}
+
+	if got := picture.Teams[2].Tiebreaker; got != TiebreakerDivision {
+		t.Errorf("expected b to be separated from c by %q, got %q", TiebreakerDivision, got)
+	}
+
+	a := picture.Teams[0]
+	if a.GamesRemaining != 2 {
+		t.Errorf("expected 2 games remaining for a, got %d", a.GamesRemaining)
+	}
+	// Second-best rival ceiling is 8 (b or c winning out), so a needs 9 wins
+	if a.MagicNumber != 1 || a.Clinched {
+		t.Errorf("unexpected magic number for a: %d (clinched=%v)", a.MagicNumber, a.Clinched)
+	}
+
+	d := picture.Teams[3]
+	if !d.Eliminated {
+		t.Errorf("expected d to be eliminated")
+	}
+}
+
+func TestPlayoffPictureThreeWayTie(t *testing.T) {
+	standings := []TeamStanding{
+		{TeamID: "b", Wins: 5, Losses: 5, PointsFor: 900},
+		{TeamID: "c", Wins: 5, Losses: 5, PointsFor: 800},
+		{TeamID: "a", Wins: 5, Losses: 5, PointsFor: 700},
+	}
+	// b beat c, c beat a, and a beat b twice: against the tied teams a is 2-1, c is 1-1, and
+	// b is 1-2, though b won its only game with c
+	matchups := []Matchup{
+		matchup(1, "b", 100, "c", 90),
+		matchup(2, "c", 100, "a", 90),
+		matchup(3, "a", 100, "b", 90),
+		matchup(4, "b", 90, "a", 100),
+	}
+
+	picture := ComputePlayoffPicture(standings, matchups, 2, 10)
+
+	for i, id := range []string{"a", "c", "b"} {
+		if picture.Teams[i].TeamID != id {
+			t.Fatalf("seed %d: expected %s, got %s", i+1, id, picture.Teams[i].TeamID)
+		}
+		if i > 0 && picture.Teams[i].Tiebreaker != TiebreakerHeadToHead {
+			t.Errorf("seed %d: tiebreaker %q, want %q", i+1, picture.Teams[i].Tiebreaker, TiebreakerHeadToHead)
+		}
+	}
+}
+
+func TestPlayoffPictureMagicNumberCountsTies(t *testing.T) {
+	standings := []TeamStanding{
+		{TeamID: "a", Name: "A", Wins: 7, Losses: 1, Ties: 2},
+		{TeamID: "b", Name: "B", Wins: 8, Losses: 2},
+		{TeamID: "c", Name: "C", Wins: 7, Losses: 3},
+	}
+	matchups := []Matchup{
+		matchup(11, "a", 0, "b", 0),
+		matchup(11, "c", 0, "a", 0),
+		matchup(12, "b", 0, "c", 0),
+		matchup(12, "a", 0, "c", 0),
+		matchup(13, "b", 0, "c", 0),
+		matchup(13, "b", 0, "a", 0),
+	}
+
+	picture := ComputePlayoffPicture(standings, matchups, 2, 10)
+
+	// c can finish 11-3 (.786). a's two ties count half a win each, so winning all four of its
+	// remaining games (11-1-2, .857) passes c, where comparing raw wins would need a fifth
+	for _, team := range picture.Teams {
+		if team.TeamID == "a" && (team.MagicNumber != 4 || team.Eliminated) {
+			t.Errorf("expected a's magic number to be 4, got %d (eliminated=%v)", team.MagicNumber, team.Eliminated)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package auth_client
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// GetLeaguePollsRequest represents the request payload for getLeaguePolls
+type GetLeaguePollsRequest struct {
+	LeagueID string `json:"leagueId"`
+}
+
+// GetLeaguePollsRaw fetches the raw list of the league's polls
+func (c *Client) GetLeaguePollsRaw() (*models.PollResponse, error) {
+	return c.postPolls("getLeaguePolls", GetLeaguePollsRequest{LeagueID: c.LeagueID})
+}
+
+// GetLeaguePolls fetches the league's polls with their current results. Polls are read-only
+// here; creating polls and voting aren't supported until the web app's requests have been
+// captured.
+func (c *Client) GetLeaguePolls() ([]models.Poll, error) {
+	raw, err := c.GetLeaguePollsRaw()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get raw polls: %w", err)
+	}
+	if len(raw.Responses) == 0 {
+		return nil, fmt.Errorf("no responses in poll response")
+	}
+	return parsePolls(raw.Responses[0].Data), nil
+}
+
+// GetLeaguePoll fetches one poll and its results
+//
+// Parameters:
+//   - pollID: The poll ID
+func (c *Client) GetLeaguePoll(pollID string) (*models.Poll, error) {
+	polls, err := c.GetLeaguePolls()
+	if err != nil {
+		return nil, err
+	}
+	for i := range polls {
+		if polls[i].ID == pollID {
+			return &polls[i], nil
+		}
+	}
+	return nil, fmt.Errorf("poll %s not found", pollID)
+}
+
+// postPolls sends a poll request and decodes the response
+func (c *Client) postPolls(method string, data interface{}) (*models.PollResponse, error) {
+	body, err := c.postFxpa(fxpaRequest{
+		Msgs: []FantraxMessage{{Method: method, Data: data}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var response models.PollResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	c.checkSchema(method, body, &response)
+
+	return &response, nil
+}
+
+// parsePolls converts raw polls
+func parsePolls(data models.PollData) []models.Poll {
+	polls := make([]models.Poll, 0, len(data.Polls))
+	for _, raw := range data.Polls {
+		poll := models.Poll{
+			ID:            raw.ID,
+			Question:      stripHTML(raw.Question),
+			Description:   stripHTML(raw.Description),
+			Closed:        raw.Closed,
+			CreatorTeamID: raw.CreatorTeamID,
+			AllowMultiple: raw.AllowMultiple,
+			Anonymous:     raw.Anonymous,
+			MyVotes:       raw.MyChoiceIDs,
+		}
+		if raw.CloseDate > 0 {
+			poll.Closes = time.UnixMilli(raw.CloseDate)
+		}
+		if raw.CreatedDate > 0 {
+			poll.Created = time.UnixMilli(raw.CreatedDate)
+		}
+		for _, choice := range raw.Choices {
+			poll.Choices = append(poll.Choices, models.PollChoice{
+				ID:           choice.ID,
+				Text:         stripHTML(choice.Text),
+				Votes:        choice.NumVotes,
+				VoterTeamIDs: choice.VoterTeamIDs,
+			})
+		}
+		polls = append(polls, poll)
+	}
+	return polls
+}
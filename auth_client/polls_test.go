@@ -0,0 +1,34 @@
+package auth_client
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestParsePolls(t *testing.T) {
+	body := `{"responses":[{"data":{"polls":[
+		{"id":"poll1","question":"Veto the Smith trade?","closeDate":1750000000000,
+		 "choices":[{"id":"yes","text":"Yes","numVotes":4},{"id":"no","text":"No","numVotes":6}]},
+		{"id":"poll2","question":"Add a DH slot?","allowMultiple":true,
+		 "choices":[{"id":"a","text":"Yes","numVotes":3},{"id":"b","text":"No","numVotes":3},{"id":"c","text":"Later","numVotes":1}]}
+	]}}]}`
+	var raw models.PollResponse
+	if err := json.Unmarshal([]byte(body), &raw); err != nil {
+		t.Fatal(err)
+	}
+	polls := parsePolls(raw.Responses[0].Data)
+
+	veto := polls[0]
+	if veto.TotalVotes() != 10 || veto.Results()[0].ID != "no" || len(veto.Leaders()) != 1 {
+		t.Errorf("unexpected veto results: %+v", veto)
+	}
+	if veto.Open(time.UnixMilli(1750000000000).Add(time.Minute)) {
+		t.Error("expected the veto poll to be closed after its close date")
+	}
+	if len(polls[1].Leaders()) != 2 {
+		t.Errorf("expected a tie: %+v", polls[1].Leaders())
+	}
+}
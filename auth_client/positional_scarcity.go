@@ -0,0 +1,174 @@
+package auth_client
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// Positional scarcity tiers, set from the FP/G of the players rostered at each position
+const (
+	TierElite       = "elite"       // At or above the 75th percentile of rostered players
+	TierStarter     = "starter"     // At or above the rostered median
+	TierReplacement = "replacement" // At or above the 25th percentile
+)
+
+// ScarcityOptions configures ComputePositionalScarcity
+type ScarcityOptions struct {
+	TopAvailable     int     // Best available players listed per position (default 5)
+	MinFantasyPoints float64 // Ignore players with fewer total points, whose FP/G is noise
+}
+
+// PositionScarcity is the available talent at one position
+type PositionScarcity struct {
+	Position string `json:"position"` // Position short name (e.g. "C", "SP")
+
+	Rostered  int `json:"rostered"`
+	Available int `json:"available"` // Free agents and waiver players
+
+	// FP/G thresholds from the rostered players at the position
+	EliteFPG       float64 `json:"eliteFpg"`
+	StarterFPG     float64 `json:"starterFpg"`
+	ReplacementFPG float64 `json:"replacementFpg"`
+
+	// Available players at or above each tier
+	AvailableElite       int `json:"availableElite"`
+	AvailableStarter     int `json:"availableStarter"`
+	AvailableReplacement int `json:"availableReplacement"`
+
+	BestAvailableFPG float64             `json:"bestAvailableFpg"`
+	BestAvailable    []models.PoolPlayer `json:"bestAvailable"`
+
+	// Gap is StarterFPG minus BestAvailableFPG: how far the best free agent falls short of a
+	// typical rostered starter. Larger is scarcer.
+	Gap    float64 `json:"gap"`
+	Scarce bool    `json:"scarce"` // No starter-tier player is available
+}
+
+// Tier returns the tier a FP/G falls in at this position, or "" below replacement level
+func (s PositionScarcity) Tier(fpg float64) string {
+	switch {
+	case fpg >= s.EliteFPG:
+		return TierElite
+	case fpg >= s.StarterFPG:
+		return TierStarter
+	case fpg >= s.ReplacementFPG:
+		return TierReplacement
+	}
+	return ""
+}
+
+// ComputePositionalScarcity measures the available talent at each position against the
+// players already rostered there, scarcest first
+//
+// Tiers come from the league itself: the FP/G of rostered players at a position sets the
+// elite, starter and replacement thresholds, and available players are counted against them.
+// Players count at every position they are eligible for; utility slots, and positions nobody
+// rosters, are left out.
+//
+// Parameters:
+//   - players: The whole player pool, rostered and available (GetPlayerPool with StatusFilterAll)
+//   - opts: Listing and noise options
+func ComputePositionalScarcity(players []models.PoolPlayer, opts ScarcityOptions) []PositionScarcity {
+	if opts.TopAvailable == 0 {
+		opts.TopAvailable = 5
+	}
+
+	rostered := make(map[string][]float64)
+	available := make(map[string][]models.PoolPlayer)
+	for _, player := range players {
+		if player.FantasyPoints < opts.MinFantasyPoints {
+			continue
+		}
+		for _, position := range scarcityPositions(player) {
+			if player.FantasyTeamID != "" {
+				rostered[position] = append(rostered[position], player.FantasyPointsPerG)
+			} else {
+				available[position] = append(available[position], player)
+			}
+		}
+	}
+
+	var report []PositionScarcity
+	for position, fpgs := range rostered {
+		sort.Float64s(fpgs)
+		s := PositionScarcity{
+			Position:       position,
+			Rostered:       len(fpgs),
+			EliteFPG:       percentile(fpgs, 0.75),
+			StarterFPG:     percentile(fpgs, 0.5),
+			ReplacementFPG: percentile(fpgs, 0.25),
+		}
+
+		pool := available[position]
+		sort.Slice(pool, func(i, j int) bool { return pool[i].FantasyPointsPerG > pool[j].FantasyPointsPerG })
+		s.Available = len(pool)
+		for _, player := range pool {
+			switch s.Tier(player.FantasyPointsPerG) {
+			case TierElite:
+				s.AvailableElite++
+				fallthrough
+			case TierStarter:
+				s.AvailableStarter++
+				fallthrough
+			case TierReplacement:
+				s.AvailableReplacement++
+			}
+		}
+		if len(pool) > 0 {
+			s.BestAvailableFPG = pool[0].FantasyPointsPerG
+		}
+		if len(pool) > opts.TopAvailable {
+			pool = pool[:opts.TopAvailable]
+		}
+		s.BestAvailable = pool
+		s.Gap = s.StarterFPG - s.BestAvailableFPG
+		s.Scarce = s.AvailableStarter == 0
+		report = append(report, s)
+	}
+
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].Gap != report[j].Gap {
+			return report[i].Gap > report[j].Gap
+		}
+		return report[i].Position < report[j].Position
+	})
+	return report
+}
+
+// GetPositionalScarcity fetches the player pool and computes the positional scarcity report
+func (c *Client) GetPositionalScarcity(opts ScarcityOptions) ([]PositionScarcity, error) {
+	players, err := c.GetPlayerPool(WithStatusFilter(StatusFilterAll))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player pool: %w", err)
+	}
+	return ComputePositionalScarcity(players, opts), nil
+}
+
+// scarcityPositions returns a pool player's eligible positions, without utility slots
+func scarcityPositions(player models.PoolPlayer) []string {
+	var positions []string
+	for _, pos := range strings.Split(stripHTML(player.PosShortNames), ",") {
+		pos = strings.TrimSpace(pos)
+		if pos == "" || strings.HasPrefix(strings.ToUpper(pos), "UT") {
+			continue
+		}
+		positions = append(positions, pos)
+	}
+	return positions
+}
+
+// percentile returns the value at fraction p of sorted values, interpolating between neighbors
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := p * float64(len(sorted)-1)
+	lower := int(rank)
+	if lower+1 >= len(sorted) {
+		return sorted[lower]
+	}
+	return sorted[lower] + (rank-float64(lower))*(sorted[lower+1]-sorted[lower])
+}
@@ -0,0 +1,36 @@
+package auth_client
+
+import (
+	"testing"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestComputePositionalScarcity(t *testing.T) {
+	player := func(id, positions, teamID string, fpg float64) models.PoolPlayer {
+		return models.PoolPlayer{PlayerID: id, PosShortNames: positions, FantasyTeamID: teamID, FantasyPointsPerG: fpg, FantasyPoints: fpg * 10}
+	}
+	players := []models.PoolPlayer{
+		// Catchers: rostered 2-5, best free agent 1.5
+		player("c1", "C,UT", "t1", 2), player("c2", "C", "t2", 3), player("c3", "C", "t3", 4), player("c4", "C", "t4", 5),
+		player("c5", "C", "", 1.5), player("c6", "C", "", 1),
+		// Outfield: rostered 2-5, free agents reach the elite tier
+		player("o1", "OF", "t1", 2), player("o2", "OF", "t2", 3), player("o3", "OF", "t3", 4), player("o4", "OF,UT", "t4", 5),
+		player("o5", "OF", "", 4.8), player("o6", "OF", "", 3.6), player("o7", "OF", "", 0.2),
+	}
+
+	report := ComputePositionalScarcity(players, ScarcityOptions{TopAvailable: 1})
+	if len(report) != 2 || report[0].Position != "C" || report[1].Position != "OF" {
+		t.Fatalf("expected C then OF, got %+v", report)
+	}
+	catchers, outfield := report[0], report[1]
+	if !catchers.Scarce || catchers.StarterFPG != 3.5 || catchers.Gap != 2 || catchers.AvailableReplacement != 0 {
+		t.Errorf("unexpected catcher scarcity: %+v", catchers)
+	}
+	if outfield.Scarce || outfield.AvailableElite != 1 || outfield.AvailableStarter != 2 || outfield.Available != 3 {
+		t.Errorf("unexpected outfield scarcity: %+v", outfield)
+	}
+	if len(outfield.BestAvailable) != 1 || outfield.BestAvailable[0].PlayerID != "o5" {
+		t.Errorf("expected o5 as the best available outfielder, got %+v", outfield.BestAvailable)
+	}
+}
@@ -0,0 +1,237 @@
+package auth_client
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// ScoreRecord is a single team score from one matchup
+type ScoreRecord struct {
+	ScoringPeriod int     `json:"scoringPeriod"`
+	Date          string  `json:"date"`
+	TeamID        string  `json:"teamId"`
+	OpponentID    string  `json:"opponentId"`
+	Score         float64 `json:"score"`
+	OpponentScore float64 `json:"opponentScore"`
+	Margin        float64 `json:"margin"` // Score minus OpponentScore
+}
+
+// StreakRecord is a run of consecutive matchup wins by one team
+type StreakRecord struct {
+	TeamID      string `json:"teamId"`
+	Length      int    `json:"length"`
+	StartPeriod int    `json:"startPeriod"`
+	EndPeriod   int    `json:"endPeriod"`
+}
+
+// BidRecord is a single free agent claim and its winning bid
+type BidRecord struct {
+	TeamID     string    `json:"teamId"`
+	TeamName   string    `json:"teamName"`
+	PlayerID   string    `json:"playerId"`
+	PlayerName string    `json:"playerName"`
+	Amount     float64   `json:"amount"`
+	Period     int       `json:"period"`
+	Date       time.Time `json:"date"`
+}
+
+// RecordBook tracks league records and can be updated incrementally as periods complete
+//
+// The book is JSON-serializable: save it after each update and load it again before the next
+// one, and only newly completed periods and newly processed claims are examined.
+type RecordBook struct {
+	HighestScore     *ScoreRecord  `json:"highestScore,omitempty"`
+	BiggestBlowout   *ScoreRecord  `json:"biggestBlowout,omitempty"`
+	LongestWinStreak *StreakRecord `json:"longestWinStreak,omitempty"`
+	LargestBid       *BidRecord    `json:"largestBid,omitempty"`
+
+	// Incremental update state
+	LastProcessedPeriod int                     `json:"lastProcessedPeriod"`
+	LastClaimDate       time.Time               `json:"lastClaimDate"`
+	CurrentStreaks      map[string]StreakRecord `json:"currentStreaks"` // Keyed by team ID
+}
+
+// NewRecordBook creates an empty record book
+func NewRecordBook() *RecordBook {
+	return &RecordBook{
+		CurrentStreaks: make(map[string]StreakRecord),
+	}
+}
+
+// LoadRecordBook reads a record book previously written with Save
+func LoadRecordBook(path string) (*RecordBook, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read record book: %w", err)
+	}
+
+	book := NewRecordBook()
+	if err := json.Unmarshal(data, book); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal record book: %w", err)
+	}
+	if book.CurrentStreaks == nil {
+		book.CurrentStreaks = make(map[string]StreakRecord)
+	}
+	return book, nil
+}
+
+// Save writes the record book to a JSON file
+func (b *RecordBook) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal record book: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write record book: %w", err)
+	}
+	return nil
+}
+
+// UpdateFromMatchups processes matchups from periods after LastProcessedPeriod up to and
+// including throughPeriod
+//
+// Only pass the number of the last completed period as throughPeriod; a period still in
+// progress would otherwise be recorded with partial scores and never revisited.
+func (b *RecordBook) UpdateFromMatchups(matchups []Matchup, throughPeriod int) {
+	byPeriod := make(map[int][]Matchup)
+	var periods []int
+	for _, m := range matchups {
+		if m.ScoringPeriod <= b.LastProcessedPeriod || m.ScoringPeriod > throughPeriod {
+			continue
+		}
+		if _, seen := byPeriod[m.ScoringPeriod]; !seen {
+			periods = append(periods, m.ScoringPeriod)
+		}
+		byPeriod[m.ScoringPeriod] = append(byPeriod[m.ScoringPeriod], m)
+	}
+	sort.Ints(periods)
+
+	for _, period := range periods {
+		for _, m := range byPeriod[period] {
+			b.recordMatchup(m)
+		}
+		b.LastProcessedPeriod = period
+	}
+}
+
+// recordMatchup updates score, blowout and streak records for one matchup
+func (b *RecordBook) recordMatchup(m Matchup) {
+	for _, teamID := range []string{m.AwayTeam.TeamID, m.HomeTeam.TeamID} {
+		if teamID == "" {
+			continue
+		}
+		team, opponent := matchSides(m, teamID)
+		score := ScoreRecord{
+			ScoringPeriod: m.ScoringPeriod,
+			Date:          m.Date,
+			TeamID:        teamID,
+			OpponentID:    opponent.TeamID,
+			Score:         team.Total,
+			OpponentScore: opponent.Total,
+			Margin:        team.Total - opponent.Total,
+		}
+
+		if b.HighestScore == nil || score.Score > b.HighestScore.Score {
+			s := score
+			b.HighestScore = &s
+		}
+		if score.Margin > 0 && (b.BiggestBlowout == nil || score.Margin > b.BiggestBlowout.Margin) {
+			s := score
+			b.BiggestBlowout = &s
+		}
+
+		if score.Margin <= 0 {
+			delete(b.CurrentStreaks, teamID)
+			continue
+		}
+
+		streak, ok := b.CurrentStreaks[teamID]
+		if !ok {
+			streak = StreakRecord{TeamID: teamID, StartPeriod: m.ScoringPeriod}
+		}
+		streak.Length++
+		streak.EndPeriod = m.ScoringPeriod
+		b.CurrentStreaks[teamID] = streak
+
+		if b.LongestWinStreak == nil || streak.Length > b.LongestWinStreak.Length {
+			s := streak
+			b.LongestWinStreak = &s
+		}
+	}
+}
+
+// UpdateFromTransactions processes executed claims processed after LastClaimDate and
+// records the largest winning bid
+func (b *RecordBook) UpdateFromTransactions(transactions []models.Transaction) {
+	latest := b.LastClaimDate
+	for _, tx := range transactions {
+		if tx.Type != "CLAIM" || !tx.Executed || !tx.ProcessedDate.After(b.LastClaimDate) {
+			continue
+		}
+		if tx.ProcessedDate.After(latest) {
+			latest = tx.ProcessedDate
+		}
+
+		amount, ok := parseBidAmount(tx.BidAmount)
+		if !ok {
+			continue
+		}
+		if b.LargestBid == nil || amount > b.LargestBid.Amount {
+			b.LargestBid = &BidRecord{
+				TeamID:     tx.TeamID,
+				TeamName:   tx.TeamName,
+				PlayerID:   tx.PlayerID,
+				PlayerName: tx.PlayerName,
+				Amount:     amount,
+				Period:     tx.Period,
+				Date:       tx.ProcessedDate,
+			}
+		}
+	}
+	b.LastClaimDate = latest
+}
+
+// parseBidAmount parses a claim bid such as "12" or "$12.50"
+func parseBidAmount(bid string) (float64, bool) {
+	bid = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(bid), "$"))
+	if bid == "" {
+		return 0, false
+	}
+	amount, err := strconv.ParseFloat(bid, 64)
+	if err != nil {
+		return 0, false
+	}
+	return amount, true
+}
+
+// UpdateRecordBook fetches the season's matchups and claims and applies everything that
+// has completed since the book was last updated
+//
+// Periods up to (but not including) the current period are treated as complete.
+func (c *Client) UpdateRecordBook(book *RecordBook) error {
+	currentPeriod, err := c.GetCurrentPeriod()
+	if err != nil {
+		return fmt.Errorf("failed to get current period: %w", err)
+	}
+
+	matchups, err := c.GetAllMatchups()
+	if err != nil {
+		return fmt.Errorf("failed to get matchups: %w", err)
+	}
+	book.UpdateFromMatchups(matchups.Matchups, currentPeriod-1)
+
+	transactions, err := c.GetAllTransactions()
+	if err != nil {
+		return fmt.Errorf("failed to get transactions: %w", err)
+	}
+	book.UpdateFromTransactions(transactions)
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package auth_client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func matchup(period int, away string, awayTotal float64, home string, homeTotal float64) Matchup {
+	return Matchup{
+		ScoringPeriod: period,
+		AwayTeam:      MatchTeam{TeamID: away, Total: awayTotal},
+		HomeTeam:      MatchTeam{TeamID: home, Total: homeTotal},
+	}
+}
+
+func TestRecordBookIncrementalUpdates(t *testing.T) {
+	matchups := []Matchup{
+		matchup(1, "a", 100, "b", 90),
+		matchup(2, "a", 120, "b", 60),
+		matchup(3, "b", 150, "a", 140),
+		matchup(4, "a", 130, "b", 80),
+	}
+
+	book := NewRecordBook()
+	book.UpdateFromMatchups(matchups, 2)
+
+	if book.LastProcessedPeriod != 2 {
+		t.Fatalf("expected last processed period 2, got %d", book.LastProcessedPeriod)
+	}
+	if book.LongestWinStreak == nil || book.LongestWinStreak.TeamID != "a" || book.LongestWinStreak.Length != 2 {
+		t.Fatalf("unexpected win streak after period 2: %+v", book.LongestWinStreak)
+	}
+
+	// Re-applying the same matchups must not double count earlier periods
+	book.UpdateFromMatchups(matchups, 4)
+
+	if book.HighestScore == nil || book.HighestScore.Score != 150 || book.HighestScore.TeamID != "b" {
+		t.Errorf("unexpected highest score: %+v", book.HighestScore)
+	}
+	if book.BiggestBlowout == nil || book.BiggestBlowout.Margin != 60 || book.BiggestBlowout.ScoringPeriod != 2 {
+		t.Errorf("unexpected biggest blowout: %+v", book.BiggestBlowout)
+	}
+	if book.LongestWinStreak.Length != 2 || book.LongestWinStreak.EndPeriod != 2 {
+		t.Errorf("unexpected longest streak: %+v", book.LongestWinStreak)
+	}
+	if streak := book.CurrentStreaks["a"]; streak.Length != 1 || streak.StartPeriod != 4 {
+		t.Errorf("unexpected current streak for team a: %+v", streak)
+	}
+}
+
+func TestRecordBookLargestBid(t *testing.T) {
+	day := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	transactions := []models.Transaction{
+		{Type: "CLAIM", Executed: true, PlayerID: "p1", BidAmount: "15", ProcessedDate: day},
+		{Type: "CLAIM", Executed: true, PlayerID: "p2", BidAmount: "$42.50", ProcessedDate: day.Add(time.Hour)},
+		{Type: "CLAIM", Executed: false, PlayerID: "p3", BidAmount: "99", ProcessedDate: day.Add(2 * time.Hour)},
+		{Type: "DROP", Executed: true, PlayerID: "p4", ProcessedDate: day.Add(3 * time.Hour)},
+	}
+
+	book := NewRecordBook()
+	book.UpdateFromTransactions(transactions)
+
+	if book.LargestBid == nil || book.LargestBid.PlayerID != "p2" || book.LargestBid.Amount != 42.5 {
+		t.Fatalf("unexpected largest bid: %+v", book.LargestBid)
+	}
+	if !book.LastClaimDate.Equal(day.Add(time.Hour)) {
+		t.Errorf("unexpected last claim date: %v", book.LastClaimDate)
+	}
+}
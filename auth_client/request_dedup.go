@@ -0,0 +1,72 @@
+package auth_client
+
+import (
+	"strings"
+	"sync"
+)
+
+// requestGroup deduplicates concurrent identical requests. Callers that ask for a key while a
+// request for it is in flight wait for that request and share its result instead of sending
+// their own. Nothing is kept once the request finishes, so later calls fetch again (or hit
+// the response cache).
+type requestGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+// flightCall is a request in flight
+type flightCall struct {
+	done    chan struct{}
+	body    []byte
+	err     error
+	waiting int // Callers sharing the result, not counting the one sending the request
+}
+
+// do runs fetch for key unless a call for key is already running, in which case it waits for
+// that call. Callers that waited get their own copy of the body.
+func (g *requestGroup) do(key string, fetch func() ([]byte, error)) ([]byte, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+	if call, ok := g.calls[key]; ok {
+		call.waiting++
+		g.mu.Unlock()
+		<-call.done
+		if call.err != nil {
+			return nil, call.err
+		}
+		return append([]byte(nil), call.body...), nil
+	}
+	call := &flightCall{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	call.body, call.err = fetch()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	close(call.done)
+	return call.body, call.err
+}
+
+// dedupRead runs a read request through the client's request group, unless
+// DisableRequestDedup is set or the client wasn't made by NewClient
+func (c *Client) dedupRead(key string, fetch func() ([]byte, error)) ([]byte, error) {
+	if c.DisableRequestDedup || c.inFlight == nil {
+		return fetch()
+	}
+	return c.inFlight.do(key, fetch)
+}
+
+// isReadOnlyFxpa reports whether every message of an fxpa request only reads data. Only
+// those are deduplicated: two identical changes sent at once are left for Fantrax to judge.
+func isReadOnlyFxpa(r fxpaRequest) bool {
+	for _, msg := range r.Msgs {
+		if !strings.HasPrefix(msg.Method, "get") {
+			return false
+		}
+	}
+	return len(r.Msgs) > 0
+}
@@ -0,0 +1,78 @@
+package auth_client
+
+import (
+	"io"
+	"net/http"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// blockingTransport counts requests and holds each one until release is closed
+type blockingTransport struct {
+	mu       sync.Mutex
+	requests int
+	started  chan struct{}
+	release  chan struct{}
+}
+
+func (t *blockingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	t.requests++
+	t.mu.Unlock()
+	t.started <- struct{}{}
+	<-t.release
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"responses":[]}`))}, nil
+}
+
+func TestConcurrentReadsShareRequest(t *testing.T) {
+	transport := &blockingTransport{started: make(chan struct{}, 10), release: make(chan struct{})}
+	c := &Client{Client: http.Client{Transport: transport}, Cookies: "test", inFlight: &requestGroup{}}
+	read := fxpaRequest{Msgs: []FantraxMessage{{Method: "getStandings", Data: map[string]string{"leagueId": "x"}}}}
+
+	const callers = 4
+	var wg sync.WaitGroup
+	bodies := make([]string, callers)
+	call := func(i int) {
+		defer wg.Done()
+		body, err := c.postFxpa(read)
+		if err != nil {
+			t.Error(err)
+		}
+		bodies[i] = string(body)
+	}
+
+	wg.Add(callers)
+	go call(0)
+	<-transport.started
+	for i := 1; i < callers; i++ {
+		go call(i)
+	}
+	// Hold the first request until every other caller is waiting on it
+	for waiting := 0; waiting < callers-1; {
+		c.inFlight.mu.Lock()
+		for _, flight := range c.inFlight.calls {
+			waiting = flight.waiting
+		}
+		c.inFlight.mu.Unlock()
+		runtime.Gosched()
+	}
+	close(transport.release)
+	wg.Wait()
+
+	if transport.requests != 1 {
+		t.Errorf("sent %d requests, want 1", transport.requests)
+	}
+	for i, body := range bodies {
+		if body != `{"responses":[]}` {
+			t.Errorf("caller %d got %q", i, body)
+		}
+	}
+
+	// Changes are never merged
+	write := fxpaRequest{Msgs: []FantraxMessage{{Method: "confirmOrExecuteTeamRosterChanges"}}}
+	if !isReadOnlyFxpa(read) || isReadOnlyFxpa(write) {
+		t.Error("isReadOnlyFxpa misclassified a request")
+	}
+}
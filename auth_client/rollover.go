@@ -0,0 +1,375 @@
+package auth_client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pmurley/go-fantrax"
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// Rollover step names, in the order RolloverSeason runs them
+const (
+	RolloverStepExport   = "export"
+	RolloverStepHistory  = "history"
+	RolloverStepKeepers  = "keepers"
+	RolloverStepSchedule = "schedule"
+	RolloverStepVerify   = "verify"
+)
+
+// rolloverSteps lists every step in execution order
+var rolloverSteps = []string{
+	RolloverStepExport,
+	RolloverStepHistory,
+	RolloverStepKeepers,
+	RolloverStepSchedule,
+	RolloverStepVerify,
+}
+
+// RolloverConfig configures RolloverSeason
+//
+// The client RolloverSeason is called on must target the new season's league.
+type RolloverConfig struct {
+	PreviousLeagueID string // League ID of the season that just ended
+	PreviousYear     int    // Year of the season that just ended (used in history; 0 takes it from standings)
+
+	// ExportDir receives the previous season's final state and the running history file
+	ExportDir string
+
+	// CheckpointFile records completed steps so an interrupted rollover can be resumed.
+	// Defaults to "rollover-checkpoint.json" inside ExportDir.
+	CheckpointFile string
+
+	// Keepers and KeeperOptions drive the keeper step; leave Keepers nil to skip it
+	Keepers       KeeperSelections
+	KeeperOptions KeeperOptions
+
+	// Schedule maps period number to that period's matchups; leave nil to skip the upload
+	Schedule map[int][]models.MatchupPair
+
+	// FailOnSettingsChange makes the verify step return an error when roster or scoring
+	// settings differ from the previous season instead of only reporting the differences
+	FailOnSettingsChange bool
+}
+
+// RolloverCheckpoint is the persisted progress of a rollover
+type RolloverCheckpoint struct {
+	CompletedSteps   []string `json:"completedSteps"`
+	SettingsChanges  []string `json:"settingsChanges,omitempty"`
+	UploadedPeriods  []int    `json:"uploadedPeriods,omitempty"`
+	KeeperDropsTotal int      `json:"keeperDropsTotal,omitempty"`
+
+	// KeepersPreviewed is set when the keeper step ran with KeeperOptions.DryRun. The step
+	// is left out of CompletedSteps so a later run without DryRun still releases players.
+	KeepersPreviewed bool `json:"keepersPreviewed,omitempty"`
+}
+
+// done returns true if the step has already completed
+func (cp *RolloverCheckpoint) done(step string) bool {
+	for _, s := range cp.CompletedSteps {
+		if s == step {
+			return true
+		}
+	}
+	return false
+}
+
+// RolloverSeason runs the end-of-season rollover as one resumable operation
+//
+// Steps run in order: export the previous season's final state, append it to the league
+// history file, release non-keepers in the new league, upload the new schedule, and compare
+// the new league's roster and scoring settings against the previous season. Progress is
+// written to the checkpoint file after every step (and after every uploaded period), so
+// calling RolloverSeason again with the same config resumes where it stopped.
+//
+// Returns the checkpoint describing what has been done, or an error from the failing step.
+func (c *Client) RolloverSeason(config RolloverConfig) (*RolloverCheckpoint, error) {
+	if config.PreviousLeagueID == "" || config.ExportDir == "" {
+		return nil, fmt.Errorf("PreviousLeagueID and ExportDir are required")
+	}
+	if err := os.MkdirAll(config.ExportDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create export directory: %w", err)
+	}
+	if config.CheckpointFile == "" {
+		config.CheckpointFile = filepath.Join(config.ExportDir, "rollover-checkpoint.json")
+	}
+
+	checkpoint, err := loadRolloverCheckpoint(config.CheckpointFile)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := c.forLeague(config.PreviousLeagueID)
+
+	for _, step := range rolloverSteps {
+		if checkpoint.done(step) {
+			continue
+		}
+
+		var stepErr error
+		switch step {
+		case RolloverStepExport:
+			stepErr = previous.exportSeasonState(config.ExportDir)
+		case RolloverStepHistory:
+			stepErr = previous.appendSeasonHistory(config.ExportDir, config.PreviousYear)
+		case RolloverStepKeepers:
+			if config.Keepers != nil {
+				var plan *KeeperPlan
+				plan, stepErr = c.ProcessKeepers(config.Keepers, config.KeeperOptions)
+				if plan != nil {
+					checkpoint.KeeperDropsTotal = plan.TotalDrops()
+				}
+			}
+		case RolloverStepSchedule:
+			stepErr = c.uploadRolloverSchedule(config, checkpoint)
+		case RolloverStepVerify:
+			checkpoint.SettingsChanges, stepErr = c.compareLeagueSettings(config.PreviousLeagueID)
+			if stepErr == nil && config.FailOnSettingsChange && len(checkpoint.SettingsChanges) > 0 {
+				stepErr = fmt.Errorf("%d settings changed since last season", len(checkpoint.SettingsChanges))
+			}
+		}
+
+		if stepErr != nil {
+			if err := checkpoint.save(config.CheckpointFile); err != nil {
+				return checkpoint, errors.Join(fmt.Errorf("rollover step %s failed: %w", step, stepErr), err)
+			}
+			return checkpoint, fmt.Errorf("rollover step %s failed: %w", step, stepErr)
+		}
+
+		if step == RolloverStepKeepers && config.Keepers != nil && config.KeeperOptions.DryRun {
+			checkpoint.KeepersPreviewed = true
+		} else {
+			checkpoint.CompletedSteps = append(checkpoint.CompletedSteps, step)
+		}
+		if err := checkpoint.save(config.CheckpointFile); err != nil {
+			return checkpoint, err
+		}
+	}
+
+	return checkpoint, nil
+}
+
+// exportSeasonState writes the client's league standings, matchups, transactions and
+// rosters to JSON files in dir
+func (c *Client) exportSeasonState(dir string) error {
+	standings, err := c.GetStandings(WithStandingsView(StandingsViewAll))
+	if err != nil {
+		return fmt.Errorf("failed to get standings: %w", err)
+	}
+	matchups, err := c.GetAllMatchups()
+	if err != nil {
+		return fmt.Errorf("failed to get matchups: %w", err)
+	}
+	transactions, err := c.GetAllTransactionsIncludingTrades()
+	if err != nil {
+		return fmt.Errorf("failed to get transactions: %w", err)
+	}
+	rosters, _, err := c.GetAllTeamRosters("")
+	if err != nil {
+		return fmt.Errorf("failed to get rosters: %w", err)
+	}
+
+	files := map[string]interface{}{
+		"standings.json":    standings,
+		"matchups.json":     matchups,
+		"transactions.json": transactions,
+		"rosters.json":      rosters,
+	}
+	for name, v := range files {
+		if err := writeJSONFile(filepath.Join(dir, c.LeagueID+"-"+name), v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendSeasonHistory adds the client's league to the history file in dir, replacing any
+// existing entry for the same year. A year of 0 is taken from the league's standings.
+func (c *Client) appendSeasonHistory(dir string, year int) error {
+	historyPath := filepath.Join(dir, "history.json")
+
+	history := &LeagueHistory{}
+	if data, err := os.ReadFile(historyPath); err == nil {
+		if err := json.Unmarshal(data, history); err != nil {
+			return fmt.Errorf("failed to parse existing history: %w", err)
+		}
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read existing history: %w", err)
+	}
+
+	season, err := c.getSeasonHistory(year)
+	if err != nil {
+		return err
+	}
+
+	seasons := history.Seasons[:0]
+	for _, s := range history.Seasons {
+		if s.Year != season.Year {
+			seasons = append(seasons, s)
+		}
+	}
+	history.Seasons = append(seasons, *season)
+	sort.SliceStable(history.Seasons, func(i, j int) bool {
+		return history.Seasons[i].Year < history.Seasons[j].Year
+	})
+
+	return writeJSONFile(historyPath, history)
+}
+
+// uploadRolloverSchedule uploads each configured period that has not been uploaded yet,
+// saving the checkpoint after every period
+func (c *Client) uploadRolloverSchedule(config RolloverConfig, checkpoint *RolloverCheckpoint) error {
+	if len(config.Schedule) == 0 {
+		return nil
+	}
+
+	uploaded := make(map[int]bool)
+	for _, p := range checkpoint.UploadedPeriods {
+		uploaded[p] = true
+	}
+
+	periods := make([]int, 0, len(config.Schedule))
+	for period := range config.Schedule {
+		periods = append(periods, period)
+	}
+	sort.Ints(periods)
+
+	setup, err := c.GetLeagueSetupMatchups()
+	if err != nil {
+		return fmt.Errorf("failed to get league setup: %w", err)
+	}
+
+	for _, period := range periods {
+		if uploaded[period] {
+			continue
+		}
+		if err := c.SetPeriodMatchups(setup, period, config.Schedule[period]); err != nil {
+			return fmt.Errorf("failed to upload period %d: %w", period, err)
+		}
+		checkpoint.UploadedPeriods = append(checkpoint.UploadedPeriods, period)
+		if err := checkpoint.save(config.CheckpointFile); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compareLeagueSettings lists roster and scoring differences between the client's league
+// and a previous league
+func (c *Client) compareLeagueSettings(previousLeagueID string) ([]string, error) {
+	publicClient, err := fantrax.NewClient(c.LeagueID, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create public client: %w", err)
+	}
+	current, err := publicClient.GetLeagueInfo(c.LeagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league info: %w", err)
+	}
+	previous, err := publicClient.GetLeagueInfo(previousLeagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get previous league info: %w", err)
+	}
+
+	var changes []string
+	was, now := previous.RosterInfo, current.RosterInfo
+	if was.MaxTotalPlayers != now.MaxTotalPlayers {
+		changes = append(changes, fmt.Sprintf("max total players: %d -> %d", was.MaxTotalPlayers, now.MaxTotalPlayers))
+	}
+	if was.MaxTotalActivePlayers != now.MaxTotalActivePlayers {
+		changes = append(changes, fmt.Sprintf("max active players: %d -> %d", was.MaxTotalActivePlayers, now.MaxTotalActivePlayers))
+	}
+	if was.MaxTotalReservePlayers != now.MaxTotalReservePlayers {
+		changes = append(changes, fmt.Sprintf("max reserve players: %d -> %d", was.MaxTotalReservePlayers, now.MaxTotalReservePlayers))
+	}
+	for _, pos := range unionKeys(was.PositionConstraints, now.PositionConstraints) {
+		if a, b := was.PositionConstraints[pos].MaxActive, now.PositionConstraints[pos].MaxActive; a != b {
+			changes = append(changes, fmt.Sprintf("max active at %s: %d -> %d", pos, a, b))
+		}
+	}
+
+	wasPoints, nowPoints := scoringPoints(previous), scoringPoints(current)
+	for _, key := range unionKeys(wasPoints, nowPoints) {
+		a, aok := wasPoints[key]
+		b, bok := nowPoints[key]
+		switch {
+		case !aok:
+			changes = append(changes, fmt.Sprintf("scoring %s added: %g", key, b))
+		case !bok:
+			changes = append(changes, fmt.Sprintf("scoring %s removed", key))
+		case a != b:
+			changes = append(changes, fmt.Sprintf("scoring %s: %g -> %g", key, a, b))
+		}
+	}
+
+	return changes, nil
+}
+
+// scoringPoints flattens a league's scoring settings into "GROUP/POSITION/CATEGORY" -> points
+func scoringPoints(info *fantrax.LeagueInfo) map[string]float64 {
+	points := make(map[string]float64)
+	for _, setting := range info.ScoringSystem.ScoringCategorySettings {
+		for _, cfg := range setting.Configs {
+			key := fmt.Sprintf("%s/%s/%s", setting.Group.Code, cfg.Position.ShortName, cfg.ScoringCategory.ShortName)
+			points[key] = cfg.Points
+		}
+	}
+	return points
+}
+
+// unionKeys returns the sorted union of two maps' keys
+func unionKeys[V any](a, b map[string]V) []string {
+	seen := make(map[string]bool)
+	for k := range a {
+		seen[k] = true
+	}
+	for k := range b {
+		seen[k] = true
+	}
+	keys := make([]string, 0, len(seen))
+	for k := range seen {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// loadRolloverCheckpoint reads the checkpoint file, returning an empty checkpoint if it
+// does not exist yet
+func loadRolloverCheckpoint(path string) (*RolloverCheckpoint, error) {
+	checkpoint := &RolloverCheckpoint{}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return checkpoint, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rollover checkpoint: %w", err)
+	}
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to parse rollover checkpoint: %w", err)
+	}
+	return checkpoint, nil
+}
+
+// save writes the checkpoint to disk
+func (cp *RolloverCheckpoint) save(path string) error {
+	if err := writeJSONFile(path, cp); err != nil {
+		return fmt.Errorf("failed to save rollover checkpoint: %w", err)
+	}
+	return nil
+}
+
+// writeJSONFile writes v to path as indented JSON
+func writeJSONFile(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", filepath.Base(path), err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
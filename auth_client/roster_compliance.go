@@ -0,0 +1,193 @@
+package auth_client
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pmurley/go-fantrax"
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// RosterLimitsFromLeagueInfo builds roster limits from the public league info settings
+//
+// The public API exposes total, active, reserve and per-position active limits. Minors and
+// IR slot limits are not published there, so MaxMinorsPlayers and MaxIRPlayers are left at
+// zero (not enforced) for the caller to fill in, as is the MaxMinorsDaysActive service-time
+// limit. Minors eligibility and IR designation
+// checks are enabled by default.
+func RosterLimitsFromLeagueInfo(info *fantrax.LeagueInfo) models.RosterLimits {
+	limits := models.RosterLimits{
+		MaxTotalPlayers:       info.RosterInfo.MaxTotalPlayers,
+		MaxActivePlayers:      info.RosterInfo.MaxTotalActivePlayers,
+		MaxReservePlayers:     info.RosterInfo.MaxTotalReservePlayers,
+		MaxActiveByPosition:   make(map[string]int),
+		RequireMinorsEligible: true,
+		RequireInjuredListIR:  true,
+	}
+	for position, constraint := range info.RosterInfo.PositionConstraints {
+		limits.MaxActiveByPosition[position] = constraint.MaxActive
+	}
+	return limits
+}
+
+// CheckRosterCompliance validates a single team's roster against the given limits
+//
+// The total player count covers the active and reserve rosters; IR and minors slots are
+// checked against their own limits.
+func CheckRosterCompliance(teamID, teamName string, roster *models.TeamRoster, limits models.RosterLimits) []models.RosterViolation {
+	var violations []models.RosterViolation
+	add := func(rule, format string, args ...interface{}) {
+		violations = append(violations, models.RosterViolation{
+			Rule:     rule,
+			TeamID:   teamID,
+			TeamName: teamName,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	active := len(roster.ActiveRoster)
+	reserve := len(roster.ReserveRoster)
+
+	if limits.MaxTotalPlayers > 0 && active+reserve > limits.MaxTotalPlayers {
+		add(models.ViolationMaxTotalPlayers, "%d players rostered, maximum is %d", active+reserve, limits.MaxTotalPlayers)
+	}
+	if limits.MaxActivePlayers > 0 && active > limits.MaxActivePlayers {
+		add(models.ViolationMaxActivePlayers, "%d active players, maximum is %d", active, limits.MaxActivePlayers)
+	}
+	if limits.MaxReservePlayers > 0 && reserve > limits.MaxReservePlayers {
+		add(models.ViolationMaxReservePlayers, "%d reserve players, maximum is %d", reserve, limits.MaxReservePlayers)
+	}
+	if limits.MaxMinorsPlayers > 0 && len(roster.MinorsRoster) > limits.MaxMinorsPlayers {
+		add(models.ViolationMaxMinorsPlayers, "%d minors players, maximum is %d", len(roster.MinorsRoster), limits.MaxMinorsPlayers)
+	}
+	if limits.MaxIRPlayers > 0 && len(roster.InjuredReserve) > limits.MaxIRPlayers {
+		add(models.ViolationMaxIRPlayers, "%d IR players, maximum is %d", len(roster.InjuredReserve), limits.MaxIRPlayers)
+	}
+
+	// Count active players by the slot they occupy
+	activeByPosition := make(map[string]int)
+	for _, player := range roster.ActiveRoster {
+		activeByPosition[positionName(player.RosterPosition)]++
+	}
+	positions := make([]string, 0, len(activeByPosition))
+	for position := range activeByPosition {
+		positions = append(positions, position)
+	}
+	sort.Strings(positions)
+	for _, position := range positions {
+		limit, ok := limits.MaxActiveByPosition[position]
+		if !ok || limit <= 0 {
+			continue
+		}
+		if count := activeByPosition[position]; count > limit {
+			add(models.ViolationMaxActiveAtPosition, "%d active players at %s, maximum is %d", count, position, limit)
+		}
+	}
+
+	if limits.RequireMinorsEligible {
+		for _, player := range roster.MinorsRoster {
+			if player.MinorsEligible {
+				continue
+			}
+			violations = append(violations, models.RosterViolation{
+				Rule:       models.ViolationMinorsIneligible,
+				TeamID:     teamID,
+				TeamName:   teamName,
+				PlayerID:   player.PlayerID,
+				PlayerName: player.Name,
+				Message:    fmt.Sprintf("%s is in a minors slot but is no longer minors eligible", player.Name),
+			})
+		}
+	}
+
+	if limits.RequireInjuredListIR {
+		violations = append(violations, CheckIREligibility(teamID, teamName, roster)...)
+	}
+
+	return violations
+}
+
+// CheckServiceTime validates a team's minors-slot players against the league's service-time
+// limit, using the team's service time from GetTeamServiceTime
+//
+// Players missing from serviceTime are not checked.
+func CheckServiceTime(teamID, teamName string, roster *models.TeamRoster, serviceTime models.TeamServiceTimeResult, limits models.RosterLimits) []models.RosterViolation {
+	if limits.MaxMinorsDaysActive <= 0 {
+		return nil
+	}
+
+	var violations []models.RosterViolation
+	for _, player := range roster.MinorsRoster {
+		st, ok := serviceTime[player.PlayerID]
+		if !ok || st.DaysActive <= limits.MaxMinorsDaysActive {
+			continue
+		}
+		violations = append(violations, models.RosterViolation{
+			Rule:       models.ViolationMinorsDaysActive,
+			TeamID:     teamID,
+			TeamName:   teamName,
+			PlayerID:   player.PlayerID,
+			PlayerName: player.Name,
+			Message:    fmt.Sprintf("%s is in a minors slot after %d days active, maximum is %d", player.Name, st.DaysActive, limits.MaxMinorsDaysActive),
+		})
+	}
+	return violations
+}
+
+// CheckLeagueRosterCompliance fetches every roster for the current period and validates
+// them against the league's roster limits
+//
+// Limits are read from the public league info endpoint via RosterLimitsFromLeagueInfo.
+// Use CheckLeagueRosterComplianceWithLimits to supply minors/IR limits or custom values.
+//
+// Returns one entry per team in league order, including teams with no violations.
+func (c *Client) CheckLeagueRosterCompliance() ([]models.TeamRosterViolations, error) {
+	publicClient, err := fantrax.NewClient(c.LeagueID, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create public client: %w", err)
+	}
+
+	info, err := publicClient.GetLeagueInfo(c.LeagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league roster limits: %w", err)
+	}
+
+	return c.CheckLeagueRosterComplianceWithLimits("", RosterLimitsFromLeagueInfo(info))
+}
+
+// CheckLeagueRosterComplianceWithLimits fetches every roster for a period and validates
+// them against the given limits
+//
+// Parameters:
+//   - period: The roster period as a string (empty string = current period)
+//   - limits: The roster limits to enforce
+//
+// The client's RosterRules are checked along with the limits. When MaxMinorsDaysActive is set,
+// each team's service time is fetched as well.
+//
+// Returns one entry per team in league order, including teams with no violations.
+func (c *Client) CheckLeagueRosterComplianceWithLimits(period string, limits models.RosterLimits) ([]models.TeamRosterViolations, error) {
+	rosters, teams, err := c.GetAllTeamRosters(period)
+	if err != nil {
+		return nil, err
+	}
+
+	report := make([]models.TeamRosterViolations, 0, len(teams))
+	for _, team := range teams {
+		violations := CheckRosterCompliance(team.ID, team.Name, rosters[team.ID], limits)
+		violations = append(violations, CheckRosterRules(team.ID, team.Name, rosters[team.ID], c.RosterRules)...)
+		if limits.MaxMinorsDaysActive > 0 {
+			serviceTime, err := c.GetTeamServiceTime(team.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get service time for team %s: %w", team.ID, err)
+			}
+			violations = append(violations, CheckServiceTime(team.ID, team.Name, rosters[team.ID], serviceTime, limits)...)
+		}
+		report = append(report, models.TeamRosterViolations{
+			TeamID:     team.ID,
+			TeamName:   team.Name,
+			Violations: violations,
+		})
+	}
+	return report, nil
+}
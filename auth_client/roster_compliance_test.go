@@ -0,0 +1,35 @@
+package auth_client
+
+import (
+	"testing"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestCheckServiceTime(t *testing.T) {
+	roster := &models.TeamRoster{
+		MinorsRoster: []models.RosterPlayer{
+			{PlayerID: "p1", Name: "Called Up"},
+			{PlayerID: "p2", Name: "Stayed Down"},
+			{PlayerID: "p3", Name: "Not Tracked"},
+		},
+		ActiveRoster: []models.RosterPlayer{{PlayerID: "p4", Name: "Regular"}},
+	}
+	serviceTime := models.TeamServiceTimeResult{
+		"p1": {ScorerID: "p1", DaysActive: 31},
+		"p2": {ScorerID: "p2", DaysActive: 30},
+		"p4": {ScorerID: "p4", DaysActive: 120},
+	}
+
+	if violations := CheckServiceTime("team1", "Team Alpha", roster, serviceTime, models.RosterLimits{}); len(violations) != 0 {
+		t.Errorf("no limit should not be enforced, got %+v", violations)
+	}
+
+	violations := CheckServiceTime("team1", "Team Alpha", roster, serviceTime, models.RosterLimits{MaxMinorsDaysActive: 30})
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %+v", violations)
+	}
+	if v := violations[0]; v.PlayerID != "p1" || v.Rule != models.ViolationMinorsDaysActive || v.TeamID != "team1" {
+		t.Errorf("unexpected violation %+v", v)
+	}
+}
@@ -0,0 +1,378 @@
+package auth_client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pmurley/go-fantrax/auth_client/parser"
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// RosterRule is a custom league constraint checked against a team's roster, beyond the
+// limits Fantrax enforces itself. Rules run in the roster compliance sweep
+// (CheckLeagueRosterComplianceWithLimits) and before RosterEditor.Apply.
+type RosterRule interface {
+	// Name identifies the rule; it is used as the Rule code of its violations
+	Name() string
+	// Check returns the roster's violations of the rule
+	Check(teamID, teamName string, roster *models.TeamRoster) []models.RosterViolation
+}
+
+// RosterRuleFunc adapts a function to RosterRule
+type RosterRuleFunc struct {
+	RuleName string
+	Func     func(teamID, teamName string, roster *models.TeamRoster) []models.RosterViolation
+}
+
+func (r RosterRuleFunc) Name() string { return r.RuleName }
+
+func (r RosterRuleFunc) Check(teamID, teamName string, roster *models.TeamRoster) []models.RosterViolation {
+	return r.Func(teamID, teamName, roster)
+}
+
+// Built-in rule names
+const (
+	RuleMaxPerProTeam = "MAX_PER_PRO_TEAM"
+	RuleAgeLimit      = "AGE_LIMIT"
+	RuleSalaryCap     = "SALARY_CAP"
+	RulePositionQuota = "POSITION_QUOTA"
+)
+
+// MaxPerProTeam limits how many players a fantasy team may roster from one real team
+type MaxPerProTeam struct {
+	Max      int
+	Statuses []string // Roster statuses counted (StatusActive, ...); empty = every player
+}
+
+func (r MaxPerProTeam) Name() string { return RuleMaxPerProTeam }
+
+func (r MaxPerProTeam) Check(teamID, teamName string, roster *models.TeamRoster) []models.RosterViolation {
+	counts := make(map[string]int)
+	for _, player := range playersWithStatus(roster, r.Statuses) {
+		if player.TeamShortName != "" {
+			counts[player.TeamShortName]++
+		}
+	}
+	proTeams := make([]string, 0, len(counts))
+	for proTeam := range counts {
+		proTeams = append(proTeams, proTeam)
+	}
+	sort.Strings(proTeams)
+
+	var violations []models.RosterViolation
+	for _, proTeam := range proTeams {
+		if counts[proTeam] > r.Max {
+			violations = append(violations, ruleViolation(r.Name(), teamID, teamName, nil,
+				"%d players from %s, maximum is %d", counts[proTeam], proTeam, r.Max))
+		}
+	}
+	return violations
+}
+
+// AgeLimit requires players with the given statuses to be within an age range, e.g. a
+// minors roster reserved for players 25 and under. Players of unknown age are not checked.
+type AgeLimit struct {
+	MinAge   int // 0 = no minimum
+	MaxAge   int // 0 = no maximum
+	Statuses []string
+}
+
+func (r AgeLimit) Name() string { return RuleAgeLimit }
+
+func (r AgeLimit) Check(teamID, teamName string, roster *models.TeamRoster) []models.RosterViolation {
+	var violations []models.RosterViolation
+	for _, player := range playersWithStatus(roster, r.Statuses) {
+		player := player
+		switch {
+		case player.Age == 0:
+		case r.MaxAge > 0 && player.Age > r.MaxAge:
+			violations = append(violations, ruleViolation(r.Name(), teamID, teamName, &player,
+				"%s is %d, maximum age is %d", player.Name, player.Age, r.MaxAge))
+		case r.MinAge > 0 && player.Age < r.MinAge:
+			violations = append(violations, ruleViolation(r.Name(), teamID, teamName, &player,
+				"%s is %d, minimum age is %d", player.Name, player.Age, r.MinAge))
+		}
+	}
+	return violations
+}
+
+// SalaryCap limits the total salary of a team's players. Fantrax rosters do not carry
+// salaries, so the league supplies them; players without a salary count as zero.
+type SalaryCap struct {
+	Cap      float64
+	Salaries map[string]float64 // Keyed by player ID
+	Statuses []string
+}
+
+func (r SalaryCap) Name() string { return RuleSalaryCap }
+
+func (r SalaryCap) Check(teamID, teamName string, roster *models.TeamRoster) []models.RosterViolation {
+	total := 0.0
+	for _, player := range playersWithStatus(roster, r.Statuses) {
+		total += r.Salaries[player.PlayerID]
+	}
+	if total > r.Cap {
+		return []models.RosterViolation{ruleViolation(r.Name(), teamID, teamName, nil,
+			"total salary %.2f is over the cap of %.2f", total, r.Cap)}
+	}
+	return nil
+}
+
+// PositionQuota requires a number of players eligible at a position, e.g. at most 13
+// pitchers or at least 2 catchers
+type PositionQuota struct {
+	Position string // Position short name (e.g. "C", "SP") or position ID
+	Min      int    // 0 = no minimum
+	Max      int    // 0 = no maximum
+	Statuses []string
+}
+
+func (r PositionQuota) Name() string { return RulePositionQuota }
+
+func (r PositionQuota) Check(teamID, teamName string, roster *models.TeamRoster) []models.RosterViolation {
+	count := 0
+	for _, player := range playersWithStatus(roster, r.Statuses) {
+		for _, position := range player.Positions {
+			if strings.EqualFold(position, r.Position) || strings.EqualFold(positionName(position), r.Position) {
+				count++
+				break
+			}
+		}
+	}
+	switch {
+	case r.Max > 0 && count > r.Max:
+		return []models.RosterViolation{ruleViolation(r.Name(), teamID, teamName, nil,
+			"%d players eligible at %s, maximum is %d", count, r.Position, r.Max)}
+	case r.Min > 0 && count < r.Min:
+		return []models.RosterViolation{ruleViolation(r.Name(), teamID, teamName, nil,
+			"%d players eligible at %s, minimum is %d", count, r.Position, r.Min)}
+	}
+	return nil
+}
+
+// CheckRosterRules runs rules against a roster
+func CheckRosterRules(teamID, teamName string, roster *models.TeamRoster, rules []RosterRule) []models.RosterViolation {
+	var violations []models.RosterViolation
+	for _, rule := range rules {
+		violations = append(violations, rule.Check(teamID, teamName, roster)...)
+	}
+	return violations
+}
+
+// RosterRuleError is returned by RosterEditor.Apply when the edited roster breaks a rule
+type RosterRuleError struct {
+	Violations []models.RosterViolation
+}
+
+func (e *RosterRuleError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = v.Message
+	}
+	return "roster breaks league rules: " + strings.Join(messages, "; ")
+}
+
+// RosterRuleConfig is one rule in a JSON rules file. Rule selects the built-in rule and the
+// other fields are its settings:
+//
+//	[
+//	  {"rule": "maxPerProTeam", "max": 4},
+//	  {"rule": "ageLimit", "maxAge": 25, "statuses": ["minors"]},
+//	  {"rule": "salaryCap", "cap": 260, "salaries": {"04fkw": 31.5}},
+//	  {"rule": "positionQuota", "position": "C", "min": 2}
+//	]
+//
+// Statuses are "active", "reserve", "ir", and "minors"; leaving them out checks every player.
+type RosterRuleConfig struct {
+	Rule     string             `json:"rule"`
+	Statuses []string           `json:"statuses,omitempty"`
+	Max      int                `json:"max,omitempty"`
+	Min      int                `json:"min,omitempty"`
+	MaxAge   int                `json:"maxAge,omitempty"`
+	MinAge   int                `json:"minAge,omitempty"`
+	Position string             `json:"position,omitempty"`
+	Cap      float64            `json:"cap,omitempty"`
+	Salaries map[string]float64 `json:"salaries,omitempty"`
+}
+
+var ruleStatuses = map[string]string{
+	"active":  StatusActive,
+	"reserve": StatusReserve,
+	"ir":      StatusIR,
+	"minors":  StatusMinors,
+}
+
+// LoadRosterRules reads a JSON list of rule configs (see RosterRuleConfig)
+func LoadRosterRules(r io.Reader) ([]RosterRule, error) {
+	var configs []RosterRuleConfig
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&configs); err != nil {
+		return nil, fmt.Errorf("failed to parse roster rules: %w", err)
+	}
+
+	rules := make([]RosterRule, 0, len(configs))
+	for i, config := range configs {
+		rule, err := config.build()
+		if err != nil {
+			return nil, fmt.Errorf("roster rule %d: %w", i+1, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// build creates the rule a config describes
+func (c RosterRuleConfig) build() (RosterRule, error) {
+	statuses := make([]string, 0, len(c.Statuses))
+	for _, name := range c.Statuses {
+		status, ok := ruleStatuses[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown status %q", name)
+		}
+		statuses = append(statuses, status)
+	}
+
+	switch strings.ToLower(c.Rule) {
+	case "maxperproteam":
+		if c.Max <= 0 {
+			return nil, fmt.Errorf("maxPerProTeam needs max")
+		}
+		return MaxPerProTeam{Max: c.Max, Statuses: statuses}, nil
+	case "agelimit":
+		if c.MinAge <= 0 && c.MaxAge <= 0 {
+			return nil, fmt.Errorf("ageLimit needs minAge or maxAge")
+		}
+		return AgeLimit{MinAge: c.MinAge, MaxAge: c.MaxAge, Statuses: statuses}, nil
+	case "salarycap":
+		if c.Cap <= 0 {
+			return nil, fmt.Errorf("salaryCap needs cap")
+		}
+		return SalaryCap{Cap: c.Cap, Salaries: c.Salaries, Statuses: statuses}, nil
+	case "positionquota":
+		if c.Position == "" || (c.Min <= 0 && c.Max <= 0) {
+			return nil, fmt.Errorf("positionQuota needs position and min or max")
+		}
+		return PositionQuota{Position: c.Position, Min: c.Min, Max: c.Max, Statuses: statuses}, nil
+	default:
+		return nil, fmt.Errorf("unknown rule %q", c.Rule)
+	}
+}
+
+// WithRosterRules adds custom league rules to the client's roster compliance checks and
+// roster edits
+func WithRosterRules(rules ...RosterRule) ClientOption {
+	return func(c *Client) {
+		c.RosterRules = append(c.RosterRules, rules...)
+	}
+}
+
+// playersWithStatus returns the roster's players with one of the statuses, or every player
+// if statuses is empty
+func playersWithStatus(roster *models.TeamRoster, statuses []string) []models.RosterPlayer {
+	if len(statuses) == 0 {
+		return roster.AllPlayers()
+	}
+	groups := map[string][]models.RosterPlayer{
+		StatusActive:  roster.ActiveRoster,
+		StatusReserve: roster.ReserveRoster,
+		StatusIR:      roster.InjuredReserve,
+		StatusMinors:  roster.MinorsRoster,
+	}
+	var players []models.RosterPlayer
+	for _, status := range statuses {
+		players = append(players, groups[status]...)
+	}
+	return players
+}
+
+// ruleViolation builds a violation of a custom rule
+func ruleViolation(rule, teamID, teamName string, player *models.RosterPlayer, format string, args ...interface{}) models.RosterViolation {
+	v := models.RosterViolation{
+		Rule:     rule,
+		TeamID:   teamID,
+		TeamName: teamName,
+		Message:  fmt.Sprintf(format, args...),
+	}
+	if player != nil {
+		v.PlayerID = player.PlayerID
+		v.PlayerName = player.Name
+	}
+	return v
+}
+
+// Validate checks the roster as edited so far against the client's RosterRules
+func (e *RosterEditor) Validate() ([]models.RosterViolation, error) {
+	roster, err := e.editedRoster(e.fieldMap)
+	if err != nil {
+		return nil, err
+	}
+	return CheckRosterRules(e.teamID, "", roster, e.client.RosterRules), nil
+}
+
+// checkRules fails if the edits break a rule the roster as loaded did not
+func (e *RosterEditor) checkRules() error {
+	if len(e.client.RosterRules) == 0 {
+		return nil
+	}
+	before, err := e.editedRoster(e.original)
+	if err != nil {
+		return err
+	}
+	after, err := e.Validate()
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[models.RosterViolation]bool)
+	for _, v := range CheckRosterRules(e.teamID, "", before, e.client.RosterRules) {
+		existing[v] = true
+	}
+	var introduced []models.RosterViolation
+	for _, v := range after {
+		if !existing[v] {
+			introduced = append(introduced, v)
+		}
+	}
+	if len(introduced) > 0 {
+		return &RosterRuleError{Violations: introduced}
+	}
+	return nil
+}
+
+// editedRoster builds the roster with each player placed by the given field map
+func (e *RosterEditor) editedRoster(fieldMap map[string]RosterPosition) (*models.TeamRoster, error) {
+	data, err := json.Marshal(e.rawRoster)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal roster for rule checks: %w", err)
+	}
+	roster, err := parser.ParseTeamRosterResponse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse roster for rule checks: %w", err)
+	}
+
+	groups := map[string][]models.RosterPlayer{
+		StatusActive:  roster.ActiveRoster,
+		StatusReserve: roster.ReserveRoster,
+		StatusIR:      roster.InjuredReserve,
+		StatusMinors:  roster.MinorsRoster,
+	}
+	placed := make(map[string][]models.RosterPlayer)
+	for status, players := range groups {
+		for _, player := range players {
+			if pos, ok := fieldMap[player.PlayerID]; ok {
+				status = pos.StID
+				player.RosterPosition = pos.PosID
+			}
+			placed[status] = append(placed[status], player)
+		}
+	}
+	roster.ActiveRoster = placed[StatusActive]
+	roster.ReserveRoster = placed[StatusReserve]
+	roster.InjuredReserve = placed[StatusIR]
+	roster.MinorsRoster = placed[StatusMinors]
+	return roster, nil
+}
@@ -0,0 +1,47 @@
+package auth_client
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestRosterRules(t *testing.T) {
+	rules, err := LoadRosterRules(strings.NewReader(`[
+		{"rule": "maxPerProTeam", "max": 1},
+		{"rule": "ageLimit", "maxAge": 25, "statuses": ["minors"]},
+		{"rule": "salaryCap", "cap": 50, "salaries": {"p1": 30, "p2": 25}},
+		{"rule": "positionQuota", "position": "C", "min": 1}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	roster := &models.TeamRoster{
+		ActiveRoster: []models.RosterPlayer{
+			{PlayerID: "p1", Name: "One", TeamShortName: "NYY", Age: 30},
+			{PlayerID: "p2", Name: "Two", TeamShortName: "NYY", Age: 28},
+		},
+		MinorsRoster: []models.RosterPlayer{
+			{PlayerID: "p3", Name: "Three", TeamShortName: "BOS", Age: 27},
+		},
+	}
+
+	got := make(map[string]int)
+	for _, v := range CheckRosterRules("t1", "Team", roster, rules) {
+		got[v.Rule]++
+	}
+	want := map[string]int{RuleMaxPerProTeam: 1, RuleAgeLimit: 1, RuleSalaryCap: 1, RulePositionQuota: 1}
+	for rule, n := range want {
+		if got[rule] != n {
+			t.Errorf("%s: got %d violations, want %d", rule, got[rule], n)
+		}
+	}
+
+	for _, bad := range []string{`[{"rule": "nope"}]`, `[{"rule": "ageLimit"}]`, `[{"rule": "maxPerProTeam", "max": 1, "statuses": ["bench"]}]`} {
+		if _, err := LoadRosterRules(strings.NewReader(bad)); err == nil {
+			t.Errorf("LoadRosterRules(%s) succeeded, want an error", bad)
+		}
+	}
+}
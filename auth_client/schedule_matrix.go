@@ -0,0 +1,138 @@
+package auth_client
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// ScheduleMatrix is a season schedule laid out as a team × period grid, one row per team
+type ScheduleMatrix struct {
+	Periods []int               `json:"periods"`
+	Rows    []ScheduleMatrixRow `json:"rows"`
+}
+
+// ScheduleMatrixRow is one team's opponents by period
+type ScheduleMatrixRow struct {
+	TeamID    string `json:"teamId"`
+	TeamName  string `json:"teamName"`
+	ShortName string `json:"shortName"`
+	// Cells holds one entry per period, in Periods order: the opponent's name followed by
+	// " (H)" for a home game or " (A)" for an away game, or "" for a bye
+	Cells []string `json:"cells"`
+}
+
+// GetScheduleAsMatrix fetches the season's matchups as a team × period matrix
+func (c *Client) GetScheduleAsMatrix() (*ScheduleMatrix, error) {
+	matchups, err := c.GetAllMatchups()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get matchups: %w", err)
+	}
+	return ScheduleMatrixFromMatchups(matchups), nil
+}
+
+// ScheduleMatrixFromMatchups lays out the season's matchups as a team × period matrix, with
+// teams ordered by name
+func ScheduleMatrixFromMatchups(result *AllMatchupsResult) *ScheduleMatrix {
+	ids := make([]string, 0, len(result.Teams))
+	for id := range result.Teams {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return result.Teams[ids[i]].Name < result.Teams[ids[j]].Name })
+
+	teams := make([]scheduleTeam, len(ids))
+	for i, id := range ids {
+		teams[i] = scheduleTeam{id: id, name: result.Teams[id].Name, shortName: result.Teams[id].ShortName}
+	}
+	byPeriod := make(map[int][]models.MatchupPair)
+	for _, m := range result.Matchups {
+		byPeriod[m.ScoringPeriod] = append(byPeriod[m.ScoringPeriod], models.MatchupPair{AwayTeamID: m.AwayTeam.TeamID, HomeTeamID: m.HomeTeam.TeamID})
+	}
+	return buildScheduleMatrix(teams, byPeriod)
+}
+
+// ScheduleMatrixFromSetup lays out the league setup matchups as a team × period matrix, with
+// teams in setup order
+func ScheduleMatrixFromSetup(setup *models.LeagueSetupMatchups) *ScheduleMatrix {
+	teams := make([]scheduleTeam, len(setup.Teams))
+	for i, team := range setup.Teams {
+		teams[i] = scheduleTeam{id: team.TeamID, name: team.Name, shortName: team.ShortName}
+	}
+	return buildScheduleMatrix(teams, setup.Matchups)
+}
+
+// Records returns the matrix as CSV records: a header row of "teamId", "shortName", "team",
+// and the period numbers, then a row per team. This is the layout the upload_schedule
+// example reads, so an exported schedule can be edited in a spreadsheet and uploaded again.
+func (m *ScheduleMatrix) Records() [][]string {
+	header := []string{"teamId", "shortName", "team"}
+	for _, period := range m.Periods {
+		header = append(header, strconv.Itoa(period))
+	}
+	records := [][]string{header}
+	for _, row := range m.Rows {
+		records = append(records, append([]string{row.TeamID, row.ShortName, row.TeamName}, row.Cells...))
+	}
+	return records
+}
+
+// WriteCSV writes the matrix as CSV
+func (m *ScheduleMatrix) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.WriteAll(m.Records()); err != nil {
+		return fmt.Errorf("failed to write schedule CSV: %w", err)
+	}
+	return nil
+}
+
+// scheduleTeam is a team's row label in the matrix
+type scheduleTeam struct {
+	id, name, shortName string
+}
+
+// buildScheduleMatrix fills the matrix from each period's matchups
+func buildScheduleMatrix(teams []scheduleTeam, byPeriod map[int][]models.MatchupPair) *ScheduleMatrix {
+	matrix := &ScheduleMatrix{}
+	for period := range byPeriod {
+		matrix.Periods = append(matrix.Periods, period)
+	}
+	sort.Ints(matrix.Periods)
+
+	names := make(map[string]string, len(teams))
+	rowIndex := make(map[string]int, len(teams))
+	for i, team := range teams {
+		names[team.id] = team.name
+		rowIndex[team.id] = i
+		matrix.Rows = append(matrix.Rows, ScheduleMatrixRow{
+			TeamID:    team.id,
+			TeamName:  team.name,
+			ShortName: team.shortName,
+			Cells:     make([]string, len(matrix.Periods)),
+		})
+	}
+	opponent := func(id string) string {
+		if name := names[id]; name != "" {
+			return name
+		}
+		return id
+	}
+
+	for col, period := range matrix.Periods {
+		for _, pair := range byPeriod[period] {
+			if IsBye(pair) {
+				continue
+			}
+			if i, ok := rowIndex[pair.AwayTeamID]; ok {
+				matrix.Rows[i].Cells[col] = opponent(pair.HomeTeamID) + " (A)"
+			}
+			if i, ok := rowIndex[pair.HomeTeamID]; ok {
+				matrix.Rows[i].Cells[col] = opponent(pair.AwayTeamID) + " (H)"
+			}
+		}
+	}
+	return matrix
+}
@@ -0,0 +1,39 @@
+package auth_client
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestScheduleMatrix(t *testing.T) {
+	setup := &models.LeagueSetupMatchups{
+		Teams: []models.LeagueSetupTeam{{TeamID: "a", Name: "Aces", ShortName: "ACE"}, {TeamID: "b", Name: "Bats", ShortName: "BAT"}, {TeamID: "c", Name: "Cubs", ShortName: "CUB"}},
+		Matchups: map[int][]models.MatchupPair{
+			2: {{AwayTeamID: "b", HomeTeamID: "a"}, {AwayTeamID: "c", HomeTeamID: ByeTeamID}},
+			1: {{AwayTeamID: "a", HomeTeamID: "b"}, {AwayTeamID: "c", HomeTeamID: ByeTeamID}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := ScheduleMatrixFromSetup(setup).WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := "teamId,shortName,team,1,2\na,ACE,Aces,Bats (A),Bats (H)\nb,BAT,Bats,Aces (H),Aces (A)\nc,CUB,Cubs,,\n"
+	if buf.String() != want {
+		t.Errorf("unexpected CSV:\n%s", buf.String())
+	}
+
+	result := &AllMatchupsResult{
+		Teams: map[string]FantasyTeam{"b": {Name: "Bats"}, "a": {Name: "Aces"}},
+		Matchups: []Matchup{
+			{ScoringPeriod: 1, AwayTeam: MatchTeam{TeamID: "a"}, HomeTeam: MatchTeam{TeamID: "b"}},
+		},
+	}
+	matrix := ScheduleMatrixFromMatchups(result)
+	if len(matrix.Rows) != 2 || matrix.Rows[0].TeamID != "a" || strings.Join(matrix.Rows[1].Cells, "|") != "Aces (H)" {
+		t.Errorf("unexpected matrix: %+v", matrix)
+	}
+}
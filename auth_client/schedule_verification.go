@@ -0,0 +1,133 @@
+package auth_client
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// PeriodVerification compares one period's matchups in Fantrax with the expected ones
+type PeriodVerification struct {
+	Period     int                  `json:"period"`
+	Passed     bool                 `json:"passed"`
+	Missing    []models.MatchupPair `json:"missing,omitempty"`    // Expected but not in Fantrax
+	Unexpected []models.MatchupPair `json:"unexpected,omitempty"` // In Fantrax but not expected
+}
+
+// ScheduleVerification is a period-by-period pass/fail report of a league's schedule against
+// an expected one
+type ScheduleVerification struct {
+	Periods []PeriodVerification `json:"periods"` // In period order
+	Passed  int                  `json:"passed"`
+	Failed  int                  `json:"failed"`
+
+	teams map[string]string // Team ID -> short name, for String
+}
+
+// OK reports whether every period matched
+func (v *ScheduleVerification) OK() bool {
+	return v.Failed == 0
+}
+
+// String formats the report one line per period, with the mismatched matchups of failed
+// periods below them
+func (v *ScheduleVerification) String() string {
+	var b strings.Builder
+	for _, period := range v.Periods {
+		if period.Passed {
+			fmt.Fprintf(&b, "Period %d: PASS\n", period.Period)
+			continue
+		}
+		fmt.Fprintf(&b, "Period %d: FAIL\n", period.Period)
+		for _, pair := range period.Missing {
+			fmt.Fprintf(&b, "  - %s\n", v.pairName(pair))
+		}
+		for _, pair := range period.Unexpected {
+			fmt.Fprintf(&b, "  + %s\n", v.pairName(pair))
+		}
+	}
+	fmt.Fprintf(&b, "%d passed, %d failed\n", v.Passed, v.Failed)
+	return b.String()
+}
+
+// pairName formats a matchup with team short names, e.g. "ACE @ BAT" or "ACE BYE"
+func (v *ScheduleVerification) pairName(pair models.MatchupPair) string {
+	name := func(teamID string) string {
+		if short := v.teams[teamID]; short != "" {
+			return short
+		}
+		return teamID
+	}
+	if IsBye(pair) {
+		return name(pair.AwayTeamID) + " BYE"
+	}
+	return name(pair.AwayTeamID) + " @ " + name(pair.HomeTeamID)
+}
+
+// VerifySchedule fetches the league setup once and checks every period of expected against
+// the matchups Fantrax has saved, e.g. after uploading a schedule with SetPeriodMatchups.
+// Matchups are compared regardless of order, but home and away must match.
+//
+// Parameters:
+//   - setup: The league setup the upload used, for team names in the report; may be nil
+//   - expected: The matchups each period should have, keyed by period
+func (c *Client) VerifySchedule(setup *models.LeagueSetupMatchups, expected map[int][]models.MatchupPair) (*ScheduleVerification, error) {
+	saved, err := c.GetLeagueSetupMatchups()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league setup: %w", err)
+	}
+	if setup == nil {
+		setup = saved
+	}
+	return CompareSchedule(setup, saved.Matchups, expected), nil
+}
+
+// CompareSchedule checks every period of expected against actual, as VerifySchedule does
+// with the matchups fetched from Fantrax
+func CompareSchedule(setup *models.LeagueSetupMatchups, actual, expected map[int][]models.MatchupPair) *ScheduleVerification {
+	report := &ScheduleVerification{teams: make(map[string]string)}
+	if setup != nil {
+		for _, team := range setup.Teams {
+			report.teams[team.TeamID] = team.ShortName
+		}
+	}
+
+	periods := make([]int, 0, len(expected))
+	for period := range expected {
+		periods = append(periods, period)
+	}
+	sort.Ints(periods)
+
+	for _, period := range periods {
+		result := PeriodVerification{Period: period}
+		result.Missing = pairsNotIn(expected[period], actual[period])
+		result.Unexpected = pairsNotIn(actual[period], expected[period])
+		result.Passed = len(result.Missing) == 0 && len(result.Unexpected) == 0
+		if result.Passed {
+			report.Passed++
+		} else {
+			report.Failed++
+		}
+		report.Periods = append(report.Periods, result)
+	}
+	return report
+}
+
+// pairsNotIn returns the matchups of a that aren't in b, counting repeats
+func pairsNotIn(a, b []models.MatchupPair) []models.MatchupPair {
+	remaining := make(map[models.MatchupPair]int, len(b))
+	for _, pair := range b {
+		remaining[pair]++
+	}
+	var missing []models.MatchupPair
+	for _, pair := range a {
+		if remaining[pair] > 0 {
+			remaining[pair]--
+			continue
+		}
+		missing = append(missing, pair)
+	}
+	return missing
+}
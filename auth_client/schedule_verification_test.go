@@ -0,0 +1,39 @@
+package auth_client
+
+import (
+	"testing"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestCompareSchedule(t *testing.T) {
+	setup := &models.LeagueSetupMatchups{Teams: []models.LeagueSetupTeam{
+		{TeamID: "a", ShortName: "ACE"}, {TeamID: "b", ShortName: "BAT"}, {TeamID: "c", ShortName: "CUB"},
+	}}
+	actual := map[int][]models.MatchupPair{
+		1: {ByeMatchup("c"), {AwayTeamID: "a", HomeTeamID: "b"}},
+		2: {{AwayTeamID: "b", HomeTeamID: "a"}, ByeMatchup("c")},
+	}
+	expected := map[int][]models.MatchupPair{
+		1: {{AwayTeamID: "a", HomeTeamID: "b"}, ByeMatchup("c")},
+		2: {{AwayTeamID: "a", HomeTeamID: "b"}, ByeMatchup("c")},
+		3: {{AwayTeamID: "a", HomeTeamID: "c"}, ByeMatchup("b")},
+	}
+
+	report := CompareSchedule(setup, actual, expected)
+	if report.OK() || report.Passed != 1 || report.Failed != 2 {
+		t.Fatalf("passed %d, failed %d, want 1 and 2", report.Passed, report.Failed)
+	}
+	// Home and away must match
+	if p := report.Periods[1]; p.Period != 2 || len(p.Missing) != 1 || len(p.Unexpected) != 1 {
+		t.Errorf("period 2 = %+v", p)
+	}
+
+	want := "Period 1: PASS\n" +
+		"Period 2: FAIL\n  - ACE @ BAT\n  + BAT @ ACE\n" +
+		"Period 3: FAIL\n  - ACE @ CUB\n  - BAT BYE\n" +
+		"1 passed, 2 failed\n"
+	if got := report.String(); got != want {
+		t.Errorf("String() =\n%s\nwant\n%s", got, want)
+	}
+}
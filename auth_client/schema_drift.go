@@ -0,0 +1,26 @@
+package auth_client
+
+import (
+	"github.com/pmurley/go-fantrax"
+	log "github.com/sirupsen/logrus"
+)
+
+// checkSchema reports any differences between an fxpa response and the type it decodes into
+// when ValidateSchema is enabled
+func (c *Client) checkSchema(method string, body []byte, target interface{}) {
+	if !c.ValidateSchema {
+		return
+	}
+	drifts, err := fantrax.DetectSchemaDrift(method, body, target)
+	if err != nil {
+		log.Warn("fantrax schema check failed: ", err)
+		return
+	}
+	for _, drift := range drifts {
+		if c.OnSchemaDrift != nil {
+			c.OnSchemaDrift(drift)
+		} else {
+			log.Warn("fantrax schema drift: ", drift.String())
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package auth_client
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ScoreAdjustment is a non-zero score adjustment applied to one team in one matchup
+type ScoreAdjustment struct {
+	ScoringPeriod int     `json:"scoringPeriod"`
+	Date          string  `json:"date"`
+	TeamID        string  `json:"teamId"`
+	OpponentID    string  `json:"opponentId"`
+	Points        float64 `json:"points"`     // Points scored before the adjustment
+	Adjustment    float64 `json:"adjustment"` // The adjustment amount (may be negative)
+	Total         float64 `json:"total"`      // Final score including the adjustment
+}
+
+// ScoreChange records a team whose final score for a period differs between two snapshots
+//
+// Stat corrections show up as a change in Points, commissioner adjustments as a change
+// in Adjustment. Either way Total is the score that decides the matchup.
+type ScoreChange struct {
+	ScoringPeriod      int     `json:"scoringPeriod"`
+	TeamID             string  `json:"teamId"`
+	OpponentID         string  `json:"opponentId"`
+	PreviousPoints     float64 `json:"previousPoints"`
+	CurrentPoints      float64 `json:"currentPoints"`
+	PreviousAdjustment float64 `json:"previousAdjustment"`
+	CurrentAdjustment  float64 `json:"currentAdjustment"`
+	PreviousTotal      float64 `json:"previousTotal"`
+	CurrentTotal       float64 `json:"currentTotal"`
+	ResultChanged      bool    `json:"resultChanged"` // True if the change flipped the matchup's winner
+}
+
+// GetScoreAdjustments returns every score adjustment applied so far this season
+//
+// Fantrax does not expose a separate stat-correction log to the endpoints this library uses;
+// adjustments are reported in the "Adj" column of each completed matchup in the SCHEDULE
+// view. This collects every non-zero entry from that column, ordered by period.
+//
+// To detect stat corrections that changed raw points after the fact, keep a previous
+// GetAllMatchups result and compare it with DetectScoreChanges.
+func (c *Client) GetScoreAdjustments() ([]ScoreAdjustment, error) {
+	result, err := c.GetAllMatchups()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get matchups: %w", err)
+	}
+	return CollectScoreAdjustments(result.Matchups), nil
+}
+
+// CollectScoreAdjustments extracts the non-zero score adjustments from a list of matchups
+func CollectScoreAdjustments(matchups []Matchup) []ScoreAdjustment {
+	var adjustments []ScoreAdjustment
+	for _, m := range matchups {
+		sides := []struct{ team, opponent MatchTeam }{
+			{m.AwayTeam, m.HomeTeam},
+			{m.HomeTeam, m.AwayTeam},
+		}
+		for _, side := range sides {
+			if side.team.Adjustment == 0 {
+				continue
+			}
+			adjustments = append(adjustments, ScoreAdjustment{
+				ScoringPeriod: m.ScoringPeriod,
+				Date:          m.Date,
+				TeamID:        side.team.TeamID,
+				OpponentID:    side.opponent.TeamID,
+				Points:        side.team.Points,
+				Adjustment:    side.team.Adjustment,
+				Total:         side.team.Total,
+			})
+		}
+	}
+
+	sort.SliceStable(adjustments, func(i, j int) bool {
+		return adjustments[i].ScoringPeriod < adjustments[j].ScoringPeriod
+	})
+	return adjustments
+}
+
+// DetectScoreChanges compares two GetAllMatchups snapshots and returns every team whose
+// points, adjustment or total for a period changed between them
+//
+// Matchups that only appear in one snapshot are ignored. Results are ordered by period.
+func DetectScoreChanges(previous, current *AllMatchupsResult) []ScoreChange {
+	type sideKey struct {
+		period int
+		teamID string
+	}
+
+	previousSides := make(map[sideKey]Matchup)
+	for _, m := range previous.Matchups {
+		previousSides[sideKey{m.ScoringPeriod, m.AwayTeam.TeamID}] = m
+		previousSides[sideKey{m.ScoringPeriod, m.HomeTeam.TeamID}] = m
+	}
+
+	var changes []ScoreChange
+	for _, m := range current.Matchups {
+		for _, teamID := range []string{m.AwayTeam.TeamID, m.HomeTeam.TeamID} {
+			old, ok := previousSides[sideKey{m.ScoringPeriod, teamID}]
+			if !ok {
+				continue
+			}
+
+			oldTeam, oldOpponent := matchSides(old, teamID)
+			newTeam, newOpponent := matchSides(m, teamID)
+			if oldTeam == newTeam {
+				continue
+			}
+
+			changes = append(changes, ScoreChange{
+				ScoringPeriod:      m.ScoringPeriod,
+				TeamID:             teamID,
+				OpponentID:         newOpponent.TeamID,
+				PreviousPoints:     oldTeam.Points,
+				CurrentPoints:      newTeam.Points,
+				PreviousAdjustment: oldTeam.Adjustment,
+				CurrentAdjustment:  newTeam.Adjustment,
+				PreviousTotal:      oldTeam.Total,
+				CurrentTotal:       newTeam.Total,
+				ResultChanged:      compareTotals(oldTeam.Total, oldOpponent.Total) != compareTotals(newTeam.Total, newOpponent.Total),
+			})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].ScoringPeriod < changes[j].ScoringPeriod
+	})
+	return changes
+}
+
+// matchSides returns the given team's side of a matchup followed by the opponent's side
+func matchSides(m Matchup, teamID string) (MatchTeam, MatchTeam) {
+	if m.HomeTeam.TeamID == teamID {
+		return m.HomeTeam, m.AwayTeam
+	}
+	return m.AwayTeam, m.HomeTeam
+}
+
+// compareTotals returns 1 for a win, -1 for a loss and 0 for a tie
+func compareTotals(total, opponentTotal float64) int {
+	switch {
+	case total > opponentTotal:
+		return 1
+	case total < opponentTotal:
+		return -1
+	default:
+		return 0
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"io"
 	"net/http"
 	"time"
+
+	"github.com/pmurley/go-fantrax/redact"
 )
 
 // MinorsEligibilityRequest represents the request payload for setting minors eligibility
@@ -43,6 +45,9 @@ func (c *Client) SetMinorsEligible(playerID string) (*MinorsEligibilityResponse,
 
 // saveMinorsEligibility is the internal function that calls the Fantrax minors eligibility override endpoint.
 func (c *Client) saveMinorsEligibility(playerID string, ineligibilityDate string) (*MinorsEligibilityResponse, error) {
+	if err := c.checkCommissioner(); err != nil {
+		return nil, err
+	}
 	requestPayload := MinorsEligibilityRequest{
 		PlayerID:                playerID,
 		MinorsIneligibilityDate: ineligibilityDate,
@@ -67,7 +72,7 @@ func (c *Client) saveMinorsEligibility(playerID string, ineligibilityDate string
 
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("minors eligibility API returned non-200 status code: %d, body: %s", resp.StatusCode, string(bodyBytes))
+		return nil, fmt.Errorf("minors eligibility API returned non-200 status code: %d, body: %s", resp.StatusCode, redact.String(string(bodyBytes)))
 	}
 
 	body, err := io.ReadAll(resp.Body)
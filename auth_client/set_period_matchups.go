@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/pmurley/go-fantrax/models"
+	"github.com/pmurley/go-fantrax/redact"
 )
 
 // SetPeriodMatchups saves matchup changes for a specific period by POSTing the
@@ -19,8 +20,14 @@ import (
 // the complete form body (all 179 periods, divisions, hidden fields, etc.) and
 // submits it. A successful save returns a 302 redirect; any other status is an error.
 //
+// The matchups are checked with ValidateMatchupPairs first, so a mistyped team ID
+// returns a *MatchupValidationError instead of reaching Fantrax.
+//
 // The setup struct is modified in-place with the new matchups for the given period.
 func (c *Client) SetPeriodMatchups(setup *models.LeagueSetupMatchups, period int, matchups []models.MatchupPair) error {
+	if err := c.checkCommissioner(); err != nil {
+		return err
+	}
 	// Validate that the period exists in the setup data
 	if _, exists := setup.Matchups[period]; !exists {
 		return fmt.Errorf("period %d not found in setup matchups", period)
@@ -28,37 +35,102 @@ func (c *Client) SetPeriodMatchups(setup *models.LeagueSetupMatchups, period int
 	if len(matchups) == 0 {
 		return fmt.Errorf("matchups must not be empty")
 	}
+	if err := ValidateMatchupPairs(setup, period, matchups); err != nil {
+		return err
+	}
 
 	// Update the matchups for the target period
 	setup.Matchups[period] = matchups
 
-	// Build the full form body
-	formBody := BuildFormBody(setup, period)
+	return c.postLeagueSetup(BuildFormBody(setup, period))
+}
+
+// ByeTeamID is the home team ID of a bye in the league setup matchups
+const ByeTeamID = "-1"
+
+// MatchupValidationError is returned by SetPeriodMatchups when the submitted matchups
+// don't fit the league
+type MatchupValidationError struct {
+	Period   int
+	Problems []string
+}
+
+func (e *MatchupValidationError) Error() string {
+	return fmt.Sprintf("invalid matchups for period %d: %s", e.Period, strings.Join(e.Problems, "; "))
+}
 
-	// POST to createLeague.go
+// ValidateMatchupPairs checks a period's matchups against the league's teams: every team
+// ID must belong to the league, no team may play twice in the period, and a bye must have
+// ByeTeamID as its home team
+//
+// Returns a *MatchupValidationError listing every problem, or nil.
+func ValidateMatchupPairs(setup *models.LeagueSetupMatchups, period int, matchups []models.MatchupPair) error {
+	var problems []string
+	seen := make(map[string]int)
+	check := func(i int, side, teamID string) {
+		if GetTeamByID(setup, teamID) == nil {
+			problems = append(problems, fmt.Sprintf("matchup %d: %s team %q is not in the league", i+1, side, teamID))
+			return
+		}
+		if first, ok := seen[teamID]; ok {
+			problems = append(problems, fmt.Sprintf("matchup %d: team %s already plays in matchup %d", i+1, teamID, first+1))
+			return
+		}
+		seen[teamID] = i
+	}
+
+	for i, pair := range matchups {
+		if isByeAlias(pair.AwayTeamID) {
+			problems = append(problems, fmt.Sprintf("matchup %d: a bye must be the home team (%q), not the away team", i+1, ByeTeamID))
+			continue
+		}
+		check(i, "away", pair.AwayTeamID)
+		switch {
+		case pair.HomeTeamID == ByeTeamID:
+		case isByeAlias(pair.HomeTeamID):
+			problems = append(problems, fmt.Sprintf("matchup %d: a bye must use home team %q, got %q", i+1, ByeTeamID, pair.HomeTeamID))
+		case pair.HomeTeamID == pair.AwayTeamID:
+			problems = append(problems, fmt.Sprintf("matchup %d: team %s can't play itself", i+1, pair.AwayTeamID))
+		default:
+			check(i, "home", pair.HomeTeamID)
+		}
+	}
+
+	if len(problems) > 0 {
+		return &MatchupValidationError{Period: period, Problems: problems}
+	}
+	return nil
+}
+
+// isByeAlias reports whether a team ID looks like an attempt at a bye
+func isByeAlias(teamID string) bool {
+	switch strings.ToLower(strings.TrimSpace(teamID)) {
+	case ByeTeamID, "", "0", "bye", "null", "none":
+		return true
+	}
+	return false
+}
+
+// postLeagueSetup POSTs a league setup form body to the createLeague.go endpoint.
+// A successful save returns a 302 redirect; any other status is an error.
+func (c *Client) postLeagueSetup(formBody url.Values) error {
 	postURL := fmt.Sprintf("https://www.fantrax.com/newui/fantasy/createLeague.go?leagueId=%s", c.LeagueID)
 	req, err := http.NewRequest("POST", postURL, strings.NewReader(formBody.Encode()))
 	if err != nil {
 		return fmt.Errorf("failed to create POST request: %w", err)
 	}
 
-	cookiesString, err := GetCookies()
+	cookiesString, err := c.cookies()
 	if err != nil {
 		return fmt.Errorf("failed to get cookies: %w", err)
 	}
 	req.Header.Set("Cookie", cookiesString)
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko)")
-
-	// Use a client that does NOT follow redirects so we can detect the 302
-	noRedirectClient := &http.Client{
-		Transport: c.Client.Transport,
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			return http.ErrUseLastResponse
-		},
-	}
+	req.Header.Set("User-Agent", c.userAgent())
 
-	resp, err := noRedirectClient.Do(req)
+	// Don't follow redirects so we can detect the 302
+	c.RateLimiter.Wait()
+	resp, err := c.noRedirectClient().Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to send POST request: %w", err)
 	}
@@ -68,7 +140,7 @@ func (c *Client) SetPeriodMatchups(setup *models.LeagueSetupMatchups, period int
 	// Include response body in error for diagnostics.
 	if resp.StatusCode != http.StatusFound {
 		body, _ := io.ReadAll(resp.Body)
-		snippet := string(body)
+		snippet := redact.String(string(body))
 		if len(snippet) > 500 {
 			snippet = snippet[:500] + "..."
 		}
@@ -82,16 +154,31 @@ func (c *Client) SetPeriodMatchups(setup *models.LeagueSetupMatchups, period int
 // This includes all hidden fields, select fields, checkbox fields, team names,
 // owner emails, divisions, hardcoded fields, and all 179 periods of matchup data.
 func BuildFormBody(setup *models.LeagueSetupMatchups, period int) url.Values {
+	form := buildSetupForm(setup)
+
+	// Signal a matchup edit
+	if _, ok := setup.FormConfig.HiddenFields["h2hConfigChangesMade"]; ok {
+		form.Set("h2hConfigChangesMade", "y")
+	}
+	form.Set("tabId", "Matchups")
+
+	// Matchup edit metadata
+	form.Set("matchupScoringPeriodToEdit", strconv.Itoa(period))
+	form.Set("matchupsEditedManually", "true")
+
+	return form
+}
+
+// buildSetupForm assembles the league setup form fields shared by every tab:
+// hidden, select, and checkbox fields, team names, owner emails, divisions,
+// hardcoded fields, and all periods of matchup data.
+func buildSetupForm(setup *models.LeagueSetupMatchups) url.Values {
 	form := url.Values{}
 	cfg := &setup.FormConfig
 
-	// Hidden fields, with overrides for matchup-edit signals
+	// Hidden fields
 	for name, value := range cfg.HiddenFields {
-		if name == "h2hConfigChangesMade" {
-			form.Set(name, "y")
-		} else {
-			form.Set(name, value)
-		}
+		form.Set(name, value)
 	}
 
 	// Select fields
@@ -128,17 +215,12 @@ func BuildFormBody(setup *models.LeagueSetupMatchups, period int) url.Values {
 	}
 
 	// Hardcoded fields required by the form submission
-	form.Set("tabId", "Matchups")
 	form.Set("gotoNextPage", "false")
 	form.Set("divisionName", "")
 	form.Set("inviteMessage", "")
 	form.Set("calculatedHeadToHeadOpponentType", "1")
 	form.Set("playoffMatchupSetConfigId", "")
 
-	// Matchup edit metadata
-	form.Set("matchupScoringPeriodToEdit", strconv.Itoa(period))
-	form.Set("matchupsEditedManually", "true")
-
 	// All matchup period data: repeated "matchups" key, one per period
 	for _, entry := range serializeMatchups(setup) {
 		form.Add("matchups", entry)
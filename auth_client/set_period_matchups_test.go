@@ -0,0 +1,32 @@
+package auth_client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestValidateMatchupPairs(t *testing.T) {
+	setup := &models.LeagueSetupMatchups{Teams: []models.LeagueSetupTeam{{TeamID: "a"}, {TeamID: "b"}, {TeamID: "c"}, {TeamID: "d"}}}
+
+	valid := []models.MatchupPair{{AwayTeamID: "a", HomeTeamID: "b"}, {AwayTeamID: "c", HomeTeamID: ByeTeamID}}
+	if err := ValidateMatchupPairs(setup, 1, valid); err != nil {
+		t.Errorf("expected valid matchups, got %v", err)
+	}
+
+	err := ValidateMatchupPairs(setup, 2, []models.MatchupPair{
+		{AwayTeamID: "a", HomeTeamID: "bb"},
+		{AwayTeamID: "c", HomeTeamID: "a"},
+		{AwayTeamID: "d", HomeTeamID: "BYE"},
+		{AwayTeamID: "-1", HomeTeamID: "b"},
+		{AwayTeamID: "b", HomeTeamID: "b"},
+	})
+	var validation *MatchupValidationError
+	if !errors.As(err, &validation) {
+		t.Fatalf("expected a MatchupValidationError, got %v", err)
+	}
+	if validation.Period != 2 || len(validation.Problems) != 5 {
+		t.Errorf("expected 5 problems in period 2, got %+v", validation)
+	}
+}
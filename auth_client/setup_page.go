@@ -0,0 +1,283 @@
+package auth_client
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// setupPage is the league setup page parsed into the parts the setup parsers read: its form
+// controls, and the JavaScript in script blocks and event handlers
+type setupPage struct {
+	inputs  []setupInput
+	selects []setupSelect
+	script  string // Script text, text outside elements, and on* handler attributes
+}
+
+// setupInput is an <input> element
+type setupInput struct {
+	Type    string // Lowercased; "text" when missing
+	Name    string
+	Value   string
+	Checked bool
+}
+
+// setupSelect is a <select> element and the value of its selected option
+type setupSelect struct {
+	Name     string
+	Value    string
+	Selected bool // Whether any option is marked selected
+}
+
+// jsArg is one argument of a JavaScript call
+type jsArg struct {
+	Value   string // Unquoted and unescaped for string literals
+	Literal bool   // A string, number, or boolean literal rather than an expression
+}
+
+// newSetupPage parses the league setup page HTML
+//
+// Controls are found by element and attribute regardless of attribute order or quoting,
+// with entities decoded. The fields the page defines in script, such as its addTeam calls
+// and matchupMap, are read from the script text. Markup inside <template>, and HTML held in
+// script strings, isn't part of the page's form, so the input templates the page's JS builds
+// rows from are never read as fields. Only hand-built pages cover this so far; a saved
+// Fantrax setup page still needs to be added to the fixtures.
+func newSetupPage(page string) (*setupPage, error) {
+	doc, err := html.Parse(strings.NewReader(page))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	p := &setupPage{}
+	var script strings.Builder
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			script.WriteString(n.Data)
+			script.WriteString("\n")
+		case html.ElementNode:
+			for _, attr := range n.Attr {
+				if strings.HasPrefix(attr.Key, "on") {
+					script.WriteString(attr.Val)
+					script.WriteString("\n")
+				}
+			}
+			switch n.DataAtom {
+			case atom.Template:
+				return
+			case atom.Input:
+				input := setupInput{
+					Type:  strings.ToLower(nodeAttr(n, "type")),
+					Name:  nodeAttr(n, "name"),
+					Value: nodeAttr(n, "value"),
+				}
+				_, input.Checked = lookupAttr(n, "checked")
+				if input.Type == "" {
+					input.Type = "text"
+				}
+				if input.Name != "" {
+					p.inputs = append(p.inputs, input)
+				}
+			case atom.Select:
+				if name := nodeAttr(n, "name"); name != "" {
+					p.selects = append(p.selects, selectedOption(n, name))
+				}
+				return
+			}
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(doc)
+	p.script = script.String()
+	return p, nil
+}
+
+// selectedOption reads the value of a select's selected option. An option without a value
+// attribute submits its text, as in a browser.
+func selectedOption(n *html.Node, name string) setupSelect {
+	sel := setupSelect{Name: name}
+	var walk func(n *html.Node) bool
+	walk = func(n *html.Node) bool {
+		if n.Type == html.ElementNode && n.DataAtom == atom.Option {
+			if _, ok := lookupAttr(n, "selected"); ok {
+				sel.Selected = true
+				if value, ok := lookupAttr(n, "value"); ok {
+					sel.Value = value
+				} else {
+					sel.Value = strings.TrimSpace(nodeText(n))
+				}
+				return true
+			}
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			if walk(child) {
+				return true
+			}
+		}
+		return false
+	}
+	walk(n)
+	return sel
+}
+
+// lookupAttr returns an element's attribute and whether it is present
+func lookupAttr(n *html.Node, key string) (string, bool) {
+	for _, attr := range n.Attr {
+		if attr.Namespace == "" && attr.Key == key {
+			return attr.Val, true
+		}
+	}
+	return "", false
+}
+
+// nodeAttr returns an element's attribute, or "" if it isn't present
+func nodeAttr(n *html.Node, key string) string {
+	value, _ := lookupAttr(n, key)
+	return value
+}
+
+// nodeText returns the text inside a node
+func nodeText(n *html.Node) string {
+	var b strings.Builder
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			b.WriteString(n.Data)
+		}
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(n)
+	return b.String()
+}
+
+// jsCalls finds the calls of a JavaScript function in src and returns each call's arguments
+//
+// String literals may use either quote and contain escaped quotes, commas, and parentheses.
+// The function's own definition is skipped.
+func jsCalls(src, fn string) [][]jsArg {
+	var calls [][]jsArg
+	for offset := 0; ; {
+		i := strings.Index(src[offset:], fn+"(")
+		if i < 0 {
+			return calls
+		}
+		start := offset + i
+		offset = start + len(fn) + 1
+		if start > 0 && isJSIdentChar(src[start-1]) {
+			continue
+		}
+		if strings.HasSuffix(strings.TrimRight(src[:start], " \t"), "function") {
+			continue
+		}
+		args, end, ok := parseJSArgs(src, offset)
+		if !ok {
+			continue
+		}
+		calls = append(calls, args)
+		offset = end
+	}
+}
+
+// parseJSArgs reads the arguments of a call starting just after its opening parenthesis and
+// returns them with the position after the closing parenthesis
+func parseJSArgs(src string, pos int) ([]jsArg, int, bool) {
+	var args []jsArg
+	start, depth := pos, 0
+	for pos < len(src) {
+		switch c := src[pos]; {
+		case c == '\'' || c == '"':
+			_, end, ok := readJSString(src, pos)
+			if !ok {
+				return nil, 0, false
+			}
+			pos = end
+			continue
+		case c == '(' || c == '[' || c == '{':
+			depth++
+		case (c == ']' || c == '}') && depth > 0:
+			depth--
+		case c == ')' && depth > 0:
+			depth--
+		case c == ')':
+			if arg := strings.TrimSpace(src[start:pos]); arg != "" || len(args) > 0 {
+				args = append(args, newJSArg(arg))
+			}
+			return args, pos + 1, true
+		case c == ',' && depth == 0:
+			args = append(args, newJSArg(strings.TrimSpace(src[start:pos])))
+			start = pos + 1
+		}
+		pos++
+	}
+	return nil, 0, false
+}
+
+// newJSArg classifies an argument's source text
+func newJSArg(arg string) jsArg {
+	if arg != "" && (arg[0] == '\'' || arg[0] == '"') {
+		if value, end, ok := readJSString(arg, 0); ok && end == len(arg) {
+			return jsArg{Value: value, Literal: true}
+		}
+		return jsArg{Value: arg}
+	}
+	_, err := strconv.ParseFloat(arg, 64)
+	return jsArg{Value: arg, Literal: arg == "true" || arg == "false" || err == nil}
+}
+
+// readJSString reads the JavaScript string literal starting at pos and returns its value
+// and the position after its closing quote
+func readJSString(src string, pos int) (string, int, bool) {
+	quote := src[pos]
+	var b strings.Builder
+	for i := pos + 1; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case c == quote:
+			return b.String(), i + 1, true
+		case c == '\n':
+			return "", 0, false
+		case c == '\\' && i+1 < len(src):
+			i++
+			switch e := src[i]; e {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'r':
+				b.WriteByte('\r')
+			case 'u', 'x':
+				size := 4
+				if e == 'x' {
+					size = 2
+				}
+				if i+size < len(src) {
+					if r, err := strconv.ParseUint(src[i+1:i+1+size], 16, 32); err == nil {
+						b.WriteRune(rune(r))
+						i += size
+						continue
+					}
+				}
+				b.WriteByte(e)
+			default:
+				b.WriteByte(e)
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", 0, false
+}
+
+// isJSIdentChar reports whether c can appear in a JavaScript identifier
+func isJSIdentChar(c byte) bool {
+	return c == '_' || c == '$' || c == '.' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
@@ -0,0 +1,148 @@
+package auth_client
+
+import (
+	"fmt"
+	"sort"
+)
+
+// TeamStandingChange is one team's movement between two standings snapshots
+type TeamStandingChange struct {
+	TeamID string `json:"teamId"`
+	Name   string `json:"name"`
+
+	PrevRank   int `json:"prevRank"`
+	Rank       int `json:"rank"`
+	RankChange int `json:"rankChange"` // Positive when the team moved up
+
+	PrevStreak    string `json:"prevStreak"`
+	Streak        string `json:"streak"`
+	StreakChanged bool   `json:"streakChanged"`
+
+	PrevGamesBack   float64 `json:"prevGamesBack"`
+	GamesBack       float64 `json:"gamesBack"`
+	GamesBackChange float64 `json:"gamesBackChange"` // Negative when the team gained ground
+
+	WinsAdded   int `json:"winsAdded"`
+	LossesAdded int `json:"lossesAdded"`
+	TiesAdded   int `json:"tiesAdded"`
+
+	New bool `json:"new"` // True if the team was not in the previous snapshot
+}
+
+// StandingsDiff is the set of changes between two standings snapshots, ordered by current rank
+type StandingsDiff struct {
+	Teams []TeamStandingChange `json:"teams"`
+}
+
+// DiffStandings compares two standings snapshots team by team
+//
+// Teams are matched by ID. A team that only appears in curr is marked New; teams that only
+// appear in prev are ignored.
+//
+// Parameters:
+//   - prev: The earlier snapshot (e.g. last week's standings)
+//   - curr: The later snapshot
+func DiffStandings(prev, curr *LeagueStandings) *StandingsDiff {
+	before := make(map[string]TeamStanding, len(prev.Teams))
+	for _, team := range prev.Teams {
+		before[team.TeamID] = team
+	}
+
+	diff := &StandingsDiff{}
+	for _, team := range curr.Teams {
+		change := TeamStandingChange{
+			TeamID:    team.TeamID,
+			Name:      team.Name,
+			Rank:      team.Rank,
+			Streak:    team.Streak,
+			GamesBack: team.GamesBack,
+		}
+
+		old, ok := before[team.TeamID]
+		if !ok {
+			change.New = true
+			diff.Teams = append(diff.Teams, change)
+			continue
+		}
+
+		change.PrevRank = old.Rank
+		change.RankChange = old.Rank - team.Rank
+		change.PrevStreak = old.Streak
+		change.StreakChanged = old.Streak != team.Streak
+		change.PrevGamesBack = old.GamesBack
+		change.GamesBackChange = team.GamesBack - old.GamesBack
+		change.WinsAdded = team.Wins - old.Wins
+		change.LossesAdded = team.Losses - old.Losses
+		change.TiesAdded = team.Ties - old.Ties
+		diff.Teams = append(diff.Teams, change)
+	}
+
+	sort.SliceStable(diff.Teams, func(i, j int) bool {
+		return diff.Teams[i].Rank < diff.Teams[j].Rank
+	})
+	return diff
+}
+
+// Movers returns the teams whose rank changed, biggest movement first
+func (d *StandingsDiff) Movers() []TeamStandingChange {
+	var movers []TeamStandingChange
+	for _, change := range d.Teams {
+		if change.RankChange != 0 {
+			movers = append(movers, change)
+		}
+	}
+	sort.SliceStable(movers, func(i, j int) bool {
+		return abs(movers[i].RankChange) > abs(movers[j].RankChange)
+	})
+	return movers
+}
+
+// Summary returns one recap line per team, in rank order
+// (e.g. "3. Team A (up 2 from 5th, 1.5 GB, streak W3)")
+func (d *StandingsDiff) Summary() []string {
+	lines := make([]string, 0, len(d.Teams))
+	for _, c := range d.Teams {
+		var movement string
+		switch {
+		case c.New:
+			movement = "new"
+		case c.RankChange > 0:
+			movement = fmt.Sprintf("up %d from %s", c.RankChange, ordinal(c.PrevRank))
+		case c.RankChange < 0:
+			movement = fmt.Sprintf("down %d from %s", -c.RankChange, ordinal(c.PrevRank))
+		default:
+			movement = "unchanged"
+		}
+
+		line := fmt.Sprintf("%d. %s (%s, %.1f GB", c.Rank, c.Name, movement, c.GamesBack)
+		if c.StreakChanged && c.Streak != "" {
+			line += ", streak " + c.Streak
+		}
+		lines = append(lines, line+")")
+	}
+	return lines
+}
+
+// ordinal formats a rank as "1st", "2nd", "3rd", "4th", ...
+func ordinal(n int) string {
+	suffix := "th"
+	if n%100 < 11 || n%100 > 13 {
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
+
+// abs returns the absolute value of n
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
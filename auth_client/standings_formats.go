@@ -0,0 +1,231 @@
+package auth_client
+
+import (
+	"strconv"
+	"strings"
+)
+
+// LeagueFormat identifies the scoring format a standings response was parsed as
+type LeagueFormat string
+
+const (
+	// LeagueFormatH2HPoints is a head-to-head league scored by fantasy points
+	LeagueFormatH2HPoints LeagueFormat = "H2H_POINTS"
+	// LeagueFormatH2HCategories is a head-to-head league where each matchup is decided category by category
+	LeagueFormatH2HCategories LeagueFormat = "H2H_CATEGORIES"
+	// LeagueFormatRotisserie ranks teams in each category and sums the standings points
+	LeagueFormatRotisserie LeagueFormat = "ROTISSERIE"
+	// LeagueFormatPoints ranks teams by total fantasy points with no matchups
+	LeagueFormatPoints LeagueFormat = "POINTS"
+	// LeagueFormatUnknown means no standings table was recognized
+	LeagueFormatUnknown LeagueFormat = "UNKNOWN"
+)
+
+// standingsTableFormat maps a standings table type other than H2hPointsBased to its league
+// format, assuming Fantrax names these tables after the format as it does the H2H points ones
+// (e.g. "H2hCategoriesBased1", "Roto1", "PointsBased1"). Unlike H2hPointsBased1-3, these names
+// have not been checked against captured responses; see Client.LeagueFormat. Anything else
+// returns "".
+func standingsTableFormat(tableType string) LeagueFormat {
+	lower := strings.ToLower(tableType)
+	switch {
+	case strings.HasPrefix(lower, "h2h"):
+		if strings.Contains(lower, "categor") {
+			return LeagueFormatH2HCategories
+		}
+		return ""
+	case strings.Contains(lower, "roto"):
+		return LeagueFormatRotisserie
+	case strings.HasPrefix(lower, "points"):
+		return LeagueFormatPoints
+	}
+	return ""
+}
+
+// tableFormat is the format a standings or matchup table is parsed as. A league format from
+// the league's settings applies to every table listing teams, except the H2H points tables,
+// which are recognized by their exact type; without one the format comes from the table type.
+func tableFormat(table Table, league LeagueFormat) LeagueFormat {
+	if strings.HasPrefix(table.TableType, "H2hPointsBased") {
+		return ""
+	}
+	switch league {
+	case LeagueFormatH2HCategories, LeagueFormatRotisserie, LeagueFormatPoints:
+		if hasTeamRows(table) {
+			return league
+		}
+	}
+	return standingsTableFormat(table.TableType)
+}
+
+// standingsColumn is the TeamStanding field a standings column fills
+type standingsColumn int
+
+const (
+	columnOther standingsColumn = iota
+	columnWins
+	columnLosses
+	columnTies
+	columnWinPct
+	columnDivRecord
+	columnGamesBack
+	columnWaiverOrder
+	columnPointsFor
+	columnPointsAgainst
+	columnStreak
+	columnTotal
+)
+
+// standingsColumns maps lower-cased header names to the field they fill. "pts" is the
+// total in roto tables and fantasy points in points tables.
+var standingsColumns = map[string]standingsColumn{
+	"w":        columnWins,
+	"l":        columnLosses,
+	"t":        columnTies,
+	"win%":     columnWinPct,
+	"pct":      columnWinPct,
+	"div":      columnDivRecord,
+	"gb":       columnGamesBack,
+	"ww":       columnWaiverOrder,
+	"waiver":   columnWaiverOrder,
+	"pf":       columnPointsFor,
+	"fpts":     columnPointsFor,
+	"pts for":  columnPointsFor,
+	"pa":       columnPointsAgainst,
+	"pts agst": columnPointsAgainst,
+	"streak":   columnStreak,
+	"strk":     columnStreak,
+	"pts":      columnTotal,
+	"total":    columnTotal,
+	"tp":       columnTotal,
+}
+
+// columnKey is the header label used to identify a column
+func columnKey(cell Cell) string {
+	for _, label := range []string{cell.ShortName, cell.Name, cell.Content} {
+		if label = strings.TrimSpace(label); label != "" {
+			return label
+		}
+	}
+	return ""
+}
+
+// hasTeamRows reports whether any row of the table names a fantasy team
+func hasTeamRows(table Table) bool {
+	for _, row := range table.Rows {
+		if standingsTeamCell(row) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// standingsTeamCell returns the cell holding the team ID, checking fixed cells first
+func standingsTeamCell(row Row) *Cell {
+	for _, cells := range [][]Cell{row.FixedCells, row.Cells} {
+		for i := range cells {
+			if cells[i].TeamID != "" {
+				return &cells[i]
+			}
+		}
+	}
+	return nil
+}
+
+// inferStandingsFormat guesses the format of an unrecognized standings table from its
+// columns: several columns that are not standard standings fields are taken to be roto
+// categories.
+func inferStandingsFormat(table Table) LeagueFormat {
+	others := 0
+	for _, cell := range table.Header.Cells {
+		if _, known := standingsColumns[strings.ToLower(columnKey(cell))]; !known {
+			others++
+		}
+	}
+	if others >= 3 {
+		return LeagueFormatRotisserie
+	}
+	return LeagueFormatPoints
+}
+
+// parseStandingsTable reads a rotisserie or points standings table, matching columns by
+// header name rather than position since the columns depend on the league's settings
+func parseStandingsTable(table Table, teamInfo map[string]FantasyTeam, format LeagueFormat) []TeamStanding {
+	teams := make([]TeamStanding, 0, len(table.Rows))
+	for _, row := range table.Rows {
+		teamCell := standingsTeamCell(row)
+		if teamCell == nil {
+			continue
+		}
+		info := teamInfo[teamCell.TeamID]
+		team := TeamStanding{
+			TeamID:    teamCell.TeamID,
+			Name:      info.Name,
+			ShortName: info.ShortName,
+			LogoURL:   info.LogoURL512,
+		}
+		if team.Name == "" {
+			team.Name = teamCell.Content
+		}
+		for _, cell := range row.FixedCells {
+			if rank, err := strconv.Atoi(strings.TrimSpace(cell.Content)); err == nil && cell.TeamID == "" {
+				team.Rank = rank
+				break
+			}
+		}
+
+		for i, cell := range row.Cells {
+			if i >= len(table.Header.Cells) || cell.TeamID != "" {
+				continue
+			}
+			key := columnKey(table.Header.Cells[i])
+			value := strings.TrimSpace(cell.Content)
+			number, numErr := strconv.ParseFloat(strings.ReplaceAll(value, ",", ""), 64)
+
+			column := standingsColumns[strings.ToLower(key)]
+			if format == LeagueFormatRotisserie && column != columnGamesBack && column != columnWaiverOrder && column != columnTotal {
+				// Roto categories such as W (pitcher wins) share names with record columns
+				column = columnOther
+			}
+
+			switch column {
+			case columnWins:
+				team.Wins = int(number)
+			case columnLosses:
+				team.Losses = int(number)
+			case columnTies:
+				team.Ties = int(number)
+			case columnWinPct:
+				team.WinPct = number
+			case columnDivRecord:
+				team.DivRecord = value
+			case columnGamesBack:
+				team.GamesBack = number
+			case columnWaiverOrder:
+				team.WaiverOrder = int(number)
+			case columnPointsFor:
+				team.PointsFor = number
+			case columnPointsAgainst:
+				team.PointsAgainst = number
+			case columnStreak:
+				team.Streak = value
+			case columnTotal:
+				if format == LeagueFormatRotisserie {
+					team.TotalPoints = number
+				} else {
+					team.PointsFor = number
+				}
+			default:
+				if format != LeagueFormatRotisserie || numErr != nil || key == "" {
+					continue
+				}
+				if team.CategoryPoints == nil {
+					team.CategoryPoints = make(map[string]float64)
+				}
+				team.CategoryPoints[key] = number
+			}
+		}
+		teams = append(teams, team)
+	}
+	return teams
+}
@@ -0,0 +1,209 @@
+package auth_client
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pmurley/go-fantrax/auth_client/parser"
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// ProbableStart is a scheduled start for a pitcher
+type ProbableStart struct {
+	PlayerID  string    `json:"playerId"`
+	Date      time.Time `json:"date"`
+	Opponent  string    `json:"opponent"` // Opponent's MLB team abbreviation (e.g. "SF")
+	Home      bool      `json:"home"`
+	Confirmed bool      `json:"confirmed"` // False for starts guessed from the pitcher's next game
+}
+
+// StreamingOptions configures FindStreamingPitchers
+type StreamingOptions struct {
+	Start time.Time // First day of the range
+	End   time.Time // Last day of the range, inclusive
+
+	// Starts are the probable starts in the range, e.g. from an MLB probable pitchers feed.
+	// When nil, each available SP's next game from the player pool is used as an unconfirmed
+	// start (see ProbableStartsFromPool).
+	Starts []ProbableStart
+
+	// OpponentFactors scale a pitcher's FP/G by opponent, keyed by MLB team abbreviation; 1.0
+	// is an average offense and above 1 a weaker one. When nil they are computed from the
+	// player pool with OpponentFactorsFromPool. Missing teams count as 1.0.
+	OpponentFactors map[string]float64
+
+	MinFPG float64 // Ignore pitchers below this FP/G
+}
+
+// StreamingPitcher is an available starting pitcher ranked for streaming
+type StreamingPitcher struct {
+	Player          models.PoolPlayer `json:"player"`
+	Starts          []ProbableStart   `json:"starts"`
+	StartPoints     []float64         `json:"startPoints"` // Projected points for each start
+	ProjectedPoints float64           `json:"projectedPoints"`
+}
+
+// FindStreamingPitchers ranks the available starting pitchers with starts in a date range by
+// projected points, highest first
+//
+// Each start is projected as the pitcher's FP/G scaled by the opponent's factor, so a pitcher
+// with two starts against weak offenses ranks above a slightly better one with a single start.
+//
+// Parameters:
+//   - players: The player pool (rostered players are skipped; GetPlayerPool with
+//     StatusFilterAll also lets opponent factors be computed from every MLB team's hitters)
+//   - opts: The date range, probable starts, and opponent factors
+func FindStreamingPitchers(players []models.PoolPlayer, opts StreamingOptions) []StreamingPitcher {
+	starts := opts.Starts
+	if starts == nil {
+		starts = ProbableStartsFromPool(players, opts.Start)
+	}
+	factors := opts.OpponentFactors
+	if factors == nil {
+		factors = OpponentFactorsFromPool(players)
+	}
+
+	startsByPlayer := make(map[string][]ProbableStart)
+	first := truncateDay(opts.Start)
+	last := truncateDay(opts.End)
+	for _, start := range starts {
+		day := truncateDay(start.Date)
+		if day.Before(first) || day.After(last) {
+			continue
+		}
+		startsByPlayer[start.PlayerID] = append(startsByPlayer[start.PlayerID], start)
+	}
+
+	var ranked []StreamingPitcher
+	for _, player := range players {
+		if player.FantasyTeamID != "" || !isStartingPitcher(player) || player.FantasyPointsPerG < opts.MinFPG {
+			continue
+		}
+		playerStarts := startsByPlayer[player.PlayerID]
+		if len(playerStarts) == 0 {
+			continue
+		}
+		sort.Slice(playerStarts, func(i, j int) bool { return playerStarts[i].Date.Before(playerStarts[j].Date) })
+
+		sp := StreamingPitcher{Player: player, Starts: playerStarts}
+		for _, start := range playerStarts {
+			factor, ok := factors[start.Opponent]
+			if !ok {
+				factor = 1
+			}
+			points := player.FantasyPointsPerG * factor
+			sp.StartPoints = append(sp.StartPoints, points)
+			sp.ProjectedPoints += points
+		}
+		ranked = append(ranked, sp)
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].ProjectedPoints != ranked[j].ProjectedPoints {
+			return ranked[i].ProjectedPoints > ranked[j].ProjectedPoints
+		}
+		return ranked[i].Player.Name < ranked[j].Player.Name
+	})
+	return ranked
+}
+
+// GetStreamingPitchers fetches the player pool and ranks streaming options for a date range.
+// opts.Starts should come from a probable pitchers feed; without one only each pitcher's next
+// game is considered.
+func (c *Client) GetStreamingPitchers(opts StreamingOptions) ([]StreamingPitcher, error) {
+	players, err := c.GetPlayerPool(WithStatusFilter(StatusFilterAll))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player pool: %w", err)
+	}
+	return FindStreamingPitchers(players, opts), nil
+}
+
+// OpponentFactorsFromPool computes an opponent factor for each MLB team from its hitters' FP/G in the
+// player pool: the league-average offense divided by the team's, so weak offenses get factors
+// above 1
+func OpponentFactorsFromPool(players []models.PoolPlayer) map[string]float64 {
+	totals := make(map[string]float64)
+	counts := make(map[string]int)
+	for _, player := range players {
+		if player.MLBTeamShortName == "" || isPitcher(player) || player.FantasyPointsPerG <= 0 {
+			continue
+		}
+		totals[player.MLBTeamShortName] += player.FantasyPointsPerG
+		counts[player.MLBTeamShortName]++
+	}
+
+	offense := make(map[string]float64, len(totals))
+	leagueTotal := 0.0
+	for team, total := range totals {
+		offense[team] = total / float64(counts[team])
+		leagueTotal += offense[team]
+	}
+	factors := make(map[string]float64, len(offense))
+	for team, teamOffense := range offense {
+		factors[team] = leagueTotal / float64(len(offense)) / teamOffense
+	}
+	return factors
+}
+
+// nextOpponent matches the player pool's next game text with its tags stripped, such as
+// "@SFWed 8:05PM" or "LAD7:10PM"
+var nextOpponent = regexp.MustCompile(`^(@?)([A-Z]{2,3}?)((?:Sun|Mon|Tue|Wed|Thu|Fri|Sat)\s*)?(\d{1,2}:\d{2}\s*[AP]M)$`)
+
+// ProbableStartsFromPool guesses starts from each SP's next game in the player pool. Fantrax
+// does not say who is starting, so these are unconfirmed: a pitcher whose turn does not come
+// up in that game still gets a start.
+func ProbableStartsFromPool(players []models.PoolPlayer, now time.Time) []ProbableStart {
+	var starts []ProbableStart
+	for _, player := range players {
+		if !isStartingPitcher(player) {
+			continue
+		}
+		match := nextOpponent.FindStringSubmatch(strings.TrimSpace(player.NextOpponent))
+		if match == nil {
+			continue
+		}
+		date, ok := parser.ParseGameTime(match[3]+" "+match[4], now)
+		if !ok {
+			continue
+		}
+		starts = append(starts, ProbableStart{
+			PlayerID: player.PlayerID,
+			Date:     date,
+			Opponent: match[2],
+			Home:     match[1] == "",
+		})
+	}
+	return starts
+}
+
+// isStartingPitcher reports whether a pool player is SP-eligible
+func isStartingPitcher(player models.PoolPlayer) bool {
+	for _, pos := range scarcityPositions(player) {
+		if strings.EqualFold(pos, "SP") {
+			return true
+		}
+	}
+	return false
+}
+
+// isPitcher reports whether a pool player is eligible only as a pitcher
+func isPitcher(player models.PoolPlayer) bool {
+	positions := scarcityPositions(player)
+	for _, pos := range positions {
+		switch strings.ToUpper(pos) {
+		case "SP", "RP", "P":
+		default:
+			return false
+		}
+	}
+	return len(positions) > 0
+}
+
+// truncateDay returns t's calendar date as midnight UTC, so dates from different time zones
+// compare by the day they fall on locally
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
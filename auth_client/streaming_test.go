@@ -0,0 +1,44 @@
+package auth_client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestFindStreamingPitchers(t *testing.T) {
+	monday := time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC) // A Monday
+	players := []models.PoolPlayer{
+		{PlayerID: "ace", Name: "Ace", PosShortNames: "SP", FantasyPointsPerG: 15, FantasyTeamID: "t1"},
+		{PlayerID: "one", Name: "One Start", PosShortNames: "SP", FantasyPointsPerG: 12, NextOpponent: "@SFWed 8:05PM"},
+		{PlayerID: "two", Name: "Two Starts", PosShortNames: "SP,RP", FantasyPointsPerG: 8},
+		{PlayerID: "pen", Name: "Reliever", PosShortNames: "RP", FantasyPointsPerG: 20, NextOpponent: "LAD7:10PM"},
+		// Hitters: SF is a weak offense, LAD a strong one
+		{PlayerID: "h1", PosShortNames: "OF", MLBTeamShortName: "SF", FantasyPointsPerG: 1},
+		{PlayerID: "h2", PosShortNames: "1B,UT", MLBTeamShortName: "LAD", FantasyPointsPerG: 3},
+	}
+
+	starts := ProbableStartsFromPool(players, monday)
+	if len(starts) != 1 || starts[0].PlayerID != "one" || starts[0].Opponent != "SF" || starts[0].Home || starts[0].Date.Weekday() != time.Wednesday {
+		t.Fatalf("unexpected starts from the pool: %+v", starts)
+	}
+
+	starts = append(starts,
+		ProbableStart{PlayerID: "two", Date: monday, Opponent: "SF", Confirmed: true},
+		ProbableStart{PlayerID: "two", Date: monday.AddDate(0, 0, 5), Opponent: "LAD", Confirmed: true},
+		ProbableStart{PlayerID: "two", Date: monday.AddDate(0, 0, 9), Opponent: "SF", Confirmed: true}, // Out of range
+		ProbableStart{PlayerID: "ace", Date: monday, Opponent: "SF", Confirmed: true},
+	)
+	ranked := FindStreamingPitchers(players, StreamingOptions{Start: monday, End: monday.AddDate(0, 0, 6), Starts: starts})
+	if len(ranked) != 2 {
+		t.Fatalf("expected two streaming options, got %+v", ranked)
+	}
+	// SF factor 2/1 = 2, LAD factor 2/3: One Start 24, Two Starts 16 + 5.33
+	if ranked[0].Player.PlayerID != "one" || ranked[0].ProjectedPoints != 24 {
+		t.Errorf("unexpected top option: %+v", ranked[0])
+	}
+	if len(ranked[1].Starts) != 2 || ranked[1].ProjectedPoints < 21.3 || ranked[1].ProjectedPoints > 21.4 {
+		t.Errorf("unexpected second option: %+v", ranked[1])
+	}
+}
@@ -0,0 +1,190 @@
+package auth_client
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// AddCoOwner invites another owner to a team (commissioner only)
+//
+// The invitation is added to the Teams tab of the league setup page and saved, which has
+// Fantrax email it to the new owner. They become a co-owner once they accept it.
+//
+// Parameters:
+//   - teamID: The fantasy team ID
+//   - email: The new co-owner's email address
+//   - message: The personal message included in the invitation email; may be empty
+func (c *Client) AddCoOwner(teamID, email, message string) error {
+	setup, err := c.teamsSetup()
+	if err != nil {
+		return err
+	}
+	if err := AddSetupOwner(setup, teamID, email); err != nil {
+		return err
+	}
+	if err := c.postLeagueSetup(BuildTeamsFormBody(setup, message)); err != nil {
+		return fmt.Errorf("failed to save co-owner of team %s: %w", teamID, err)
+	}
+	return nil
+}
+
+// WithdrawInvitation removes an owner who hasn't joined yet from a team, withdrawing their
+// invitation (commissioner only)
+//
+// This is not a way to remove a co-owner: the setup page has no field for an owner once they
+// join, so joined co-owners fail with ErrOwnerJoined and still have to be removed on Fantrax.
+//
+// Parameters:
+//   - teamID: The fantasy team ID
+//   - email: The invited owner's email address
+func (c *Client) WithdrawInvitation(teamID, email string) error {
+	setup, err := c.teamsSetup()
+	if err != nil {
+		return err
+	}
+	if err := WithdrawSetupInvitation(setup, teamID, email); err != nil {
+		return err
+	}
+	if err := c.postLeagueSetup(BuildTeamsFormBody(setup, "")); err != nil {
+		return fmt.Errorf("failed to withdraw invitation to team %s: %w", teamID, err)
+	}
+	return nil
+}
+
+// ResendInvitations sends the league invitation again to every owner who hasn't joined
+// (commissioner only)
+//
+// The Teams tab is saved unchanged with the invitation message, as the setup page's Save
+// button does, which re-sends the invitation to each pending owner email.
+//
+// Parameters:
+//   - message: The personal message included in the invitation emails; may be empty
+//
+// Returns the owners the invitation was sent to.
+func (c *Client) ResendInvitations(message string) ([]models.LeagueOwner, error) {
+	setup, err := c.teamsSetup()
+	if err != nil {
+		return nil, err
+	}
+	pending := PendingOwners(setup)
+	if len(pending) == 0 {
+		return nil, fmt.Errorf("no owners have pending invitations")
+	}
+	if err := c.postLeagueSetup(BuildTeamsFormBody(setup, message)); err != nil {
+		return nil, fmt.Errorf("failed to resend invitations: %w", err)
+	}
+	return pending, nil
+}
+
+// teamsSetup checks the user is the commissioner and fetches the league setup page
+func (c *Client) teamsSetup() (*models.LeagueSetupMatchups, error) {
+	if err := c.checkCommissioner(); err != nil {
+		return nil, err
+	}
+	setup, err := c.GetLeagueSetupMatchups()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league setup: %w", err)
+	}
+	return setup, nil
+}
+
+// BuildTeamsFormBody assembles the league setup form body for saving the Teams tab
+//
+// Parameters:
+//   - setup: The league setup, with any owner changes already applied
+//   - inviteMessage: The personal message sent with invitations to pending owners
+func BuildTeamsFormBody(setup *models.LeagueSetupMatchups, inviteMessage string) url.Values {
+	form := buildSetupForm(setup)
+	form.Set("tabId", "Teams")
+	form.Set("inviteMessage", inviteMessage)
+	return form
+}
+
+// AddSetupOwner adds an invited owner to a team in the league setup
+//
+// The owner gets a "NULL_N" placeholder user ID and an owner email field, as the setup
+// page's JS does when an owner is added to a team.
+func AddSetupOwner(setup *models.LeagueSetupMatchups, teamID, email string) error {
+	email = strings.TrimSpace(email)
+	if !strings.Contains(email, "@") || strings.ContainsAny(email, ", ") {
+		return fmt.Errorf("invalid owner email %q", email)
+	}
+	team := GetTeamByID(setup, teamID)
+	if team == nil {
+		return fmt.Errorf("team %s not found in league setup", teamID)
+	}
+	for _, t := range setup.Teams {
+		for _, owner := range t.Owners {
+			if strings.EqualFold(owner.Email, email) {
+				return fmt.Errorf("%s is already an owner of team %s", email, t.TeamID)
+			}
+		}
+	}
+
+	// The next placeholder after those handed out by the page
+	next := 0
+	for _, t := range setup.Teams {
+		for _, owner := range t.Owners {
+			var n int
+			if _, err := fmt.Sscanf(owner.UserID, "NULL_%d", &n); err == nil && n >= next {
+				next = n + 1
+			}
+		}
+	}
+	owner := models.TeamOwner{Email: email, UserID: fmt.Sprintf("NULL_%d", next)}
+	team.Owners = append(team.Owners, owner)
+
+	if setup.FormConfig.OwnerEmailFields == nil {
+		setup.FormConfig.OwnerEmailFields = make(map[string]string)
+	}
+	setup.FormConfig.OwnerEmailFields[ownerEmailField(owner, teamID)] = email
+	return nil
+}
+
+// ErrOwnerJoined is returned when withdrawing the invitation of an owner who has already
+// joined the league
+var ErrOwnerJoined = errors.New("owner has joined the league; joined owners can't be removed through the league setup page")
+
+// WithdrawSetupInvitation removes an owner who hasn't joined yet from a team in the league
+// setup
+func WithdrawSetupInvitation(setup *models.LeagueSetupMatchups, teamID, email string) error {
+	team := GetTeamByID(setup, teamID)
+	if team == nil {
+		return fmt.Errorf("team %s not found in league setup", teamID)
+	}
+	for i, owner := range team.Owners {
+		if !strings.EqualFold(owner.Email, strings.TrimSpace(email)) {
+			continue
+		}
+		if owner.IsCommissioner {
+			return fmt.Errorf("%s is the commissioner and can't be removed from team %s", email, teamID)
+		}
+		if owner.JoinedLeague {
+			return fmt.Errorf("%s: %w", email, ErrOwnerJoined)
+		}
+		team.Owners = append(team.Owners[:i:i], team.Owners[i+1:]...)
+		delete(setup.FormConfig.OwnerEmailFields, ownerEmailField(owner, teamID))
+		return nil
+	}
+	return fmt.Errorf("%s is not an owner of team %s", email, teamID)
+}
+
+// PendingOwners lists the owners in the league setup who haven't joined yet
+func PendingOwners(setup *models.LeagueSetupMatchups) []models.LeagueOwner {
+	var pending []models.LeagueOwner
+	for _, owner := range LeagueOwnersFromSetup(setup.Teams) {
+		if !owner.Joined && !owner.Commissioner && owner.Email != "" {
+			pending = append(pending, owner)
+		}
+	}
+	return pending
+}
+
+// ownerEmailField is the form field key of an owner's email input
+func ownerEmailField(owner models.TeamOwner, teamID string) string {
+	return fmt.Sprintf("teamOwnerEmail,%s,%s,%s", owner.Email, teamID, owner.UserID)
+}
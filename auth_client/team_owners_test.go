@@ -0,0 +1,64 @@
+package auth_client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestSetupOwnerChanges(t *testing.T) {
+	html := `
+addTeam('Aces', 'ACE', 'ace@example.com', 't1', 'u1', true, true, 0);
+addTeam('Aces', 'ACE', 'co@example.com', 't1', 'u2', false, true, 0);
+addTeam('Bats', 'BAT', 'invite@example.com', 't2', 'NULL', false, false, 0);`
+	teams, err := parseTeams(html)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := parseFormConfig(html, teams, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	setup := &models.LeagueSetupMatchups{Teams: teams, FormConfig: *cfg}
+	if len(cfg.OwnerEmailFields) != 1 {
+		t.Fatalf("expected one pending owner field, got %v", cfg.OwnerEmailFields)
+	}
+
+	if err := AddSetupOwner(setup, "t1", "new@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	form := BuildTeamsFormBody(setup, "Join us")
+	if got := form.Get("teamOwnerEmail,new@example.com,t1,NULL_1"); got != "new@example.com" {
+		t.Errorf("expected a field for the new owner, got %q", got)
+	}
+	if form.Get("tabId") != "Teams" || form.Get("inviteMessage") != "Join us" {
+		t.Errorf("unexpected tab fields: %v", form)
+	}
+	if err := AddSetupOwner(setup, "t2", "CO@example.com"); err == nil {
+		t.Error("expected an error adding an existing owner")
+	}
+	if err := AddSetupOwner(setup, "t9", "x@example.com"); err == nil {
+		t.Error("expected an error for an unknown team")
+	}
+	if pending := PendingOwners(setup); len(pending) != 2 {
+		t.Errorf("expected 2 pending owners, got %+v", pending)
+	}
+
+	if err := WithdrawSetupInvitation(setup, "t1", "co@example.com"); !errors.Is(err, ErrOwnerJoined) {
+		t.Errorf("got %v, want ErrOwnerJoined for a joined owner", err)
+	}
+	if err := WithdrawSetupInvitation(setup, "t1", "ace@example.com"); err == nil {
+		t.Error("expected an error removing the commissioner")
+	}
+	if err := WithdrawSetupInvitation(setup, "t2", "invite@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	form = BuildTeamsFormBody(setup, "")
+	if _, ok := form["teamOwnerEmail,invite@example.com,t2,NULL_0"]; ok {
+		t.Error("expected the removed owner's field to be dropped")
+	}
+	if len(setup.Teams[1].Owners) != 0 {
+		t.Errorf("expected team t2 to have no owners, got %+v", setup.Teams[1].Owners)
+	}
+}
@@ -0,0 +1,47 @@
+package auth_client
+
+import (
+	"fmt"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// GetTeams fetches the league's teams, with divisions, as a registry keyed by team ID
+func (c *Client) GetTeams() (*models.TeamRegistry, error) {
+	home, err := c.GetLeagueHomeInfo()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league home info: %w", err)
+	}
+	return TeamsFromHomeInfo(home), nil
+}
+
+// TeamsFromHomeInfo builds the team registry from the league home info, taking each team's
+// division from the home standings
+func TeamsFromHomeInfo(home *LeagueHomeInfo) *models.TeamRegistry {
+	byTeam := DivisionsByTeam(MergeDivisions(nil, home.Standings, nil))
+	registry := models.NewTeamRegistry(nil)
+	for _, team := range home.Teams {
+		t := team.Team()
+		t.Division = byTeam[t.ID].Name
+		registry.Add(t)
+	}
+	return registry
+}
+
+// Team converts the league home team to the canonical models.Team
+func (t LeagueTeam) Team() models.Team {
+	return models.Team{
+		ID:           t.ID,
+		Name:         t.Name,
+		ShortName:    t.ShortName,
+		Commissioner: t.Commissioner,
+		LogoID:       t.LogoID,
+		LogoURL128:   t.LogoURL128,
+		LogoURL256:   t.LogoURL256,
+	}
+}
+
+// Team converts the standings row's team to the canonical models.Team
+func (t TeamStanding) Team() models.Team {
+	return models.Team{ID: t.TeamID, Name: t.Name, ShortName: t.ShortName, LogoURL128: t.LogoURL}
+}
@@ -0,0 +1,52 @@
+package auth_client
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestTeamsFromHomeInfo(t *testing.T) {
+	home := &LeagueHomeInfo{
+		Teams: []LeagueTeam{
+			{ID: "a", Name: "Aces", ShortName: "ACE", Commissioner: true},
+			{ID: "b", Name: "Bats", ShortName: "BAT"},
+		},
+		Standings: []DivisionStandings{
+			{DivisionName: "East", Teams: []TeamStandingRow{{TeamID: "a"}}},
+			{DivisionName: "West", Teams: []TeamStandingRow{{TeamID: "b"}}},
+		},
+	}
+
+	teams := TeamsFromHomeInfo(home)
+	if ids := teams.IDs(); len(ids) != 2 || ids[0] != "a" {
+		t.Fatalf("unexpected team order: %v", ids)
+	}
+	if team, ok := teams.Get("b"); !ok || team.Division != "West" || team.ShortName != "BAT" {
+		t.Errorf("unexpected team b: %+v", team)
+	}
+	if team, ok := teams.Find("ace"); !ok || team.ID != "a" || !team.Commissioner {
+		t.Errorf("expected to find Aces by short name: %+v", team)
+	}
+	if name := teams.Name("z"); name != "z" {
+		t.Errorf("expected an unknown team to fall back to its ID, got %q", name)
+	}
+
+	teams.Add(TeamStanding{TeamID: "a", Name: "Aces", LogoURL: "logo.png"}.Team())
+	if team, _ := teams.Get("a"); team.LogoURL128 != "logo.png" || len(teams.Teams) != 2 {
+		t.Errorf("expected the standing to fill in the logo: %+v", team)
+	}
+
+	data, err := json.Marshal(teams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded models.TeamRegistry
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if team, ok := decoded.Get("b"); !ok || team.Name != "Bats" {
+		t.Errorf("expected lookups to work after unmarshaling: %+v", team)
+	}
+}
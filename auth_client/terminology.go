@@ -0,0 +1,117 @@
+package auth_client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// Terminology maps the generic roster status and position names the package uses to a
+// league's own labels, for leagues that rename them (e.g. Minors to "Taxi", IR to "Injured
+// List"). Names without an entry are shown as is. A nil *Terminology changes nothing.
+type Terminology struct {
+	// Statuses maps "Active", "Reserve", "IR", and "Minors" to the league's labels
+	Statuses map[string]string `json:"statuses,omitempty"`
+
+	// Positions maps position names such as "Util" or "SP" to the league's labels
+	Positions map[string]string `json:"positions,omitempty"`
+}
+
+// Status returns the league's label for a generic status name
+func (t *Terminology) Status(name string) string {
+	if t == nil {
+		return name
+	}
+	return lookupLabel(t.Statuses, name)
+}
+
+// Position returns the league's label for a generic position name
+func (t *Terminology) Position(name string) string {
+	if t == nil {
+		return name
+	}
+	return lookupLabel(t.Positions, name)
+}
+
+// lookupLabel finds name in labels, ignoring case, and returns its label or name itself
+func lookupLabel(labels map[string]string, name string) string {
+	if label, ok := labels[name]; ok && label != "" {
+		return label
+	}
+	for generic, label := range labels {
+		if strings.EqualFold(generic, name) && label != "" {
+			return label
+		}
+	}
+	return name
+}
+
+// terminology returns the client's Terminology; nil for a nil client, as in roster editors
+// built without one
+func (c *Client) terminology() *Terminology {
+	if c == nil {
+		return nil
+	}
+	return c.Terminology
+}
+
+// statusName returns a status ID's name in the league's terminology
+func (c *Client) statusName(statusID string) string {
+	return c.terminology().Status(statusName(statusID))
+}
+
+// positionName returns a position ID's name in the league's terminology
+func (c *Client) positionName(positionID string) string {
+	return c.terminology().Position(positionName(positionID))
+}
+
+// PositionLabels returns the position labels a roster response shows where they differ
+// from the generic names, keyed by generic name. Only players eligible at a single position
+// are read, so each label belongs to exactly one position ID.
+func PositionLabels(resp *models.TeamRosterResponse) map[string]string {
+	labels := make(map[string]string)
+	if resp == nil || len(resp.Responses) == 0 {
+		return labels
+	}
+	for _, table := range resp.Responses[0].Data.Tables {
+		for _, row := range table.Rows {
+			if len(row.Scorer.PosIDsNoFlex) != 1 {
+				continue
+			}
+			label := strings.TrimSpace(stripHTML(row.Scorer.PosShortNames))
+			name := positionName(row.Scorer.PosIDsNoFlex[0])
+			if label == "" || strings.Contains(label, ",") || strings.HasPrefix(name, "Pos(") || strings.EqualFold(label, name) {
+				continue
+			}
+			labels[name] = label
+		}
+	}
+	return labels
+}
+
+// LoadLeagueTerminology fills in the position labels the league shows on the user's roster
+// (see PositionLabels), keeping any already set in Terminology. Fantrax's responses don't
+// name roster statuses, so renamed statuses have to be set in Terminology.Statuses.
+func (c *Client) LoadLeagueTerminology() error {
+	resp, err := c.GetMyTeamRosterInfoRaw("")
+	if err != nil {
+		return fmt.Errorf("failed to get your roster: %w", err)
+	}
+	learned := PositionLabels(resp)
+	if len(learned) == 0 {
+		return nil
+	}
+	if c.Terminology == nil {
+		c.Terminology = &Terminology{}
+	}
+	if c.Terminology.Positions == nil {
+		c.Terminology.Positions = make(map[string]string)
+	}
+	for name, label := range learned {
+		if c.Terminology.Position(name) == name {
+			c.Terminology.Positions[name] = label
+		}
+	}
+	return nil
+}
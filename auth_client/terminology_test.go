@@ -0,0 +1,60 @@
+package auth_client
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestTerminology(t *testing.T) {
+	terminology := &Terminology{
+		Statuses:  map[string]string{"minors": "Taxi", "IR": "Injured List"},
+		Positions: map[string]string{"Util": "DH"},
+	}
+	c := &Client{Terminology: terminology}
+	if got := c.statusName(StatusMinors); got != "Taxi" {
+		t.Errorf("statusName(minors) = %q, want Taxi", got)
+	}
+	if got := c.statusName(StatusReserve); got != "Reserve" {
+		t.Errorf("statusName(reserve) = %q, want Reserve", got)
+	}
+	if got := c.positionName(PosUtil); got != "DH" {
+		t.Errorf("positionName(util) = %q, want DH", got)
+	}
+
+	editor := &RosterEditor{client: c, fieldMap: map[string]RosterPosition{"a": {StID: StatusMinors}}, playerNames: map[string]string{"a": "Prospect"}}
+	if err := editor.MoveToIR("a"); err != nil {
+		t.Fatal(err)
+	}
+	if got := editor.GetPendingChanges(); len(got) != 1 || got[0] != "Prospect: Taxi → Injured List" {
+		t.Errorf("pending changes = %q", got)
+	}
+
+	// Slots are matched by their generic names but shown in the league's labels
+	roster := &models.TeamRoster{
+		ActiveRoster:  []models.RosterPlayer{{PlayerID: "1", Name: "Idle", RosterPosition: PosUtil, PosShortNames: "1B"}},
+		ReserveRoster: []models.RosterPlayer{{PlayerID: "2", Name: "Bench", PosShortNames: "OF", NextGame: &models.GameInfo{}}},
+	}
+	issues := findLineupIssues(roster, terminology)
+	if len(issues) != 1 || issues[0].Slot != "DH" || !strings.Contains(issues[0].Message, "at DH") {
+		t.Errorf("issues = %+v", issues)
+	}
+}
+
+func TestPositionLabels(t *testing.T) {
+	var resp models.TeamRosterResponse
+	body := `{"responses":[{"data":{"tables":[{"rows":[
+		{"scorer":{"posIdsNoFlex":["015"],"posShortNames":"<b>SP</b>"}},
+		{"scorer":{"posIdsNoFlex":["016"],"posShortNames":"<b>RELIEF</b>"}},
+		{"scorer":{"posIdsNoFlex":["002","008"],"posShortNames":"C,OF"}}
+	]}]}}]}`
+	if err := json.Unmarshal([]byte(body), &resp); err != nil {
+		t.Fatal(err)
+	}
+	labels := PositionLabels(&resp)
+	if len(labels) != 1 || labels["RP"] != "RELIEF" {
+		t.Errorf("PositionLabels = %v, want only RP relabeled", labels)
+	}
+}
@@ -0,0 +1,235 @@
+package auth_client
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pmurley/go-fantrax"
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// PlayerValueSource supplies a single comparable value for a player, such as a
+// rest-of-season projection or a dynasty ranking
+type PlayerValueSource interface {
+	// PlayerValue returns the player's value and whether the source knows the player
+	PlayerValue(playerID string) (float64, bool)
+}
+
+// PointsPerGameToDate values players by their fantasy points per game so far this season,
+// from the player pool. It is not a projection: it ignores playing time still to come, and
+// small samples early in the season or after an injury can make it misleading. Use
+// projections.ProjectedPoints for rest-of-season projections.
+type PointsPerGameToDate map[string]float64
+
+// NewPointsPerGameToDate builds a PointsPerGameToDate from player pool results
+func NewPointsPerGameToDate(players []models.PoolPlayer) PointsPerGameToDate {
+	source := make(PointsPerGameToDate, len(players))
+	for _, p := range players {
+		source[p.PlayerID] = p.FantasyPointsPerG
+	}
+	return source
+}
+
+// PlayerValue implements PlayerValueSource
+func (s PointsPerGameToDate) PlayerValue(playerID string) (float64, bool) {
+	value, ok := s[playerID]
+	return value, ok
+}
+
+// TradePlayerValue is one player moving in a trade and the value assigned to them
+type TradePlayerValue struct {
+	PlayerID  string
+	Name      string
+	Positions []string // Eligible position short names
+	Value     float64
+	Valued    bool // False if the value source did not know the player
+}
+
+// TradeSideAnalysis describes how a trade changes one team
+type TradeSideAnalysis struct {
+	TeamID     string
+	PlayersIn  []TradePlayerValue
+	PlayersOut []TradePlayerValue
+	ValueIn    float64
+	ValueOut   float64
+	ValueDelta float64 // ValueIn minus ValueOut
+
+	// RosterSpotDelta is the net change in rostered players; a positive value means the team
+	// must open roster spots to accept the trade
+	RosterSpotDelta int
+
+	// PositionDelta is the net change in players eligible at each position
+	PositionDelta map[string]int
+
+	// DepthAfter is the number of rostered players eligible at each position after the trade
+	DepthAfter map[string]int
+
+	// Surpluses and Deficits list positions where DepthAfter is above or below the league's
+	// active slot count. Both are empty when no slot counts were supplied.
+	Surpluses []string
+	Deficits  []string
+}
+
+// TradeAnalysis is the result of evaluating a proposed trade
+type TradeAnalysis struct {
+	Teams []TradeSideAnalysis
+}
+
+// Team returns the analysis for one team in the trade, or nil if the team is not involved
+func (a *TradeAnalysis) Team(teamID string) *TradeSideAnalysis {
+	for i := range a.Teams {
+		if a.Teams[i].TeamID == teamID {
+			return &a.Teams[i]
+		}
+	}
+	return nil
+}
+
+// AnalyzeTrade evaluates a proposed trade from each involved team's perspective
+//
+// Parameters:
+//   - items: The player movements in the trade
+//   - rosters: Current rosters for every team in the trade, keyed by team ID
+//   - values: The value source used to price each player
+//   - slots: Active slots per position short name (e.g. RosterLimits.MaxActiveByPosition);
+//     may be nil to skip surplus/deficit detection
+//
+// Returns an error if a traded player is not on the roster of the team sending them.
+func AnalyzeTrade(items []TradeItem, rosters map[string]*models.TeamRoster, values PlayerValueSource, slots map[string]int) (*TradeAnalysis, error) {
+	sides := make(map[string]*TradeSideAnalysis)
+	var order []string
+	side := func(teamID string) *TradeSideAnalysis {
+		if s, ok := sides[teamID]; ok {
+			return s
+		}
+		s := &TradeSideAnalysis{
+			TeamID:        teamID,
+			PositionDelta: make(map[string]int),
+			DepthAfter:    make(map[string]int),
+		}
+		sides[teamID] = s
+		order = append(order, teamID)
+		return s
+	}
+
+	for _, item := range items {
+		roster, ok := rosters[item.FromTeamID]
+		if !ok {
+			return nil, fmt.Errorf("no roster supplied for team %s", item.FromTeamID)
+		}
+		player := roster.FindPlayer(item.PlayerID)
+		if player == nil {
+			return nil, fmt.Errorf("player %s is not on team %s", item.PlayerID, item.FromTeamID)
+		}
+
+		value, valued := values.PlayerValue(item.PlayerID)
+		moved := TradePlayerValue{
+			PlayerID:  player.PlayerID,
+			Name:      player.Name,
+			Positions: rosterPlayerPositions(*player),
+			Value:     value,
+			Valued:    valued,
+		}
+
+		from := side(item.FromTeamID)
+		from.PlayersOut = append(from.PlayersOut, moved)
+		from.ValueOut += value
+		from.RosterSpotDelta--
+		for _, pos := range moved.Positions {
+			from.PositionDelta[pos]--
+		}
+
+		to := side(item.ToTeamID)
+		to.PlayersIn = append(to.PlayersIn, moved)
+		to.ValueIn += value
+		to.RosterSpotDelta++
+		for _, pos := range moved.Positions {
+			to.PositionDelta[pos]++
+		}
+	}
+
+	analysis := &TradeAnalysis{}
+	for _, teamID := range order {
+		s := sides[teamID]
+		s.ValueDelta = s.ValueIn - s.ValueOut
+
+		if roster, ok := rosters[teamID]; ok {
+			for _, player := range roster.AllPlayers() {
+				for _, pos := range rosterPlayerPositions(player) {
+					s.DepthAfter[pos]++
+				}
+			}
+		}
+		for pos, delta := range s.PositionDelta {
+			s.DepthAfter[pos] += delta
+		}
+
+		for pos, count := range slots {
+			if count <= 0 {
+				continue
+			}
+			switch depth := s.DepthAfter[pos]; {
+			case depth > count:
+				s.Surpluses = append(s.Surpluses, pos)
+			case depth < count:
+				s.Deficits = append(s.Deficits, pos)
+			}
+		}
+		sort.Strings(s.Surpluses)
+		sort.Strings(s.Deficits)
+
+		analysis.Teams = append(analysis.Teams, *s)
+	}
+
+	return analysis, nil
+}
+
+// AnalyzeTrade evaluates a proposed trade using current rosters and the league's active slot
+// counts, valuing players by fantasy points per game to date (see PointsPerGameToDate)
+//
+// Projections have to be supplied by the caller, since Fantrax serves none here: use the
+// package-level AnalyzeTrade with projections.ProjectedPoints, or another PlayerValueSource,
+// to evaluate against rest-of-season projections.
+func (c *Client) AnalyzeTrade(items []TradeItem) (*TradeAnalysis, error) {
+	rosters := make(map[string]*models.TeamRoster)
+	for _, item := range items {
+		for _, teamID := range []string{item.FromTeamID, item.ToTeamID} {
+			if _, ok := rosters[teamID]; ok {
+				continue
+			}
+			roster, err := c.GetTeamRosterInfo("", teamID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get roster for team %s: %w", teamID, err)
+			}
+			rosters[teamID] = roster
+		}
+	}
+
+	players, err := c.GetPlayerPool()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player pool: %w", err)
+	}
+
+	publicClient, err := fantrax.NewClient(c.LeagueID, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create public client: %w", err)
+	}
+	info, err := publicClient.GetLeagueInfo(c.LeagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league roster settings: %w", err)
+	}
+
+	return AnalyzeTrade(items, rosters, NewPointsPerGameToDate(players), RosterLimitsFromLeagueInfo(info).MaxActiveByPosition)
+}
+
+// rosterPlayerPositions returns a player's eligible position short names
+func rosterPlayerPositions(player models.RosterPlayer) []string {
+	var positions []string
+	for _, pos := range strings.Split(stripHTML(player.PosShortNames), ",") {
+		if pos = strings.TrimSpace(pos); pos != "" {
+			positions = append(positions, pos)
+		}
+	}
+	return positions
+}
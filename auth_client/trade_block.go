@@ -0,0 +1,105 @@
+package auth_client
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// GetTradeBlocksRequest represents the request payload for getTradeBlocks
+type GetTradeBlocksRequest struct {
+	LeagueID string `json:"leagueId"`
+}
+
+// GetTradeBlocksRaw fetches the raw trade block of every team in the league
+func (c *Client) GetTradeBlocksRaw() (*models.TradeBlockResponse, error) {
+	body, err := c.postFxpa(fxpaRequest{
+		Msgs: []FantraxMessage{
+			{
+				Method: "getTradeBlocks",
+				Data:   GetTradeBlocksRequest{LeagueID: c.LeagueID},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var response models.TradeBlockResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	c.checkSchema("getTradeBlocks", body, &response)
+
+	return &response, nil
+}
+
+// GetTradeBlocks fetches every team's trade block: the players offered, positions offered
+// and wanted, and the team's note. Teams with an empty block are included. Trade blocks are
+// read-only here; saving one isn't supported until the web app's request has been captured.
+func (c *Client) GetTradeBlocks() ([]models.TradeBlock, error) {
+	raw, err := c.GetTradeBlocksRaw()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get raw trade blocks: %w", err)
+	}
+	if len(raw.Responses) == 0 {
+		return nil, fmt.Errorf("no responses in trade block response")
+	}
+	return parseTradeBlocks(raw.Responses[0].Data, c.Terminology), nil
+}
+
+// GetTradeBlock fetches one team's trade block
+//
+// Parameters:
+//   - teamID: The fantasy team ID (empty string = the user's team, see GetMyTeamID)
+func (c *Client) GetTradeBlock(teamID string) (*models.TradeBlock, error) {
+	teamID, err := c.teamOrMine(teamID)
+	if err != nil {
+		return nil, err
+	}
+	blocks, err := c.GetTradeBlocks()
+	if err != nil {
+		return nil, err
+	}
+	for i := range blocks {
+		if blocks[i].TeamID == teamID {
+			return &blocks[i], nil
+		}
+	}
+	return &models.TradeBlock{TeamID: teamID}, nil
+}
+
+// parseTradeBlocks converts raw trade blocks, naming teams and positions
+func parseTradeBlocks(data models.TradeBlockData, terminology *Terminology) []models.TradeBlock {
+	teamNames := make(map[string]string)
+	for _, team := range data.FantasyTeams {
+		teamNames[team.ID] = team.Name
+	}
+
+	blocks := make([]models.TradeBlock, 0, len(data.TradeBlocks))
+	for _, raw := range data.TradeBlocks {
+		block := models.TradeBlock{
+			TeamID:      raw.TeamID,
+			TeamName:    teamNames[raw.TeamID],
+			Note:        stripHTML(raw.Note),
+			LastUpdated: raw.LastUpdated,
+		}
+		for _, p := range raw.PlayersOffered {
+			block.PlayersOffered = append(block.PlayersOffered, models.TradeBlockPlayer{
+				PlayerID:      p.ScorerID,
+				Name:          p.Name,
+				TeamShortName: p.TeamShortName,
+				Positions:     rosterPlayerPositions(models.RosterPlayer{PosShortNames: p.PosShortNames}),
+			})
+		}
+		for _, id := range raw.PositionsOffered {
+			block.PositionsOffered = append(block.PositionsOffered, terminology.Position(positionName(id)))
+		}
+		for _, id := range raw.PositionsWanted {
+			block.PositionsWanted = append(block.PositionsWanted, terminology.Position(positionName(id)))
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
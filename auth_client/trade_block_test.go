@@ -0,0 +1,40 @@
+package auth_client
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestParseTradeBlocks(t *testing.T) {
+	body := `{"responses":[{"data":{
+		"fantasyTeams":[{"id":"t1","name":"Sluggers"},{"id":"t2","name":"Aces"}],
+		"tradeBlocks":[
+			{"teamId":"t1","note":"<b>Need</b> pitching","positionsWanted":["015","016"],
+			 "playersOffered":[{"scorerId":"p1","name":"Big Bat","teamShortName":"NYY","posShortNames":"<b>1B</b>,OF"}]},
+			{"teamId":"t2"}
+		]}}]}`
+	var raw models.TradeBlockResponse
+	if err := json.Unmarshal([]byte(body), &raw); err != nil {
+		t.Fatal(err)
+	}
+
+	blocks := parseTradeBlocks(raw.Responses[0].Data, nil)
+	if len(blocks) != 2 {
+		t.Fatalf("expected 2 blocks, got %d", len(blocks))
+	}
+	b := blocks[0]
+	if b.TeamName != "Sluggers" || b.Note != "Need pitching" || b.Empty() {
+		t.Errorf("unexpected block: %+v", b)
+	}
+	if len(b.PositionsWanted) != 2 || b.PositionsWanted[0] != "SP" || b.PositionsWanted[1] != "RP" {
+		t.Errorf("unexpected positions wanted: %v", b.PositionsWanted)
+	}
+	if len(b.PlayersOffered) != 1 || b.PlayersOffered[0].PlayerID != "p1" || len(b.PlayersOffered[0].Positions) != 2 {
+		t.Errorf("unexpected players offered: %+v", b.PlayersOffered)
+	}
+	if !blocks[1].Empty() {
+		t.Errorf("expected an empty block: %+v", blocks[1])
+	}
+}
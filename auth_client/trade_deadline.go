@@ -0,0 +1,83 @@
+package auth_client
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// TradeDeadlinePolicy describes a league's trade deadline
+//
+// The PENDING transaction view gives the time a trade is scheduled to process, not when it
+// was proposed, so a trade proposed just before the deadline can process after it once the
+// league's review period is over. Set Grace to at least the review period to let those
+// trades through.
+type TradeDeadlinePolicy struct {
+	Deadline time.Time
+	Grace    time.Duration // Late trades processing within Grace of the deadline are allowed
+}
+
+// LateTrade is a pending trade that processes after the trade deadline
+type LateTrade struct {
+	Trade models.PendingTransaction `json:"trade"`
+	Late  time.Duration             `json:"late"` // How long after the deadline the trade processes
+}
+
+// FindLateTrades returns the pending trades that process after the deadline plus grace
+//
+// Trades with no known process time are treated as late, as they can go through at any time.
+func FindLateTrades(pending []models.PendingTransaction, policy TradeDeadlinePolicy) []LateTrade {
+	cutoff := policy.Deadline.Add(policy.Grace)
+	var late []LateTrade
+	for _, tx := range pending {
+		if tx.Type != "TRADE" {
+			continue
+		}
+		if !tx.ProcessTime.IsZero() && !tx.ProcessTime.After(cutoff) {
+			continue
+		}
+		entry := LateTrade{Trade: tx}
+		if !tx.ProcessTime.IsZero() {
+			entry.Late = tx.ProcessTime.Sub(policy.Deadline)
+		}
+		late = append(late, entry)
+	}
+	return late
+}
+
+// CheckTradeDeadline flags the pending trades that process after the trade deadline
+// (commissioner only)
+//
+// Late trades are only reported. The fxpa request the web app sends to reject a trade hasn't
+// been captured, so rejecting them is left to the commissioner on Fantrax.
+//
+// Parameters:
+//   - policy: The deadline and grace period
+//
+// Returns every late trade.
+func (c *Client) CheckTradeDeadline(policy TradeDeadlinePolicy) ([]LateTrade, error) {
+	if err := c.checkCommissioner(); err != nil {
+		return nil, err
+	}
+	pending, err := c.GetPendingTransactions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending trades: %w", err)
+	}
+	return FindLateTrades(pending, policy), nil
+}
+
+// Description summarizes a late trade for logs and alerts
+func (t LateTrade) Description() string {
+	players := ""
+	for i, p := range t.Trade.TradePlayers {
+		if i > 0 {
+			players += ", "
+		}
+		players += fmt.Sprintf("%s (%s → %s)", p.PlayerName, p.FromTeamName, p.ToTeamName)
+	}
+	if t.Trade.ProcessTime.IsZero() {
+		return fmt.Sprintf("trade %s with no process time: %s", t.Trade.ID, players)
+	}
+	return fmt.Sprintf("trade %s processes %s after the deadline: %s", t.Trade.ID, t.Late.Round(time.Minute), players)
+}
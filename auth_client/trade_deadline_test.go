@@ -0,0 +1,27 @@
+package auth_client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestFindLateTrades(t *testing.T) {
+	deadline := time.Date(2025, 8, 15, 23, 59, 0, 0, time.UTC)
+	pending := []models.PendingTransaction{
+		{ID: "before", Type: "TRADE", ProcessTime: deadline.Add(-time.Hour)},
+		{ID: "grace", Type: "TRADE", ProcessTime: deadline.Add(24 * time.Hour)},
+		{ID: "late", Type: "TRADE", ProcessTime: deadline.Add(72 * time.Hour)},
+		{ID: "unknown", Type: "TRADE"},
+		{ID: "claim", Type: "CLAIM", ProcessTime: deadline.Add(72 * time.Hour)},
+	}
+
+	late := FindLateTrades(pending, TradeDeadlinePolicy{Deadline: deadline, Grace: 48 * time.Hour})
+	if len(late) != 2 || late[0].Trade.ID != "late" || late[1].Trade.ID != "unknown" {
+		t.Fatalf("unexpected late trades: %+v", late)
+	}
+	if late[0].Late != 72*time.Hour {
+		t.Errorf("expected the trade to be 72h late, got %v", late[0].Late)
+	}
+}
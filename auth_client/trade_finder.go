@@ -0,0 +1,339 @@
+package auth_client
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/pmurley/go-fantrax"
+	"github.com/pmurley/go-fantrax/models"
+	"github.com/pmurley/go-fantrax/redact"
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultTradeValueGap is the default TradeFinderOptions.MaxValueGap
+const DefaultTradeValueGap = 0.15
+
+// TradeFinderOptions controls which trades FindTrades suggests
+type TradeFinderOptions struct {
+	TeamID         string  // Only suggest trades involving this team ("" = every pair of teams)
+	MaxPlayers     int     // Players per side: 1 for 1-for-1 only, 2 to add 2-for-2 (0 = 2)
+	MaxValueGap    float64 // Largest value difference allowed, as a fraction of the larger side (0 = DefaultTradeValueGap)
+	TradeBlockOnly bool    // Only offer players that are on their team's trade block
+	Candidates     int     // Players per team considered for 2-for-2 trades, by value (0 = 8)
+	Limit          int     // Maximum suggestions returned (0 = no limit)
+}
+
+// TradeSuggestion is a candidate trade between two teams
+type TradeSuggestion struct {
+	Items    []TradeItem    `json:"items"`
+	Analysis *TradeAnalysis `json:"analysis"`
+
+	// Needs lists, per team ID, the positions the team is short at that the trade fills
+	Needs map[string][]string `json:"needs"`
+
+	// TradeBlockPlayers is the number of traded players on their team's trade block
+	TradeBlockPlayers int `json:"tradeBlockPlayers"`
+
+	ValueGap float64 `json:"valueGap"` // Absolute difference between the two sides' values
+	Score    float64 `json:"score"`    // Higher is better; suggestions are ordered by it
+}
+
+// tradeTeam is one team's view for the trade finder
+type tradeTeam struct {
+	id      string
+	roster  *models.TeamRoster
+	needs   map[string]bool // Positions short of active slots, or wanted on the trade block
+	surplus map[string]bool // Positions deeper than active slots, or offered on the trade block
+	onBlock map[string]bool // Player IDs on the trade block
+}
+
+// FindTrades proposes 1-for-1 and 2-for-2 trades that fill both teams' positional needs at
+// roughly even value, ranked best first
+//
+// A team needs a position when fewer of its players are eligible there than the league has
+// active slots, or when its trade block asks for it. Each side of a suggested trade sends
+// players from positions it is deep at (or that are on its trade block) and receives at
+// least one player eligible at a position it needs. Suggestions score one point per need
+// filled and half a point per traded player on a trade block, less the value gap as a
+// fraction of the larger side.
+//
+// Parameters:
+//   - rosters: Rosters keyed by fantasy team ID (e.g. from GetAllTeamRosters)
+//   - blocks: Trade blocks (e.g. from GetTradeBlocks); may be nil
+//   - values: The value source used to price each player, such as rest-of-season projections
+//   - slots: Active slots per position short name (e.g. RosterLimits.MaxActiveByPosition)
+//   - opts: Which trades to consider
+func FindTrades(rosters map[string]*models.TeamRoster, blocks []models.TradeBlock, values PlayerValueSource, slots map[string]int, opts TradeFinderOptions) []TradeSuggestion {
+	maxPlayers := opts.MaxPlayers
+	if maxPlayers <= 0 {
+		maxPlayers = 2
+	}
+	maxGap := opts.MaxValueGap
+	if maxGap <= 0 {
+		maxGap = DefaultTradeValueGap
+	}
+	candidates := opts.Candidates
+	if candidates <= 0 {
+		candidates = 8
+	}
+
+	blockByTeam := make(map[string]models.TradeBlock)
+	for _, block := range blocks {
+		blockByTeam[block.TeamID] = block
+	}
+
+	var teams []*tradeTeam
+	for id, roster := range rosters {
+		teams = append(teams, newTradeTeam(id, roster, blockByTeam[id], slots))
+	}
+	sort.Slice(teams, func(i, j int) bool { return teams[i].id < teams[j].id })
+
+	var suggestions []TradeSuggestion
+	for i, a := range teams {
+		for _, b := range teams[i+1:] {
+			if opts.TeamID != "" && a.id != opts.TeamID && b.id != opts.TeamID {
+				continue
+			}
+			outA := a.tradeable(b, values, opts.TradeBlockOnly)
+			outB := b.tradeable(a, values, opts.TradeBlockOnly)
+
+			for _, pa := range outA {
+				for _, pb := range outB {
+					if s, ok := suggestTrade(a, b, []string{pa}, []string{pb}, rosters, values, slots, maxGap); ok {
+						suggestions = append(suggestions, s)
+					}
+				}
+			}
+			if maxPlayers < 2 {
+				continue
+			}
+
+			pairsA := playerPairs(topByValue(outA, values, candidates))
+			pairsB := playerPairs(topByValue(outB, values, candidates))
+			for _, pa := range pairsA {
+				for _, pb := range pairsB {
+					if s, ok := suggestTrade(a, b, pa, pb, rosters, values, slots, maxGap); ok {
+						suggestions = append(suggestions, s)
+					}
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		return suggestions[i].ValueGap < suggestions[j].ValueGap
+	})
+	if opts.Limit > 0 && len(suggestions) > opts.Limit {
+		suggestions = suggestions[:opts.Limit]
+	}
+	return suggestions
+}
+
+// FindTrades fetches every roster and trade block and proposes trades, valuing players by
+// fantasy points per game to date (see PointsPerGameToDate)
+//
+// Fantrax serves no projections to this client, so points per game to date is the only value
+// it can default to. Use the package-level FindTrades with projections.ProjectedPoints, or
+// another PlayerValueSource, to value players by rest-of-season projections instead. If the
+// trade blocks can't be read, suggestions are made from roster depth alone.
+func (c *Client) FindTrades(opts TradeFinderOptions) ([]TradeSuggestion, error) {
+	rosters, _, err := c.GetAllTeamRosters("")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rosters: %w", err)
+	}
+
+	blocks, err := c.GetTradeBlocks()
+	if err != nil {
+		log.Warn("failed to get trade blocks, suggesting trades from roster depth only: ", redact.Error(err))
+		blocks = nil
+	}
+
+	players, err := c.GetPlayerPool()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player pool: %w", err)
+	}
+
+	publicClient, err := fantrax.NewClient(c.LeagueID, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create public client: %w", err)
+	}
+	info, err := publicClient.GetLeagueInfo(c.LeagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league roster settings: %w", err)
+	}
+
+	return FindTrades(rosters, blocks, NewPointsPerGameToDate(players), RosterLimitsFromLeagueInfo(info).MaxActiveByPosition, opts), nil
+}
+
+// newTradeTeam works out a team's needs and surpluses from its depth and trade block
+func newTradeTeam(id string, roster *models.TeamRoster, block models.TradeBlock, slots map[string]int) *tradeTeam {
+	team := &tradeTeam{
+		id:      id,
+		roster:  roster,
+		needs:   make(map[string]bool),
+		surplus: make(map[string]bool),
+		onBlock: make(map[string]bool),
+	}
+	for slot, count := range slots {
+		if count <= 0 {
+			continue
+		}
+		switch depth := slotDepth(roster.AllPlayers(), slot); {
+		case depth < count:
+			team.needs[slot] = true
+		case depth > count:
+			team.surplus[slot] = true
+		}
+	}
+	for _, pos := range block.PositionsWanted {
+		team.needs[pos] = true
+	}
+	for _, pos := range block.PositionsOffered {
+		team.surplus[pos] = true
+	}
+	for _, p := range block.PlayersOffered {
+		team.onBlock[p.PlayerID] = true
+	}
+	return team
+}
+
+// tradeable returns the players a team could send to other: those on its trade block, or
+// at a position the team is deep at that other needs, ordered by value
+func (t *tradeTeam) tradeable(other *tradeTeam, values PlayerValueSource, blockOnly bool) []string {
+	var ids []string
+	for _, player := range t.roster.AllPlayers() {
+		if t.onBlock[player.PlayerID] {
+			ids = append(ids, player.PlayerID)
+			continue
+		}
+		if blockOnly {
+			continue
+		}
+		positions := rosterPlayerPositions(player)
+		for pos := range other.needs {
+			if t.surplus[pos] && slotAccepts(pos, positions) {
+				ids = append(ids, player.PlayerID)
+				break
+			}
+		}
+	}
+	return topByValue(ids, values, len(ids))
+}
+
+// suggestTrade builds a suggestion for a sending outA and b sending outB, or reports false if
+// the trade doesn't fill a need on both sides or the values are too far apart
+func suggestTrade(a, b *tradeTeam, outA, outB []string, rosters map[string]*models.TeamRoster, values PlayerValueSource, slots map[string]int, maxGap float64) (TradeSuggestion, bool) {
+	valueA, valueB := sumValues(outA, values), sumValues(outB, values)
+	gap := math.Abs(valueA - valueB)
+	larger := math.Max(valueA, valueB)
+	if larger <= 0 || gap > maxGap*larger {
+		return TradeSuggestion{}, false
+	}
+
+	needsA := filledNeeds(a, b.roster, outB)
+	needsB := filledNeeds(b, a.roster, outA)
+	if len(needsA) == 0 || len(needsB) == 0 {
+		return TradeSuggestion{}, false
+	}
+
+	var items []TradeItem
+	blockPlayers := 0
+	for _, id := range outA {
+		items = append(items, TradeItem{PlayerID: id, FromTeamID: a.id, ToTeamID: b.id})
+		if a.onBlock[id] {
+			blockPlayers++
+		}
+	}
+	for _, id := range outB {
+		items = append(items, TradeItem{PlayerID: id, FromTeamID: b.id, ToTeamID: a.id})
+		if b.onBlock[id] {
+			blockPlayers++
+		}
+	}
+	analysis, err := AnalyzeTrade(items, rosters, values, slots)
+	if err != nil {
+		return TradeSuggestion{}, false
+	}
+
+	return TradeSuggestion{
+		Items:             items,
+		Analysis:          analysis,
+		Needs:             map[string][]string{a.id: needsA, b.id: needsB},
+		TradeBlockPlayers: blockPlayers,
+		ValueGap:          gap,
+		Score:             float64(len(needsA)+len(needsB)) + 0.5*float64(blockPlayers) - gap/larger,
+	}, true
+}
+
+// filledNeeds returns the positions team needs that the incoming players are eligible at
+func filledNeeds(team *tradeTeam, from *models.TeamRoster, incoming []string) []string {
+	filled := make(map[string]bool)
+	for _, id := range incoming {
+		player := from.FindPlayer(id)
+		if player == nil {
+			continue
+		}
+		positions := rosterPlayerPositions(*player)
+		for pos := range team.needs {
+			if slotAccepts(pos, positions) {
+				filled[pos] = true
+			}
+		}
+	}
+	needs := make([]string, 0, len(filled))
+	for pos := range filled {
+		needs = append(needs, pos)
+	}
+	sort.Strings(needs)
+	return needs
+}
+
+// slotDepth counts the players eligible for a slot
+func slotDepth(players []models.RosterPlayer, slot string) int {
+	depth := 0
+	for _, player := range players {
+		if slotAccepts(slot, rosterPlayerPositions(player)) {
+			depth++
+		}
+	}
+	return depth
+}
+
+// topByValue returns up to n player IDs, most valuable first
+func topByValue(ids []string, values PlayerValueSource, n int) []string {
+	sorted := append([]string(nil), ids...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		vi, _ := values.PlayerValue(sorted[i])
+		vj, _ := values.PlayerValue(sorted[j])
+		return vi > vj
+	})
+	if len(sorted) > n {
+		sorted = sorted[:n]
+	}
+	return sorted
+}
+
+// playerPairs returns every pair of distinct players
+func playerPairs(ids []string) [][]string {
+	var pairs [][]string
+	for i := range ids {
+		for j := i + 1; j < len(ids); j++ {
+			pairs = append(pairs, []string{ids[i], ids[j]})
+		}
+	}
+	return pairs
+}
+
+// sumValues totals the players' values
+func sumValues(ids []string, values PlayerValueSource) float64 {
+	total := 0.0
+	for _, id := range ids {
+		value, _ := values.PlayerValue(id)
+		total += value
+	}
+	return total
+}
@@ -0,0 +1,54 @@
+package auth_client
+
+import (
+	"testing"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestFindTrades(t *testing.T) {
+	player := func(id, pos string) models.RosterPlayer {
+		return models.RosterPlayer{PlayerID: id, Name: id, PosShortNames: pos}
+	}
+	rosters := map[string]*models.TeamRoster{
+		"a": {ActiveRoster: []models.RosterPlayer{player("c1", "C"), player("c2", "C"), player("of1", "OF")}},
+		"b": {ActiveRoster: []models.RosterPlayer{player("ss1", "SS"), player("ss2", "SS"), player("of2", "OF")}},
+	}
+	slots := map[string]int{"C": 1, "SS": 1, "OF": 1}
+	values := PointsPerGameToDate{"c1": 3.0, "c2": 2.0, "of1": 4.0, "ss1": 3.1, "ss2": 1.0, "of2": 4.0}
+
+	suggestions := FindTrades(rosters, nil, values, slots, TradeFinderOptions{})
+	if len(suggestions) == 0 {
+		t.Fatal("expected suggestions")
+	}
+	best := suggestions[0]
+	if len(best.Items) != 2 || best.Items[0].PlayerID != "c1" || best.Items[1].PlayerID != "ss1" {
+		t.Errorf("expected c1 for ss1 first, got %+v", best.Items)
+	}
+	if best.Needs["a"][0] != "SS" || best.Needs["b"][0] != "C" {
+		t.Errorf("unexpected needs: %v", best.Needs)
+	}
+	for _, s := range suggestions {
+		for _, item := range s.Items {
+			if item.PlayerID == "of1" || item.PlayerID == "of2" {
+				t.Errorf("outfielders fill no need and shouldn't be offered: %+v", s.Items)
+			}
+		}
+		if s.ValueGap > DefaultTradeValueGap*4 {
+			t.Errorf("value gap too large: %+v", s)
+		}
+	}
+
+	// A trade block puts b's outfielder up for a's catcher
+	blocks := []models.TradeBlock{{TeamID: "b", PlayersOffered: []models.TradeBlockPlayer{{PlayerID: "of2"}}, PositionsWanted: []string{"C"}}}
+	suggestions = FindTrades(rosters, blocks, values, slots, TradeFinderOptions{TradeBlockOnly: true, MaxPlayers: 1})
+	if len(suggestions) != 0 {
+		t.Errorf("expected no block-only trades since a has nothing on its block, got %+v", suggestions)
+	}
+	suggestions = FindTrades(rosters, blocks, values, slots, TradeFinderOptions{MaxPlayers: 1, TeamID: "a"})
+	for _, s := range suggestions {
+		if len(s.Items) != 2 {
+			t.Errorf("expected only 1-for-1 trades: %+v", s.Items)
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package auth_client
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// TransactionArchive is a local, de-duplicated copy of a league's transaction history that
+// BackfillTransactions fills in and resumes
+type TransactionArchive struct {
+	LeagueID     string               `json:"leagueId"`
+	Transactions []models.Transaction `json:"transactions"`
+
+	// NextPage is the next page to fetch for each view whose first walk is unfinished
+	NextPage map[string]int `json:"nextPage"`
+	// Complete records the views that have been walked to the last page at least once
+	Complete map[string]bool `json:"complete"`
+
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	mu   sync.Mutex
+	seen map[string]bool
+}
+
+// NewTransactionArchive returns an empty transaction archive
+func NewTransactionArchive() *TransactionArchive {
+	return &TransactionArchive{
+		NextPage: make(map[string]int),
+		Complete: make(map[string]bool),
+	}
+}
+
+// LoadTransactionArchive reads a transaction archive from a JSON file
+func LoadTransactionArchive(path string) (*TransactionArchive, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transaction archive: %w", err)
+	}
+
+	archive := NewTransactionArchive()
+	if err := json.Unmarshal(data, archive); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal transaction archive: %w", err)
+	}
+	if archive.NextPage == nil {
+		archive.NextPage = make(map[string]int)
+	}
+	if archive.Complete == nil {
+		archive.Complete = make(map[string]bool)
+	}
+	return archive, nil
+}
+
+// Save writes the archive to a JSON file. The file is replaced atomically, so an
+// interrupted save leaves the previous copy intact.
+func (a *TransactionArchive) Save(path string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.save(path)
+}
+
+func (a *TransactionArchive) save(path string) error {
+	data, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal transaction archive: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write transaction archive: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to replace transaction archive: %w", err)
+	}
+	return nil
+}
+
+// Add stores the transactions not already in the archive and returns how many were new
+func (a *TransactionArchive) Add(txs []models.Transaction) int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.add(txs)
+}
+
+func (a *TransactionArchive) add(txs []models.Transaction) int {
+	if a.seen == nil {
+		a.seen = make(map[string]bool, len(a.Transactions))
+		for _, tx := range a.Transactions {
+			a.seen[transactionKey(tx)] = true
+		}
+	}
+	added := 0
+	for _, tx := range txs {
+		key := transactionKey(tx)
+		if a.seen[key] {
+			continue
+		}
+		a.seen[key] = true
+		a.Transactions = append(a.Transactions, tx)
+		added++
+	}
+	if added > 0 {
+		a.UpdatedAt = time.Now()
+	}
+	return added
+}
+
+// Sorted returns the archived transactions, most recent first
+func (a *TransactionArchive) Sorted() []models.Transaction {
+	a.mu.Lock()
+	txs := append([]models.Transaction(nil), a.Transactions...)
+	a.mu.Unlock()
+	sort.SliceStable(txs, func(i, j int) bool { return txs[i].ProcessedDate.After(txs[j].ProcessedDate) })
+	return txs
+}
+
+// transactionKey identifies one transaction row. A trade has one row per player or pick
+// moving, all sharing the transaction ID.
+func transactionKey(tx models.Transaction) string {
+	pick := ""
+	if tx.DraftPick != nil {
+		pick = tx.DraftPick.String()
+	}
+	return strings.Join([]string{tx.ID, tx.Type, tx.PlayerID, tx.TeamID, tx.FromTeamID, tx.ToTeamID, pick}, "|")
+}
+
+// BackfillProgress reports one fetched page
+type BackfillProgress struct {
+	View       string
+	Page       int
+	TotalPages int
+	Added      int // New transactions on the page
+}
+
+// BackfillOptions configures BackfillTransactions
+type BackfillOptions struct {
+	Views       []string // Views to walk (nil = TransactionViewClaimDrop and TransactionViewTrade)
+	PageSize    int      // Results per page (0 = 250)
+	SkipDeleted bool     // Leave out transactions that were later deleted
+	Path        string   // Save the archive here after every page; "" = don't save
+	Restart     bool     // Walk every view from page 1 again, ignoring saved progress
+	Progress    func(BackfillProgress)
+}
+
+// BackfillResult summarizes a backfill run
+type BackfillResult struct {
+	Pages int            // Pages fetched
+	Added map[string]int // New transactions per view
+}
+
+// BackfillTransactions walks the league's transaction history into a local archive, saving
+// after every page so an interrupted run resumes where it stopped
+//
+// A view's first walk goes page by page to the end. Once a view is complete, later runs
+// fetch from page 1 only until a page adds nothing new, picking up recent transactions
+// cheaply. Deleted transactions are included by default so pages don't shift when a
+// transaction is deleted mid-walk; new transactions shift rows onto later pages instead,
+// which de-duplication absorbs.
+//
+// Fantrax's page count can change between requests and out-of-range pages may repeat the
+// last page, so the walk ends at the latest reported page count or when a page returns no
+// rows or the same rows as the page before.
+//
+// Parameters:
+//   - archive: The archive to fill (e.g. from LoadTransactionArchive or NewTransactionArchive)
+//   - opts: Views, page size, save path, and progress callback
+func (c *Client) BackfillTransactions(archive *TransactionArchive, opts BackfillOptions) (*BackfillResult, error) {
+	includeDeleted := !opts.SkipDeleted
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = 250
+	}
+	fetch := func(view string, page int) ([]models.Transaction, *models.PaginatedResultSet, error) {
+		return c.getTransactionPage(GetTransactionDetailsHistoryRequest{
+			LeagueID:          c.LeagueID,
+			MaxResultsPerPage: strconv.Itoa(pageSize),
+			ExecutedOnly:      true,
+			IncludeDeleted:    includeDeleted,
+			View:              view,
+			PageNumber:        strconv.Itoa(page),
+		})
+	}
+
+	archive.mu.Lock()
+	if archive.LeagueID == "" {
+		archive.LeagueID = c.LeagueID
+	}
+	leagueID := archive.LeagueID
+	archive.mu.Unlock()
+	if leagueID != c.LeagueID {
+		return nil, fmt.Errorf("transaction archive is for league %s, not %s", leagueID, c.LeagueID)
+	}
+	return backfillTransactions(archive, opts, fetch)
+}
+
+// backfillTransactions is BackfillTransactions with the page fetch supplied
+func backfillTransactions(archive *TransactionArchive, opts BackfillOptions, fetch func(view string, page int) ([]models.Transaction, *models.PaginatedResultSet, error)) (*BackfillResult, error) {
+	views := opts.Views
+	if len(views) == 0 {
+		views = []string{TransactionViewClaimDrop, TransactionViewTrade}
+	}
+	result := &BackfillResult{Added: make(map[string]int)}
+
+	archive.mu.Lock()
+	defer archive.mu.Unlock()
+	if opts.Restart {
+		archive.NextPage = make(map[string]int)
+		archive.Complete = make(map[string]bool)
+	}
+
+	for _, view := range views {
+		refresh := archive.Complete[view]
+		page := archive.NextPage[view]
+		if page < 1 || refresh {
+			page = 1
+		}
+
+		var previous []string
+		for {
+			txs, pagination, err := fetch(view, page)
+			if err != nil {
+				// Progress up to the last page is already saved
+				return result, fmt.Errorf("backfill of %s stopped at page %d: %w", view, page, err)
+			}
+			result.Pages++
+
+			keys := make([]string, len(txs))
+			for i, tx := range txs {
+				keys[i] = transactionKey(tx)
+			}
+			repeated := len(keys) > 0 && strings.Join(keys, "\n") == strings.Join(previous, "\n")
+			previous = keys
+
+			added := archive.add(txs)
+			result.Added[view] += added
+			totalPages := 0
+			if pagination != nil {
+				totalPages = pagination.TotalNumPages
+			}
+			if opts.Progress != nil {
+				opts.Progress(BackfillProgress{View: view, Page: page, TotalPages: totalPages, Added: added})
+			}
+
+			done := len(txs) == 0 || repeated || page >= totalPages || (refresh && added == 0)
+			if done {
+				archive.Complete[view] = true
+				delete(archive.NextPage, view)
+			} else {
+				archive.NextPage[view] = page + 1
+			}
+			if opts.Path != "" {
+				if err := archive.save(opts.Path); err != nil {
+					return result, err
+				}
+			}
+			if done {
+				break
+			}
+			page++
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,84 @@
+package auth_client
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestBackfillTransactions(t *testing.T) {
+	// Four claim pages of two rows; page 2 repeats a row from page 1, as happens when a new
+	// transaction shifts the listing mid-walk
+	pages := map[int][]models.Transaction{}
+	for page := 1; page <= 4; page++ {
+		for row := 0; row < 2; row++ {
+			id := fmt.Sprintf("tx%d", (page-1)*2+row)
+			pages[page] = append(pages[page], models.Transaction{ID: id, Type: "CLAIM", PlayerID: "p" + id})
+		}
+	}
+	pages[2][0] = pages[1][1]
+
+	failAt := 3
+	calls := 0
+	fetch := func(view string, page int) ([]models.Transaction, *models.PaginatedResultSet, error) {
+		calls++
+		if view != TransactionViewClaimDrop {
+			return nil, &models.PaginatedResultSet{TotalNumPages: 0}, nil
+		}
+		if page == failAt {
+			return nil, nil, errors.New("connection reset")
+		}
+		return pages[page], &models.PaginatedResultSet{TotalNumPages: 4}, nil
+	}
+
+	path := filepath.Join(t.TempDir(), "transactions.json")
+	archive := NewTransactionArchive()
+	if _, err := backfillTransactions(archive, BackfillOptions{Path: path}, fetch); err == nil {
+		t.Fatal("expected the failed page to stop the backfill")
+	}
+
+	// Resume from the saved file
+	resumed, err := LoadTransactionArchive(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resumed.Transactions) != 3 || resumed.NextPage[TransactionViewClaimDrop] != 3 {
+		t.Fatalf("unexpected saved progress: %d transactions, next page %d", len(resumed.Transactions), resumed.NextPage[TransactionViewClaimDrop])
+	}
+	failAt, calls = 0, 0
+	result, err := backfillTransactions(resumed, BackfillOptions{Path: path}, fetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resumed.Transactions) != 7 || result.Added[TransactionViewClaimDrop] != 4 || calls != 3 {
+		t.Errorf("expected pages 3 and 4 plus the trade view, got %d transactions and %d calls", len(resumed.Transactions), calls)
+	}
+	if !resumed.Complete[TransactionViewClaimDrop] || !resumed.Complete[TransactionViewTrade] {
+		t.Errorf("expected both views complete: %v", resumed.Complete)
+	}
+
+	// Refreshing a complete view stops at the first page with nothing new
+	calls = 0
+	if _, err := backfillTransactions(resumed, BackfillOptions{Views: []string{TransactionViewClaimDrop}}, fetch); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Errorf("expected one page for a refresh, got %d", calls)
+	}
+
+	// An out-of-range page that repeats the previous one ends the walk
+	calls = 0
+	repeat := func(view string, page int) ([]models.Transaction, *models.PaginatedResultSet, error) {
+		calls++
+		return pages[1], &models.PaginatedResultSet{TotalNumPages: 99}, nil
+	}
+	if _, err := backfillTransactions(NewTransactionArchive(), BackfillOptions{Views: []string{TransactionViewTrade}}, repeat); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("expected the repeated page to end the walk, got %d calls", calls)
+	}
+}
@@ -0,0 +1,37 @@
+package auth_client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pmurley/go-fantrax/auth_client/parser"
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestBucketTransactions(t *testing.T) {
+	eastern := time.FixedZone("EST", -5*3600)
+	txs := []models.Transaction{
+		{ID: "late", ProcessedDate: time.Date(2025, 6, 2, 3, 0, 0, 0, time.UTC)}, // Sunday 22:00 Eastern
+		{ID: "mon", ProcessedDate: time.Date(2025, 6, 2, 15, 0, 0, 0, time.UTC)},
+		{ID: "may", ProcessedDate: time.Date(2025, 5, 20, 15, 0, 0, 0, time.UTC)},
+		{ID: "undated"},
+	}
+
+	days := parser.GroupTransactionsByDay(txs, eastern)
+	if len(days["2025-06-01"]) != 1 || len(days["2025-06-02"]) != 1 {
+		t.Errorf("unexpected days: %v", days)
+	}
+
+	weeks := parser.BucketTransactions(txs, parser.BucketWeek, eastern)
+	if len(weeks) != 3 || weeks[0].Key != "2025-05-19" || weeks[1].Key != "2025-05-26" || weeks[2].Key != "2025-06-02" {
+		t.Fatalf("unexpected weeks: %+v", weeks)
+	}
+	if weeks[1].Transactions[0].ID != "late" {
+		t.Errorf("expected the Sunday evening transaction in the week of May 26, got %+v", weeks[1])
+	}
+
+	months := parser.GroupTransactionsByMonth(txs, time.UTC)
+	if len(months["2025-06"]) != 2 || len(months["2025-05"]) != 1 {
+		t.Errorf("unexpected months: %v", months)
+	}
+}
@@ -0,0 +1,101 @@
+package auth_client
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/pmurley/go-fantrax/auth_client/parser"
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// feeMessageRegex matches a dollar amount in a response message that mentions a fee
+var feeMessageRegex = regexp.MustCompile(`(?i)fee[^$]*(\$[\d,]+(?:\.\d+)?)`)
+
+// Fee returns the fee reported in the claim/drop response messages
+//
+// The response has no structured fee field, so the detail and other messages are searched
+// for a dollar amount following the word "fee". Returns false if no fee was mentioned.
+func (r *CreateClaimDropResponse) Fee() (float64, bool) {
+	messages := append(append([]string{}, r.DetailMessages...), r.OtherMessages...)
+	for _, msg := range messages {
+		if m := feeMessageRegex.FindStringSubmatch(stripHTML(msg)); m != nil {
+			return parser.ParseFeeAmount(m[1])
+		}
+	}
+	return 0, false
+}
+
+// TeamFeeSummary is the total fees a team has been charged, split by transaction type
+type TeamFeeSummary struct {
+	TeamID       string  `json:"teamId"`
+	TeamName     string  `json:"teamName"`
+	ClaimFees    float64 `json:"claimFees"`
+	DropFees     float64 `json:"dropFees"`
+	TradeFees    float64 `json:"tradeFees"`
+	Total        float64 `json:"total"`
+	Transactions int     `json:"transactions"` // Number of transactions that carried a fee
+}
+
+// SummarizeTransactionFees totals per-move fees by team
+//
+// Claim and drop fees are charged to the transacting team; trade fees are charged to the
+// team receiving the player. Transactions without a fee are ignored. The result is ordered
+// by total fees, highest first.
+func SummarizeTransactionFees(txs []models.Transaction) []TeamFeeSummary {
+	byTeam := make(map[string]*TeamFeeSummary)
+	for _, tx := range txs {
+		if tx.Fee == 0 {
+			continue
+		}
+
+		teamID, teamName := tx.TeamID, tx.TeamName
+		if tx.Type == "TRADE" {
+			teamID, teamName = tx.ToTeamID, tx.ToTeamName
+		}
+		if teamID == "" {
+			continue
+		}
+
+		summary, ok := byTeam[teamID]
+		if !ok {
+			summary = &TeamFeeSummary{TeamID: teamID, TeamName: teamName}
+			byTeam[teamID] = summary
+		}
+
+		switch tx.Type {
+		case "CLAIM":
+			summary.ClaimFees += tx.Fee
+		case "DROP":
+			summary.DropFees += tx.Fee
+		case "TRADE":
+			summary.TradeFees += tx.Fee
+		}
+		summary.Total += tx.Fee
+		summary.Transactions++
+	}
+
+	summaries := make([]TeamFeeSummary, 0, len(byTeam))
+	for _, summary := range byTeam {
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Total != summaries[j].Total {
+			return summaries[i].Total > summaries[j].Total
+		}
+		return summaries[i].TeamName < summaries[j].TeamName
+	})
+	return summaries
+}
+
+// GetTeamFeeSummary fetches the full claim/drop and trade history and totals the fees
+// charged to each team
+//
+// Only leagues that charge per-move fees will have non-empty results.
+func (c *Client) GetTeamFeeSummary() ([]TeamFeeSummary, error) {
+	txs, err := c.GetAllTransactionsIncludingTrades()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	return SummarizeTransactionFees(txs), nil
+}
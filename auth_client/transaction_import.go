@@ -0,0 +1,552 @@
+package auth_client
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// ImportKind is the type of transaction in an import row
+type ImportKind string
+
+const (
+	ImportAdd   ImportKind = "add"
+	ImportDrop  ImportKind = "drop"
+	ImportTrade ImportKind = "trade"
+)
+
+// ImportRow is one desired transaction read from an import file. Teams and players may be
+// given by ID or by name.
+type ImportRow struct {
+	Line     int // Line number in the source file, for error messages
+	Kind     ImportKind
+	Team     string // Team adding, dropping, or trading away the player
+	Player   string
+	ToTeam   string // Trades only: the team receiving the player
+	Group    string // Trades only: rows with the same group are executed as one trade
+	Position string // Adds only: slot position ID or short name (e.g. "SS"); defaults to the player's default position
+	Status   string // Adds only: "active", "reserve", "ir", "minors" or a status ID; defaults to reserve
+	Waivers  bool   // Drops only: drop to waivers instead of free agency
+}
+
+// LoadTransactionsCSV reads desired transactions from CSV
+//
+// The first row must be a header with "type", "team" and "player" columns. The optional
+// columns are "to_team", "group", "position", "status" and "waivers". Other columns are
+// ignored, so a spreadsheet exported from another platform only needs the columns renamed.
+func LoadTransactionsCSV(r io.Reader) ([]ImportRow, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transaction CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("transaction CSV is empty")
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case "teamid", "team_id", "from_team":
+			name = "team"
+		case "player_id", "playerid":
+			name = "player"
+		case "toteam", "to_team_id":
+			name = "to_team"
+		}
+		columns[name] = i
+	}
+	for _, required := range []string{"type", "team", "player"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("transaction CSV header must contain type, team and player columns")
+		}
+	}
+
+	var rows []ImportRow
+	for i, record := range records[1:] {
+		field := func(name string) string {
+			col, ok := columns[name]
+			if !ok || col >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[col])
+		}
+		if field("type") == "" && field("player") == "" {
+			continue
+		}
+
+		row := ImportRow{
+			Line:     i + 2,
+			Kind:     ImportKind(strings.ToLower(field("type"))),
+			Team:     field("team"),
+			Player:   field("player"),
+			ToTeam:   field("to_team"),
+			Group:    field("group"),
+			Position: field("position"),
+			Status:   field("status"),
+		}
+		if waivers := field("waivers"); waivers != "" {
+			row.Waivers, err = strconv.ParseBool(waivers)
+			if err != nil {
+				return nil, fmt.Errorf("transaction CSV line %d: invalid waivers value %q", row.Line, waivers)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// ImportStep is one resolved transaction. Trades may combine several rows.
+type ImportStep struct {
+	Kind  ImportKind
+	Lines []int // Source lines the step came from
+
+	// Adds and drops
+	TeamID     string
+	PlayerID   string
+	PlayerName string
+	PositionID string // Adds only
+	StatusID   string // Adds only
+	ToWaivers  bool   // Drops only
+
+	// Trades
+	Items []TradeItem
+
+	Problems []string // Validation problems; a plan with problems is not executed
+}
+
+// ImportPlan is the validated list of transactions to execute, in file order
+type ImportPlan struct {
+	Steps []ImportStep
+}
+
+// Valid returns true if no step in the plan has validation problems
+func (p *ImportPlan) Valid() bool {
+	for _, step := range p.Steps {
+		if len(step.Problems) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Problems returns every validation problem in the plan, prefixed with its source lines
+func (p *ImportPlan) Problems() []string {
+	var problems []string
+	for _, step := range p.Steps {
+		for _, problem := range step.Problems {
+			problems = append(problems, fmt.Sprintf("line %s: %s", joinLines(step.Lines), problem))
+		}
+	}
+	return problems
+}
+
+func joinLines(lines []int) string {
+	parts := make([]string, len(lines))
+	for i, line := range lines {
+		parts[i] = strconv.Itoa(line)
+	}
+	return strings.Join(parts, ",")
+}
+
+// ErrInvalidImportPlan is returned by ImportTransactions when the rows fail validation
+var ErrInvalidImportPlan = errors.New("transaction import failed validation")
+
+// importPositions maps position short names to slot position IDs
+var importPositions = map[string]string{
+	"C":    PosC,
+	"1B":   Pos1B,
+	"3B":   Pos3B,
+	"SS":   PosSS,
+	"MI":   PosMI,
+	"CF":   PosCF,
+	"OF":   PosOF,
+	"UT":   PosUtil,
+	"UTIL": PosUtil,
+	"SP":   PosSP,
+	"RP":   PosRP,
+	"P":    PosP,
+}
+
+// importStatuses maps status names to status IDs
+var importStatuses = map[string]string{
+	"active":          StatusActive,
+	"reserve":         StatusReserve,
+	"bench":           StatusReserve,
+	"ir":              StatusIR,
+	"injured reserve": StatusIR,
+	"minors":          StatusMinors,
+}
+
+// BuildImportPlan resolves import rows against the league's teams, current rosters, and
+// available players, and validates each transaction
+//
+// Dropped and traded players must be on the named team's roster; added players must be in
+// available. Rows are checked in file order against the rosters as the rows before them
+// leave them, so a player can be added and then traded, or dropped and re-added, but not
+// added twice. The rows of a trade group become one step at the group's last row, where the
+// trade runs, and are all checked against the rosters at that point. Unknown or ambiguous
+// names are reported as problems rather than guessed.
+//
+// Parameters:
+//   - rows: The transactions to import, e.g. from LoadTransactionsCSV
+//   - rosters: Current rosters keyed by team ID
+//   - teams: The league's teams
+//   - available: Free agents and waiver players (only needed when rows contain adds)
+func BuildImportPlan(rows []ImportRow, rosters map[string]*models.TeamRoster, teams []models.FantasyTeam, available []models.PoolPlayer) *ImportPlan {
+	plan := &ImportPlan{}
+	state := newImportState(rosters, available)
+
+	// A trade group runs as one trade at its last row, so its rows are held until then
+	lastRow := make(map[string]int)
+	for i, row := range rows {
+		if row.Kind == ImportTrade && row.Group != "" {
+			lastRow[row.Group] = i
+		}
+	}
+	pending := make(map[string][]ImportRow)
+
+	for i, row := range rows {
+		if row.Kind == ImportTrade && row.Group != "" && i < lastRow[row.Group] {
+			pending[row.Group] = append(pending[row.Group], row)
+			continue
+		}
+
+		step := ImportStep{Kind: row.Kind, Lines: []int{row.Line}}
+		problem := func(format string, args ...interface{}) {
+			step.Problems = append(step.Problems, fmt.Sprintf(format, args...))
+		}
+
+		teamID, teamProblem := matchTeam(teams, row.Team)
+		if teamProblem != "" {
+			problem("%s", teamProblem)
+		}
+
+		switch row.Kind {
+		case ImportAdd:
+			step.TeamID = teamID
+			step.PlayerID, step.PlayerName, step.PositionID = matchAvailablePlayer(state.available, row.Player, problem)
+			if row.Position != "" {
+				if id, ok := importPositions[strings.ToUpper(row.Position)]; ok {
+					step.PositionID = id
+				} else if _, err := strconv.Atoi(row.Position); err == nil {
+					step.PositionID = row.Position
+				} else {
+					problem("unknown position %q", row.Position)
+				}
+			}
+			step.StatusID = StatusReserve
+			if row.Status != "" {
+				if id, ok := importStatuses[strings.ToLower(row.Status)]; ok {
+					step.StatusID = id
+				} else if _, err := strconv.Atoi(row.Status); err == nil {
+					step.StatusID = row.Status
+				} else {
+					problem("unknown status %q", row.Status)
+				}
+			}
+			if step.PlayerID != "" && step.PositionID == "" {
+				problem("no default position for %s; set the position column", step.PlayerName)
+			}
+			if len(step.Problems) == 0 {
+				state.add(teamID, step.PlayerID, step.PlayerName)
+			}
+
+		case ImportDrop:
+			step.TeamID = teamID
+			step.ToWaivers = row.Waivers
+			step.PlayerID, step.PlayerName = matchTeamPlayer(state.rosters, teamID, row.Player, problem)
+			if len(step.Problems) == 0 {
+				state.drop(teamID, step.PlayerID)
+			}
+
+		case ImportTrade:
+			group := []ImportRow{row}
+			if row.Group != "" {
+				group = append(pending[row.Group], row)
+			}
+			step = planTrade(group, teams, state)
+
+		default:
+			problem("unknown transaction type %q (want add, drop or trade)", row.Kind)
+		}
+
+		plan.Steps = append(plan.Steps, step)
+	}
+
+	return plan
+}
+
+// planTrade checks the rows of one trade against the rosters as the rows before the trade
+// leave them, and moves each player whose row has no problems
+func planTrade(group []ImportRow, teams []models.FantasyTeam, state *importState) ImportStep {
+	step := ImportStep{Kind: ImportTrade}
+	for _, row := range group {
+		var problems []string
+		problem := func(format string, args ...interface{}) {
+			problems = append(problems, fmt.Sprintf(format, args...))
+		}
+
+		teamID, teamProblem := matchTeam(teams, row.Team)
+		if teamProblem != "" {
+			problem("%s", teamProblem)
+		}
+		toTeamID, toProblem := matchTeam(teams, row.ToTeam)
+		if toProblem != "" {
+			problem("to_team: %s", toProblem)
+		} else if toTeamID == teamID {
+			problem("player is already on team %s", row.ToTeam)
+		}
+		playerID, playerName := matchTeamPlayer(state.rosters, teamID, row.Player, problem)
+		if len(problems) == 0 {
+			state.move(teamID, toTeamID, playerID, playerName)
+		}
+
+		step.Lines = append(step.Lines, row.Line)
+		step.Items = append(step.Items, TradeItem{PlayerID: playerID, FromTeamID: teamID, ToTeamID: toTeamID})
+		step.Problems = append(step.Problems, problems...)
+	}
+	return step
+}
+
+// importState is the league's rosters and available players as the import rows checked so
+// far leave them
+type importState struct {
+	rosters   map[string]*models.TeamRoster // Every player in ActiveRoster
+	available []models.PoolPlayer
+}
+
+// newImportState copies rosters and available so rows can be applied without changing them
+func newImportState(rosters map[string]*models.TeamRoster, available []models.PoolPlayer) *importState {
+	state := &importState{
+		rosters:   make(map[string]*models.TeamRoster, len(rosters)),
+		available: append([]models.PoolPlayer(nil), available...),
+	}
+	for teamID, roster := range rosters {
+		if roster != nil {
+			state.rosters[teamID] = &models.TeamRoster{ActiveRoster: roster.AllPlayers()}
+		}
+	}
+	return state
+}
+
+// add moves an available player onto a team
+func (s *importState) add(teamID, playerID, name string) {
+	s.available = slices.DeleteFunc(s.available, func(p models.PoolPlayer) bool { return p.PlayerID == playerID })
+	if roster := s.rosters[teamID]; roster != nil {
+		roster.ActiveRoster = append(roster.ActiveRoster, models.RosterPlayer{PlayerID: playerID, Name: name})
+	}
+}
+
+// drop moves a player from a team to the available players. Their default position isn't
+// known, so re-adding them needs a position.
+func (s *importState) drop(teamID, playerID string) {
+	if player := s.remove(teamID, playerID); player != nil {
+		s.available = append(s.available, models.PoolPlayer{PlayerID: player.PlayerID, Name: player.Name})
+	}
+}
+
+// move trades a player from one team to another
+func (s *importState) move(fromTeamID, toTeamID, playerID, name string) {
+	s.remove(fromTeamID, playerID)
+	if roster := s.rosters[toTeamID]; roster != nil {
+		roster.ActiveRoster = append(roster.ActiveRoster, models.RosterPlayer{PlayerID: playerID, Name: name})
+	}
+}
+
+// remove takes a player off a team's roster, returning them or nil
+func (s *importState) remove(teamID, playerID string) *models.RosterPlayer {
+	roster := s.rosters[teamID]
+	if roster == nil {
+		return nil
+	}
+	i := slices.IndexFunc(roster.ActiveRoster, func(p models.RosterPlayer) bool { return p.PlayerID == playerID })
+	if i < 0 {
+		return nil
+	}
+	player := roster.ActiveRoster[i]
+	roster.ActiveRoster = slices.Delete(roster.ActiveRoster, i, i+1)
+	return &player
+}
+
+// matchTeam finds a team by ID, name, or short name
+func matchTeam(teams []models.FantasyTeam, team string) (string, string) {
+	if team == "" {
+		return "", "team is required"
+	}
+	var matches []string
+	for _, t := range teams {
+		if t.ID == team {
+			return t.ID, ""
+		}
+		if strings.EqualFold(t.Name, team) || strings.EqualFold(t.ShortName, team) {
+			matches = append(matches, t.ID)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Sprintf("team %q is not in this league", team)
+	case 1:
+		return matches[0], ""
+	default:
+		return "", fmt.Sprintf("team %q matches %d teams; use the team ID", team, len(matches))
+	}
+}
+
+// matchTeamPlayer finds a player on a team's roster by ID or name
+func matchTeamPlayer(rosters map[string]*models.TeamRoster, teamID, player string, problem func(string, ...interface{})) (string, string) {
+	if teamID == "" {
+		return "", ""
+	}
+	roster := rosters[teamID]
+	if roster == nil {
+		problem("no roster available for team %s", teamID)
+		return "", ""
+	}
+	players := roster.AllPlayers()
+	playerID, p := matchRosterPlayer(players, player)
+	if p != "" {
+		problem("%s", p)
+		return "", ""
+	}
+	for _, rp := range players {
+		if rp.PlayerID == playerID {
+			return playerID, rp.Name
+		}
+	}
+	return playerID, ""
+}
+
+// matchAvailablePlayer finds an available player by ID or name, returning their ID, name,
+// and default position
+func matchAvailablePlayer(available []models.PoolPlayer, player string, problem func(string, ...interface{})) (string, string, string) {
+	var matches []models.PoolPlayer
+	for _, p := range available {
+		if p.PlayerID == player {
+			return p.PlayerID, p.Name, p.DefaultPosID
+		}
+		if strings.EqualFold(p.Name, player) {
+			matches = append(matches, p)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		problem("%q is not an available player", player)
+		return "", "", ""
+	case 1:
+		return matches[0].PlayerID, matches[0].Name, matches[0].DefaultPosID
+	default:
+		problem("%q matches %d available players; use the player ID", player, len(matches))
+		return "", "", ""
+	}
+}
+
+// ImportOptions configures ImportTransactions
+type ImportOptions struct {
+	Period int  // Roster period for the transactions (0 = current period)
+	DryRun bool // Resolve and validate without executing anything
+
+	// Progress, if set, is called after each step is attempted
+	Progress func(ImportProgress)
+}
+
+// ImportProgress reports the outcome of one import step
+type ImportProgress struct {
+	Step  ImportStep
+	Done  int // Steps attempted so far, including this one
+	Total int
+	Err   error
+}
+
+// ImportTransactions resolves, validates, and executes transactions as commissioner, for
+// example to replay a league's history after migrating it from another platform
+//
+// Rosters (and, if any row is an add, available players) are fetched and the rows checked
+// with BuildImportPlan. If any row has problems nothing is executed and the plan is returned
+// with ErrInvalidImportPlan. With DryRun set the validated plan is returned without making
+// changes.
+//
+// Steps run one at a time in file order; the first failure stops the import and the error is
+// returned alongside the plan.
+func (c *Client) ImportTransactions(rows []ImportRow, opts ImportOptions) (*ImportPlan, error) {
+	period := opts.Period
+	if period == 0 {
+		currentPeriod, err := c.GetCurrentPeriod()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current period: %w", err)
+		}
+		period = currentPeriod
+	}
+
+	rosters, teams, err := c.GetAllTeamRosters(fmt.Sprintf("%d", period))
+	if err != nil {
+		return nil, err
+	}
+
+	var available []models.PoolPlayer
+	for _, row := range rows {
+		if row.Kind == ImportAdd {
+			available, err = c.GetPlayerPool(WithStatusFilter(StatusFilterAvailable))
+			if err != nil {
+				return nil, fmt.Errorf("failed to get available players: %w", err)
+			}
+			break
+		}
+	}
+
+	plan := BuildImportPlan(rows, rosters, teams, available)
+	if !plan.Valid() {
+		return plan, ErrInvalidImportPlan
+	}
+	if opts.DryRun {
+		return plan, nil
+	}
+
+	for i, step := range plan.Steps {
+		err := c.executeImportStep(period, step)
+		if opts.Progress != nil {
+			opts.Progress(ImportProgress{Step: step, Done: i + 1, Total: len(plan.Steps), Err: err})
+		}
+		if err != nil {
+			return plan, fmt.Errorf("line %s: %w", joinLines(step.Lines), err)
+		}
+	}
+
+	return plan, nil
+}
+
+func (c *Client) executeImportStep(period int, step ImportStep) error {
+	switch step.Kind {
+	case ImportAdd:
+		response, err := c.CommissionerAdd(period, step.TeamID, step.PlayerID, step.PositionID, step.StatusID)
+		if err != nil {
+			return fmt.Errorf("failed to add %s: %w", step.PlayerName, err)
+		}
+		if !response.IsSuccess() {
+			return fmt.Errorf("add of %s was not executed: %s", step.PlayerName, response.GenericMessage)
+		}
+	case ImportDrop:
+		response, err := c.CommissionerDrop(period, step.TeamID, step.PlayerID, step.ToWaivers)
+		if err != nil {
+			return fmt.Errorf("failed to drop %s: %w", step.PlayerName, err)
+		}
+		if !response.IsSuccess() {
+			return fmt.Errorf("drop of %s was not executed: %s", step.PlayerName, response.GenericMessage)
+		}
+	case ImportTrade:
+		response, err := c.CommissionerTrade(period, step.Items, "", false)
+		if err != nil {
+			return fmt.Errorf("failed to execute trade: %w", err)
+		}
+		if !response.IsSuccess() {
+			return fmt.Errorf("trade was not executed: %s", response.GenericMessage)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package auth_client
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestBuildImportPlan(t *testing.T) {
+	rows, err := LoadTransactionsCSV(strings.NewReader(`type,team,player,to_team,group,position,status,notes
+add,Sluggers,Jane Doe,,,SS,active,from ESPN
+drop,t1,Sam Smith,,,,,
+trade,t1,Ann Lee,Flyers,A,,,
+trade,FLY,Bo Park,t1,A,,,
+drop,t2,Nobody,,,,,
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	teams := []models.FantasyTeam{
+		{ID: "t1", Name: "Sluggers", ShortName: "SLG"},
+		{ID: "t2", Name: "Flyers", ShortName: "FLY"},
+	}
+	rosters := map[string]*models.TeamRoster{
+		"t1": {ActiveRoster: []models.RosterPlayer{{PlayerID: "p1", Name: "Sam Smith"}, {PlayerID: "p2", Name: "Ann Lee"}}},
+		"t2": {ActiveRoster: []models.RosterPlayer{{PlayerID: "p3", Name: "Bo Park"}}},
+	}
+	available := []models.PoolPlayer{{PlayerID: "p9", Name: "Jane Doe", DefaultPosID: PosUtil}}
+
+	plan := BuildImportPlan(rows, rosters, teams, available)
+	if len(plan.Steps) != 4 {
+		t.Fatalf("got %d steps, want 4 (the two trade rows share a group)", len(plan.Steps))
+	}
+
+	add := plan.Steps[0]
+	if add.TeamID != "t1" || add.PlayerID != "p9" || add.PositionID != PosSS || add.StatusID != StatusActive {
+		t.Errorf("add resolved to %+v", add)
+	}
+	if drop := plan.Steps[1]; drop.PlayerID != "p1" || len(drop.Problems) != 0 {
+		t.Errorf("drop resolved to %+v", drop)
+	}
+	trade := plan.Steps[2]
+	want := []TradeItem{{PlayerID: "p2", FromTeamID: "t1", ToTeamID: "t2"}, {PlayerID: "p3", FromTeamID: "t2", ToTeamID: "t1"}}
+	if len(trade.Items) != 2 || trade.Items[0] != want[0] || trade.Items[1] != want[1] {
+		t.Errorf("trade items = %+v, want %+v", trade.Items, want)
+	}
+
+	if plan.Valid() {
+		t.Fatal("plan with an unknown player should be invalid")
+	}
+	if problems := plan.Problems(); len(problems) != 1 || !strings.HasPrefix(problems[0], "line 6:") {
+		t.Errorf("problems = %v", problems)
+	}
+}
+
+func TestBuildImportPlanAppliesRowsInOrder(t *testing.T) {
+	teams := []models.FantasyTeam{{ID: "t1", Name: "Sluggers"}, {ID: "t2", Name: "Flyers"}}
+	rosters := map[string]*models.TeamRoster{
+		"t1": {ActiveRoster: []models.RosterPlayer{{PlayerID: "p1", Name: "Sam Smith"}}},
+		"t2": {ActiveRoster: []models.RosterPlayer{{PlayerID: "p3", Name: "Bo Park"}}},
+	}
+	available := []models.PoolPlayer{{PlayerID: "p9", Name: "Jane Doe", DefaultPosID: PosUtil}}
+
+	tests := []struct {
+		name     string
+		csv      string
+		problems int
+	}{
+		{"add then trade", "add,t1,Jane Doe,,\ntrade,t1,Jane Doe,t2,\n", 0},
+		{"drop then re-add", "drop,t1,Sam Smith,,\nadd,t2,Sam Smith,,SS\n", 0},
+		{"re-add needs a position", "drop,t1,Sam Smith,,\nadd,t2,Sam Smith,,\n", 1},
+		{"double add", "add,t1,Jane Doe,,\nadd,t2,Jane Doe,,\n", 1},
+		{"drop a traded player", "trade,t2,Bo Park,t1,\ndrop,t2,Bo Park,,\n", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := LoadTransactionsCSV(strings.NewReader("type,team,player,to_team,position\n" + tt.csv))
+			if err != nil {
+				t.Fatal(err)
+			}
+			plan := BuildImportPlan(rows, rosters, teams, available)
+			if problems := plan.Problems(); len(problems) != tt.problems {
+				t.Errorf("problems = %v, want %d", problems, tt.problems)
+			}
+		})
+	}
+
+	// The caller's rosters and available players are left unchanged
+	if len(rosters["t1"].ActiveRoster) != 1 || len(available) != 1 {
+		t.Errorf("inputs were modified: %+v, %+v", rosters["t1"], available)
+	}
+}
+
+func TestBuildImportPlanTradeGroupRunsAtLastRow(t *testing.T) {
+	teams := []models.FantasyTeam{{ID: "t1", Name: "Sluggers"}, {ID: "t2", Name: "Flyers"}}
+	rosters := map[string]*models.TeamRoster{
+		"t1": {ActiveRoster: []models.RosterPlayer{{PlayerID: "p1", Name: "Sam Smith"}}},
+	}
+	available := []models.PoolPlayer{{PlayerID: "p9", Name: "Jane Doe", DefaultPosID: PosUtil}}
+
+	rows, err := LoadTransactionsCSV(strings.NewReader(`type,team,player,to_team,group
+trade,t1,Sam Smith,t2,A
+add,t1,Jane Doe,,
+trade,t1,Jane Doe,t2,A
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plan := BuildImportPlan(rows, rosters, teams, available)
+	if !plan.Valid() {
+		t.Fatalf("problems = %v", plan.Problems())
+	}
+	if len(plan.Steps) != 2 || plan.Steps[0].Kind != ImportAdd || plan.Steps[1].Kind != ImportTrade {
+		t.Fatalf("steps = %+v, want the add and then the trade", plan.Steps)
+	}
+	trade := plan.Steps[1]
+	if len(trade.Lines) != 2 || trade.Lines[0] != 2 || trade.Lines[1] != 4 {
+		t.Errorf("trade lines = %v, want [2 4]", trade.Lines)
+	}
+	if len(trade.Items) != 2 || trade.Items[0].PlayerID != "p1" || trade.Items[1].PlayerID != "p9" {
+		t.Errorf("trade items = %+v", trade.Items)
+	}
+
+	// A row between the group's rows sees the rosters from before the trade
+	rows, err = LoadTransactionsCSV(strings.NewReader(`type,team,player,to_team,group
+trade,t1,Sam Smith,t2,A
+drop,t2,Sam Smith,,
+trade,t1,Jane Doe,t2,A
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if problems := BuildImportPlan(rows, rosters, teams, available).Problems(); len(problems) != 2 {
+		t.Errorf("problems = %v, want the early drop and the unrostered trade player", problems)
+	}
+}
@@ -0,0 +1,45 @@
+package auth_client
+
+import (
+	"testing"
+
+	"github.com/pmurley/go-fantrax/auth_client/parser"
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestParseTransactionNotes(t *testing.T) {
+	commissionerDate := models.TableCell{
+		Key:     "date",
+		Content: "Tue Jun 10, 2025, 8:07AM",
+		Icon:    "COMMISSIONER",
+		ToolTip: "<b>Processed</b> Tue Jun 10, 2025, 8:07:00 AM<br/><b>Note:</b> Reversing a claim made in error &amp; refunding the bid<br/>",
+		Rowspan: 2,
+	}
+	response := &models.TransactionHistoryResponse{Responses: []models.TransactionDataResponse{{Data: models.TransactionData{
+		Table: models.TransactionTable{Rows: []models.TransactionRow{
+			{TxSetID: "a", TransactionCode: "CLAIM", Scorer: models.TransactionPlayer{ScorerID: "p1"}, Cells: []models.TableCell{commissionerDate}},
+			{TxSetID: "a", TransactionCode: "DROP", Scorer: models.TransactionPlayer{ScorerID: "p2"}},
+			{TxSetID: "b", TransactionCode: "CLAIM", Scorer: models.TransactionPlayer{ScorerID: "p3"}, Msg: "  Swapping for <i>depth</i> "},
+			{TxSetID: "c", TransactionCode: "CLAIM", Scorer: models.TransactionPlayer{ScorerID: "p4"}},
+		}},
+	}}}}
+
+	txs, _, err := parser.ParseTransactions(response, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"Reversing a claim made in error & refunding the bid",
+		"Reversing a claim made in error & refunding the bid", // Shared by the group
+		"Swapping for depth",
+		"",
+	}
+	for i, note := range want {
+		if txs[i].Note != note {
+			t.Errorf("row %d: expected note %q, got %q", i, note, txs[i].Note)
+		}
+	}
+	if txs[0].ExecutedBy != "COMMISSIONER" {
+		t.Errorf("expected a commissioner action, got %q", txs[0].ExecutedBy)
+	}
+}
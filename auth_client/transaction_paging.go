@@ -0,0 +1,150 @@
+package auth_client
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// transactionPageSize is the page size GetAllTransactions and GetAllTrades request
+const transactionPageSize = 250
+
+// transactionRowKey identifies a transaction row across pages. Rows of one transaction share
+// its ID (a claim and its drop, or every player in a trade), so the player and teams are part
+// of the key.
+func transactionRowKey(tx models.Transaction) string {
+	return tx.ID + "|" + tx.Type + "|" + tx.PlayerID + "|" + tx.PlayerName + "|" + tx.TeamID + "|" + tx.FromTeamID + "|" + tx.ToTeamID
+}
+
+// transactionMerger collects rows from pages fetched while the history may be changing,
+// keeping the first copy of each row in history order
+type transactionMerger struct {
+	seen map[string]bool
+	rows []models.Transaction
+}
+
+// add merges a page into the rows and returns the rows not seen before. New rows go next to
+// the rows they neighbour on the page, or at the end if the page has no rows seen before.
+func (m *transactionMerger) add(page []models.Transaction) []models.Transaction {
+	var added, leading []models.Transaction
+	at := -1 // Where the next new row goes; -1 until a row seen before is found
+	for _, tx := range page {
+		key := transactionRowKey(tx)
+		if !m.seen[key] {
+			m.seen[key] = true
+			added = append(added, tx)
+			if at < 0 {
+				leading = append(leading, tx)
+			} else {
+				m.rows = slices.Insert(m.rows, at, tx)
+				at++
+			}
+			continue
+		}
+		at = slices.IndexFunc(m.rows, func(row models.Transaction) bool { return transactionRowKey(row) == key })
+		if len(leading) > 0 {
+			m.rows = slices.Insert(m.rows, at, leading...)
+			at += len(leading)
+			leading = nil
+		}
+		at++
+	}
+	m.rows = append(m.rows, leading...)
+	return added
+}
+
+// getAllTransactionPages fetches every executed transaction in a history view, newest first.
+// afterPage, when set, is called with the rows each page adds.
+//
+// By default the loop trusts the page count of each response, so rows that shift between pages
+// while it runs are repeated or skipped. With Client.ReconcileTransactionPages set, rows are
+// merged by transaction ID, and when the total Fantrax reports changes the affected pages are
+// fetched again: earlier pages when rows were removed, and the newest pages (until one adds
+// nothing new) when rows arrived.
+func (c *Client) getAllTransactionPages(view, operation string, afterPage func([]models.Transaction)) ([]models.Transaction, error) {
+	var all []models.Transaction
+	merger := &transactionMerger{seen: make(map[string]bool)}
+	fetched := 0
+	lastTotal := -1
+	arrived := false
+
+	fetch := func(pageNumber int) ([]models.Transaction, *models.PaginatedResultSet, error) {
+		transactions, pagination, err := c.getTransactionPage(GetTransactionDetailsHistoryRequest{
+			LeagueID:          c.LeagueID,
+			MaxResultsPerPage: fmt.Sprintf("%d", transactionPageSize),
+			ExecutedOnly:      true,
+			IncludeDeleted:    false,
+			View:              view,
+			PageNumber:        fmt.Sprintf("%d", pageNumber),
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		if c.ReconcileTransactionPages {
+			transactions = merger.add(transactions)
+		} else {
+			all = append(all, transactions...)
+		}
+		if afterPage != nil {
+			afterPage(transactions)
+		}
+		fetched++
+		rowsSoFar := len(all)
+		if c.ReconcileTransactionPages {
+			rowsSoFar = len(merger.rows)
+		}
+		c.reportPageProgress(operation, fetched, len(transactions), rowsSoFar, pagination)
+		return transactions, pagination, nil
+	}
+
+	for pageNumber := 1; ; pageNumber++ {
+		_, pagination, err := fetch(pageNumber)
+		if err != nil {
+			return nil, err
+		}
+		if pagination == nil {
+			break // No response data
+		}
+
+		if c.ReconcileTransactionPages {
+			total := pagination.TotalNumResults
+			if lastTotal >= 0 && total != lastTotal {
+				if total > lastTotal {
+					arrived = true
+				} else {
+					// Rows from this page moved onto pages already fetched; go back over them
+					back := (lastTotal - total + transactionPageSize - 1) / transactionPageSize
+					lastTotal = total
+					pageNumber = max(pageNumber-back-1, 0)
+					continue
+				}
+			}
+			lastTotal = total
+		}
+
+		if pageNumber >= pagination.TotalNumPages {
+			break
+		}
+	}
+
+	if !c.ReconcileTransactionPages {
+		return all, nil
+	}
+	if !arrived {
+		return merger.rows, nil
+	}
+
+	// Rows that arrived mid-fetch are at the front of the history; read from the start until
+	// a page holds only rows already seen
+	for pageNumber := 1; ; pageNumber++ {
+		added, pagination, err := fetch(pageNumber)
+		if err != nil {
+			return nil, err
+		}
+		if len(added) == 0 || pagination == nil || pageNumber >= pagination.TotalNumPages {
+			break
+		}
+	}
+	return merger.rows, nil
+}
@@ -0,0 +1,105 @@
+package auth_client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// historyTransport serves a claim history newest first, calling change after each request so
+// the history can shift between pages
+type historyTransport struct {
+	ids      []int
+	requests int
+	change   func(t *historyTransport)
+}
+
+func (t *historyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var envelope struct {
+		Msgs []struct {
+			Data GetTransactionDetailsHistoryRequest `json:"data"`
+		} `json:"msgs"`
+	}
+	if err := json.NewDecoder(req.Body).Decode(&envelope); err != nil {
+		return nil, err
+	}
+	page, _ := strconv.Atoi(envelope.Msgs[0].Data.PageNumber)
+
+	var rows []string
+	for i := (page - 1) * transactionPageSize; i < page*transactionPageSize && i < len(t.ids); i++ {
+		rows = append(rows, fmt.Sprintf(`{"txSetId":"%d","transactionCode":"CLAIM","scorer":{"scorerId":"p%d"},"cells":[]}`, t.ids[i], t.ids[i]))
+	}
+	pages := (len(t.ids) + transactionPageSize - 1) / transactionPageSize
+	body := fmt.Sprintf(`{"responses":[{"data":{"paginatedResultSet":{"totalNumPages":%d,"pageNumber":%d,"totalNumResults":%d},"table":{"rows":[%s]}}}]}`,
+		pages, page, len(t.ids), strings.Join(rows, ","))
+
+	t.requests++
+	if t.change != nil {
+		t.change(t)
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+}
+
+// history returns IDs from newest down to oldest
+func history(newest, oldest int) []int {
+	var ids []int
+	for id := newest; id >= oldest; id-- {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestReconcileTransactionPages(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(t *historyTransport)
+		want   []int
+	}{
+		{
+			name: "rows arrive after the first page",
+			change: func(t *historyTransport) {
+				if t.requests == 1 {
+					t.ids = append(history(603, 601), t.ids...)
+				}
+			},
+			want: history(603, 1),
+		},
+		{
+			// Rows already read stay; those that moved up onto page 1 are not skipped
+			name: "rows removed after the first page",
+			change: func(t *historyTransport) {
+				if t.requests == 1 {
+					t.ids = append(history(600, 591), history(580, 1)...)
+				}
+			},
+			want: history(600, 1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &historyTransport{ids: history(600, 1), change: tt.change}
+			c := &Client{Client: http.Client{Transport: transport}, Cookies: "test", ReconcileTransactionPages: true}
+
+			transactions, err := c.GetAllTransactions()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, tx := range transactions {
+				got = append(got, tx.ID)
+			}
+			var want []string
+			for _, id := range tt.want {
+				want = append(want, strconv.Itoa(id))
+			}
+			if strings.Join(got, ",") != strings.Join(want, ",") {
+				t.Errorf("got %d rows, want %d in order:\ngot  %v\nwant %v", len(got), len(want), got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package auth_client
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// DefaultRotationDays is the days between a starting pitcher's starts in a five-man rotation
+const DefaultRotationDays = 5
+
+// TwoStartPitcher is a starting pitcher with two or more starts in a scoring period
+type TwoStartPitcher struct {
+	Player models.PoolPlayer `json:"player"`
+	Starts []ProbableStart   `json:"starts"` // Starts in the period; projected ones are unconfirmed
+	Owned  bool              `json:"owned"`  // On a fantasy team (see Player.FantasyTeamName)
+}
+
+// Confirmed returns true if every start comes from probable pitcher data rather than a
+// rotation projection
+func (p TwoStartPitcher) Confirmed() bool {
+	for _, start := range p.Starts {
+		if !start.Confirmed {
+			return false
+		}
+	}
+	return true
+}
+
+// FindTwoStartPitchers returns the SPs with at least two starts between start and end
+// (inclusive), best FP/G first
+//
+// Known starts are taken from starts; after a pitcher's last known start, further starts are
+// projected every rotationDays (0 = DefaultRotationDays) and marked unconfirmed. Probable
+// pitchers are rarely announced a full week ahead, so most second starts are projections.
+//
+// Parameters:
+//   - players: The player pool, for SP eligibility and ownership
+//   - starts: Known probable starts, e.g. from ProbableStartsFromPool or an MLB feed
+//   - start, end: The scoring period's first and last days
+//   - rotationDays: Days between projected starts
+func FindTwoStartPitchers(players []models.PoolPlayer, starts []ProbableStart, start, end time.Time, rotationDays int) []TwoStartPitcher {
+	if rotationDays <= 0 {
+		rotationDays = DefaultRotationDays
+	}
+	first, last := truncateDay(start), truncateDay(end)
+
+	startsByPlayer := make(map[string][]ProbableStart)
+	for _, s := range starts {
+		startsByPlayer[s.PlayerID] = append(startsByPlayer[s.PlayerID], s)
+	}
+
+	var pitchers []TwoStartPitcher
+	for _, player := range players {
+		known := startsByPlayer[player.PlayerID]
+		if len(known) == 0 || !isStartingPitcher(player) {
+			continue
+		}
+		sort.Slice(known, func(i, j int) bool { return known[i].Date.Before(known[j].Date) })
+
+		var inPeriod []ProbableStart
+		for _, s := range known {
+			if day := truncateDay(s.Date); !day.Before(first) && !day.After(last) {
+				inPeriod = append(inPeriod, s)
+			}
+		}
+		latest := known[len(known)-1]
+		for next := latest.Date.AddDate(0, 0, rotationDays); !truncateDay(next).After(last); next = next.AddDate(0, 0, rotationDays) {
+			if !truncateDay(next).Before(first) {
+				inPeriod = append(inPeriod, ProbableStart{PlayerID: player.PlayerID, Date: next})
+			}
+		}
+
+		if len(inPeriod) >= 2 {
+			pitchers = append(pitchers, TwoStartPitcher{Player: player, Starts: inPeriod, Owned: player.FantasyTeamID != ""})
+		}
+	}
+
+	sort.Slice(pitchers, func(i, j int) bool {
+		if pitchers[i].Player.FantasyPointsPerG != pitchers[j].Player.FantasyPointsPerG {
+			return pitchers[i].Player.FantasyPointsPerG > pitchers[j].Player.FantasyPointsPerG
+		}
+		return pitchers[i].Player.Name < pitchers[j].Player.Name
+	})
+	return pitchers
+}
+
+// GetTwoStartPitchers finds the SPs projected to start twice in a scoring period, with their
+// ownership from the player pool
+//
+// Parameters:
+//   - period: The scoring period
+//   - starts: Known probable starts; nil uses each SP's next game from the player pool
+func (c *Client) GetTwoStartPitchers(period int, starts []ProbableStart) ([]TwoStartPitcher, error) {
+	matchups, err := c.GetAllMatchups()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule: %w", err)
+	}
+	start, end, err := PeriodDateRange(matchups.Matchups, period)
+	if err != nil {
+		return nil, err
+	}
+
+	players, err := c.GetPlayerPool(WithStatusFilter(StatusFilterAll))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player pool: %w", err)
+	}
+	if starts == nil {
+		starts = ProbableStartsFromPool(players, time.Now().In(c.userLocation()))
+	}
+	return FindTwoStartPitchers(players, starts, start, end, DefaultRotationDays), nil
+}
+
+// PeriodDateRange returns a scoring period's first and last days from the schedule. A
+// period ends the day before the next one starts; the season's last period is taken to
+// last a week.
+func PeriodDateRange(matchups []Matchup, period int) (time.Time, time.Time, error) {
+	starts := make(map[int]time.Time)
+	for _, m := range matchups {
+		if _, ok := starts[m.ScoringPeriod]; ok || m.Date == "" {
+			continue
+		}
+		date, err := time.Parse("Mon Jan 2, 2006", m.Date)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("failed to parse date of period %d: %w", m.ScoringPeriod, err)
+		}
+		starts[m.ScoringPeriod] = date
+	}
+
+	start, ok := starts[period]
+	if !ok {
+		return time.Time{}, time.Time{}, fmt.Errorf("no schedule dates for scoring period %d", period)
+	}
+	end := start.AddDate(0, 0, 6)
+	next := time.Time{}
+	for p, date := range starts {
+		if p > period && date.After(start) && (next.IsZero() || date.Before(next)) {
+			next = date
+		}
+	}
+	if !next.IsZero() {
+		end = next.AddDate(0, 0, -1)
+	}
+	return start, end, nil
+}
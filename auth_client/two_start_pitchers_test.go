@@ -0,0 +1,47 @@
+package auth_client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestFindTwoStartPitchers(t *testing.T) {
+	schedule := []Matchup{
+		{ScoringPeriod: 9, Date: "Mon Jun 1, 2026"},
+		{ScoringPeriod: 10, Date: "Mon Jun 8, 2026"},
+	}
+	start, end, err := PeriodDateRange(schedule, 9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if end.Format("2006-01-02") != "2026-06-07" {
+		t.Fatalf("expected period 9 to end Jun 7, got %s", end)
+	}
+
+	eastern := time.FixedZone("EDT", -4*3600)
+	players := []models.PoolPlayer{
+		{PlayerID: "a", Name: "Early", PosShortNames: "SP", FantasyPointsPerG: 10, FantasyTeamID: "t1"},
+		{PlayerID: "b", Name: "Late", PosShortNames: "SP", FantasyPointsPerG: 12},
+		{PlayerID: "c", Name: "Confirmed", PosShortNames: "SP", FantasyPointsPerG: 8},
+	}
+	starts := []ProbableStart{
+		{PlayerID: "a", Date: time.Date(2026, 6, 2, 19, 5, 0, 0, eastern), Confirmed: true}, // Projected again Jun 7
+		{PlayerID: "b", Date: time.Date(2026, 6, 4, 19, 5, 0, 0, eastern), Confirmed: true}, // Next would be Jun 9
+		{PlayerID: "c", Date: time.Date(2026, 5, 27, 19, 5, 0, 0, eastern), Confirmed: true},
+		{PlayerID: "c", Date: time.Date(2026, 6, 1, 19, 5, 0, 0, eastern), Confirmed: true},
+		{PlayerID: "c", Date: time.Date(2026, 6, 6, 19, 5, 0, 0, eastern), Confirmed: true},
+	}
+
+	pitchers := FindTwoStartPitchers(players, starts, start, end, 0)
+	if len(pitchers) != 2 || pitchers[0].Player.PlayerID != "a" || pitchers[1].Player.PlayerID != "c" {
+		t.Fatalf("expected a and c, got %+v", pitchers)
+	}
+	if !pitchers[0].Owned || pitchers[0].Confirmed() || pitchers[0].Starts[1].Date.Day() != 7 {
+		t.Errorf("unexpected two-start entry for a: %+v", pitchers[0])
+	}
+	if !pitchers[1].Confirmed() || len(pitchers[1].Starts) != 2 {
+		t.Errorf("unexpected two-start entry for c: %+v", pitchers[1])
+	}
+}
@@ -0,0 +1,216 @@
+package auth_client
+
+import (
+	"fmt"
+	"sort"
+)
+
+// WhatIfChange is a hypothetical change to a season's matchups, applied by WhatIfStandings
+type WhatIfChange func(matchups []Matchup) ([]Matchup, error)
+
+// SwapResult swaps the two teams' scores in a team's matchup, turning a win into a loss
+// and a loss into a win
+//
+// Parameters:
+//   - period: The scoring period of the matchup
+//   - teamID: Either team in the matchup
+func SwapResult(period int, teamID string) WhatIfChange {
+	return func(matchups []Matchup) ([]Matchup, error) {
+		i, err := findMatchup(matchups, period, teamID)
+		if err != nil {
+			return nil, err
+		}
+		m := &matchups[i]
+		m.AwayTeam.Total, m.HomeTeam.Total = m.HomeTeam.Total, m.AwayTeam.Total
+		m.AwayTeam.Points, m.HomeTeam.Points = m.HomeTeam.Points, m.AwayTeam.Points
+		m.AwayTeam.Adjustment, m.HomeTeam.Adjustment = m.HomeTeam.Adjustment, m.AwayTeam.Adjustment
+		return matchups, nil
+	}
+}
+
+// AdjustPoints adds points to a team's score in one period, as a commissioner adjustment
+// would. Negative points take points away.
+//
+// Parameters:
+//   - period: The scoring period of the matchup
+//   - teamID: The team whose score changes
+//   - points: The adjustment
+func AdjustPoints(period int, teamID string, points float64) WhatIfChange {
+	return func(matchups []Matchup) ([]Matchup, error) {
+		i, err := findMatchup(matchups, period, teamID)
+		if err != nil {
+			return nil, err
+		}
+		side := &matchups[i].AwayTeam
+		if matchups[i].HomeTeam.TeamID == teamID {
+			side = &matchups[i].HomeTeam
+		}
+		side.Adjustment += points
+		side.Total += points
+		return matchups, nil
+	}
+}
+
+// ExcludePeriod drops every matchup in a scoring period, as if it had not been played
+func ExcludePeriod(period int) WhatIfChange {
+	return func(matchups []Matchup) ([]Matchup, error) {
+		kept := matchups[:0]
+		for _, m := range matchups {
+			if m.ScoringPeriod != period {
+				kept = append(kept, m)
+			}
+		}
+		return kept, nil
+	}
+}
+
+// findMatchup returns the index of a team's matchup in a period
+func findMatchup(matchups []Matchup, period int, teamID string) (int, error) {
+	for i, m := range matchups {
+		if m.ScoringPeriod == period && (m.AwayTeam.TeamID == teamID || m.HomeTeam.TeamID == teamID) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("no matchup for team %s in period %d", teamID, period)
+}
+
+// WhatIfTeam is one team's record under a what-if scenario, next to its actual record
+type WhatIfTeam struct {
+	TeamID        string  `json:"teamId"`
+	Name          string  `json:"name"`
+	Rank          int     `json:"rank"`
+	Wins          int     `json:"wins"`
+	Losses        int     `json:"losses"`
+	Ties          int     `json:"ties"`
+	PointsFor     float64 `json:"pointsFor"`
+	PointsAgainst float64 `json:"pointsAgainst"`
+
+	ActualRank   int `json:"actualRank"`
+	ActualWins   int `json:"actualWins"`
+	ActualLosses int `json:"actualLosses"`
+	ActualTies   int `json:"actualTies"`
+}
+
+// RankChange returns how many places the team moved under the scenario (positive = up)
+func (t WhatIfTeam) RankChange() int {
+	return t.ActualRank - t.Rank
+}
+
+// WhatIfResult is the standings under a what-if scenario
+type WhatIfResult struct {
+	CompletedThrough int          `json:"completedThrough"`
+	Teams            []WhatIfTeam `json:"teams"` // Ordered by scenario rank
+}
+
+// WhatIfStandings recomputes head-to-head standings from a season's matchups under
+// hypothetical changes, and reports each team's scenario record next to the actual one
+//
+// Standings are computed from the matchups alone, without API calls: teams are ranked by win
+// percentage (ties count as half a win), then by points for. This is the same ordering
+// Fantrax uses by default but ignores league-specific tiebreakers, so compare the scenario
+// with the actual columns rather than with the live standings. In H2H category leagues each
+// matchup counts once, won by the team that took more categories.
+//
+// Parameters:
+//   - result: The season's matchups (e.g. from GetAllMatchups); it is not modified
+//   - completedThrough: The last scoring period whose results count (0 = every period with scores)
+//   - changes: The changes to apply, in order
+func WhatIfStandings(result *AllMatchupsResult, completedThrough int, changes ...WhatIfChange) (*WhatIfResult, error) {
+	actual := completedMatchups(result.Matchups, completedThrough)
+	scenario := append([]Matchup(nil), actual...)
+	for _, change := range changes {
+		var err error
+		if scenario, err = change(scenario); err != nil {
+			return nil, err
+		}
+	}
+
+	actualTeams := rankMatchupStandings(result, actual)
+	actualByID := make(map[string]WhatIfTeam, len(actualTeams))
+	for _, t := range actualTeams {
+		actualByID[t.TeamID] = t
+	}
+
+	whatIf := &WhatIfResult{CompletedThrough: completedThrough}
+	for _, t := range rankMatchupStandings(result, scenario) {
+		a := actualByID[t.TeamID]
+		t.ActualRank, t.ActualWins, t.ActualLosses, t.ActualTies = a.Rank, a.Wins, a.Losses, a.Ties
+		whatIf.Teams = append(whatIf.Teams, t)
+	}
+	return whatIf, nil
+}
+
+// completedMatchups returns the matchups through completedThrough, or every matchup with a
+// score when completedThrough is 0
+func completedMatchups(matchups []Matchup, completedThrough int) []Matchup {
+	var completed []Matchup
+	for _, m := range matchups {
+		if m.AwayTeam.TeamID == "" || m.HomeTeam.TeamID == "" {
+			continue // Bye
+		}
+		if completedThrough > 0 && m.ScoringPeriod > completedThrough {
+			continue
+		}
+		if completedThrough == 0 && m.AwayTeam.Total == 0 && m.HomeTeam.Total == 0 {
+			continue // Not played yet
+		}
+		completed = append(completed, m)
+	}
+	return completed
+}
+
+// rankMatchupStandings tallies records from matchups and ranks the teams. Every team in
+// result.Teams is included, even without matchups.
+func rankMatchupStandings(result *AllMatchupsResult, matchups []Matchup) []WhatIfTeam {
+	teams := make(map[string]*WhatIfTeam)
+	team := func(id string) *WhatIfTeam {
+		if t, ok := teams[id]; ok {
+			return t
+		}
+		t := &WhatIfTeam{TeamID: id, Name: result.Teams[id].Name}
+		teams[id] = t
+		return t
+	}
+	for id := range result.Teams {
+		team(id)
+	}
+
+	for _, m := range matchups {
+		away, home := team(m.AwayTeam.TeamID), team(m.HomeTeam.TeamID)
+		away.PointsFor += m.AwayTeam.Total
+		away.PointsAgainst += m.HomeTeam.Total
+		home.PointsFor += m.HomeTeam.Total
+		home.PointsAgainst += m.AwayTeam.Total
+		switch compareTotals(m.AwayTeam.Total, m.HomeTeam.Total) {
+		case 1:
+			away.Wins++
+			home.Losses++
+		case -1:
+			home.Wins++
+			away.Losses++
+		default:
+			away.Ties++
+			home.Ties++
+		}
+	}
+
+	ranked := make([]WhatIfTeam, 0, len(teams))
+	for _, t := range teams {
+		ranked = append(ranked, *t)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		pi := winPct(ranked[i].Wins, ranked[i].Losses, ranked[i].Ties)
+		pj := winPct(ranked[j].Wins, ranked[j].Losses, ranked[j].Ties)
+		if pi != pj {
+			return pi > pj
+		}
+		if ranked[i].PointsFor != ranked[j].PointsFor {
+			return ranked[i].PointsFor > ranked[j].PointsFor
+		}
+		return ranked[i].TeamID < ranked[j].TeamID
+	})
+	for i := range ranked {
+		ranked[i].Rank = i + 1
+	}
+	return ranked
+}
@@ -0,0 +1,52 @@
+package auth_client
+
+import "testing"
+
+func TestWhatIfStandings(t *testing.T) {
+	result := &AllMatchupsResult{
+		Matchups: []Matchup{
+			matchup(1, "a", 100, "b", 90),
+			matchup(1, "c", 80, "d", 70),
+			matchup(2, "a", 100, "c", 95),
+			matchup(2, "b", 60, "d", 65),
+			matchup(3, "a", 0, "d", 0), // Not played yet
+			matchup(3, "b", 0, "c", 0),
+		},
+		Teams: map[string]FantasyTeam{"a": {Name: "A"}, "b": {Name: "B"}, "c": {Name: "C"}, "d": {Name: "D"}},
+	}
+
+	// Give c the period 2 win over a, and wipe out period 1
+	whatIf, err := WhatIfStandings(result, 0, SwapResult(2, "c"), ExcludePeriod(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantOrder := []string{"c", "d", "a", "b"}
+	for i, id := range wantOrder {
+		if got := whatIf.Teams[i].TeamID; got != id {
+			t.Fatalf("rank %d: expected %s, got %s", i+1, id, got)
+		}
+	}
+	c := whatIf.Teams[0]
+	if c.Wins != 1 || c.Losses != 0 || c.ActualRank != 2 || c.ActualWins != 1 || c.ActualLosses != 1 || c.RankChange() != 1 {
+		t.Errorf("unexpected record for c: %+v", c)
+	}
+	if result.Matchups[2].AwayTeam.Total != 100 {
+		t.Errorf("input matchups were modified")
+	}
+
+	// A 15-point adjustment turns b's period 2 loss into a win
+	whatIf, err = WhatIfStandings(result, 2, AdjustPoints(2, "b", 15))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, team := range whatIf.Teams {
+		if team.TeamID == "b" && (team.Wins != 1 || team.ActualWins != 0) {
+			t.Errorf("unexpected record for b: %+v", team)
+		}
+	}
+
+	if _, err := WhatIfStandings(result, 0, SwapResult(9, "a")); err == nil {
+		t.Error("expected an error for a missing matchup")
+	}
+}
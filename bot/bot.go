@@ -0,0 +1,191 @@
+// Package bot provides chat command handlers for a Fantrax league: standings, the current
+// scoreboard, team rosters, player lookup, and a transaction feed. It has no chat platform
+// dependency; replies are plain text sized for Discord, so embedding a league bot takes a few
+// lines of glue. With discordgo, for example:
+//
+//	b := bot.New(publicClient, authClient, fantrax.MLB)
+//	session.AddHandler(func(s *discordgo.Session, m *discordgo.MessageCreate) {
+//		if m.Author.Bot {
+//			return
+//		}
+//		if reply, ok := b.Handle(m.Content); ok {
+//			for _, chunk := range bot.Split(reply, bot.DiscordMessageLimit) {
+//				s.ChannelMessageSend(m.ChannelID, chunk)
+//			}
+//		}
+//	})
+//
+// and a ticker that posts each message from b.Feed().Poll() to the league channel.
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pmurley/go-fantrax"
+	"github.com/pmurley/go-fantrax/auth_client"
+)
+
+// DiscordMessageLimit is the longest message Discord accepts, in characters
+const DiscordMessageLimit = 2000
+
+// Handler runs a command with the words that followed it and returns the reply
+type Handler func(args []string) (string, error)
+
+type command struct {
+	usage   string
+	help    string
+	handler Handler
+}
+
+// Bot routes chat messages to command handlers
+type Bot struct {
+	Public *fantrax.Client     // Rosters and player lookup
+	Auth   *auth_client.Client // Standings, scoreboard, and transactions; may be nil
+	Sport  fantrax.Sport
+
+	// Prefix marks a message as a command (default "!")
+	Prefix string
+
+	commands map[string]command
+}
+
+// New creates a bot with the built-in commands registered
+//
+// Parameters:
+//   - public: A public API client for the league
+//   - auth: An authenticated client for the league, or nil to disable the commands that need one
+//   - sport: The league's sport, used for player lookups
+func New(public *fantrax.Client, auth *auth_client.Client, sport fantrax.Sport) *Bot {
+	b := &Bot{
+		Public:   public,
+		Auth:     auth,
+		Sport:    sport,
+		Prefix:   "!",
+		commands: make(map[string]command),
+	}
+	b.Register("standings", "", "League standings", b.standings)
+	b.Register("scoreboard", "[period]", "Matchup scores for the current or given period", b.scoreboard)
+	b.Register("roster", "<team>", "A team's roster", b.roster)
+	b.Register("player", "<name>", "Find a player and the fantasy team that owns them", b.player)
+	b.Register("digest", "[days]", "Top pickups, notable drops, and trades of the last week or given days", b.digest)
+	b.Register("help", "", "List commands", b.help)
+	return b
+}
+
+// Register adds or replaces a command. Names are matched case-insensitively.
+//
+// Parameters:
+//   - name: The command word, without the prefix
+//   - usage: Argument summary shown in help (e.g. "<team>")
+//   - help: One-line description shown in help
+//   - handler: Called with the words after the command
+func (b *Bot) Register(name, usage, help string, handler Handler) {
+	if b.commands == nil {
+		b.commands = make(map[string]command)
+	}
+	b.commands[strings.ToLower(name)] = command{usage: usage, help: help, handler: handler}
+}
+
+// Handle runs the command in a chat message. ok is false if the message is not a command
+// this bot knows, so callers can ignore it. Handler errors are returned as the reply.
+func (b *Bot) Handle(message string) (reply string, ok bool) {
+	message = strings.TrimSpace(message)
+	if !strings.HasPrefix(message, b.Prefix) {
+		return "", false
+	}
+	fields := strings.Fields(strings.TrimPrefix(message, b.Prefix))
+	if len(fields) == 0 {
+		return "", false
+	}
+	cmd, found := b.commands[strings.ToLower(fields[0])]
+	if !found {
+		return "", false
+	}
+
+	reply, err := cmd.handler(fields[1:])
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err), true
+	}
+	return reply, true
+}
+
+func (b *Bot) help(args []string) (string, error) {
+	names := make([]string, 0, len(b.commands))
+	for name := range b.commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	sb.WriteString("Commands:\n")
+	for _, name := range names {
+		cmd := b.commands[name]
+		usage := b.Prefix + name
+		if cmd.usage != "" {
+			usage += " " + cmd.usage
+		}
+		fmt.Fprintf(&sb, "`%s` - %s\n", usage, cmd.help)
+	}
+	return sb.String(), nil
+}
+
+// Split breaks a reply into messages of at most limit characters, splitting between lines
+// where possible. A code block cut by a split is closed and reopened so each message renders
+// on its own.
+func Split(reply string, limit int) []string {
+	const fence = "```"
+	if limit <= 2*len(fence)+2 || len([]rune(reply)) <= limit {
+		return []string{reply}
+	}
+
+	var chunks []string
+	var current strings.Builder
+	inCode := false
+	codeOpen := "" // The line that opened the current code block, e.g. "```" or "```text"
+
+	flush := func() {
+		text := current.String()
+		if inCode {
+			text += fence
+		}
+		chunks = append(chunks, strings.TrimRight(text, "\n"))
+		current.Reset()
+		if inCode {
+			current.WriteString(codeOpen + "\n")
+		}
+	}
+
+	for _, line := range strings.SplitAfter(reply, "\n") {
+		// Leave room to close an open code block
+		room := limit - len(fence)
+		for len([]rune(current.String()))+len([]rune(line)) > room {
+			reopened := 0
+			if inCode {
+				reopened = len([]rune(codeOpen)) + 1
+			}
+			if len([]rune(current.String())) > reopened {
+				flush()
+				continue
+			}
+			// A single line longer than a message is cut where it must be
+			fit := room - len([]rune(current.String()))
+			runes := []rune(line)
+			current.WriteString(string(runes[:fit]))
+			line = string(runes[fit:])
+			flush()
+		}
+		current.WriteString(line)
+		if strings.HasPrefix(strings.TrimSpace(line), fence) {
+			inCode = !inCode
+			if inCode {
+				codeOpen = strings.TrimSpace(line)
+			}
+		}
+	}
+	if current.Len() > 0 {
+		chunks = append(chunks, strings.TrimRight(current.String(), "\n"))
+	}
+	return chunks
+}
@@ -0,0 +1,137 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestHandleDispatch(t *testing.T) {
+	b := New(nil, nil, "MLB")
+	b.Register("ping", "", "Reply with pong", func(args []string) (string, error) {
+		return "pong " + strings.Join(args, " "), nil
+	})
+
+	if reply, ok := b.Handle("!PING a b"); !ok || reply != "pong a b" {
+		t.Errorf("Handle(!PING a b) = %q, %v", reply, ok)
+	}
+	if _, ok := b.Handle("ping"); ok {
+		t.Error("message without the prefix was handled")
+	}
+	if _, ok := b.Handle("!unknown"); ok {
+		t.Error("unknown command was handled")
+	}
+	if reply, ok := b.Handle("!standings"); !ok || !strings.HasPrefix(reply, "Error:") {
+		t.Errorf("standings without an auth client = %q, %v", reply, ok)
+	}
+	if reply, _ := b.Handle("!help"); !strings.Contains(reply, "`!roster <team>`") {
+		t.Errorf("help does not list roster usage:\n%s", reply)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	var sb strings.Builder
+	sb.WriteString("```\n")
+	for i := 0; i < 100; i++ {
+		sb.WriteString("line of a long table\n")
+	}
+	sb.WriteString("```")
+
+	chunks := Split(sb.String(), 500)
+	if len(chunks) < 2 {
+		t.Fatalf("got %d chunks, want several", len(chunks))
+	}
+	for i, chunk := range chunks {
+		if n := len([]rune(chunk)); n > 500 {
+			t.Errorf("chunk %d has %d characters", i, n)
+		}
+		if strings.Count(chunk, "```")%2 != 0 {
+			t.Errorf("chunk %d leaves a code block open:\n%s", i, chunk)
+		}
+	}
+}
+
+func TestFeedUpdate(t *testing.T) {
+	day := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	history := []models.Transaction{
+		{ID: "1", Type: "CLAIM", TeamName: "Aces", PlayerID: "p1", PlayerName: "Old Claim", ProcessedDate: day},
+	}
+
+	f := New(nil, nil, "MLB").Feed()
+	if messages := f.update(history); len(messages) != 0 {
+		t.Fatalf("first poll posted %v, want nothing", messages)
+	}
+
+	history = append(history,
+		models.Transaction{ID: "3", Type: "TRADE", TradeGroupID: "t1", PlayerID: "p3", PlayerName: "Traded One",
+			FromTeamName: "Aces", ToTeamName: "Bats", ProcessedDate: day.Add(2 * time.Hour)},
+		models.Transaction{ID: "2", Type: "DROP", TeamName: "Bats", PlayerID: "p2", PlayerName: "Dropped",
+			ProcessedDate: day.Add(time.Hour)},
+		models.Transaction{ID: "4", Type: "TRADE", TradeGroupID: "t1", PlayerID: "p4", PlayerName: "Traded Two",
+			FromTeamName: "Bats", ToTeamName: "Aces", ProcessedDate: day.Add(2 * time.Hour)},
+	)
+	messages := f.update(history)
+	if len(messages) != 2 {
+		t.Fatalf("got %d messages, want 2: %v", len(messages), messages)
+	}
+	if messages[0] != "**Bats** dropped Dropped" {
+		t.Errorf("first message = %q", messages[0])
+	}
+	if !strings.Contains(messages[1], "Traded One") || !strings.Contains(messages[1], "Traded Two") {
+		t.Errorf("trade players were not combined: %q", messages[1])
+	}
+	if again := f.update(history); len(again) != 0 {
+		t.Errorf("repeat poll posted %v", again)
+	}
+}
+
+func TestBuildDigest(t *testing.T) {
+	start := time.Date(2025, 5, 5, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 7)
+	history := []models.Transaction{
+		{ID: "0", Type: "CLAIM", TeamID: "b", TeamName: "Bats", PlayerID: "p1", PlayerName: "Old Pickup",
+			BidAmount: "30", Executed: true, ProcessedDate: start.AddDate(0, 0, -10)},
+		{ID: "1", Type: "CLAIM", TeamID: "a", TeamName: "Aces", PlayerID: "p2", PlayerName: "Cheap",
+			BidAmount: "2", Executed: true, ProcessedDate: start.Add(time.Hour)},
+		{ID: "2", Type: "CLAIM", TeamID: "b", TeamName: "Bats", PlayerID: "p3", PlayerName: "Prize",
+			BidAmount: "$41", Executed: true, ProcessedDate: start.Add(2 * time.Hour)},
+		{ID: "3", Type: "DROP", TeamID: "b", TeamName: "Bats", PlayerID: "p1", PlayerName: "Old Pickup",
+			Executed: true, ProcessedDate: start.Add(2 * time.Hour)},
+		{ID: "4", Type: "DROP", TeamID: "a", TeamName: "Aces", PlayerID: "p4", PlayerName: "Nobody",
+			Executed: true, ProcessedDate: start.Add(3 * time.Hour)},
+		{ID: "5", Type: "CLAIM", TeamID: "a", TeamName: "Aces", PlayerID: "p5", PlayerName: "Pending",
+			BidAmount: "99", ProcessedDate: start.Add(3 * time.Hour)},
+		{ID: "6", Type: "TRADE", TradeGroupID: "t1", PlayerID: "p6", PlayerName: "Star", FromTeamID: "a",
+			FromTeamName: "Aces", ToTeamID: "b", ToTeamName: "Bats", Executed: true, ProcessedDate: start.AddDate(0, 0, 2)},
+		{ID: "7", Type: "TRADE", TradeGroupID: "t1", DraftPick: &models.DraftPick{Year: 2026, Round: 1},
+			FromTeamID: "b", FromTeamName: "Bats", ToTeamID: "a", ToTeamName: "Aces", Executed: true, ProcessedDate: start.AddDate(0, 0, 2)},
+		{ID: "8", Type: "CLAIM", TeamID: "a", TeamName: "Aces", PlayerID: "p7", PlayerName: "Next Week",
+			BidAmount: "50", Executed: true, ProcessedDate: end},
+	}
+
+	d := BuildDigest(history, start, end, DigestOptions{})
+	if d.Claims != 2 || d.Drops != 2 || d.TotalFAAB != 43 {
+		t.Errorf("counts = %d claims, %d drops, $%v, want 2, 2, $43", d.Claims, d.Drops, d.TotalFAAB)
+	}
+	if len(d.TopPickups) != 2 || d.TopPickups[0].Transaction.PlayerName != "Prize" {
+		t.Errorf("top pickups = %+v, want Prize first", d.TopPickups)
+	}
+	if len(d.NotableDrops) != 1 || d.NotableDrops[0].Transaction.PlayerName != "Old Pickup" || d.NotableDrops[0].Value != 30 {
+		t.Errorf("notable drops = %+v, want Old Pickup at $30", d.NotableDrops)
+	}
+	if len(d.Trades) != 1 || len(d.Trades[0].Received) != 2 {
+		t.Fatalf("trades = %+v, want one two-team trade", d.Trades)
+	}
+	if got := d.Trades[0].Received[1].Assets; len(got) != 1 || got[0] != "2026 Round 1 pick" {
+		t.Errorf("Aces received %v, want the pick", got)
+	}
+
+	md := d.Markdown()
+	for _, want := range []string{"### Top pickups", "**Bats** added Prize for $41", "(claimed for $30)", "**Aces** received 2026 Round 1 pick"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown is missing %q:\n%s", want, md)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pmurley/go-fantrax"
+	"github.com/pmurley/go-fantrax/auth_client"
+	"github.com/pmurley/go-fantrax/projections"
+)
+
+// maxPlayerMatches caps the players listed for one lookup
+const maxPlayerMatches = 10
+
+var errNoAuthClient = errors.New("this command needs a logged-in Fantrax client")
+
+func (b *Bot) standings(args []string) (string, error) {
+	if b.Auth == nil {
+		return "", errNoAuthClient
+	}
+	standings, err := b.Auth.GetStandings()
+	if err != nil {
+		return "", fmt.Errorf("failed to get standings: %w", err)
+	}
+	return FormatStandings(standings), nil
+}
+
+func (b *Bot) scoreboard(args []string) (string, error) {
+	if b.Auth == nil {
+		return "", errNoAuthClient
+	}
+
+	var period int
+	var err error
+	if len(args) > 0 {
+		if period, err = strconv.Atoi(args[0]); err != nil {
+			return "", fmt.Errorf("invalid period %q", args[0])
+		}
+	} else if period, err = b.Auth.GetCurrentPeriod(); err != nil {
+		return "", fmt.Errorf("failed to get current period: %w", err)
+	}
+
+	matchups, err := b.Auth.GetAllMatchups()
+	if err != nil {
+		return "", fmt.Errorf("failed to get matchups: %w", err)
+	}
+	return FormatScoreboard(matchups, period), nil
+}
+
+func (b *Bot) roster(args []string) (string, error) {
+	if len(args) == 0 {
+		return "", fmt.Errorf("usage: %sroster <team>", b.Prefix)
+	}
+	rosters, err := b.Public.GetTeamRostersDetailed(b.Sport)
+	if err != nil {
+		return "", fmt.Errorf("failed to get rosters: %w", err)
+	}
+	team, err := findTeam(rosters, strings.Join(args, " "))
+	if err != nil {
+		return "", err
+	}
+	var terminology *auth_client.Terminology
+	if b.Auth != nil {
+		terminology = b.Auth.Terminology
+	}
+	return FormatRoster(team, terminology), nil
+}
+
+func (b *Bot) player(args []string) (string, error) {
+	if len(args) == 0 {
+		return "", fmt.Errorf("usage: %splayer <name>", b.Prefix)
+	}
+	players, err := b.Public.GetPlayerIds(b.Sport)
+	if err != nil {
+		return "", fmt.Errorf("failed to get player IDs: %w", err)
+	}
+	rosters, err := b.Public.GetTeamRosters()
+	if err != nil {
+		return "", fmt.Errorf("failed to get rosters: %w", err)
+	}
+	query := strings.Join(args, " ")
+	return FormatPlayerLookup(query, FindPlayers(*players, query), rosters), nil
+}
+
+// findTeam matches a team by name, preferring an exact match over a partial one
+func findTeam(rosters *fantrax.DetailedLeagueRosters, query string) (fantrax.DetailedTeamRoster, error) {
+	q := strings.ToLower(strings.TrimSpace(query))
+	var partial []fantrax.DetailedTeamRoster
+	for _, team := range rosters.Rosters {
+		name := strings.ToLower(team.TeamName)
+		if name == q || team.TeamID == query {
+			return team, nil
+		}
+		if strings.Contains(name, q) {
+			partial = append(partial, team)
+		}
+	}
+	switch len(partial) {
+	case 1:
+		return partial[0], nil
+	case 0:
+		return fantrax.DetailedTeamRoster{}, fmt.Errorf("no team matches %q", query)
+	}
+	names := make([]string, len(partial))
+	for i, team := range partial {
+		names[i] = team.TeamName
+	}
+	sort.Strings(names)
+	return fantrax.DetailedTeamRoster{}, fmt.Errorf("%q matches %s", query, strings.Join(names, ", "))
+}
+
+// FindPlayers returns the players whose normalized name contains the query, exact matches
+// first, then by name
+func FindPlayers(players map[string]fantrax.Player, query string) []fantrax.Player {
+	q := projections.NormalizeName(query)
+	if q == "" {
+		return nil
+	}
+	var matches []fantrax.Player
+	for _, p := range players {
+		if strings.Contains(projections.NormalizeName(p.Name), q) {
+			matches = append(matches, p)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		ei := projections.NormalizeName(matches[i].Name) == q
+		ej := projections.NormalizeName(matches[j].Name) == q
+		if ei != ej {
+			return ei
+		}
+		if matches[i].Name != matches[j].Name {
+			return matches[i].Name < matches[j].Name
+		}
+		return matches[i].FantraxId < matches[j].FantraxId
+	})
+	return matches
+}
+
+// FormatStandings renders standings as a code block table
+func FormatStandings(standings *auth_client.LeagueStandings) string {
+	teams := append([]auth_client.TeamStanding(nil), standings.Teams...)
+	sort.SliceStable(teams, func(i, j int) bool { return teams[i].Rank < teams[j].Rank })
+
+	var sb strings.Builder
+	if standings.LeagueName != "" {
+		fmt.Fprintf(&sb, "**%s standings**\n", standings.LeagueName)
+	}
+	sb.WriteString("```\n")
+	switch standings.Format {
+	case auth_client.LeagueFormatRotisserie:
+		for _, t := range teams {
+			fmt.Fprintf(&sb, "%2d. %-24s %8.1f\n", t.Rank, truncate(t.Name, 24), t.TotalPoints)
+		}
+	case auth_client.LeagueFormatPoints:
+		for _, t := range teams {
+			fmt.Fprintf(&sb, "%2d. %-24s %8.1f\n", t.Rank, truncate(t.Name, 24), t.PointsFor)
+		}
+	default:
+		for _, t := range teams {
+			record := fmt.Sprintf("%d-%d", t.Wins, t.Losses)
+			if t.Ties > 0 {
+				record += fmt.Sprintf("-%d", t.Ties)
+			}
+			fmt.Fprintf(&sb, "%2d. %-24s %-8s %8.1f\n", t.Rank, truncate(t.Name, 24), record, t.PointsFor)
+		}
+	}
+	sb.WriteString("```")
+	return sb.String()
+}
+
+// FormatScoreboard renders a period's matchups with their scores
+func FormatScoreboard(matchups *auth_client.AllMatchupsResult, period int) string {
+	name := func(teamID string) string {
+		if team, ok := matchups.Teams[teamID]; ok && team.Name != "" {
+			return team.Name
+		}
+		return teamID
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "**Scoreboard, period %d**\n", period)
+	found := false
+	for _, m := range matchups.Matchups {
+		if m.ScoringPeriod != period {
+			continue
+		}
+		found = true
+		fmt.Fprintf(&sb, "%s %s - %s %s\n",
+			name(m.AwayTeam.TeamID), formatScore(m.AwayTeam.Total),
+			formatScore(m.HomeTeam.Total), name(m.HomeTeam.TeamID))
+	}
+	if !found {
+		sb.WriteString("No matchups for this period.\n")
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// rosterStatusNames are the generic names of the public API's roster statuses, as used by
+// auth_client.Terminology
+var rosterStatusNames = map[fantrax.RosterStatus]string{
+	fantrax.StatusActive:         "Active",
+	fantrax.StatusReserve:        "Reserve",
+	fantrax.StatusInjuredReserve: "IR",
+	fantrax.StatusMinors:         "Minors",
+}
+
+// FormatRoster renders a team's roster grouped by roster status, with the statuses named in
+// the league's terminology (which may be nil)
+func FormatRoster(team fantrax.DetailedTeamRoster, terminology *auth_client.Terminology) string {
+	order := []fantrax.RosterStatus{
+		fantrax.StatusActive, fantrax.StatusReserve, fantrax.StatusInjuredReserve, fantrax.StatusMinors,
+	}
+	byStatus := make(map[string][]fantrax.DetailedRosterItem)
+	for _, p := range team.Players {
+		byStatus[p.Status] = append(byStatus[p.Status], p)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "**%s**\n```\n", team.TeamName)
+	for _, status := range order {
+		players := byStatus[string(status)]
+		if len(players) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "%s\n", terminology.Status(rosterStatusNames[status]))
+		for _, p := range players {
+			name := p.Name
+			if !p.Found {
+				name = p.ID
+			}
+			fmt.Fprintf(&sb, "  %-4s %-26s %s\n", p.Position, truncate(name, 26), p.Team)
+		}
+	}
+	sb.WriteString("```")
+	return sb.String()
+}
+
+// FormatPlayerLookup renders player search results with the fantasy team that owns each
+// player, or "FA" for free agents
+func FormatPlayerLookup(query string, matches []fantrax.Player, rosters *fantrax.LeagueRosters) string {
+	if len(matches) == 0 {
+		return fmt.Sprintf("No players match %q.", query)
+	}
+
+	owners := make(map[string]string)
+	if rosters != nil {
+		for _, team := range rosters.Rosters {
+			for _, item := range team.RosterItems {
+				owners[item.ID] = team.TeamName
+			}
+		}
+	}
+
+	var sb strings.Builder
+	for i, p := range matches {
+		if i == maxPlayerMatches {
+			fmt.Fprintf(&sb, "...and %d more\n", len(matches)-maxPlayerMatches)
+			break
+		}
+		owner, ok := owners[p.FantraxId]
+		if !ok {
+			owner = "FA"
+		}
+		fmt.Fprintf(&sb, "**%s** (%s, %s) - %s\n", p.Name, p.Position, p.Team, owner)
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+func formatScore(score float64) string {
+	return strconv.FormatFloat(score, 'f', -1, 64)
+}
+
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
@@ -0,0 +1,293 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// Digest defaults
+const (
+	DefaultDigestPickups = 5
+	DefaultDigestDrops   = 5
+	DefaultDigestWindow  = 7 * 24 * time.Hour
+)
+
+// DigestOptions controls what a digest includes
+type DigestOptions struct {
+	TopPickups   int // Claims listed, highest bid first (default DefaultDigestPickups)
+	NotableDrops int // Drops listed, most valuable first (default DefaultDigestDrops)
+
+	// PlayerValues ranks dropped players by player ID (e.g. fantasy points per game from the
+	// player pool). A drop of a player without a value is ranked by the bid the team paid
+	// for them, when the claim is in the transactions given to BuildDigest.
+	PlayerValues map[string]float64
+}
+
+// Digest summarizes the moves made in a time window
+type Digest struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+
+	TopPickups   []DigestPickup `json:"topPickups"`
+	NotableDrops []DigestDrop   `json:"notableDrops"`
+	Trades       []DigestTrade  `json:"trades"`
+
+	Claims    int     `json:"claims"`    // Claims in the window
+	Drops     int     `json:"drops"`     // Drops in the window
+	TotalFAAB float64 `json:"totalFaab"` // Sum of the window's claim bids
+}
+
+// DigestPickup is a claim in a digest
+type DigestPickup struct {
+	Transaction models.Transaction `json:"transaction"`
+	Bid         float64            `json:"bid"`
+}
+
+// DigestDrop is a drop in a digest
+type DigestDrop struct {
+	Transaction models.Transaction `json:"transaction"`
+	Value       float64            `json:"value"`   // The player's value, or the bid paid for them
+	PaidBid     bool               `json:"paidBid"` // Value is the bid paid rather than a PlayerValues entry
+}
+
+// DigestTrade is an executed trade with the assets each team received
+type DigestTrade struct {
+	ID       string            `json:"id"`
+	Date     time.Time         `json:"date"`
+	Received []DigestTradeSide `json:"received"` // One entry per team, in the order they appear
+}
+
+// DigestTradeSide is what one team received in a trade
+type DigestTradeSide struct {
+	TeamID   string   `json:"teamId"`
+	TeamName string   `json:"teamName"`
+	Assets   []string `json:"assets"` // Players as "Name (POS, TEAM)" and draft picks
+}
+
+// Empty reports whether nothing happened in the window
+func (d *Digest) Empty() bool {
+	return d.Claims == 0 && d.Drops == 0 && len(d.Trades) == 0
+}
+
+// BuildDigest summarizes the transactions processed in [start, end)
+//
+// Only executed moves are counted; pending claims and proposed trades are skipped.
+// Transactions from before start are still read to find what a dropped player cost, so pass
+// the full history rather than just the window when bids should rank drops.
+//
+// Parameters:
+//   - transactions: Transactions including trades (e.g. from GetAllTransactionsIncludingTrades)
+//   - start: The first instant of the window
+//   - end: The end of the window, exclusive
+//   - opts: Limits and player values
+func BuildDigest(transactions []models.Transaction, start, end time.Time, opts DigestOptions) *Digest {
+	if opts.TopPickups <= 0 {
+		opts.TopPickups = DefaultDigestPickups
+	}
+	if opts.NotableDrops <= 0 {
+		opts.NotableDrops = DefaultDigestDrops
+	}
+
+	sorted := append([]models.Transaction(nil), transactions...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ProcessedDate.Before(sorted[j].ProcessedDate)
+	})
+
+	d := &Digest{Start: start, End: end}
+	paid := make(map[string]float64) // Team ID and player ID to the last bid paid
+	trades := make(map[string]int)   // Trade group ID to index in d.Trades
+	for _, tx := range sorted {
+		if !tx.Executed {
+			continue
+		}
+		inWindow := !tx.ProcessedDate.Before(start) && tx.ProcessedDate.Before(end)
+		key := tx.TeamID + "|" + tx.PlayerID
+
+		switch tx.Type {
+		case "CLAIM":
+			bid := parseBid(tx.BidAmount)
+			paid[key] = bid
+			if inWindow {
+				d.Claims++
+				d.TotalFAAB += bid
+				d.TopPickups = append(d.TopPickups, DigestPickup{Transaction: tx, Bid: bid})
+			}
+		case "DROP":
+			if inWindow {
+				d.Drops++
+				drop := DigestDrop{Transaction: tx}
+				if value, ok := opts.PlayerValues[tx.PlayerID]; ok {
+					drop.Value = value
+				} else if bid, ok := paid[key]; ok {
+					drop.Value, drop.PaidBid = bid, true
+				}
+				d.NotableDrops = append(d.NotableDrops, drop)
+			}
+			delete(paid, key)
+		case "TRADE":
+			if inWindow {
+				addTradeAsset(d, trades, tx)
+			}
+		}
+	}
+
+	sort.SliceStable(d.TopPickups, func(i, j int) bool { return d.TopPickups[i].Bid > d.TopPickups[j].Bid })
+	if len(d.TopPickups) > opts.TopPickups {
+		d.TopPickups = d.TopPickups[:opts.TopPickups]
+	}
+
+	// A drop is notable only if something says the player was worth keeping
+	notable := d.NotableDrops[:0]
+	for _, drop := range d.NotableDrops {
+		if drop.Value > 0 {
+			notable = append(notable, drop)
+		}
+	}
+	sort.SliceStable(notable, func(i, j int) bool { return notable[i].Value > notable[j].Value })
+	if len(notable) > opts.NotableDrops {
+		notable = notable[:opts.NotableDrops]
+	}
+	d.NotableDrops = notable
+	return d
+}
+
+// addTradeAsset adds one row of a trade to the digest, grouping rows by trade
+func addTradeAsset(d *Digest, trades map[string]int, tx models.Transaction) {
+	group := tx.TradeGroupID
+	if group == "" {
+		group = tx.ID
+	}
+	i, ok := trades[group]
+	if !ok {
+		i = len(d.Trades)
+		trades[group] = i
+		d.Trades = append(d.Trades, DigestTrade{ID: group, Date: tx.ProcessedDate})
+	}
+	trade := &d.Trades[i]
+
+	asset := describePlayer(tx)
+	if tx.DraftPick != nil {
+		asset = tx.DraftPick.String() + " pick"
+	}
+	to := tradeSide(trade, tx.ToTeamID, tx.ToTeamName)
+	// The giving team gets a side too, so a one-sided trade still names both teams
+	tradeSide(trade, tx.FromTeamID, tx.FromTeamName)
+	trade.Received[to].Assets = append(trade.Received[to].Assets, asset)
+}
+
+// tradeSide returns the index of a team's side of a trade, adding it if needed
+func tradeSide(trade *DigestTrade, teamID, teamName string) int {
+	for i, side := range trade.Received {
+		if side.TeamID == teamID {
+			return i
+		}
+	}
+	trade.Received = append(trade.Received, DigestTradeSide{TeamID: teamID, TeamName: teamName})
+	return len(trade.Received) - 1
+}
+
+// parseBid reads a bid amount such as "12", "$12.50", or "1,000"; 0 if there is none
+func parseBid(amount string) float64 {
+	amount = strings.TrimSpace(strings.NewReplacer("$", "", ",", "").Replace(amount))
+	bid, err := strconv.ParseFloat(amount, 64)
+	if err != nil {
+		return 0
+	}
+	return bid
+}
+
+// Markdown renders the digest for posting to the league channel
+func (d *Digest) Markdown() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## Transaction digest: %s – %s\n\n",
+		d.Start.Format("Jan 2"), d.End.Add(-time.Nanosecond).Format("Jan 2, 2006"))
+	if d.Empty() {
+		sb.WriteString("No moves.\n")
+		return sb.String()
+	}
+	fmt.Fprintf(&sb, "%d claims ($%s FAAB spent), %d drops, %d trades\n",
+		d.Claims, formatBid(d.TotalFAAB), d.Drops, len(d.Trades))
+
+	if len(d.TopPickups) > 0 {
+		sb.WriteString("\n### Top pickups\n")
+		for _, pickup := range d.TopPickups {
+			fmt.Fprintf(&sb, "- **%s** added %s", pickup.Transaction.TeamName, describePlayer(pickup.Transaction))
+			if pickup.Bid > 0 {
+				fmt.Fprintf(&sb, " for $%s", formatBid(pickup.Bid))
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	if len(d.NotableDrops) > 0 {
+		sb.WriteString("\n### Notable drops\n")
+		for _, drop := range d.NotableDrops {
+			fmt.Fprintf(&sb, "- **%s** dropped %s", drop.Transaction.TeamName, describePlayer(drop.Transaction))
+			if drop.PaidBid {
+				fmt.Fprintf(&sb, " (claimed for $%s)", formatBid(drop.Value))
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	if len(d.Trades) > 0 {
+		sb.WriteString("\n### Trades\n")
+		for _, trade := range d.Trades {
+			fmt.Fprintf(&sb, "- %s\n", trade.Date.Format("Jan 2"))
+			for _, side := range trade.Received {
+				assets := "nothing"
+				if len(side.Assets) > 0 {
+					assets = strings.Join(side.Assets, ", ")
+				}
+				fmt.Fprintf(&sb, "  - **%s** received %s\n", side.TeamName, assets)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// formatBid shows whole-dollar bids without decimals
+func formatBid(bid float64) string {
+	return strconv.FormatFloat(bid, 'f', -1, 64)
+}
+
+// Digest builds the digest of the window ending at end, for posting on a weekly timer
+//
+// Parameters:
+//   - end: The end of the window, exclusive (usually now)
+//   - window: The window's length (DefaultDigestWindow if 0)
+//   - opts: Limits and player values
+func (b *Bot) Digest(end time.Time, window time.Duration, opts DigestOptions) (*Digest, error) {
+	if b.Auth == nil {
+		return nil, errNoAuthClient
+	}
+	if window <= 0 {
+		window = DefaultDigestWindow
+	}
+	transactions, err := b.Auth.GetAllTransactionsIncludingTrades()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	return BuildDigest(transactions, end.Add(-window), end, opts), nil
+}
+
+func (b *Bot) digest(args []string) (string, error) {
+	window := DefaultDigestWindow
+	if len(args) > 0 {
+		days, err := strconv.Atoi(args[0])
+		if err != nil || days <= 0 {
+			return "", fmt.Errorf("invalid number of days %q", args[0])
+		}
+		window = time.Duration(days) * 24 * time.Hour
+	}
+	d, err := b.Digest(time.Now(), window, DigestOptions{})
+	if err != nil {
+		return "", err
+	}
+	return d.Markdown(), nil
+}
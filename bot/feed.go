@@ -0,0 +1,124 @@
+package bot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// Feed reports league transactions that have not been seen yet
+type Feed struct {
+	bot *Bot
+
+	// Backfill posts the transactions already in the history on the first poll. By default
+	// the first poll only records them, so a restarted bot does not repost old moves.
+	Backfill bool
+
+	// PageSize is the number of history entries fetched per poll (default "50")
+	PageSize string
+
+	seen   map[string]bool
+	primed bool
+}
+
+// Feed creates a transaction feed for the bot's league. It needs the bot's auth client.
+func (b *Bot) Feed() *Feed {
+	return &Feed{bot: b, PageSize: "50", seen: make(map[string]bool)}
+}
+
+// Poll fetches the transaction history and returns one message per new claim, drop, or
+// trade, oldest first. Call it on a timer and post each message to the league channel.
+func (f *Feed) Poll() ([]string, error) {
+	if f.bot.Auth == nil {
+		return nil, errNoAuthClient
+	}
+	transactions, err := f.bot.Auth.GetTransactionHistory(f.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	return f.update(transactions), nil
+}
+
+// update records transactions and returns messages for the ones not seen before
+func (f *Feed) update(transactions []models.Transaction) []string {
+	var fresh []models.Transaction
+	for _, tx := range transactions {
+		key := tx.ID + "|" + tx.PlayerID
+		if f.seen[key] {
+			continue
+		}
+		f.seen[key] = true
+		fresh = append(fresh, tx)
+	}
+	if !f.primed {
+		f.primed = true
+		if !f.Backfill {
+			return nil
+		}
+	}
+	return FormatTransactions(fresh)
+}
+
+// FormatTransactions renders transactions as messages, oldest first, with the players of a
+// trade combined into one message
+func FormatTransactions(transactions []models.Transaction) []string {
+	sorted := append([]models.Transaction(nil), transactions...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ProcessedDate.Before(sorted[j].ProcessedDate)
+	})
+
+	var messages []string
+	trades := make(map[string]int) // Trade group ID to message index
+	var tradeLines [][]string
+	var tradeIndex []int
+
+	for _, tx := range sorted {
+		switch tx.Type {
+		case "TRADE":
+			group := tx.TradeGroupID
+			if group == "" {
+				group = tx.ID
+			}
+			i, ok := trades[group]
+			if !ok {
+				i = len(tradeLines)
+				trades[group] = i
+				tradeLines = append(tradeLines, nil)
+				tradeIndex = append(tradeIndex, len(messages))
+				messages = append(messages, "")
+			}
+			tradeLines[i] = append(tradeLines[i], fmt.Sprintf("  %s: %s → %s",
+				describePlayer(tx), tx.FromTeamName, tx.ToTeamName))
+		case "CLAIM":
+			line := fmt.Sprintf("**%s** added %s", tx.TeamName, describePlayer(tx))
+			if tx.BidAmount != "" {
+				line += fmt.Sprintf(" for $%s", tx.BidAmount)
+			}
+			messages = append(messages, line)
+		case "DROP":
+			messages = append(messages, fmt.Sprintf("**%s** dropped %s", tx.TeamName, describePlayer(tx)))
+		default:
+			messages = append(messages, fmt.Sprintf("**%s** %s %s", tx.TeamName, strings.ToLower(tx.Type), describePlayer(tx)))
+		}
+	}
+	for i, lines := range tradeLines {
+		messages[tradeIndex[i]] = "**Trade**\n" + strings.Join(lines, "\n")
+	}
+	return messages
+}
+
+func describePlayer(tx models.Transaction) string {
+	var details []string
+	if tx.PlayerPosition != "" {
+		details = append(details, tx.PlayerPosition)
+	}
+	if tx.PlayerTeam != "" {
+		details = append(details, tx.PlayerTeam)
+	}
+	if len(details) == 0 {
+		return tx.PlayerName
+	}
+	return fmt.Sprintf("%s (%s)", tx.PlayerName, strings.Join(details, ", "))
+}
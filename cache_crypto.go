@@ -0,0 +1,177 @@
+package fantrax
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/pmurley/go-fantrax/redact"
+)
+
+// Environment variables read by CacheCipherFromEnv
+const (
+	// CacheKeyEnv holds the cache encryption key (any passphrase)
+	CacheKeyEnv = "FANTRAX_CACHE_KEY"
+	// CacheKeyCommandEnv holds a command that prints the key, for reading it from a keychain,
+	// e.g. "security find-generic-password -s fantrax -w" on macOS or
+	// "secret-tool lookup service fantrax" on Linux
+	CacheKeyCommandEnv = "FANTRAX_CACHE_KEY_COMMAND"
+)
+
+// encryptedPrefix marks encrypted cache files, so plaintext files written before encryption
+// was enabled can still be told apart and read
+var encryptedPrefix = []byte("FXENC1\x00")
+
+// ErrCacheEncrypted is returned when an encrypted cache file is read without its key
+var ErrCacheEncrypted = errors.New("cache file is encrypted; set " + CacheKeyEnv + " or " + CacheKeyCommandEnv)
+
+// CacheCipher encrypts cache files with AES-256-GCM. A nil *CacheCipher leaves data as
+// plaintext.
+type CacheCipher struct {
+	aead cipher.AEAD
+}
+
+// NewCacheCipher creates a cipher from a passphrase. The AES key is the SHA-256 of the
+// passphrase, so any non-empty string works.
+func NewCacheCipher(passphrase string) (*CacheCipher, error) {
+	if passphrase == "" {
+		return nil, errors.New("cache encryption key is empty")
+	}
+	redact.AddSecret(passphrase)
+	key := sha256.Sum256([]byte(passphrase))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cache cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cache cipher: %w", err)
+	}
+	return &CacheCipher{aead: aead}, nil
+}
+
+// CacheCipherFromEnv creates a cipher from FANTRAX_CACHE_KEY, or from the output of
+// FANTRAX_CACHE_KEY_COMMAND. It returns nil, and no error, when neither is set.
+func CacheCipherFromEnv() (*CacheCipher, error) {
+	if key := os.Getenv(CacheKeyEnv); key != "" {
+		return NewCacheCipher(key)
+	}
+	command := os.Getenv(CacheKeyCommandEnv)
+	if command == "" {
+		return nil, nil
+	}
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run %s: %w", CacheKeyCommandEnv, err)
+	}
+	return NewCacheCipher(strings.TrimSpace(string(out)))
+}
+
+// LocalCacheCipher creates a cipher from a random key kept in go-fantrax/cache.key under the
+// user's config directory, creating the key, readable only by the user, on first use. It is
+// for caches that must not be written in plaintext when no key is configured: the files are
+// safe to copy or commit by accident, but anyone who can read the user's files can read them.
+func LocalCacheCipher() (*CacheCipher, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find a directory for the cache key: %w", err)
+	}
+	keyFile := filepath.Join(dir, "go-fantrax", "cache.key")
+	key, err := os.ReadFile(keyFile)
+	if errors.Is(err, os.ErrNotExist) {
+		key, err = createCacheKey(keyFile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache key: %w", err)
+	}
+	return NewCacheCipher(strings.TrimSpace(string(key)))
+}
+
+// createCacheKey writes a random key to keyFile, unless another process got there first, and
+// returns the key in the file
+func createCacheKey(keyFile string) ([]byte, error) {
+	if err := os.MkdirAll(filepath.Dir(keyFile), 0700); err != nil {
+		return nil, err
+	}
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(keyFile), "cache.key.*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.WriteString(hex.EncodeToString(raw))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Linking fails if the key exists, so processes starting together all use the first key
+	if err := os.Link(tmp.Name(), keyFile); err != nil && !errors.Is(err, os.ErrExist) {
+		return nil, err
+	}
+	return os.ReadFile(keyFile)
+}
+
+// Seal encrypts data for writing to a cache file
+func (c *CacheCipher) Seal(data []byte) ([]byte, error) {
+	if c == nil {
+		return data, nil
+	}
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	out := append([]byte{}, encryptedPrefix...)
+	out = append(out, nonce...)
+	return c.aead.Seal(out, nonce, data, nil), nil
+}
+
+// Open decrypts data read from a cache file. Plaintext files are returned unchanged, so a
+// cache written before encryption was enabled stays readable until its entries are rewritten.
+func (c *CacheCipher) Open(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, encryptedPrefix) {
+		return data, nil
+	}
+	if c == nil {
+		return nil, ErrCacheEncrypted
+	}
+	data = data[len(encryptedPrefix):]
+	if len(data) < c.aead.NonceSize() {
+		return nil, errors.New("encrypted cache file is truncated")
+	}
+	nonce, sealed := data[:c.aead.NonceSize()], data[c.aead.NonceSize():]
+	plain, err := c.aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt cache file (wrong key?): %w", err)
+	}
+	return plain, nil
+}
+
+// WithCacheEncryption encrypts the response cache with cipher. Without this option the cache
+// is encrypted when FANTRAX_CACHE_KEY or FANTRAX_CACHE_KEY_COMMAND is set.
+func WithCacheEncryption(cipher *CacheCipher) ClientOption {
+	return func(c *Client) {
+		c.cacheCipher = cipher
+	}
+}
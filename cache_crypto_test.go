@@ -0,0 +1,102 @@
+package fantrax
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCacheCipher(t *testing.T) {
+	c, err := NewCacheCipher("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain := []byte(`[{"name":"FX_RM","value":"secret"}]`)
+
+	sealed, err := c.Seal(plain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(sealed, []byte("secret")) {
+		t.Error("sealed data contains the plaintext")
+	}
+	if opened, err := c.Open(sealed); err != nil || !bytes.Equal(opened, plain) {
+		t.Errorf("Open = %q, %v; want the plaintext", opened, err)
+	}
+
+	// Plaintext written before encryption was enabled is still readable
+	if opened, err := c.Open(plain); err != nil || !bytes.Equal(opened, plain) {
+		t.Errorf("Open(plaintext) = %q, %v", opened, err)
+	}
+
+	other, _ := NewCacheCipher("another key")
+	if _, err := other.Open(sealed); err == nil {
+		t.Error("opened with the wrong key")
+	}
+	var none *CacheCipher
+	if _, err := none.Open(sealed); !errors.Is(err, ErrCacheEncrypted) {
+		t.Errorf("Open without a key: got %v, want ErrCacheEncrypted", err)
+	}
+}
+
+func TestFileCacheEncryption(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewFileCache(dir, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache.Cipher, _ = NewCacheCipher("key")
+
+	if err := cache.Set("k", []byte(`{"a":1}`)); err != nil {
+		t.Fatal(err)
+	}
+	raw, err := os.ReadFile(filepath.Join(dir, "k.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(raw, []byte(`"a"`)) {
+		t.Error("cache file is not encrypted")
+	}
+	if data, ok := cache.Get("k"); !ok || string(data) != `{"a":1}` {
+		t.Errorf("Get = %q, %v", data, ok)
+	}
+
+	cache.Cipher, _ = NewCacheCipher("rotated")
+	if _, ok := cache.Get("k"); ok {
+		t.Error("entry encrypted with the old key was returned")
+	}
+}
+
+func TestLocalCacheCipher(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+
+	first, err := LocalCacheCipher()
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(filepath.Join(dir, "go-fantrax", "cache.key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("key file mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	// A second cipher reads the same key rather than making a new one
+	sealed, err := first.Seal([]byte("leagues"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := LocalCacheCipher()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opened, err := second.Open(sealed); err != nil || string(opened) != "leagues" {
+		t.Errorf("Open = %q, %v", opened, err)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/davecgh/go-spew/spew"
+	"github.com/pmurley/go-fantrax/redact"
+	log "github.com/sirupsen/logrus"
 	"io"
 	"net/http"
 	"time"
@@ -19,23 +21,81 @@ type Client struct {
 	Cache        *FileCache
 	CacheEnabled bool
 	LeagueId     string
+
+	// APIToken is the user secret ID from the Fantrax user profile. When set it is sent as
+	// the userSecretId parameter on every request.
+	APIToken string
+
+	// ValidateSchema compares each response against the type it decodes into and reports
+	// differences to OnSchemaDrift (or logs them when OnSchemaDrift is nil)
+	ValidateSchema bool
+	OnSchemaDrift  func(SchemaDrift)
+
+	// PlayerIDStore, when set, keeps player ID maps on disk with their own TTL
+	PlayerIDStore *PlayerIDStore
+
+	// RateLimiter, when set, spaces out requests to Fantrax
+	RateLimiter *RateLimiter
+
+	// UserAgent replaces DefaultUserAgent, and AppID is appended to it to identify the tool
+	// making requests (see WithUserAgent and WithAppID)
+	UserAgent string
+	AppID     string
+
+	cacheDir    string
+	cacheTTL    time.Duration
+	cacheCipher *CacheCipher
+}
+
+// ClientOption is a functional option for configuring NewClient
+type ClientOption func(*Client)
+
+// WithAPIToken authenticates requests with a Fantrax user secret ID (found on the user
+// profile page). This enables read endpoints such as GetLeagues without cookies or login
+// credentials.
+func WithAPIToken(token string) ClientOption {
+	return func(c *Client) {
+		c.APIToken = token
+		redact.AddSecret(token)
+	}
+}
+
+// WithSchemaValidation enables schema drift detection. Each response is compared with the
+// struct it is decoded into, and unknown fields, missing fields, and type mismatches are passed
+// to handler. A nil handler logs the drift instead.
+func WithSchemaValidation(handler func(SchemaDrift)) ClientOption {
+	return func(c *Client) {
+		c.ValidateSchema = true
+		c.OnSchemaDrift = handler
+	}
 }
 
 // NewClient creates a new Fantrax API client
-func NewClient(leagueId string, cacheEnabled bool) (*Client, error) {
+func NewClient(leagueId string, cacheEnabled bool, opts ...ClientOption) (*Client, error) {
 	client := &Client{
 		BaseURL:      "https://www.fantrax.com/fxea",
-		HTTPClient:   &http.Client{Timeout: 30 * time.Second},
+		HTTPClient:   &http.Client{Transport: SharedTransport(), Timeout: 30 * time.Second},
 		CacheEnabled: cacheEnabled,
 		LeagueId:     leagueId,
+		cacheDir:     CachePath,
+		cacheTTL:     24 * time.Hour,
+	}
+	for _, opt := range opts {
+		opt(client)
 	}
 
 	// Initialize cache if enabled
-	if cacheEnabled {
-		cache, err := NewFileCache(CachePath, 24*time.Hour)
+	if client.CacheEnabled {
+		cache, err := NewFileCache(client.cacheDir, client.cacheTTL)
 		if err != nil {
 			return nil, err
 		}
+		if client.cacheCipher == nil {
+			if client.cacheCipher, err = CacheCipherFromEnv(); err != nil {
+				return nil, err
+			}
+		}
+		cache.Cipher = client.cacheCipher
 		client.Cache = cache
 	}
 	return client, nil
@@ -59,31 +119,92 @@ func (c *Client) fetchWithCache(endpoint string, params map[string]string, resul
 	}
 
 	fmt.Printf("Cache miss: %s\n", cacheKey)
+
+	// An expired entry with validators is revalidated with a conditional request, so an
+	// unchanged response is not downloaded again
+	stale, validators, _ := c.Cache.GetStale(cacheKey)
+
 	// Cache miss - make the request
-	var responseData []byte
-	err := c.makeRequestRaw(endpoint, params, &responseData)
+	responseData, newValidators, notModified, err := c.makeConditionalRequest(endpoint, params, validators)
 	if err != nil {
 		return err
 	}
+	if notModified {
+		log.Debug("cache revalidated: ", cacheKey)
+		if err := c.Cache.Touch(cacheKey); err != nil {
+			log.Warn("failed to refresh cache entry: ", err)
+		}
+		return json.Unmarshal(stale, result)
+	}
 
 	// Store in cache
 	if err := c.Cache.Set(cacheKey, responseData); err != nil {
 		// Log but don't fail the request
 		fmt.Printf("Failed to cache response: %v\n", err)
+	} else if err := c.Cache.SetValidators(cacheKey, newValidators); err != nil {
+		log.Warn("failed to cache response validators: ", err)
 	}
 
 	// Unmarshal the response
 	return json.Unmarshal(responseData, result)
 }
 
+// decode unmarshals a response into result, checking it for schema drift first when
+// validation is enabled
+func (c *Client) decode(endpoint string, raw []byte, result interface{}) error {
+	if err := json.Unmarshal(raw, result); err != nil {
+		return err
+	}
+	c.checkSchema(endpoint, raw, result)
+	return nil
+}
+
+// checkSchema reports any differences between a response and the type it decodes into
+func (c *Client) checkSchema(endpoint string, raw []byte, target interface{}) {
+	if !c.ValidateSchema {
+		return
+	}
+	drifts, err := DetectSchemaDrift(endpoint, raw, target)
+	if err != nil {
+		log.Warn("fantrax schema check failed: ", err)
+		return
+	}
+	for _, drift := range drifts {
+		if c.OnSchemaDrift != nil {
+			c.OnSchemaDrift(drift)
+		} else {
+			log.Warn("fantrax schema drift: ", drift)
+		}
+	}
+}
+
 // makeRequestRaw makes an API request and returns the raw response body
 func (c *Client) makeRequestRaw(endpoint string, params map[string]string, responseData *[]byte) error {
+	body, _, _, err := c.makeConditionalRequest(endpoint, params, CacheValidators{})
+	if err != nil {
+		return err
+	}
+	*responseData = body
+	return nil
+}
+
+// makeConditionalRequest makes an API request, sending If-None-Match/If-Modified-Since when
+// validators are given. It returns the body and validators of the response, or notModified
+// if the server answered 304 (in which case the body is empty).
+func (c *Client) makeConditionalRequest(endpoint string, params map[string]string, validators CacheValidators) ([]byte, CacheValidators, bool, error) {
 	// Build URL with query parameters
 	url := c.BaseURL + endpoint
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return fmt.Errorf("error creating request: %w", err)
+		return nil, CacheValidators{}, false, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("User-Agent", UserAgent(c.UserAgent, c.AppID))
+	if validators.ETag != "" {
+		req.Header.Set("If-None-Match", validators.ETag)
+	}
+	if validators.LastModified != "" {
+		req.Header.Set("If-Modified-Since", validators.LastModified)
 	}
 
 	// Add query parameters
@@ -91,27 +212,39 @@ func (c *Client) makeRequestRaw(endpoint string, params map[string]string, respo
 	for k, v := range params {
 		q.Add(k, v)
 	}
+	// The token is added here rather than to params so it never becomes part of a cache key
+	if c.APIToken != "" && q.Get("userSecretId") == "" {
+		q.Set("userSecretId", c.APIToken)
+	}
 	req.URL.RawQuery = q.Encode()
 
 	// Make the request
+	c.RateLimiter.Wait()
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("error making GET request: %w", err)
+		// The request URL in err carries the API token
+		return nil, CacheValidators{}, false, fmt.Errorf("error making GET request: %w", redact.Error(err))
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotModified && !validators.IsZero() {
+		return nil, validators, true, nil
+	}
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("API returned non-200 status code: %d", resp.StatusCode)
+		return nil, CacheValidators{}, false, fmt.Errorf("API returned non-200 status code: %d", resp.StatusCode)
 	}
 
 	// Read the entire response body
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("error reading response body: %w", err)
+		return nil, CacheValidators{}, false, fmt.Errorf("error reading response body: %w", err)
 	}
 
-	*responseData = body
-	return nil
+	newValidators := CacheValidators{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
+	return body, newValidators, false, nil
 }
 
 // makeRequest makes an API request and unmarshals the response into result
@@ -0,0 +1,105 @@
+// fantrax-server serves a league's standings, rosters, matchups, and transactions as JSON
+// from a local HTTP server. Data is fetched from Fantrax in the background on an interval and
+// served from memory, so league websites can poll it freely without adding load on Fantrax.
+//
+// Usage:
+//
+//	FANTRAX_LEAGUE_ID=... go run ./cmd/fantrax-server -sport MLB -addr :8080 -refresh 10m
+//
+// The league, credentials, cache, and rate limit are read with the config package, so a
+// fantrax.json file works in place of environment variables.
+//
+// Endpoints:
+//
+//	GET /api/rosters       Team rosters with player names (public API)
+//	GET /api/standings     Standings (requires login)
+//	GET /api/matchups      Season matchups (requires login)
+//	GET /api/transactions  Recent claims, drops, and trades (requires login)
+//	GET /api/snapshot      All of the above with full rosters (requires login)
+//	GET /api/players       Rostered players joined with their team's standing (requires login)
+//	GET /api/teams         Teams joined with their standing, roster, and matchups (requires login)
+//	GET /api/status        Last refresh time and error for each dataset
+//
+// /api/players accepts the filters maxAge, position, team, group (Active, Reserve, IR, Minors),
+// and maxRank, plus sort=age or sort=rank and limit, e.g. /api/players?maxAge=22&sort=age.
+// /api/teams accepts sort=rank and limit.
+//
+// Responses carry an ETag, so clients can poll with If-None-Match. Pass -public to serve only
+// the public API data without logging in.
+package main
+
+import (
+	"flag"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pmurley/go-fantrax"
+	"github.com/pmurley/go-fantrax/config"
+	"github.com/pmurley/go-fantrax/models"
+	"github.com/pmurley/go-fantrax/redact"
+	log "github.com/sirupsen/logrus"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8080", "address to listen on")
+	sport := flag.String("sport", "", "league sport, e.g. MLB, NFL, NHL, NBA (default from the config, else MLB)")
+	refresh := flag.Duration("refresh", 10*time.Minute, "how often to refresh data from Fantrax")
+	publicOnly := flag.Bool("public", false, "serve only public API data; do not log in")
+	transactions := flag.Int("transactions", 100, "number of recent transactions to serve")
+	flag.Parse()
+	redact.Install()
+
+	cfg, err := config.Require()
+	if err != nil {
+		log.Fatal(err)
+	}
+	leagueID := cfg.LeagueID
+	if *sport == "" {
+		*sport = cfg.Sport
+	}
+	if *sport == "" {
+		*sport = "MLB"
+	}
+	if *refresh < time.Minute {
+		log.Fatal("-refresh must be at least 1m")
+	}
+
+	public, err := cfg.PublicClient()
+	if err != nil {
+		log.Fatalf("Failed to create public client: %v", err)
+	}
+
+	s := &server{interval: *refresh}
+	s.add("rosters", func() (interface{}, error) {
+		return public.GetTeamRostersDetailed(fantrax.Sport(*sport))
+	})
+
+	if !*publicOnly {
+		client, err := cfg.AuthClient()
+		if err != nil {
+			log.Fatalf("Failed to create auth client (pass -public to skip login): %v", err)
+		}
+		standings := s.add("standings", func() (interface{}, error) { return client.GetStandings() })
+		matchups := s.add("matchups", func() (interface{}, error) { return client.GetAllMatchups() })
+		transactions := s.add("transactions", func() (interface{}, error) {
+			return client.GetTransactionHistory(strconv.Itoa(*transactions))
+		})
+		// The snapshot joins fresh rosters with the datasets above, so a failure in one of
+		// them doesn't hold back the others
+		snap := s.add("snapshot", snapshotFetcher(client.LeagueID, standings, matchups, transactions,
+			func() (map[string]*models.TeamRoster, []models.FantasyTeam, error) {
+				return client.GetAllTeamRosters("")
+			}))
+		latest := snapshotSource(snap)
+		s.addQuery("players", playersQuery(latest))
+		s.addQuery("teams", teamsQuery(latest))
+	}
+
+	go s.run(make(chan struct{}))
+
+	log.Infof("Serving league %s on http://%s/api (refreshing every %s)", leagueID, *addr, *refresh)
+	if err := http.ListenAndServe(*addr, s.handler()); err != nil {
+		log.Fatal(err)
+	}
+}
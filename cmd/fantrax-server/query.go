@@ -0,0 +1,145 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pmurley/go-fantrax/auth_client"
+	"github.com/pmurley/go-fantrax/models"
+	"github.com/pmurley/go-fantrax/snapshot"
+)
+
+// snapshotSource returns the latest snapshot fetched by a dataset
+func snapshotSource(d *dataset) func() (*snapshot.Snapshot, error) {
+	return func() (*snapshot.Snapshot, error) {
+		snap, ok := d.latest().(*snapshot.Snapshot)
+		if !ok || snap == nil {
+			return nil, errors.New("league snapshot not loaded yet")
+		}
+		return snap, nil
+	}
+}
+
+// snapshotFetcher builds a snapshot from freshly fetched rosters and the last good standings,
+// matchups, and transactions, which are refreshed before it. It fails only until each of them
+// has loaded once.
+func snapshotFetcher(leagueID string, standings, matchups, transactions *dataset,
+	rosters func() (map[string]*models.TeamRoster, []models.FantasyTeam, error)) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		snap := &snapshot.Snapshot{LeagueID: leagueID, TakenAt: time.Now()}
+		var ok bool
+		if snap.Standings, ok = standings.latest().(*auth_client.LeagueStandings); !ok {
+			return nil, errors.New("standings not loaded yet")
+		}
+		if snap.Matchups, ok = matchups.latest().(*auth_client.AllMatchupsResult); !ok {
+			return nil, errors.New("matchups not loaded yet")
+		}
+		if snap.Transactions, ok = transactions.latest().([]models.Transaction); !ok {
+			return nil, errors.New("transactions not loaded yet")
+		}
+		var err error
+		if snap.Rosters, snap.Teams, err = rosters(); err != nil {
+			return nil, fmt.Errorf("failed to get rosters: %w", err)
+		}
+		return snap, nil
+	}
+}
+
+// intParam reads an optional integer query parameter
+func intParam(values url.Values, name string) (int, bool, error) {
+	raw := values.Get(name)
+	if raw == "" {
+		return 0, false, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid %s %q", name, raw)
+	}
+	return n, true, nil
+}
+
+// playersQuery answers /api/players from the latest snapshot
+func playersQuery(latest func() (*snapshot.Snapshot, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		snap, err := latest()
+		if err != nil {
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": err.Error()})
+			return
+		}
+		q, err := buildPlayersQuery(snap.QueryPlayers(), r.URL.Query())
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, q.All())
+	}
+}
+
+func buildPlayersQuery(q *snapshot.Query[snapshot.PlayerRow], values url.Values) (*snapshot.Query[snapshot.PlayerRow], error) {
+	if age, ok, err := intParam(values, "maxAge"); err != nil {
+		return nil, err
+	} else if ok {
+		q = q.Where(snapshot.AgeUnder(age + 1))
+	}
+	if rank, ok, err := intParam(values, "maxRank"); err != nil {
+		return nil, err
+	} else if ok {
+		q = q.Where(snapshot.TeamRankAtMost(rank))
+	}
+	if position := values.Get("position"); position != "" {
+		q = q.Where(snapshot.AtPosition(position))
+	}
+	if team := values.Get("team"); team != "" {
+		q = q.Where(snapshot.OnTeam(team))
+	}
+	if group := values.Get("group"); group != "" {
+		q = q.Where(snapshot.InGroup(group))
+	}
+
+	switch sort := values.Get("sort"); sort {
+	case "":
+	case "age":
+		q = q.OrderBy(snapshot.ByAge)
+	case "rank":
+		q = q.OrderBy(snapshot.ByTeamRank)
+	default:
+		return nil, fmt.Errorf("invalid sort %q", sort)
+	}
+
+	limit, _, err := intParam(values, "limit")
+	if err != nil {
+		return nil, err
+	}
+	return q.Limit(limit), nil
+}
+
+// teamsQuery answers /api/teams from the latest snapshot
+func teamsQuery(latest func() (*snapshot.Snapshot, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		snap, err := latest()
+		if err != nil {
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": err.Error()})
+			return
+		}
+		values := r.URL.Query()
+		q := snap.QueryTeams()
+		switch sort := values.Get("sort"); sort {
+		case "":
+		case "rank":
+			q = q.OrderBy(snapshot.ByRank)
+		default:
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("invalid sort %q", sort)})
+			return
+		}
+		limit, _, err := intParam(values, "limit")
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, q.Limit(limit).All())
+	}
+}
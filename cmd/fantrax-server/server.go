@@ -0,0 +1,189 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// dataset is one kind of league data, refreshed from Fantrax in the background and served
+// from memory
+type dataset struct {
+	name  string
+	fetch func() (interface{}, error)
+
+	mu        sync.RWMutex
+	value     interface{}
+	body      []byte
+	etag      string
+	updatedAt time.Time
+	lastErr   error
+	errorAt   time.Time
+}
+
+// refresh fetches the dataset. On failure the previous data keeps being served.
+func (d *dataset) refresh() {
+	value, err := d.fetch()
+	var body []byte
+	if err == nil {
+		body, err = json.Marshal(value)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err != nil {
+		d.lastErr = err
+		d.errorAt = time.Now()
+		log.Warnf("failed to refresh %s: %v", d.name, err)
+		return
+	}
+	sum := sha256.Sum256(body)
+	d.value = value
+	d.body = body
+	d.etag = `"` + hex.EncodeToString(sum[:8]) + `"`
+	d.updatedAt = time.Now()
+	d.lastErr = nil
+}
+
+// ServeHTTP writes the latest data, or 503 if it has never been fetched successfully
+func (d *dataset) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	d.mu.RLock()
+	body, etag, updatedAt, lastErr := d.body, d.etag, d.updatedAt, d.lastErr
+	d.mu.RUnlock()
+
+	if body == nil {
+		message := "not loaded yet"
+		if lastErr != nil {
+			message = lastErr.Error()
+		}
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": message})
+		return
+	}
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Last-Modified", updatedAt.UTC().Format(http.TimeFormat))
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+// latest returns the most recently fetched value, or nil
+func (d *dataset) latest() interface{} {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.value
+}
+
+// datasetStatus is the /api/status entry for one dataset
+type datasetStatus struct {
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+	Error     string     `json:"error,omitempty"`
+	ErrorAt   *time.Time `json:"errorAt,omitempty"`
+}
+
+func (d *dataset) status() datasetStatus {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	var s datasetStatus
+	if !d.updatedAt.IsZero() {
+		updatedAt := d.updatedAt
+		s.UpdatedAt = &updatedAt
+	}
+	if d.lastErr != nil {
+		errorAt := d.errorAt
+		s.Error = d.lastErr.Error()
+		s.ErrorAt = &errorAt
+	}
+	return s
+}
+
+// server serves datasets under /api/<name> and refreshes them on an interval
+type server struct {
+	datasets []*dataset
+	interval time.Duration
+
+	// queries are extra endpoints under /api that compute their response per request
+	queries map[string]http.HandlerFunc
+}
+
+// add registers a dataset. Datasets are refreshed in the order they are added.
+func (s *server) add(name string, fetch func() (interface{}, error)) *dataset {
+	d := &dataset{name: name, fetch: fetch}
+	s.datasets = append(s.datasets, d)
+	return d
+}
+
+// addQuery registers an endpoint that answers from already fetched data
+func (s *server) addQuery(name string, handler http.HandlerFunc) {
+	if s.queries == nil {
+		s.queries = make(map[string]http.HandlerFunc)
+	}
+	s.queries[name] = handler
+}
+
+func (s *server) handler() http.Handler {
+	mux := http.NewServeMux()
+	for _, d := range s.datasets {
+		mux.Handle("GET /api/"+d.name, d)
+	}
+	for name, handler := range s.queries {
+		mux.HandleFunc("GET /api/"+name, handler)
+	}
+	mux.HandleFunc("GET /api/status", func(w http.ResponseWriter, r *http.Request) {
+		statuses := make(map[string]datasetStatus, len(s.datasets))
+		for _, d := range s.datasets {
+			statuses[d.name] = d.status()
+		}
+		writeJSON(w, http.StatusOK, statuses)
+	})
+	mux.HandleFunc("GET /api", func(w http.ResponseWriter, r *http.Request) {
+		names := make([]string, 0, len(s.datasets)+len(s.queries)+1)
+		for _, d := range s.datasets {
+			names = append(names, "/api/"+d.name)
+		}
+		for name := range s.queries {
+			names = append(names, "/api/"+name)
+		}
+		names = append(names, "/api/status")
+		sort.Strings(names)
+		writeJSON(w, http.StatusOK, map[string][]string{"endpoints": names})
+	})
+	return mux
+}
+
+// refreshAll fetches every dataset once, one at a time to stay gentle on Fantrax
+func (s *server) refreshAll() {
+	for _, d := range s.datasets {
+		d.refresh()
+	}
+}
+
+// run refreshes the datasets now and then every interval until stop is closed
+func (s *server) run(stop <-chan struct{}) {
+	s.refreshAll()
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.refreshAll()
+		case <-stop:
+			return
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pmurley/go-fantrax/auth_client"
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestServerServesLastGoodData(t *testing.T) {
+	fail := true
+	s := &server{}
+	s.add("standings", func() (interface{}, error) {
+		if fail {
+			return nil, errors.New("fantrax is down")
+		}
+		return map[string]int{"teams": 12}, nil
+	})
+	handler := s.handler()
+
+	get := func(path, etag string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	s.refreshAll()
+	if rec := get("/api/standings", ""); rec.Code != http.StatusServiceUnavailable || !strings.Contains(rec.Body.String(), "fantrax is down") {
+		t.Fatalf("before first load: %d %s", rec.Code, rec.Body)
+	}
+
+	fail = false
+	s.refreshAll()
+	rec := get("/api/standings", "")
+	if rec.Code != http.StatusOK || rec.Body.String() != `{"teams":12}` {
+		t.Fatalf("after load: %d %s", rec.Code, rec.Body)
+	}
+	etag := rec.Header().Get("ETag")
+	if rec := get("/api/standings", etag); rec.Code != http.StatusNotModified {
+		t.Errorf("conditional request: got %d, want 304", rec.Code)
+	}
+
+	// A failed refresh keeps serving the previous data and reports the error in the status
+	fail = true
+	s.refreshAll()
+	if rec := get("/api/standings", ""); rec.Code != http.StatusOK {
+		t.Errorf("after failed refresh: got %d, want 200", rec.Code)
+	}
+	if rec := get("/api/status", ""); !strings.Contains(rec.Body.String(), "fantrax is down") {
+		t.Errorf("status does not report the error: %s", rec.Body)
+	}
+}
+
+func TestSnapshotUsesLastGoodDatasets(t *testing.T) {
+	failStandings := false
+	s := &server{}
+	standings := s.add("standings", func() (interface{}, error) {
+		if failStandings {
+			return nil, errors.New("standings failed")
+		}
+		return &auth_client.LeagueStandings{}, nil
+	})
+	matchups := s.add("matchups", func() (interface{}, error) { return &auth_client.AllMatchupsResult{}, nil })
+	transactions := s.add("transactions", func() (interface{}, error) { return []models.Transaction{}, nil })
+	snap := s.add("snapshot", snapshotFetcher("league", standings, matchups, transactions,
+		func() (map[string]*models.TeamRoster, []models.FantasyTeam, error) {
+			return map[string]*models.TeamRoster{}, nil, nil
+		}))
+
+	s.refreshAll()
+	first := snap.status().UpdatedAt
+	if first == nil {
+		t.Fatalf("snapshot not loaded: %+v", snap.status())
+	}
+
+	// A failing dataset reports its own error without holding back the others
+	failStandings = true
+	s.refreshAll()
+	if status := standings.status(); status.Error == "" {
+		t.Error("standings status does not report the failure")
+	}
+	for _, d := range []*dataset{matchups, transactions, snap} {
+		if status := d.status(); status.Error != "" || status.UpdatedAt == nil {
+			t.Errorf("%s status = %+v, want refreshed", d.name, status)
+		}
+	}
+	if updated := snap.status().UpdatedAt; updated.Before(*first) {
+		t.Errorf("snapshot was not refreshed")
+	}
+}
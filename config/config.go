@@ -0,0 +1,309 @@
+// Package config loads the settings shared by go-fantrax tools — league ID, credentials,
+// cache, rate limit, and team aliases — from a JSON file with environment variable overrides,
+// and builds clients from them.
+//
+// Load reads the file named by FANTRAX_CONFIG, or fantrax.json in the working directory, if
+// either exists; a missing file is not an error, so tools still run from the environment
+// alone. Environment variables override the file:
+//
+//	FANTRAX_LEAGUE_ID, FANTRAX_SPORT, FANTRAX_API_TOKEN, FANTRAX_COOKIES,
+//	FANTRAX_COOKIE_FILE, FANTRAX_CACHE_DIR, FANTRAX_CACHE_TTL, FANTRAX_RATE_LIMIT,
+//	FANTRAX_USER_AGENT, FANTRAX_APP_ID
+//
+// An example file:
+//
+//	{
+//	  "leagueId": "abc123",
+//	  "sport": "MLB",
+//	  "credentials": {"cookieFile": "~/.fantrax-cookies"},
+//	  "cache": {"enabled": true, "dir": ".fantrax-cache", "ttl": "6h"},
+//	  "rateLimit": "500ms",
+//	  "teamAliases": {"aces": "t1abc", "bats": "t2def"},
+//	  "terminology": {"statuses": {"Minors": "Taxi", "IR": "Injured List"}}
+//	}
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pmurley/go-fantrax"
+	"github.com/pmurley/go-fantrax/auth_client"
+	"github.com/pmurley/go-fantrax/redact"
+)
+
+// DefaultFile is the config file Load reads when FANTRAX_CONFIG is not set
+const DefaultFile = "fantrax.json"
+
+// ErrNoLeagueID is returned by Require when no league ID is configured
+var ErrNoLeagueID = errors.New("no league ID configured; set leagueId in the config file or FANTRAX_LEAGUE_ID")
+
+// Duration is a time.Duration written in JSON as a string such as "500ms" or "24h"
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string such as \"10m\": %w", err)
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// Credentials says where the logged-in client gets its cookies. With neither field set, the
+// library's default lookup is used: FANTRAX_COOKIES, the cookie cache, then a browser login
+// with FANTRAX_USERNAME and FANTRAX_PASSWORD.
+type Credentials struct {
+	Cookies    string `json:"cookies,omitempty"`    // Cookie header, e.g. "FX_RM=..."
+	CookieFile string `json:"cookieFile,omitempty"` // File containing the Cookie header
+}
+
+// Cache configures response caching. Enabled applies to both clients; Dir and TTL apply to
+// the public client, as the logged-in client always caches in auth_client.CacheDir.
+type Cache struct {
+	Enabled bool     `json:"enabled"`
+	Dir     string   `json:"dir,omitempty"` // Default fantrax.CachePath
+	TTL     Duration `json:"ttl,omitempty"` // Default 24h
+}
+
+// Config holds the settings shared by go-fantrax tools
+type Config struct {
+	LeagueID    string      `json:"leagueId"`
+	Sport       string      `json:"sport,omitempty"`
+	APIToken    string      `json:"apiToken,omitempty"` // User secret ID for the public API
+	Credentials Credentials `json:"credentials,omitempty"`
+	Cache       Cache       `json:"cache,omitempty"`
+
+	// RateLimit is the minimum time between requests to Fantrax, shared by every client built
+	// from the config (0 = unlimited)
+	RateLimit Duration `json:"rateLimit,omitempty"`
+
+	// UserAgent replaces the default User-Agent, and AppID (e.g. "league-bot/1.2") is appended
+	// to it to identify the tool, on every client built from the config
+	UserAgent string `json:"userAgent,omitempty"`
+	AppID     string `json:"appId,omitempty"`
+
+	// Terminology names roster statuses and positions in the league's own labels on the
+	// logged-in client (e.g. {"statuses": {"Minors": "Taxi"}})
+	Terminology *auth_client.Terminology `json:"terminology,omitempty"`
+
+	// TeamAliases maps short names people use for teams to Fantrax team IDs
+	TeamAliases map[string]string `json:"teamAliases,omitempty"`
+
+	// Path is the file the config was read from, empty if none
+	Path string `json:"-"`
+
+	limiter *fantrax.RateLimiter
+}
+
+// Load reads the config file (FANTRAX_CONFIG, or fantrax.json if present) and applies
+// environment overrides
+func Load() (*Config, error) {
+	path := os.Getenv("FANTRAX_CONFIG")
+	required := path != ""
+	if path == "" {
+		path = DefaultFile
+	}
+
+	config := &Config{}
+	if _, err := os.Stat(path); err == nil || required {
+		if config, err = LoadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	if err := config.applyEnv(); err != nil {
+		return nil, err
+	}
+	redact.AddSecret(config.APIToken, config.Credentials.Cookies)
+	return config, nil
+}
+
+// LoadFile reads a config file without applying environment overrides
+func LoadFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+	config.Path = path
+	return &config, nil
+}
+
+// Require loads the config like Load and fails with ErrNoLeagueID if no league is configured
+func Require() (*Config, error) {
+	config, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	if config.LeagueID == "" {
+		return nil, ErrNoLeagueID
+	}
+	return config, nil
+}
+
+// applyEnv overrides settings from the environment
+func (c *Config) applyEnv() error {
+	values := []struct {
+		name   string
+		target *string
+	}{
+		{"FANTRAX_LEAGUE_ID", &c.LeagueID},
+		{"FANTRAX_SPORT", &c.Sport},
+		{"FANTRAX_API_TOKEN", &c.APIToken},
+		{"FANTRAX_COOKIES", &c.Credentials.Cookies},
+		{"FANTRAX_COOKIE_FILE", &c.Credentials.CookieFile},
+		{"FANTRAX_CACHE_DIR", &c.Cache.Dir},
+		{"FANTRAX_USER_AGENT", &c.UserAgent},
+		{"FANTRAX_APP_ID", &c.AppID},
+	}
+	for _, s := range values {
+		if v := os.Getenv(s.name); v != "" {
+			*s.target = v
+		}
+	}
+	if os.Getenv("FANTRAX_CACHE_DIR") != "" {
+		c.Cache.Enabled = true
+	}
+
+	durations := []struct {
+		name   string
+		target *Duration
+	}{
+		{"FANTRAX_CACHE_TTL", &c.Cache.TTL},
+		{"FANTRAX_RATE_LIMIT", &c.RateLimit},
+	}
+	for _, d := range durations {
+		v := os.Getenv(d.name)
+		if v == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", d.name, err)
+		}
+		*d.target = Duration(parsed)
+	}
+	return nil
+}
+
+// RateLimiter returns the limiter shared by the config's clients, or nil if RateLimit is 0
+func (c *Config) RateLimiter() *fantrax.RateLimiter {
+	if c.RateLimit <= 0 {
+		return nil
+	}
+	if c.limiter == nil {
+		c.limiter = fantrax.NewRateLimiter(time.Duration(c.RateLimit))
+	}
+	return c.limiter
+}
+
+// PublicClient creates a public API client for the configured league
+//
+// Parameters:
+//   - opts: Extra options, applied after the configured ones
+func (c *Config) PublicClient(opts ...fantrax.ClientOption) (*fantrax.Client, error) {
+	var options []fantrax.ClientOption
+	if c.APIToken != "" {
+		options = append(options, fantrax.WithAPIToken(c.APIToken))
+	}
+	if c.Cache.Enabled {
+		dir, ttl := c.Cache.Dir, time.Duration(c.Cache.TTL)
+		if dir == "" {
+			dir = fantrax.CachePath
+		}
+		if ttl == 0 {
+			ttl = 24 * time.Hour
+		}
+		options = append(options, fantrax.WithCache(dir, ttl))
+	}
+	if limiter := c.RateLimiter(); limiter != nil {
+		options = append(options, fantrax.WithRateLimiter(limiter))
+	}
+	if c.UserAgent != "" {
+		options = append(options, fantrax.WithUserAgent(c.UserAgent))
+	}
+	if c.AppID != "" {
+		options = append(options, fantrax.WithAppID(c.AppID))
+	}
+	return fantrax.NewClient(c.LeagueID, false, append(options, opts...)...)
+}
+
+// AuthClient creates a logged-in client for the configured league
+//
+// Parameters:
+//   - opts: Extra options, applied after the configured ones
+func (c *Config) AuthClient(opts ...auth_client.ClientOption) (*auth_client.Client, error) {
+	var options []auth_client.ClientOption
+	cookies, err := c.cookies()
+	if err != nil {
+		return nil, err
+	}
+	if cookies != "" {
+		options = append(options, auth_client.WithCookies(cookies))
+	}
+	if limiter := c.RateLimiter(); limiter != nil {
+		options = append(options, auth_client.WithRateLimiter(limiter))
+	}
+	if c.UserAgent != "" {
+		options = append(options, auth_client.WithUserAgent(c.UserAgent))
+	}
+	if c.AppID != "" {
+		options = append(options, auth_client.WithAppID(c.AppID))
+	}
+	if c.Terminology != nil {
+		options = append(options, auth_client.WithTerminology(c.Terminology))
+	}
+	return auth_client.NewClient(c.LeagueID, c.Cache.Enabled, append(options, opts...)...)
+}
+
+func (c *Config) cookies() (string, error) {
+	if c.Credentials.Cookies != "" {
+		return c.Credentials.Cookies, nil
+	}
+	if c.Credentials.CookieFile == "" {
+		return "", nil
+	}
+	path := c.Credentials.CookieFile
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to expand cookie file path: %w", err)
+		}
+		path = filepath.Join(home, rest)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read cookie file: %w", err)
+	}
+	cookies := strings.TrimSpace(string(data))
+	redact.AddSecret(cookies)
+	return cookies, nil
+}
+
+// TeamID resolves a team alias to its Fantrax team ID. Aliases are matched
+// case-insensitively; anything that is not an alias is returned unchanged, so team IDs pass
+// through.
+func (c *Config) TeamID(team string) string {
+	for alias, id := range c.TeamAliases {
+		if strings.EqualFold(alias, team) {
+			return id
+		}
+	}
+	return team
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadWithEnvOverrides(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "league.json")
+	err := os.WriteFile(path, []byte(`{
+		"leagueId": "from-file",
+		"sport": "NHL",
+		"cache": {"enabled": true, "ttl": "6h"},
+		"rateLimit": "250ms",
+		"teamAliases": {"Aces": "t1"}
+	}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("FANTRAX_CONFIG", path)
+	t.Setenv("FANTRAX_LEAGUE_ID", "from-env")
+	t.Setenv("FANTRAX_RATE_LIMIT", "1s")
+
+	config, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.LeagueID != "from-env" || config.Sport != "NHL" || config.Path != path {
+		t.Errorf("config = %+v", config)
+	}
+	if time.Duration(config.RateLimit) != time.Second || time.Duration(config.Cache.TTL) != 6*time.Hour {
+		t.Errorf("rate limit %v, cache TTL %v", config.RateLimit, config.Cache.TTL)
+	}
+	if config.RateLimiter() != config.RateLimiter() {
+		t.Error("clients built from one config do not share a rate limiter")
+	}
+	if got := config.TeamID("aces"); got != "t1" {
+		t.Errorf("TeamID(aces) = %q", got)
+	}
+	if got := config.TeamID("t9"); got != "t9" {
+		t.Errorf("TeamID(t9) = %q", got)
+	}
+}
+
+func TestRequireWithoutLeague(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("FANTRAX_CONFIG", "")
+	t.Setenv("FANTRAX_LEAGUE_ID", "")
+	if _, err := Require(); err != ErrNoLeagueID {
+		t.Errorf("Require() error = %v, want ErrNoLeagueID", err)
+	}
+}
@@ -1,6 +1,9 @@
 package fantrax
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+)
 
 // DraftResults represents the response from the getDraftResults endpoint
 type DraftResults struct {
@@ -23,16 +26,31 @@ type DraftPick struct {
 	PlayerID    string `json:"playerId"`
 }
 
-// GetDraftResults fetches draft results for a specific league
-func (c *Client) GetDraftResults(leagueID string) (*DraftResults, error) {
+// GetDraftResultsRaw fetches the getDraftResults response without parsing it
+func (c *Client) GetDraftResultsRaw(leagueID string) (json.RawMessage, error) {
 	endpoint := "/general/getDraftResults"
 	params := map[string]string{"leagueId": leagueID}
 
-	var results DraftResults
-	err := c.fetchWithCache(endpoint, params, &results)
+	var raw json.RawMessage
+	err := c.fetchWithCache(endpoint, params, &raw)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get draft results: %w", err)
 	}
 
+	return raw, nil
+}
+
+// GetDraftResults fetches draft results for a specific league
+func (c *Client) GetDraftResults(leagueID string) (*DraftResults, error) {
+	raw, err := c.GetDraftResultsRaw(leagueID)
+	if err != nil {
+		return nil, err
+	}
+
+	var results DraftResults
+	if err := c.decode("/general/getDraftResults", raw, &results); err != nil {
+		return nil, fmt.Errorf("failed to parse draft results: %w", err)
+	}
+
 	return &results, nil
 }
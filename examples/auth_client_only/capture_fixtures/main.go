@@ -0,0 +1,111 @@
+// capture_fixtures records sanitized responses from your league into the fixture corpus used
+// by the golden tests. See auth_client/testdata/README.md.
+//
+// Usage:
+//
+//	FANTRAX_LEAGUE_ID=... go run ./examples/auth_client_only/capture_fixtures -name my_league_nhl
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/pmurley/go-fantrax/auth_client"
+	"github.com/pmurley/go-fantrax/config"
+	"github.com/pmurley/go-fantrax/internal/fixtures"
+)
+
+func main() {
+	name := flag.String("name", "", "fixture file name (without extension), e.g. points_league_nhl")
+	dir := flag.String("dir", "auth_client/testdata/fixtures", "fixture corpus directory")
+	flag.Parse()
+
+	cfg, err := config.Require()
+	if err != nil || *name == "" {
+		log.Fatal("configure a league (see the config package) and pass -name")
+	}
+	leagueID := cfg.LeagueID
+
+	client, err := cfg.AuthClient()
+	if err != nil {
+		log.Fatalf("Failed to create auth client: %v", err)
+	}
+
+	// Typed raw responses are re-encoded, so fields the models do not know about are dropped
+	typed := func(v interface{}, err error) ([]byte, error) {
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(v)
+	}
+
+	captures := []struct {
+		endpoint string
+		fetch    func() ([]byte, error)
+	}{
+		{"getStandings", func() ([]byte, error) { return client.GetStandingsRaw() }},
+		{"getStandings-schedule", func() ([]byte, error) { return client.GetAllMatchupsRaw() }},
+		{"getTransactionDetailsHistory", func() ([]byte, error) { return client.GetTransactionDetailsHistoryRaw("100") }},
+		{"getTransactionDetailsHistory-pending", func() ([]byte, error) {
+			return client.GetTransactionDetailsHistoryFullRaw(auth_client.GetTransactionDetailsHistoryRequest{
+				LeagueID:          leagueID,
+				MaxResultsPerPage: "100",
+				View:              auth_client.TransactionViewPending,
+			})
+		}},
+		{"getTeamRosterInfo", func() ([]byte, error) { return typed(client.GetMyTeamRosterInfoRaw("")) }},
+		{"getPlayerStats", func() ([]byte, error) {
+			return typed(client.GetPlayerPoolRaw(auth_client.StatusFilterAll, 1))
+		}},
+		{"getStandings-playoffs", func() ([]byte, error) {
+			return client.GetStandingsRaw(auth_client.WithStandingsView(auth_client.StandingsViewPlayoffs))
+		}},
+		{"getLeagueHomeInfo", func() ([]byte, error) { return client.GetLeagueHomeInfoRaw() }},
+		{"getTeamServiceTime", func() ([]byte, error) { return typed(client.GetTeamServiceTimeRaw("")) }},
+		{"getTradeBlocks", func() ([]byte, error) { return typed(client.GetTradeBlocksRaw()) }},
+		{"getLeaguePolls", func() ([]byte, error) { return typed(client.GetLeaguePollsRaw()) }},
+	}
+
+	for _, capture := range captures {
+		raw, err := capture.fetch()
+		if err != nil {
+			fmt.Printf("skip %s: %v\n", capture.endpoint, err)
+			continue
+		}
+		sanitized, err := fixtures.Sanitize(raw, leagueID)
+		if err != nil {
+			fmt.Printf("skip %s: %v\n", capture.endpoint, err)
+			continue
+		}
+		write(filepath.Join(*dir, capture.endpoint, *name+".json"), sanitized)
+	}
+
+	if html, err := client.GetLeagueSetupMatchupsRaw(); err == nil {
+		write(filepath.Join(*dir, "createLeague", *name+".html"), fixtures.SanitizeHTML(html, leagueID))
+	} else {
+		fmt.Printf("skip createLeague: %v\n", err)
+	}
+	if html, err := client.GetIllegalRosterOverviewRaw(); err == nil {
+		write(filepath.Join(*dir, "illegalRosterOverrideAdmin", *name+".html"), fixtures.SanitizeHTML(html, leagueID))
+	} else {
+		fmt.Printf("skip illegalRosterOverrideAdmin: %v\n", err)
+	}
+
+	fmt.Println("\nReview every file before committing: the sanitizer removes known personal fields,")
+	fmt.Println("but team names and free-text fields are kept as-is.")
+	fmt.Println("Then run: go test ./auth_client -run TestGoldenFixtures -update")
+}
+
+func write(path string, data []byte) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		log.Fatalf("Failed to create %s: %v", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		log.Fatalf("Failed to write %s: %v", path, err)
+	}
+	fmt.Printf("wrote %s\n", path)
+}
@@ -7,23 +7,25 @@ import (
 	"time"
 
 	"github.com/pmurley/go-fantrax/auth_client"
+	"github.com/pmurley/go-fantrax/config"
 )
 
 func main() {
-	// Get league ID from environment variable
-	leagueID := os.Getenv("FANTRAX_LEAGUE_ID")
-	if leagueID == "" {
-		log.Fatal("Please set FANTRAX_LEAGUE_ID environment variable")
+	// Get league ID from the config file or environment
+	cfg, err := config.Require()
+	if err != nil {
+		log.Fatal(err)
 	}
+	leagueID := cfg.LeagueID
 
 	// Get team ID from environment variable
-	targetTeamID := os.Getenv("FANTRAX_TEAM_ID")
+	targetTeamID := cfg.TeamID(os.Getenv("FANTRAX_TEAM_ID"))
 	if targetTeamID == "" {
 		log.Fatal("Please set FANTRAX_TEAM_ID environment variable")
 	}
 
 	// Create authenticated client (must be commissioner account)
-	client, err := auth_client.NewClient(leagueID, false)
+	client, err := cfg.AuthClient()
 	if err != nil {
 		log.Fatalf("Failed to create client: %v", err)
 	}
@@ -7,19 +7,24 @@ import (
 	"log"
 	"os"
 
-	"github.com/pmurley/go-fantrax/auth_client"
 	"github.com/pmurley/go-fantrax/auth_client/parser"
+	"github.com/pmurley/go-fantrax/config"
 )
 
 func main() {
-	// Get league ID from environment or use a default
-	leagueID := os.Getenv("FANTRAX_LEAGUE_ID")
+	// Get league ID from the config file or environment, or use a default
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	leagueID := cfg.LeagueID
 	if leagueID == "" {
 		leagueID = "q8lydqf5m4u30rca" // Using the league ID from the example
+		cfg.LeagueID = leagueID
 	}
 
-	// Create an auth client (with caching disabled for fresh data)
-	client, err := auth_client.NewClient(leagueID, false)
+	// Create an auth client from the config
+	client, err := cfg.AuthClient()
 	if err != nil {
 		log.Fatalf("Failed to create auth client: %v", err)
 	}
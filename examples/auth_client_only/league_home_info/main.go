@@ -6,18 +6,23 @@ import (
 	"log"
 	"os"
 
-	"github.com/pmurley/go-fantrax/auth_client"
+	"github.com/pmurley/go-fantrax/config"
 )
 
 func main() {
-	// Get league ID from environment variable or use default
-	leagueID := os.Getenv("FANTRAX_LEAGUE_ID")
+	// Get league ID from the config file or environment, or use a default
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	leagueID := cfg.LeagueID
 	if leagueID == "" {
 		leagueID = "q8lydqf5m4u30rca" // Default from the example
+		cfg.LeagueID = leagueID
 	}
 
-	// Create client with caching enabled
-	client, err := auth_client.NewClient(leagueID, true)
+	// Create client (caching follows the config)
+	client, err := cfg.AuthClient()
 	if err != nil {
 		log.Fatalf("Failed to create auth client: %v", err)
 	}
@@ -3,19 +3,20 @@ package main
 import (
 	"fmt"
 	"log"
-	"os"
 
 	"github.com/pmurley/go-fantrax/auth_client"
+	"github.com/pmurley/go-fantrax/config"
+	"github.com/pmurley/go-fantrax/redact"
 )
 
 func main() {
-	leagueID := os.Getenv("FANTRAX_LEAGUE_ID")
-	if leagueID == "" {
-		log.Fatal("Please set FANTRAX_LEAGUE_ID environment variable")
+	cfg, err := config.Require()
+	if err != nil {
+		log.Fatal(err)
 	}
 
 	// Create authenticated client
-	client, err := auth_client.NewClient(leagueID, false)
+	client, err := cfg.AuthClient()
 	if err != nil {
 		log.Fatalf("Failed to create client: %v", err)
 	}
@@ -99,7 +100,7 @@ func main() {
 
 	fmt.Println()
 	for key, value := range setup.FormConfig.OwnerEmailFields {
-		fmt.Printf("  OwnerEmail: %s = %s\n", key, value)
+		fmt.Printf("  OwnerEmail: %s = %s\n", redact.String(key), redact.String(value))
 	}
 
 	// Example: SetPeriodMatchups usage (commented out to avoid hitting live server)
@@ -1,25 +1,24 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"log"
+	"net/url"
 	"os"
-	"sort"
-	"strings"
 
 	"github.com/pmurley/go-fantrax/auth_client"
+	"github.com/pmurley/go-fantrax/config"
 	"github.com/pmurley/go-fantrax/models"
 )
 
 func main() {
-	leagueID := os.Getenv("FANTRAX_LEAGUE_ID")
-	if leagueID == "" {
-		log.Fatal("Please set FANTRAX_LEAGUE_ID environment variable")
+	cfg, err := config.Require()
+	if err != nil {
+		log.Fatal(err)
 	}
 
 	// Create authenticated client
-	client, err := auth_client.NewClient(leagueID, false)
+	client, err := cfg.AuthClient()
 	if err != nil {
 		log.Fatalf("Failed to create client: %v", err)
 	}
@@ -75,69 +74,41 @@ func main() {
 		teamName(setup, newPairs[i].AwayTeamID), teamName(setup, newPairs[i].HomeTeamID),
 		teamName(setup, newPairs[j].AwayTeamID), teamName(setup, newPairs[j].HomeTeamID))
 
-	// Update the matchups in-place (same as SetPeriodMatchups does before building the form)
-	setup.Matchups[period] = newPairs
-
-	// Build the form body (but do NOT send it)
-	form := auth_client.BuildFormBody(setup, period)
-
-	// Save as decoded JSON (matching the format of matchup_save_post_decoded.json)
-	decoded := make(map[string]interface{})
-	for key, values := range form {
-		if len(values) == 1 {
-			decoded[key] = values[0]
-		} else {
-			decoded[key] = values
+	// Build the form body (but do NOT send it) and compare it with a captured
+	// known-good body, if one was given: a URL-encoded POST body or its JSON export
+	var knownGood url.Values
+	if len(os.Args) > 1 {
+		data, err := os.ReadFile(os.Args[1])
+		if err != nil {
+			log.Fatalf("Failed to read captured form: %v", err)
+		}
+		if knownGood, err = auth_client.ParseCapturedForm(data); err != nil {
+			log.Fatalf("Failed to parse captured form: %v", err)
 		}
 	}
-
-	jsonBytes, err := json.MarshalIndent(decoded, "", "  ")
+	diff, err := auth_client.GenerateMatchupFormDiff(setup, period, newPairs, knownGood)
 	if err != nil {
-		log.Fatalf("Failed to marshal JSON: %v", err)
+		log.Fatalf("Failed to generate form: %v", err)
 	}
+	form := diff.Form
 
-	outPath := "/Users/pmurley/GolandProjects/go-fantrax/matchup_save_post_generated.json"
-	if err := os.WriteFile(outPath, jsonBytes, 0644); err != nil {
-		log.Fatalf("Failed to write file: %v", err)
-	}
-	fmt.Printf("\nSaved generated POST body to: %s\n", outPath)
-
-	// Also save the raw URL-encoded form for reference
-	rawPath := "/Users/pmurley/GolandProjects/go-fantrax/matchup_save_post_generated_raw.txt"
+	// Save the raw URL-encoded form for reference
+	rawPath := "matchup_save_post_generated_raw.txt"
 	if err := os.WriteFile(rawPath, []byte(form.Encode()), 0644); err != nil {
 		log.Fatalf("Failed to write raw file: %v", err)
 	}
-	fmt.Printf("Saved raw URL-encoded form to: %s\n", rawPath)
+	fmt.Printf("\nSaved raw URL-encoded form to: %s\n", rawPath)
 
-	// Quick summary comparison
 	fmt.Printf("\n=== Quick Comparison ===\n")
 	fmt.Printf("Generated form has %d unique keys\n", len(form))
-
-	// Count matchups entries
 	fmt.Printf("  matchups entries: %d\n", len(form["matchups"]))
 	fmt.Printf("  ~~divisions entries: %d\n", len(form["~~divisions"]))
 
-	// List all keys sorted
-	var keys []string
-	for k := range form {
-		keys = append(keys, k)
-	}
-	sort.Strings(keys)
-	fmt.Printf("\nAll %d form keys:\n", len(keys))
-	for _, k := range keys {
-		vals := form[k]
-		if len(vals) == 1 {
-			v := vals[0]
-			if len(v) > 80 {
-				v = v[:80] + "..."
-			}
-			fmt.Printf("  %s = %s\n", k, v)
-		} else {
-			fmt.Printf("  %s = [%d values]\n", k, len(vals))
-		}
+	if knownGood == nil {
+		fmt.Println("\nPass a captured POST body to compare against: matchup_post_comparison captured.json")
+		return
 	}
-
-	fmt.Println("\nDone! Now compare with: diff <(jq -S . matchup_save_post_decoded.json) <(jq -S . matchup_save_post_generated.json)")
+	fmt.Printf("\nDifferences from %s (- missing, + extra, ~ changed):\n%s", os.Args[1], diff)
 }
 
 func teamName(setup *models.LeagueSetupMatchups, teamID string) string {
@@ -150,28 +121,3 @@ func teamName(setup *models.LeagueSetupMatchups, teamID string) string {
 	}
 	return teamID
 }
-
-// sortedKeys returns sorted keys from a string map for deterministic output.
-func sortedKeys(m map[string]string) []string {
-	keys := make([]string, 0, len(m))
-	for k := range m {
-		keys = append(keys, k)
-	}
-	sort.Strings(keys)
-	return keys
-}
-
-// contains checks if a string is in a slice.
-func contains(s []string, target string) bool {
-	for _, v := range s {
-		if v == target {
-			return true
-		}
-	}
-	return false
-}
-
-// join helper for display.
-func join(parts []string) string {
-	return strings.Join(parts, ", ")
-}
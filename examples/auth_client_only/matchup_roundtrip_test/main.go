@@ -13,22 +13,22 @@ package main
 import (
 	"fmt"
 	"log"
-	"os"
 	"time"
 
 	"github.com/pmurley/go-fantrax/auth_client"
+	"github.com/pmurley/go-fantrax/config"
 	"github.com/pmurley/go-fantrax/models"
 )
 
 const testPeriod = 1
 
 func main() {
-	leagueID := os.Getenv("FANTRAX_LEAGUE_ID")
-	if leagueID == "" {
-		log.Fatal("Please set FANTRAX_LEAGUE_ID environment variable")
+	cfg, err := config.Require()
+	if err != nil {
+		log.Fatal(err)
 	}
 
-	client, err := auth_client.NewClient(leagueID, false)
+	client, err := cfg.AuthClient()
 	if err != nil {
 		log.Fatalf("Failed to create client: %v", err)
 	}
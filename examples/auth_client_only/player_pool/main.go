@@ -3,21 +3,22 @@ package main
 import (
 	"fmt"
 	"log"
-	"os"
 
 	"github.com/pmurley/go-fantrax/auth_client"
+	"github.com/pmurley/go-fantrax/config"
+	"github.com/pmurley/go-fantrax/models"
 )
 
 func main() {
-	// Get league ID from environment variable
-	leagueID := os.Getenv("FANTRAX_LEAGUE_ID")
-	if leagueID == "" {
-		log.Fatal("FANTRAX_LEAGUE_ID must be set")
+	// Get league ID from the config file or environment
+	cfg, err := config.Require()
+	if err != nil {
+		log.Fatal(err)
 	}
 
-	// Create client (caching disabled for fresh data)
+	// Create client from the config
 	fmt.Println("Creating auth client...")
-	client, err := auth_client.NewClient(leagueID, false)
+	client, err := cfg.AuthClient()
 	if err != nil {
 		log.Fatalf("Failed to create auth client: %v", err)
 	}
@@ -108,6 +109,10 @@ func main() {
 		fmt.Printf("NextOpponent:    %s\n", p.NextOpponent)
 		fmt.Printf("HeadshotURL:     %s\n", p.HeadshotURL)
 		fmt.Printf("Icons:           %v\n", p.Icons)
+		fmt.Printf("IconKinds:       %v\n", models.IconKinds(p.Icons))
+		if injury := p.IconTooltip(models.IconKindInjury); injury != "" {
+			fmt.Printf("Injury:          %s\n", injury)
+		}
 		fmt.Printf("Actions:         %v\n", p.Actions)
 	}
 
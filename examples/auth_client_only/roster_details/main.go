@@ -3,11 +3,9 @@ package main
 import (
 	"fmt"
 	"log"
-	"os"
 	"regexp"
 
-	"github.com/pmurley/go-fantrax/auth_client"
-
+	"github.com/pmurley/go-fantrax/config"
 	"github.com/pmurley/go-fantrax/models"
 )
 
@@ -18,14 +16,19 @@ func stripHTML(html string) string {
 }
 
 func main() {
-	// Get league ID from environment variable or use default
-	leagueID := os.Getenv("FANTRAX_LEAGUE_ID")
+	// Get league ID from the config file or environment, or use a default
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	leagueID := cfg.LeagueID
 	if leagueID == "" {
 		leagueID = "q8lydqf5m4u30rca" // Default from the example
+		cfg.LeagueID = leagueID
 	}
 
-	// Create client with caching enabled
-	client, err := auth_client.NewClient(leagueID, true)
+	// Create client (caching follows the config)
+	client, err := cfg.AuthClient()
 	if err != nil {
 		log.Fatalf("Failed to create auth client: %v", err)
 	}
@@ -6,28 +6,32 @@ import (
 	"os"
 	"time"
 
-	"github.com/pmurley/go-fantrax/auth_client"
+	"github.com/pmurley/go-fantrax/config"
 )
 
 func main() {
-	// Get league ID from environment variable
-	leagueID := os.Getenv("FANTRAX_LEAGUE_ID")
-	if leagueID == "" {
-		log.Fatal("Please set FANTRAX_LEAGUE_ID environment variable")
-	}
-
-	// Get team ID from environment variable
-	targetTeamID := os.Getenv("FANTRAX_TEAM_ID")
-	if targetTeamID == "" {
-		log.Fatal("Please set FANTRAX_TEAM_ID environment variable")
+	// Get league ID from the config file or environment
+	cfg, err := config.Require()
+	if err != nil {
+		log.Fatal(err)
 	}
+	leagueID := cfg.LeagueID
 
 	// Create authenticated client
-	client, err := auth_client.NewClient(leagueID, false)
+	client, err := cfg.AuthClient()
 	if err != nil {
 		log.Fatalf("Failed to create client: %v", err)
 	}
 
+	// Get team ID from environment variable, defaulting to your own team
+	targetTeamID := cfg.TeamID(os.Getenv("FANTRAX_TEAM_ID"))
+	if targetTeamID == "" {
+		targetTeamID, err = client.GetMyTeamID()
+		if err != nil {
+			log.Fatalf("Set FANTRAX_TEAM_ID or use an account with a team in the league: %v", err)
+		}
+	}
+
 	fmt.Println("=== Simple Roster Editing Example ===\n")
 	fmt.Printf("League ID: %s\n", leagueID)
 	fmt.Printf("Logged in as: %s\n\n", client.UserInfo.Username)
@@ -18,6 +18,7 @@ import (
 	"time"
 
 	"github.com/pmurley/go-fantrax/auth_client"
+	"github.com/pmurley/go-fantrax/config"
 	"github.com/pmurley/go-fantrax/models"
 )
 
@@ -29,9 +30,9 @@ var spreadsheetNameOverrides = map[string]string{
 }
 
 func main() {
-	leagueID := os.Getenv("FANTRAX_LEAGUE_ID")
-	if leagueID == "" {
-		log.Fatal("Please set FANTRAX_LEAGUE_ID environment variable")
+	cfg, err := config.Require()
+	if err != nil {
+		log.Fatal(err)
 	}
 
 	// Parse CLI flags
@@ -69,7 +70,7 @@ func main() {
 
 	// ── Step 2: Fetch current Fantrax setup ─────────────────────────────
 	fmt.Println("\n=== Fetching Fantrax league setup ===")
-	client, err := auth_client.NewClient(leagueID, false)
+	client, err := cfg.AuthClient()
 	if err != nil {
 		log.Fatalf("Failed to create client: %v", err)
 	}
@@ -113,17 +114,13 @@ func main() {
 	fmt.Println("All opponent names resolved to Fantrax IDs")
 
 	// ── Step 4: Build matchup pairs per period from CSV ─────────────────
-	// Find the "Agents" team ID for the bye
-	agentsID := ""
-	for _, team := range setup.Teams {
-		if team.Name == "Agents" {
-			agentsID = team.TeamID
-			break
-		}
-	}
-	if agentsID == "" {
-		log.Fatal("Could not find 'Agents' team in Fantrax setup")
+	// Find the ghost team (e.g. "Agents") that takes the bye
+	ghost, ok := auth_client.FindGhostTeam(setup)
+	if !ok {
+		log.Fatal("Could not find a ghost team in Fantrax setup")
 	}
+	agentsID := ghost.TeamID
+	fmt.Printf("Ghost team: %s (%s)\n", ghost.Name, ghost.Reason)
 
 	newMatchups := buildMatchupsFromCSV(csvSchedule, periodColumns, nameToID, agentsID)
 	fmt.Printf("Built matchups for %d periods\n", len(newMatchups))
@@ -201,6 +198,23 @@ func main() {
 		}
 	}
 	fmt.Printf("\nUploaded %d periods successfully\n", uploaded)
+
+	// ── Step 7: Re-fetch the setup and verify every period in range ────
+	fmt.Println("\n=== Verifying schedule ===")
+	expected := make(map[int][]models.MatchupPair)
+	for p := periodStart; p <= periodEnd; p++ {
+		if pairs, ok := newMatchups[p]; ok {
+			expected[p] = pairs
+		}
+	}
+	report, err := client.VerifySchedule(setup, expected)
+	if err != nil {
+		log.Fatalf("Failed to verify schedule: %v", err)
+	}
+	fmt.Print(report)
+	if !report.OK() {
+		log.Fatalf("%d periods don't match the CSV", report.Failed)
+	}
 }
 
 // scheduleCell represents one cell in the CSV: an opponent and whether the
@@ -367,7 +381,7 @@ func buildMatchupsFromCSV(
 
 		// Add the Agents bye matchup
 		if !seen[agentsID] {
-			pairs = append(pairs, models.MatchupPair{AwayTeamID: agentsID, HomeTeamID: "-1"})
+			pairs = append(pairs, auth_client.ByeMatchup(agentsID))
 		}
 
 		result[period] = pairs
@@ -8,17 +8,23 @@ import (
 	"strings"
 
 	"github.com/pmurley/go-fantrax"
+	"github.com/pmurley/go-fantrax/config"
 )
 
 func main() {
-	// Get league ID from environment variable or use default
-	leagueID := os.Getenv("FANTRAX_LEAGUE_ID")
+	// Get league ID from the config file or environment, or use a default
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	leagueID := cfg.LeagueID
 	if leagueID == "" {
 		leagueID = "q8lydqf5m4u30rca" // Default from the example
+		cfg.LeagueID = leagueID
 	}
 
-	// Create client with caching enabled
-	client, err := fantrax.NewClient(leagueID, true)
+	// Create client (caching follows the config)
+	client, err := cfg.PublicClient()
 	if err != nil {
 		log.Fatalf("Failed to create client: %v", err)
 	}
@@ -143,9 +149,9 @@ func generateMarkdown(info *fantrax.LeagueInfo) string {
 
 		if info.ScoringSystem.ScoringCategories.HITTING != nil {
 			sb.WriteString("#### Hitting\n\n")
-			for categoryID, categoryData := range info.ScoringSystem.ScoringCategories.HITTING {
-				if name, ok := categoryData["name"]; ok {
-					sb.WriteString(fmt.Sprintf("- **%s** (ID: `%s`)\n", name, categoryID))
+			for categoryID, category := range info.ScoringSystem.ScoringCategories.HITTING {
+				if category.Name != "" {
+					sb.WriteString(fmt.Sprintf("- **%s** (ID: `%s`)\n", category.Name, categoryID))
 				}
 			}
 			sb.WriteString("\n")
@@ -153,9 +159,9 @@ func generateMarkdown(info *fantrax.LeagueInfo) string {
 
 		if info.ScoringSystem.ScoringCategories.PITCHING != nil {
 			sb.WriteString("#### Pitching\n\n")
-			for categoryID, categoryData := range info.ScoringSystem.ScoringCategories.PITCHING {
-				if name, ok := categoryData["name"]; ok {
-					sb.WriteString(fmt.Sprintf("- **%s** (ID: `%s`)\n", name, categoryID))
+			for categoryID, category := range info.ScoringSystem.ScoringCategories.PITCHING {
+				if category.Name != "" {
+					sb.WriteString(fmt.Sprintf("- **%s** (ID: `%s`)\n", category.Name, categoryID))
 				}
 			}
 			sb.WriteString("\n")
@@ -0,0 +1,192 @@
+package fantrax
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// ExportFormatVersion identifies the layout of LeagueExport. It is bumped whenever a field
+// is renamed or removed so consumers can detect incompatible files.
+const ExportFormatVersion = "1"
+
+// LeagueExport is a self-contained, tool-neutral description of a league: teams, rosters,
+// roster slots, scoring settings and schedule. Players carry cross-reference IDs (Rotowire,
+// STATS, SportRadar) so valuation tools can join them to their own data without knowing
+// Fantrax IDs.
+type LeagueExport struct {
+	FormatVersion string              `json:"formatVersion"`
+	Source        string              `json:"source"` // Always "fantrax"
+	LeagueID      string              `json:"leagueId"`
+	LeagueName    string              `json:"leagueName"`
+	Sport         string              `json:"sport"`
+	Period        int                 `json:"period"` // Roster period the rosters were taken from
+	ScoringType   string              `json:"scoringType"`
+	DraftType     string              `json:"draftType"`
+	RosterSlots   ExportRosterSlots   `json:"rosterSlots"`
+	Scoring       []ExportScoringRule `json:"scoring"`
+	Teams         []ExportTeam        `json:"teams"`
+	Schedule      []ExportPeriod      `json:"schedule"`
+}
+
+// ExportRosterSlots describes roster size limits
+type ExportRosterSlots struct {
+	MaxTotal    int            `json:"maxTotal"`
+	MaxActive   int            `json:"maxActive"`
+	MaxReserve  int            `json:"maxReserve"`
+	ActiveByPos map[string]int `json:"activeByPosition"` // Position short name -> active slots
+}
+
+// ExportScoringRule is the points awarded for one stat category at one position group
+type ExportScoringRule struct {
+	Group    string  `json:"group"`    // e.g. "Hitting", "Pitching"
+	Position string  `json:"position"` // Position short name the rule applies to
+	Stat     string  `json:"stat"`     // Stat short name, e.g. "HR"
+	StatName string  `json:"statName"`
+	Points   float64 `json:"points"`
+}
+
+// ExportTeam is one fantasy team and its roster
+type ExportTeam struct {
+	ID       string         `json:"id"`
+	Name     string         `json:"name"`
+	Division string         `json:"division,omitempty"`
+	Roster   []ExportPlayer `json:"roster"`
+}
+
+// ExportPlayer is one rostered player
+type ExportPlayer struct {
+	FantraxID    string  `json:"fantraxId"`
+	Name         string  `json:"name,omitempty"`
+	Team         string  `json:"team,omitempty"`      // Professional team abbreviation
+	Positions    string  `json:"positions,omitempty"` // Eligible positions, comma separated
+	SlotPosition string  `json:"slotPosition"`        // Roster slot the player occupies
+	Status       string  `json:"status"`              // ACTIVE, RESERVE, MINORS or INJURED_RESERVE
+	RotowireID   *int    `json:"rotowireId,omitempty"`
+	StatsIncID   *int    `json:"statsIncId,omitempty"`
+	SportRadarID *string `json:"sportRadarId,omitempty"`
+}
+
+// ExportPeriod is one scoring period of the schedule
+type ExportPeriod struct {
+	Period   int             `json:"period"`
+	Matchups []ExportMatchup `json:"matchups"`
+}
+
+// ExportMatchup is one head-to-head pairing
+type ExportMatchup struct {
+	AwayTeamID string `json:"awayTeamId"`
+	HomeTeamID string `json:"homeTeamId"`
+}
+
+// BuildLeagueExport assembles a LeagueExport from data already fetched with the public API
+//
+// Parameters:
+//   - leagueID: The league the data belongs to
+//   - sport: The league's sport
+//   - info: League settings from GetLeagueInfo
+//   - rosters: Rosters from GetTeamRosters
+//   - players: Player details from GetPlayerIds (may be nil; players are then exported by ID only)
+func BuildLeagueExport(leagueID string, sport Sport, info *LeagueInfo, rosters *LeagueRosters, players map[string]Player) *LeagueExport {
+	export := &LeagueExport{
+		FormatVersion: ExportFormatVersion,
+		Source:        "fantrax",
+		LeagueID:      leagueID,
+		LeagueName:    info.LeagueName,
+		Sport:         string(sport),
+		Period:        rosters.Period,
+		ScoringType:   info.ScoringSystem.Type,
+		DraftType:     info.DraftType,
+		RosterSlots: ExportRosterSlots{
+			MaxTotal:    info.RosterInfo.MaxTotalPlayers,
+			MaxActive:   info.RosterInfo.MaxTotalActivePlayers,
+			MaxReserve:  info.RosterInfo.MaxTotalReservePlayers,
+			ActiveByPos: make(map[string]int, len(info.RosterInfo.PositionConstraints)),
+		},
+	}
+	for pos, constraint := range info.RosterInfo.PositionConstraints {
+		export.RosterSlots.ActiveByPos[pos] = constraint.MaxActive
+	}
+
+	for _, setting := range info.ScoringSystem.ScoringCategorySettings {
+		for _, config := range setting.Configs {
+			export.Scoring = append(export.Scoring, ExportScoringRule{
+				Group:    setting.Group.Name,
+				Position: config.Position.ShortName,
+				Stat:     config.ScoringCategory.ShortName,
+				StatName: config.ScoringCategory.Name,
+				Points:   config.Points,
+			})
+		}
+	}
+
+	teamIDs := make([]string, 0, len(info.TeamInfo))
+	for id := range info.TeamInfo {
+		teamIDs = append(teamIDs, id)
+	}
+	sort.Strings(teamIDs)
+
+	for _, id := range teamIDs {
+		team := info.TeamInfo[id]
+		exportTeam := ExportTeam{ID: id, Name: team.Name, Division: team.Division}
+		for _, item := range rosters.Rosters[id].RosterItems {
+			player := ExportPlayer{
+				FantraxID:    item.ID,
+				SlotPosition: item.Position,
+				Status:       item.Status,
+			}
+			if details, ok := players[item.ID]; ok {
+				player.Name = details.Name
+				player.Team = details.Team
+				player.Positions = details.Position
+				player.RotowireID = details.RotowireId
+				player.StatsIncID = details.StatsIncId
+				player.SportRadarID = details.SportRadarId
+			}
+			exportTeam.Roster = append(exportTeam.Roster, player)
+		}
+		export.Teams = append(export.Teams, exportTeam)
+	}
+
+	for _, period := range info.Matchups {
+		exportPeriod := ExportPeriod{Period: period.Period}
+		for _, m := range period.MatchupList {
+			exportPeriod.Matchups = append(exportPeriod.Matchups, ExportMatchup{AwayTeamID: m.Away.ID, HomeTeamID: m.Home.ID})
+		}
+		export.Schedule = append(export.Schedule, exportPeriod)
+	}
+
+	return export
+}
+
+// ExportLeague fetches league settings, current rosters and player details and assembles
+// them into a LeagueExport
+func (c *Client) ExportLeague(sport Sport) (*LeagueExport, error) {
+	info, err := c.GetLeagueInfo(c.LeagueId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export league: %w", err)
+	}
+
+	rosters, err := c.GetTeamRosters()
+	if err != nil {
+		return nil, fmt.Errorf("failed to export league: %w", err)
+	}
+
+	players, err := c.GetPlayerIds(sport)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export league: %w", err)
+	}
+
+	return BuildLeagueExport(c.LeagueId, sport, info, rosters, *players), nil
+}
+
+// WriteJSON writes the export as indented JSON
+func (e *LeagueExport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(e); err != nil {
+		return fmt.Errorf("failed to write league export: %w", err)
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package fantrax
 import (
 	"crypto/md5"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -21,6 +22,9 @@ type Cache interface {
 type FileCache struct {
 	CacheDir string
 	TTL      time.Duration
+
+	// Cipher, when set, encrypts cached responses at rest
+	Cipher *CacheCipher
 }
 
 // NewFileCache creates a new file-based cache
@@ -73,6 +77,9 @@ func (fc *FileCache) Get(key string) ([]byte, bool) {
 	if err != nil {
 		return nil, false // Failed to read
 	}
+	if data, err = fc.Cipher.Open(data); err != nil {
+		return nil, false // Encrypted with another key
+	}
 
 	return data, true
 }
@@ -80,5 +87,65 @@ func (fc *FileCache) Get(key string) ([]byte, bool) {
 // Set stores data in the cache
 func (fc *FileCache) Set(key string, data []byte) error {
 	cacheFile := filepath.Join(fc.CacheDir, key+".json")
-	return os.WriteFile(cacheFile, data, 0644)
+	if fc.Cipher == nil {
+		return os.WriteFile(cacheFile, data, 0644)
+	}
+	sealed, err := fc.Cipher.Seal(data)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(cacheFile, sealed, 0600)
+}
+
+// CacheValidators holds the HTTP validators returned with a cached response, used to
+// revalidate it with a conditional request once the entry has expired
+type CacheValidators struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// IsZero reports whether no validators were recorded
+func (v CacheValidators) IsZero() bool {
+	return v.ETag == "" && v.LastModified == ""
+}
+
+// GetStale retrieves cached data regardless of its age, along with any validators stored
+// for it
+func (fc *FileCache) GetStale(key string) ([]byte, CacheValidators, bool) {
+	var validators CacheValidators
+	data, err := os.ReadFile(filepath.Join(fc.CacheDir, key+".json"))
+	if err != nil {
+		return nil, validators, false
+	}
+	if data, err = fc.Cipher.Open(data); err != nil {
+		return nil, validators, false
+	}
+	if meta, err := os.ReadFile(filepath.Join(fc.CacheDir, key+".meta")); err == nil {
+		_ = json.Unmarshal(meta, &validators)
+	}
+	return data, validators, true
+}
+
+// SetValidators stores the HTTP validators for a cache entry. Empty validators remove any
+// previously stored ones.
+func (fc *FileCache) SetValidators(key string, validators CacheValidators) error {
+	metaFile := filepath.Join(fc.CacheDir, key+".meta")
+	if validators.IsZero() {
+		if err := os.Remove(metaFile); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	data, err := json.Marshal(validators)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(metaFile, data, 0644)
+}
+
+// Touch marks a cache entry as fresh again without rewriting it, after the server confirmed
+// it is unchanged
+func (fc *FileCache) Touch(key string) error {
+	now := time.Now()
+	return os.Chtimes(filepath.Join(fc.CacheDir, key+".json"), now, now)
 }
@@ -0,0 +1,103 @@
+package fantrax
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Run `go test . -run TestGoldenFixtures -update` to rewrite the golden files after an
+// intentional change. See auth_client/testdata/README.md for adding fixtures.
+var updateGolden = flag.Bool("update", false, "rewrite golden files from the current decoder output")
+
+// goldenDecoders maps each fixture directory under testdata/fixtures to the type its files
+// decode into. Every fixture directory must have an entry.
+var goldenDecoders = map[string]func() interface{}{
+	"getLeagueInfo":   func() interface{} { return &LeagueInfo{} },
+	"getTeamRosters":  func() interface{} { return &LeagueRosters{} },
+	"getDraftResults": func() interface{} { return &DraftResults{} },
+}
+
+// goldenOutput is what gets compared: the decoded value plus any schema drift between the
+// fixture and the Go types, so a fixture exposing an unmodeled field shows up in review
+type goldenOutput struct {
+	Result interface{}   `json:"result"`
+	Drift  []SchemaDrift `json:"drift,omitempty"`
+}
+
+// TestGoldenFixtures decodes every public API fixture and compares the result with the
+// checked-in golden output
+func TestGoldenFixtures(t *testing.T) {
+	dirs, err := os.ReadDir(filepath.Join("testdata", "fixtures"))
+	if err != nil {
+		t.Fatalf("failed to read fixture corpus: %v", err)
+	}
+
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		newTarget, ok := goldenDecoders[dir.Name()]
+		if !ok {
+			t.Errorf("fixture directory %q has no decoder registered in goldenDecoders", dir.Name())
+			continue
+		}
+
+		files, err := os.ReadDir(filepath.Join("testdata", "fixtures", dir.Name()))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", dir.Name(), err)
+		}
+		for _, file := range files {
+			if file.IsDir() {
+				continue
+			}
+			t.Run(dir.Name()+"/"+file.Name(), func(t *testing.T) {
+				data, err := os.ReadFile(filepath.Join("testdata", "fixtures", dir.Name(), file.Name()))
+				if err != nil {
+					t.Fatal(err)
+				}
+				target := newTarget()
+				if err := json.Unmarshal(data, target); err != nil {
+					t.Fatalf("decode failed: %v", err)
+				}
+				drift, err := DetectSchemaDrift(dir.Name(), data, target)
+				if err != nil {
+					t.Fatal(err)
+				}
+				compareGolden(t, filepath.Join("testdata", "golden", dir.Name(),
+					strings.TrimSuffix(file.Name(), filepath.Ext(file.Name()))+".json"),
+					goldenOutput{Result: target, Drift: drift})
+			})
+		}
+	}
+}
+
+func compareGolden(t *testing.T, goldenPath string, result interface{}) {
+	got, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		t.Fatalf("failed to marshal result: %v", err)
+	}
+	got = append(got, '\n')
+
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(goldenPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(goldenPath, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatalf("missing golden file (run with -update to create it): %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("decoded output differs from %s (run with -update if the change is intended)\n got: %s", goldenPath, got)
+	}
+}
@@ -0,0 +1,101 @@
+// Package fixtures prepares captured Fantrax responses for the test fixture corpus.
+package fixtures
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Redacted replaces the string values removed by Sanitize
+const Redacted = "redacted"
+
+// sensitiveKeys are the JSON keys whose values identify a person or an account. They are
+// matched case-insensitively at any depth.
+var sensitiveKeys = map[string]bool{
+	"email":           true,
+	"fname":           true,
+	"lname":           true,
+	"username":        true,
+	"userid":          true,
+	"usersecretid":    true,
+	"owners":          true,
+	"ownername":       true,
+	"commissioners":   true,
+	"pushids":         true,
+	"s1":              true,
+	"s2":              true,
+	"s3":              true,
+	"phone":           true,
+	"locationdisplay": true,
+}
+
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+
+// Sanitize removes personal data from a captured JSON response so it can be committed as a
+// fixture. Values under sensitive keys (emails, names of owners, user IDs, ...) are replaced
+// while keeping their JSON type, email addresses anywhere are replaced, and the league ID is
+// replaced with "fixture". The output is indented with sorted keys so fixtures diff cleanly.
+func Sanitize(data []byte, leagueID string) ([]byte, error) {
+	var doc interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	doc = sanitizeValue(doc, false, leagueID)
+
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode sanitized response: %w", err)
+	}
+	return append(out, '\n'), nil
+}
+
+// SanitizeHTML removes email addresses and the league ID from a captured HTML page
+func SanitizeHTML(html []byte, leagueID string) []byte {
+	out := emailPattern.ReplaceAll(html, []byte(Redacted))
+	if leagueID != "" {
+		out = bytes.ReplaceAll(out, []byte(leagueID), []byte("fixture"))
+	}
+	return out
+}
+
+func sanitizeValue(v interface{}, redact bool, leagueID string) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			val[k] = sanitizeValue(val[k], redact || sensitiveKeys[strings.ToLower(k)], leagueID)
+		}
+		return val
+	case []interface{}:
+		for i := range val {
+			val[i] = sanitizeValue(val[i], redact, leagueID)
+		}
+		return val
+	case string:
+		if redact {
+			return Redacted
+		}
+		if leagueID != "" {
+			val = strings.ReplaceAll(val, leagueID, "fixture")
+		}
+		return emailPattern.ReplaceAllString(val, Redacted)
+	case json.Number:
+		if redact {
+			return json.Number("0")
+		}
+		return val
+	default:
+		return val
+	}
+}
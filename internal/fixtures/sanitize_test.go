@@ -0,0 +1,26 @@
+package fixtures
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSanitize(t *testing.T) {
+	in := []byte(`{"leagueId":"abc123","userInfo":{"email":"owner@example.com","userId":"u1","fName":"Pat"},
+		"teamHeadingInfo":{"owners":{"value":"Pat Smith"}},"rows":[{"name":"Casey Fielder","note":"mail me at x@y.org"}],
+		"refUrl":"https://www.fantrax.com/fantasy/league/abc123/home","pushIds":[12,13]}`)
+
+	out, err := Sanitize(in, "abc123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := string(out)
+	for _, leaked := range []string{"owner@example.com", "x@y.org", "Pat", "u1", "abc123", "12"} {
+		if strings.Contains(s, leaked) {
+			t.Errorf("sanitized output still contains %q:\n%s", leaked, s)
+		}
+	}
+	if !strings.Contains(s, "Casey Fielder") {
+		t.Errorf("player names should be kept:\n%s", s)
+	}
+}
@@ -1,6 +1,13 @@
 package fantrax
 
-import "fmt"
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/pmurley/go-fantrax/models"
+)
 
 // LeagueInfo represents the response from the getLeagueInfo endpoint
 type LeagueInfo struct {
@@ -71,10 +78,11 @@ type ScoringSystem struct {
 	Type                    string                   `json:"type"`
 }
 
-// ScoringCategories contains scoring categories organized by group
+// ScoringCategories contains scoring categories organized by group, keyed by category ID.
+// See LeagueInfo.ScoringCatalog for a flattened view with point values.
 type ScoringCategories struct {
-	HITTING  map[string]map[string]string `json:"HITTING"`
-	PITCHING map[string]map[string]string `json:"PITCHING"`
+	HITTING  map[string]ScoringCategory `json:"HITTING"`
+	PITCHING map[string]ScoringCategory `json:"PITCHING"`
 }
 
 // ScoringCategorySetting represents a group of scoring configurations
@@ -122,16 +130,146 @@ type TeamInfo struct {
 	ID       string `json:"id"`
 }
 
-// GetLeagueInfo fetches draft results for a specific league
-func (c *Client) GetLeagueInfo(leagueID string) (*LeagueInfo, error) {
+// Team converts the team to the canonical models.Team
+func (t TeamInfo) Team() models.Team {
+	return models.Team{ID: t.ID, Name: t.Name, Division: t.Division}
+}
+
+// Teams returns the league's teams as a registry, ordered by name
+func (i *LeagueInfo) Teams() *models.TeamRegistry {
+	teams := make([]models.Team, 0, len(i.TeamInfo))
+	for _, team := range i.TeamInfo {
+		teams = append(teams, team.Team())
+	}
+	sort.Slice(teams, func(a, b int) bool { return teams[a].Name < teams[b].Name })
+	return models.NewTeamRegistry(teams)
+}
+
+// LeagueInfoField names a top-level section of the getLeagueInfo response
+type LeagueInfoField string
+
+const (
+	LeagueInfoMatchups       LeagueInfoField = "matchups"
+	LeagueInfoRosterInfo     LeagueInfoField = "rosterInfo"
+	LeagueInfoPlayerStatuses LeagueInfoField = "playerInfo"
+	LeagueInfoPoolSettings   LeagueInfoField = "poolSettings"
+	LeagueInfoScoringSystem  LeagueInfoField = "scoringSystem"
+	LeagueInfoTeamInfo       LeagueInfoField = "teamInfo"
+	LeagueInfoDraftSettings  LeagueInfoField = "draftSettings"
+)
+
+// LeagueInfoOption is a functional option for configuring GetLeagueInfo
+type LeagueInfoOption func(*leagueInfoConfig)
+
+type leagueInfoConfig struct {
+	fields []LeagueInfoField
+}
+
+// WithLeagueInfoFields decodes only the given sections of the response. The league name and
+// draft type are always decoded; every other section not listed is left at its zero value.
+//
+// The endpoint has no server-side field selection, so the full response is still downloaded
+// (and cached), but the sections not listed are skipped while decoding: a schedule tool asking
+// only for LeagueInfoMatchups never builds the large player and scoring tables.
+func WithLeagueInfoFields(fields ...LeagueInfoField) LeagueInfoOption {
+	return func(c *leagueInfoConfig) {
+		c.fields = append(c.fields, fields...)
+	}
+}
+
+// GetLeagueInfoRaw fetches the getLeagueInfo response without parsing it
+func (c *Client) GetLeagueInfoRaw(leagueID string) (json.RawMessage, error) {
 	endpoint := "/general/getLeagueInfo"
 	params := map[string]string{"leagueId": leagueID}
 
-	var results LeagueInfo
-	err := c.fetchWithCache(endpoint, params, &results)
+	var raw json.RawMessage
+	err := c.fetchWithCache(endpoint, params, &raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league info: %w", err)
+	}
+
+	return raw, nil
+}
+
+// GetLeagueInfo fetches the league's settings, schedule, rosters configuration, and teams.
+// When caching is enabled, an expired cache entry is revalidated with the server (ETag /
+// Last-Modified) instead of being downloaded again.
+//
+// Parameters:
+//   - leagueID: The league to fetch
+//   - opts: Optional WithLeagueInfoFields to decode only some sections
+func (c *Client) GetLeagueInfo(leagueID string, opts ...LeagueInfoOption) (*LeagueInfo, error) {
+	config := &leagueInfoConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	raw, err := c.GetLeagueInfoRaw(leagueID)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := decodeLeagueInfo(raw, config.fields)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get league info: %w", err)
 	}
+	c.checkSchema("/general/getLeagueInfo", raw, results)
 
+	return results, nil
+}
+
+// decodeLeagueInfo unmarshals the response, restricted to the given sections when any are
+// given. Sections not asked for are skipped by the decoder rather than decoded.
+func decodeLeagueInfo(raw []byte, fields []LeagueInfoField) (*LeagueInfo, error) {
+	var results LeagueInfo
+	if len(fields) == 0 {
+		if err := json.Unmarshal(raw, &results); err != nil {
+			return nil, err
+		}
+		return &results, nil
+	}
+
+	keep := map[string]bool{"leagueName": true, "draftType": true}
+	for _, field := range fields {
+		keep[string(field)] = true
+	}
+	sections := results.sections()
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	if token, err := decoder.Token(); err != nil {
+		return nil, err
+	} else if token != json.Delim('{') {
+		return nil, fmt.Errorf("league info is not a JSON object")
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := token.(string)
+		target, known := sections[key]
+		if !known || !keep[key] {
+			var skipped json.RawMessage
+			target = &skipped
+		}
+		if err := decoder.Decode(target); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", key, err)
+		}
+	}
 	return &results, nil
 }
+
+// sections maps each top-level key of the getLeagueInfo response to the field it fills
+func (i *LeagueInfo) sections() map[string]interface{} {
+	return map[string]interface{}{
+		"leagueName":                     &i.LeagueName,
+		string(LeagueInfoDraftSettings):  &i.DraftSettings,
+		string(LeagueInfoMatchups):       &i.Matchups,
+		string(LeagueInfoRosterInfo):     &i.RosterInfo,
+		string(LeagueInfoPlayerStatuses): &i.PlayerStatuses,
+		string(LeagueInfoPoolSettings):   &i.PoolSettings,
+		string(LeagueInfoScoringSystem):  &i.ScoringSystem,
+		string(LeagueInfoTeamInfo):       &i.TeamInfo,
+		"draftType":                      &i.DraftType,
+	}
+}
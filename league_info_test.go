@@ -0,0 +1,32 @@
+package fantrax
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDecodeLeagueInfoFields(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "fixtures", "getLeagueInfo", "handbuilt_points_league_mlb.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	full, err := decodeLeagueInfo(data, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := decodeLeagueInfo(data, []LeagueInfoField{LeagueInfoMatchups, LeagueInfoTeamInfo})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.LeagueName != full.LeagueName || info.DraftType != full.DraftType {
+		t.Errorf("league name and draft type should always be decoded: %q %q", info.LeagueName, info.DraftType)
+	}
+	if len(info.Matchups) != len(full.Matchups) || len(info.TeamInfo) != len(full.TeamInfo) || len(info.Matchups) == 0 {
+		t.Errorf("got %d periods and %d teams, want %d and %d", len(info.Matchups), len(info.TeamInfo), len(full.Matchups), len(full.TeamInfo))
+	}
+	if info.ScoringSystem.Type != "" || info.PlayerStatuses != nil || info.RosterInfo.MaxTotalPlayers != 0 {
+		t.Errorf("sections not asked for were decoded: %+v", info)
+	}
+}
@@ -0,0 +1,200 @@
+package fantrax
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// rulesFingerprintPrefix starts the comment that records which settings a rules document
+// was generated from
+const rulesFingerprintPrefix = "<!-- fantrax-settings: "
+
+// RulesFingerprint returns a hash of the settings that appear in the league rules document.
+// Player eligibility is left out, so the fingerprint only changes when the league's
+// settings, teams, or schedule do.
+func (i *LeagueInfo) RulesFingerprint() string {
+	settings := struct {
+		LeagueName    string
+		DraftType     string
+		DraftSettings DraftSettings
+		Matchups      []MatchupPeriod
+		RosterInfo    RosterInfo
+		PoolSettings  PoolSettings
+		Scoring       []ScoringCategoryInfo
+		Type          string
+		TeamInfo      map[string]TeamInfo
+	}{i.LeagueName, i.DraftType, i.DraftSettings, i.Matchups, i.RosterInfo, i.PoolSettings,
+		i.ScoringCatalog().Categories, i.ScoringSystem.Type, i.TeamInfo}
+	data, _ := json.Marshal(settings)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// RulesMarkdown renders the league's settings as a human-readable rules document in
+// Markdown: format, roster limits, scoring, teams, and schedule
+//
+// Only what the public getLeagueInfo endpoint publishes is covered. Waiver, trade, and
+// transaction settings aren't included there, so add them to the document by hand.
+func (i *LeagueInfo) RulesMarkdown() string {
+	var b strings.Builder
+	name := i.LeagueName
+	if name == "" {
+		name = "League"
+	}
+	fmt.Fprintf(&b, "# %s Rules\n\n", name)
+
+	b.WriteString("## Format\n\n")
+	fmt.Fprintf(&b, "- Scoring: %s\n", humanizeSetting(i.ScoringSystem.Type))
+	draftType := i.DraftType
+	if draftType == "" {
+		draftType = i.DraftSettings.DraftType
+	}
+	if draftType != "" {
+		fmt.Fprintf(&b, "- Draft: %s\n", humanizeSetting(draftType))
+	}
+	if i.PoolSettings.DuplicatePlayerType != "" {
+		fmt.Fprintf(&b, "- Player ownership: %s\n", humanizeSetting(i.PoolSettings.DuplicatePlayerType))
+	}
+	if i.PoolSettings.PlayerSourceType != "" {
+		fmt.Fprintf(&b, "- Player pool: %s\n", humanizeSetting(i.PoolSettings.PlayerSourceType))
+	}
+	fmt.Fprintf(&b, "- Teams: %d\n\n", len(i.TeamInfo))
+
+	b.WriteString("## Rosters\n\n")
+	fmt.Fprintf(&b, "- Maximum players: %d\n", i.RosterInfo.MaxTotalPlayers)
+	fmt.Fprintf(&b, "- Maximum active players: %d\n", i.RosterInfo.MaxTotalActivePlayers)
+	fmt.Fprintf(&b, "- Maximum reserve players: %d\n\n", i.RosterInfo.MaxTotalReservePlayers)
+	if len(i.RosterInfo.PositionConstraints) > 0 {
+		b.WriteString("| Position | Active slots |\n|---|---|\n")
+		positions := make([]string, 0, len(i.RosterInfo.PositionConstraints))
+		for pos := range i.RosterInfo.PositionConstraints {
+			positions = append(positions, pos)
+		}
+		sort.Strings(positions)
+		for _, pos := range positions {
+			fmt.Fprintf(&b, "| %s | %d |\n", pos, i.RosterInfo.PositionConstraints[pos].MaxActive)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Scoring\n\n")
+	catalog := i.ScoringCatalog()
+	group := ""
+	for n, category := range catalog.Categories {
+		if category.Group != group {
+			group = category.Group
+			groupName := category.GroupName
+			if groupName == "" {
+				groupName = humanizeSetting(group)
+			}
+			fmt.Fprintf(&b, "### %s\n\n", groupName)
+			if len(category.Points) > 0 {
+				b.WriteString("| Stat | Category | Points |\n|---|---|---|\n")
+			} else {
+				b.WriteString("| Stat | Category |\n|---|---|\n")
+			}
+		}
+		if len(category.Points) > 0 {
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", category.ShortName, category.Name, formatRulePoints(category.Points))
+		} else {
+			fmt.Fprintf(&b, "| %s | %s |\n", category.ShortName, category.Name)
+		}
+		if n+1 == len(catalog.Categories) || catalog.Categories[n+1].Group != group {
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("## Teams\n\n")
+	teams := make([]TeamInfo, 0, len(i.TeamInfo))
+	for _, team := range i.TeamInfo {
+		teams = append(teams, team)
+	}
+	sort.Slice(teams, func(a, c int) bool {
+		if teams[a].Division != teams[c].Division {
+			return teams[a].Division < teams[c].Division
+		}
+		return teams[a].Name < teams[c].Name
+	})
+	division := ""
+	for n, team := range teams {
+		if team.Division != "" && (n == 0 || team.Division != division) {
+			division = team.Division
+			fmt.Fprintf(&b, "\n**%s**\n\n", division)
+		}
+		fmt.Fprintf(&b, "- %s\n", team.Name)
+	}
+	b.WriteString("\n")
+
+	b.WriteString("## Schedule\n\n")
+	fmt.Fprintf(&b, "%d scoring periods.\n\n", len(i.Matchups))
+	for _, period := range i.Matchups {
+		fmt.Fprintf(&b, "### Period %d\n\n", period.Period)
+		for _, m := range period.MatchupList {
+			fmt.Fprintf(&b, "- %s at %s\n", m.Away.Name, m.Home.Name)
+		}
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintf(&b, "%s%s -->\n", rulesFingerprintPrefix, i.RulesFingerprint())
+	return b.String()
+}
+
+// WriteRules writes the league rules document to path, regenerating it only when the
+// settings have changed since it was last written
+//
+// Returns whether the file was written.
+func (i *LeagueInfo) WriteRules(path string) (bool, error) {
+	marker := []byte(rulesFingerprintPrefix + i.RulesFingerprint() + " -->")
+	if existing, err := os.ReadFile(path); err == nil && bytes.Contains(existing, marker) {
+		return false, nil
+	} else if err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("failed to read rules document: %w", err)
+	}
+
+	if err := os.WriteFile(path, []byte(i.RulesMarkdown()), 0644); err != nil {
+		return false, fmt.Errorf("failed to write rules document: %w", err)
+	}
+	return true, nil
+}
+
+// formatRulePoints shows one value when every position scores the same, otherwise the
+// value at each position
+func formatRulePoints(points map[string]float64) string {
+	positions := make([]string, 0, len(points))
+	for pos := range points {
+		positions = append(positions, pos)
+	}
+	sort.Strings(positions)
+
+	same := true
+	for _, pos := range positions {
+		if points[pos] != points[positions[0]] {
+			same = false
+			break
+		}
+	}
+	if same {
+		return strconv.FormatFloat(points[positions[0]], 'f', -1, 64)
+	}
+	parts := make([]string, len(positions))
+	for n, pos := range positions {
+		parts[n] = fmt.Sprintf("%s: %s", pos, strconv.FormatFloat(points[pos], 'f', -1, 64))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// humanizeSetting turns a setting code such as "ONE_TEAM" into "One team"
+func humanizeSetting(code string) string {
+	if code == "" {
+		return "Unknown"
+	}
+	words := strings.ToLower(strings.ReplaceAll(code, "_", " "))
+	return strings.ToUpper(words[:1]) + words[1:]
+}
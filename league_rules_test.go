@@ -0,0 +1,38 @@
+package fantrax
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLeagueRules(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "fixtures", "getLeagueInfo", "handbuilt_points_league_mlb.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := decodeLeagueInfo(data, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc := info.RulesMarkdown()
+	for _, want := range []string{"## Rosters", "## Scoring", "| HR |", "## Schedule", "### Period 1", "Snake"} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("rules document is missing %q", want)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "rules.md")
+	if written, err := info.WriteRules(path); err != nil || !written {
+		t.Fatalf("expected the first write to happen: %v %v", written, err)
+	}
+	if written, err := info.WriteRules(path); err != nil || written {
+		t.Fatalf("expected unchanged settings to skip the write: %v %v", written, err)
+	}
+	info.RosterInfo.MaxTotalPlayers++
+	if written, err := info.WriteRules(path); err != nil || !written {
+		t.Fatalf("expected changed settings to rewrite: %v %v", written, err)
+	}
+}
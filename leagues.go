@@ -0,0 +1,56 @@
+package fantrax
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// UserLeagues represents the response from the getLeagues endpoint
+type UserLeagues struct {
+	Leagues []UserLeague `json:"leagues"`
+}
+
+// UserLeague is one league the token's user belongs to, along with the user's team in it
+type UserLeague struct {
+	LeagueName string `json:"leagueName"`
+	LeagueID   string `json:"leagueId"`
+	TeamName   string `json:"teamName"`
+	TeamID     string `json:"teamId"`
+	Sport      string `json:"sport"`
+}
+
+// GetLeaguesRaw fetches the getLeagues response without parsing it. The client must be
+// created with WithAPIToken.
+func (c *Client) GetLeaguesRaw() (json.RawMessage, error) {
+	if c.APIToken == "" {
+		return nil, fmt.Errorf("failed to get leagues: client has no API token (use WithAPIToken)")
+	}
+
+	endpoint := "/general/getLeagues"
+	params := map[string]string{}
+
+	var raw []byte
+	// Not cached: the result is specific to the token, which is not part of the cache key
+	err := c.makeRequestRaw(endpoint, params, &raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leagues: %w", err)
+	}
+
+	return raw, nil
+}
+
+// GetLeagues fetches every league and team belonging to the user identified by the client's
+// API token. The client must be created with WithAPIToken.
+func (c *Client) GetLeagues() (*UserLeagues, error) {
+	raw, err := c.GetLeaguesRaw()
+	if err != nil {
+		return nil, err
+	}
+
+	var leagues UserLeagues
+	if err := c.decode("/general/getLeagues", raw, &leagues); err != nil {
+		return nil, fmt.Errorf("failed to parse leagues: %w", err)
+	}
+
+	return &leagues, nil
+}
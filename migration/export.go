@@ -0,0 +1,281 @@
+// Package migration moves a league from another platform onto Fantrax. It reads roster,
+// draft, and schedule exports, matches teams and players to their Fantrax IDs, and replays
+// the rosters and schedule into a Fantrax league as commissioner.
+package migration
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Platform is the site a league export came from
+type Platform string
+
+const (
+	PlatformESPN  Platform = "espn"
+	PlatformYahoo Platform = "yahoo"
+)
+
+// ExportPlayer is one rostered player in a source league
+type ExportPlayer struct {
+	Team     string // Fantasy team name in the source league
+	Name     string
+	ProTeam  string // Professional team abbreviation
+	Position string
+	ID       string // Player ID in the system named by PlanOptions.IDType, if the export has one
+}
+
+// ExportPick is one draft pick in a source league
+type ExportPick struct {
+	Round   int
+	Pick    int
+	Team    string
+	Name    string
+	ProTeam string
+	ID      string
+}
+
+// ExportMatchup is one scheduled matchup in a source league, by fantasy team name
+type ExportMatchup struct {
+	Period int
+	Away   string
+	Home   string // Empty for a bye
+}
+
+// LeagueExport is everything read from a source league. Any part may be empty.
+type LeagueExport struct {
+	Platform Platform
+	Rosters  []ExportPlayer
+	Draft    []ExportPick
+	Schedule []ExportMatchup
+}
+
+// Columns tells the loaders which CSV columns hold which values. Names are matched
+// case-insensitively against the header row; an empty name means the column is absent.
+type Columns struct {
+	Team     string
+	Player   string
+	ProTeam  string
+	Position string
+	ID       string // Player ID column; see PlanOptions.IDType
+
+	// Draft exports
+	Round string
+	Pick  string
+
+	// Schedule exports
+	Period string
+	Away   string
+	Home   string
+}
+
+// ESPNColumns and YahooColumns are starting points for the sites' CSV downloads of league
+// rosters, draft results, and schedules. The sites change their exports from time to time,
+// so copy and adjust a preset if your file's headers differ.
+var (
+	ESPNColumns = Columns{
+		Team:     "Team",
+		Player:   "Player",
+		ProTeam:  "Pro Team",
+		Position: "Pos",
+		Round:    "Round",
+		Pick:     "Pick",
+		Period:   "Matchup Period",
+		Away:     "Away",
+		Home:     "Home",
+	}
+	YahooColumns = Columns{
+		Team:     "Fantasy Team",
+		Player:   "Player",
+		ProTeam:  "Team",
+		Position: "Position",
+		Round:    "Round",
+		Pick:     "Pick",
+		Period:   "Week",
+		Away:     "Team 1",
+		Home:     "Team 2",
+	}
+)
+
+// ColumnsFor returns the preset columns for a platform
+func ColumnsFor(platform Platform) (Columns, error) {
+	switch platform {
+	case PlatformESPN:
+		return ESPNColumns, nil
+	case PlatformYahoo:
+		return YahooColumns, nil
+	}
+	return Columns{}, fmt.Errorf("unknown platform %q", platform)
+}
+
+// csvTable is a CSV file with its header indexed for column lookups
+type csvTable struct {
+	kind    string
+	columns map[string]int
+	records [][]string
+}
+
+func readCSVTable(r io.Reader, kind string) (*csvTable, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s CSV: %w", kind, err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%s CSV is empty", kind)
+	}
+
+	table := &csvTable{kind: kind, columns: make(map[string]int), records: records[1:]}
+	for i, name := range records[0] {
+		table.columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	return table, nil
+}
+
+// column returns the index of a named column, -1 for an unnamed one, or an error when a
+// required column is missing
+func (t *csvTable) column(name string, required bool) (int, error) {
+	if name == "" {
+		if required {
+			return -1, fmt.Errorf("%s CSV column mapping is incomplete", t.kind)
+		}
+		return -1, nil
+	}
+	i, ok := t.columns[strings.ToLower(name)]
+	if !ok {
+		if required {
+			return -1, fmt.Errorf("%s CSV has no %q column", t.kind, name)
+		}
+		return -1, nil
+	}
+	return i, nil
+}
+
+func cell(record []string, col int) string {
+	if col < 0 || col >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[col])
+}
+
+// LoadRostersCSV reads a roster export with one row per rostered player
+func LoadRostersCSV(r io.Reader, columns Columns) ([]ExportPlayer, error) {
+	table, err := readCSVTable(r, "roster")
+	if err != nil {
+		return nil, err
+	}
+	teamCol, err := table.column(columns.Team, true)
+	if err != nil {
+		return nil, err
+	}
+	playerCol, err := table.column(columns.Player, true)
+	if err != nil {
+		return nil, err
+	}
+	proTeamCol, _ := table.column(columns.ProTeam, false)
+	positionCol, _ := table.column(columns.Position, false)
+	idCol, _ := table.column(columns.ID, false)
+
+	var players []ExportPlayer
+	for _, record := range table.records {
+		player := ExportPlayer{
+			Team:     cell(record, teamCol),
+			Name:     cell(record, playerCol),
+			ProTeam:  cell(record, proTeamCol),
+			Position: cell(record, positionCol),
+			ID:       cell(record, idCol),
+		}
+		if player.Team == "" || player.Name == "" {
+			continue
+		}
+		players = append(players, player)
+	}
+	return players, nil
+}
+
+// LoadDraftCSV reads a draft results export with one row per pick
+func LoadDraftCSV(r io.Reader, columns Columns) ([]ExportPick, error) {
+	table, err := readCSVTable(r, "draft")
+	if err != nil {
+		return nil, err
+	}
+	teamCol, err := table.column(columns.Team, true)
+	if err != nil {
+		return nil, err
+	}
+	playerCol, err := table.column(columns.Player, true)
+	if err != nil {
+		return nil, err
+	}
+	roundCol, _ := table.column(columns.Round, false)
+	pickCol, _ := table.column(columns.Pick, false)
+	proTeamCol, _ := table.column(columns.ProTeam, false)
+	idCol, _ := table.column(columns.ID, false)
+
+	var picks []ExportPick
+	for line, record := range table.records {
+		pick := ExportPick{
+			Team:    cell(record, teamCol),
+			Name:    cell(record, playerCol),
+			ProTeam: cell(record, proTeamCol),
+			ID:      cell(record, idCol),
+		}
+		if pick.Team == "" || pick.Name == "" {
+			continue
+		}
+		if pick.Round, err = optionalInt(cell(record, roundCol)); err != nil {
+			return nil, fmt.Errorf("draft CSV line %d: invalid round: %w", line+2, err)
+		}
+		if pick.Pick, err = optionalInt(cell(record, pickCol)); err != nil {
+			return nil, fmt.Errorf("draft CSV line %d: invalid pick: %w", line+2, err)
+		}
+		picks = append(picks, pick)
+	}
+	return picks, nil
+}
+
+// LoadScheduleCSV reads a schedule export with one row per matchup
+func LoadScheduleCSV(r io.Reader, columns Columns) ([]ExportMatchup, error) {
+	table, err := readCSVTable(r, "schedule")
+	if err != nil {
+		return nil, err
+	}
+	periodCol, err := table.column(columns.Period, true)
+	if err != nil {
+		return nil, err
+	}
+	awayCol, err := table.column(columns.Away, true)
+	if err != nil {
+		return nil, err
+	}
+	homeCol, _ := table.column(columns.Home, false)
+
+	var matchups []ExportMatchup
+	for line, record := range table.records {
+		matchup := ExportMatchup{Away: cell(record, awayCol), Home: cell(record, homeCol)}
+		if matchup.Away == "" {
+			continue
+		}
+		// Periods are often written as "Week 3" or "Matchup 3"
+		period := cell(record, periodCol)
+		if fields := strings.Fields(period); len(fields) > 0 {
+			period = fields[len(fields)-1]
+		}
+		if matchup.Period, err = strconv.Atoi(period); err != nil {
+			return nil, fmt.Errorf("schedule CSV line %d: invalid period %q", line+2, cell(record, periodCol))
+		}
+		matchups = append(matchups, matchup)
+	}
+	return matchups, nil
+}
+
+func optionalInt(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(s)
+}
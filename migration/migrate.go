@@ -0,0 +1,289 @@
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pmurley/go-fantrax"
+	"github.com/pmurley/go-fantrax/auth_client"
+	"github.com/pmurley/go-fantrax/models"
+	"github.com/pmurley/go-fantrax/projections"
+)
+
+// Plan is a source league mapped onto a Fantrax league
+type Plan struct {
+	// TeamIDs maps each source team name to the Fantrax team ID it became
+	TeamIDs map[string]string
+
+	// Adds are commissioner adds that rebuild the rosters, with teams and players given by
+	// Fantrax ID, ready for auth_client.ImportTransactions
+	Adds []auth_client.ImportRow
+
+	// Schedule holds the matchups for each period, by Fantrax team ID
+	Schedule map[int][]models.MatchupPair
+
+	// Problems lists teams and players that could not be mapped. A plan with problems is not
+	// executed.
+	Problems []string
+}
+
+// Valid returns true if every team and player was mapped
+func (p *Plan) Valid() bool {
+	return len(p.Problems) == 0
+}
+
+// ErrInvalidPlan is returned by Migrate when the export could not be fully mapped
+var ErrInvalidPlan = errors.New("league export could not be mapped onto the Fantrax league")
+
+// PlanOptions configures BuildPlan
+type PlanOptions struct {
+	// TeamMap maps source team names to Fantrax team IDs. Teams not listed are matched to a
+	// Fantrax team with the same name or short name.
+	TeamMap map[string]string
+
+	// IDType names the ID system of the export's ID column, if it has one. Players are
+	// otherwise matched by name and professional team.
+	IDType projections.IDType
+}
+
+// BuildPlan maps a league export onto a Fantrax league
+//
+// Rosters come from the roster export, or from the draft results when there is no roster
+// export; with both, adds follow draft order and undrafted players come last. Players are
+// matched through the cross-platform ID map when the export carries IDs, then by normalized
+// name plus professional team, then by name alone when it is unique.
+//
+// Parameters:
+//   - export: The source league
+//   - teams: The Fantrax league's teams (e.g. LeagueSetupMatchups.Teams)
+//   - players: The sport's player ID map from fantrax.Client.GetPlayerIds
+//   - opts: Team overrides and the export's ID system
+func BuildPlan(export *LeagueExport, teams []models.LeagueSetupTeam, players map[string]fantrax.Player, opts PlanOptions) *Plan {
+	plan := &Plan{
+		TeamIDs:  make(map[string]string),
+		Schedule: make(map[int][]models.MatchupPair),
+	}
+
+	teamID := func(name string) string {
+		if id, ok := plan.TeamIDs[name]; ok {
+			return id
+		}
+		id, problem := matchTeam(teams, name, opts.TeamMap)
+		if problem != "" {
+			plan.Problems = append(plan.Problems, problem)
+		}
+		plan.TeamIDs[name] = id
+		return id
+	}
+
+	matcher := newPlayerMatcher(players, opts.IDType)
+	added := make(map[string]bool)
+	add := func(team, name, proTeam, id string) {
+		tid := teamID(team)
+		playerID, problem := matcher.match(name, proTeam, id)
+		if problem != "" {
+			plan.Problems = append(plan.Problems, fmt.Sprintf("%s (%s): %s", name, team, problem))
+			return
+		}
+		if tid == "" || added[playerID] {
+			return
+		}
+		added[playerID] = true
+		plan.Adds = append(plan.Adds, auth_client.ImportRow{
+			Line:   len(plan.Adds) + 1,
+			Kind:   auth_client.ImportAdd,
+			Team:   tid,
+			Player: playerID,
+		})
+	}
+
+	picks := append([]ExportPick(nil), export.Draft...)
+	sort.SliceStable(picks, func(i, j int) bool {
+		if picks[i].Round != picks[j].Round {
+			return picks[i].Round < picks[j].Round
+		}
+		return picks[i].Pick < picks[j].Pick
+	})
+	if len(export.Rosters) > 0 {
+		// Keep only drafted players who are still on the roster they are listed under
+		rostered := make(map[string]bool, len(export.Rosters))
+		for _, p := range export.Rosters {
+			rostered[p.Team+"|"+projections.NormalizeName(p.Name)] = true
+		}
+		for _, pick := range picks {
+			if rostered[pick.Team+"|"+projections.NormalizeName(pick.Name)] {
+				add(pick.Team, pick.Name, pick.ProTeam, pick.ID)
+			}
+		}
+		for _, p := range export.Rosters {
+			add(p.Team, p.Name, p.ProTeam, p.ID)
+		}
+	} else {
+		for _, pick := range picks {
+			add(pick.Team, pick.Name, pick.ProTeam, pick.ID)
+		}
+	}
+
+	for _, m := range export.Schedule {
+		pair := models.MatchupPair{AwayTeamID: teamID(m.Away), HomeTeamID: "-1"}
+		if m.Home != "" {
+			pair.HomeTeamID = teamID(m.Home)
+		}
+		plan.Schedule[m.Period] = append(plan.Schedule[m.Period], pair)
+	}
+
+	sort.Strings(plan.Problems)
+	return plan
+}
+
+// matchTeam finds the Fantrax team for a source team name
+func matchTeam(teams []models.LeagueSetupTeam, name string, overrides map[string]string) (string, string) {
+	if id, ok := overrides[name]; ok {
+		for _, t := range teams {
+			if t.TeamID == id {
+				return id, ""
+			}
+		}
+		return "", fmt.Sprintf("team %q is mapped to %s, which is not in the Fantrax league", name, id)
+	}
+	var matches []string
+	for _, t := range teams {
+		if strings.EqualFold(t.Name, name) || strings.EqualFold(t.ShortName, name) {
+			matches = append(matches, t.TeamID)
+		}
+	}
+	switch len(matches) {
+	case 1:
+		return matches[0], ""
+	case 0:
+		return "", fmt.Sprintf("team %q has no Fantrax team with the same name; add it to TeamMap", name)
+	default:
+		return "", fmt.Sprintf("team %q matches %d Fantrax teams; add it to TeamMap", name, len(matches))
+	}
+}
+
+// playerMatcher resolves source players to Fantrax IDs
+type playerMatcher struct {
+	ids        projections.IDMap
+	byNameTeam map[string]string
+	byName     map[string][]string
+}
+
+func newPlayerMatcher(players map[string]fantrax.Player, idType projections.IDType) *playerMatcher {
+	m := &playerMatcher{
+		byNameTeam: make(map[string]string, len(players)),
+		byName:     make(map[string][]string, len(players)),
+	}
+	if idType != projections.IDTypeNone {
+		m.ids = projections.IDMapFromPlayerIds(players, idType)
+	}
+	for id, p := range players {
+		name := projections.NormalizeName(p.Name)
+		m.byNameTeam[name+"|"+strings.ToUpper(p.Team)] = id
+		m.byName[name] = append(m.byName[name], id)
+	}
+	return m
+}
+
+func (m *playerMatcher) match(name, proTeam, id string) (string, string) {
+	if id != "" && m.ids != nil {
+		if fantraxID, ok := m.ids[id]; ok {
+			return fantraxID, ""
+		}
+	}
+	normalized := projections.NormalizeName(name)
+	if fantraxID, ok := m.byNameTeam[normalized+"|"+strings.ToUpper(proTeam)]; ok {
+		return fantraxID, ""
+	}
+	switch candidates := m.byName[normalized]; len(candidates) {
+	case 1:
+		return candidates[0], ""
+	case 0:
+		return "", "no Fantrax player with this name"
+	default:
+		return "", fmt.Sprintf("name matches %d Fantrax players and the pro team does not narrow it down", len(candidates))
+	}
+}
+
+// Options configures Migrate
+type Options struct {
+	PlanOptions
+
+	Period       int  // Roster period for the adds (0 = current period)
+	DryRun       bool // Map and validate without changing the Fantrax league
+	SkipRosters  bool
+	SkipSchedule bool
+
+	// Progress, if set, is called after each add is attempted
+	Progress func(auth_client.ImportProgress)
+}
+
+// Result is the outcome of Migrate
+type Result struct {
+	Plan      *Plan
+	Import    *auth_client.ImportPlan // Validated adds, nil when rosters were skipped or the plan was invalid
+	Scheduled []int                   // Periods whose matchups were saved
+}
+
+// Migrate maps a league export onto the commissioner client's Fantrax league and, unless
+// DryRun is set, rebuilds the rosters with commissioner adds and saves the schedule
+//
+// Nothing is changed if any team or player cannot be mapped (ErrInvalidPlan) or an add fails
+// validation (auth_client.ErrInvalidImportPlan). Schedule periods that do not exist in the
+// Fantrax league are reported as errors after the rosters are built.
+//
+// Parameters:
+//   - client: A commissioner client for the destination league
+//   - public: A public API client, used to fetch the player ID map
+//   - sport: The league's sport
+//   - export: The source league
+//   - opts: Mapping and execution options
+func Migrate(client *auth_client.Client, public *fantrax.Client, sport fantrax.Sport, export *LeagueExport, opts Options) (*Result, error) {
+	if err := client.RequireCommissioner(); err != nil {
+		return nil, err
+	}
+
+	setup, err := client.GetLeagueSetupMatchups()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league setup: %w", err)
+	}
+	players, err := public.GetPlayerIds(sport)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player IDs: %w", err)
+	}
+
+	result := &Result{Plan: BuildPlan(export, setup.Teams, *players, opts.PlanOptions)}
+	if !result.Plan.Valid() {
+		return result, ErrInvalidPlan
+	}
+
+	if !opts.SkipRosters && len(result.Plan.Adds) > 0 {
+		result.Import, err = client.ImportTransactions(result.Plan.Adds, auth_client.ImportOptions{
+			Period:   opts.Period,
+			DryRun:   opts.DryRun,
+			Progress: opts.Progress,
+		})
+		if err != nil {
+			return result, err
+		}
+	}
+
+	if opts.SkipSchedule || opts.DryRun {
+		return result, nil
+	}
+	periods := make([]int, 0, len(result.Plan.Schedule))
+	for period := range result.Plan.Schedule {
+		periods = append(periods, period)
+	}
+	sort.Ints(periods)
+	for _, period := range periods {
+		if err := client.SetPeriodMatchups(setup, period, result.Plan.Schedule[period]); err != nil {
+			return result, fmt.Errorf("failed to set matchups for period %d: %w", period, err)
+		}
+		result.Scheduled = append(result.Scheduled, period)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,50 @@
+package migration
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pmurley/go-fantrax"
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestBuildPlan(t *testing.T) {
+	rosters, err := LoadRostersCSV(strings.NewReader("Fantasy Team,Player,Team,Position\n"+
+		"Bombers,José Ramírez,CLE,3B\n"+
+		"Bombers,Luis García Jr.,WSH,2B\n"+
+		"Aces,Luis Garcia,HOU,SP\n"+
+		"Aces,Nobody Real,FA,OF\n"), YahooColumns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schedule, err := LoadScheduleCSV(strings.NewReader("Week,Team 1,Team 2\nWeek 1,Bombers,Aces\n"), YahooColumns)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	teams := []models.LeagueSetupTeam{{TeamID: "t1", Name: "Bombers"}, {TeamID: "t2", Name: "Team Two"}}
+	players := map[string]fantrax.Player{
+		"a": {Name: "Jose Ramirez", Team: "CLE"},
+		"b": {Name: "Luis Garcia", Team: "WSH"},
+		"c": {Name: "Luis Garcia", Team: "HOU"},
+	}
+
+	plan := BuildPlan(&LeagueExport{Platform: PlatformYahoo, Rosters: rosters, Schedule: schedule},
+		teams, players, PlanOptions{TeamMap: map[string]string{"Aces": "t2"}})
+
+	if len(plan.Adds) != 3 {
+		t.Fatalf("got %d adds, want 3: %+v", len(plan.Adds), plan.Adds)
+	}
+	want := map[string]string{"a": "t1", "b": "t1", "c": "t2"}
+	for _, add := range plan.Adds {
+		if want[add.Player] != add.Team {
+			t.Errorf("player %s added to %s, want %s", add.Player, add.Team, want[add.Player])
+		}
+	}
+	if pairs := plan.Schedule[1]; len(pairs) != 1 || pairs[0] != (models.MatchupPair{AwayTeamID: "t1", HomeTeamID: "t2"}) {
+		t.Errorf("schedule = %+v", plan.Schedule)
+	}
+	if plan.Valid() || len(plan.Problems) != 1 || !strings.Contains(plan.Problems[0], "Nobody Real") {
+		t.Errorf("problems = %v", plan.Problems)
+	}
+}
@@ -0,0 +1,20 @@
+package models
+
+// Division is a league division with the identifier each Fantrax page uses for it, so
+// standings, schedules, and league setup data can be joined by division
+type Division struct {
+	Name           string   `json:"name"`
+	SetupID        string   `json:"setupId,omitempty"`        // divisionName_{id} field on the league setup page
+	StandingsTabID string   `json:"standingsTabId,omitempty"` // Division tab ID on the standings page
+	TeamIDs        []string `json:"teamIds"`
+}
+
+// HasTeam reports whether the team is in the division
+func (d Division) HasTeam(teamID string) bool {
+	for _, id := range d.TeamIDs {
+		if id == teamID {
+			return true
+		}
+	}
+	return false
+}
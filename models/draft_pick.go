@@ -0,0 +1,18 @@
+package models
+
+import "fmt"
+
+// DraftPick identifies a future draft pick traded in a transaction
+type DraftPick struct {
+	Year             int    `json:"year"`
+	Round            int    `json:"round"`
+	OriginalTeamName string `json:"originalTeamName,omitempty"` // Team the pick originally belonged to, when Fantrax names it
+}
+
+// String returns the pick as "2026 Round 1 (Team Name)"
+func (p DraftPick) String() string {
+	if p.OriginalTeamName == "" {
+		return fmt.Sprintf("%d Round %d", p.Year, p.Round)
+	}
+	return fmt.Sprintf("%d Round %d (%s)", p.Year, p.Round, p.OriginalTeamName)
+}
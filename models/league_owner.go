@@ -0,0 +1,13 @@
+package models
+
+// LeagueOwner is one owner of a fantasy team. Co-owned teams have one entry per owner.
+// Email is left out of JSON so dumped owner lists do not carry addresses.
+type LeagueOwner struct {
+	TeamID        string `json:"teamId"`
+	TeamName      string `json:"teamName"`
+	TeamShortName string `json:"teamShortName"`
+	UserID        string `json:"userId,omitempty"` // Empty if the owner has been invited but hasn't joined
+	Email         string `json:"-"`                // Visible to the commissioner only
+	Commissioner  bool   `json:"commissioner"`
+	Joined        bool   `json:"joined"` // False while an invitation is outstanding
+}
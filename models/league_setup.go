@@ -21,8 +21,9 @@ type LeagueSetupTeam struct {
 }
 
 // TeamOwner represents a single owner of a team, parsed from addTeam() JS calls.
+// Email is left out of JSON so dumped setups do not carry owner addresses.
 type TeamOwner struct {
-	Email          string
+	Email          string `json:"-"`
 	UserID         string // Original userId from addTeam(); "NULL" if owner hasn't joined
 	IsCommissioner bool
 	JoinedLeague   bool
@@ -59,7 +60,8 @@ type LeagueSetupFormConfig struct {
 	// OwnerEmailFields stores the computed teamOwnerEmail form field keys and values.
 	// Only owners where !IsCommissioner && !JoinedLeague generate email input fields.
 	// Key format: "teamOwnerEmail,{email},{teamId},{userId}" -> email value.
-	OwnerEmailFields map[string]string
+	// Left out of JSON, as both keys and values hold owner email addresses.
+	OwnerEmailFields map[string]string `json:"-"`
 	// DivisionNames maps divisionId -> division name for divisionName_{divId} POST fields.
 	DivisionNames map[string]string
 	// Divisions stores the ~~divisions values for POST reconstruction.
@@ -0,0 +1,41 @@
+package models
+
+import "sort"
+
+// MinorsStint is an unbroken run of scoring periods a player spent in a team's minors
+type MinorsStint struct {
+	StartPeriod int  `json:"startPeriod"`
+	EndPeriod   int  `json:"endPeriod"`
+	Periods     int  `json:"periods"`
+	Ongoing     bool `json:"ongoing"` // True if the stint runs through the latest period in the history
+}
+
+// MinorsStints splits the player's period history into minors stints, oldest first
+//
+// A stint ends at the first period with any other status, or at a period missing from the
+// history, so a player sent down twice in a season has two stints.
+func (p PlayerServiceTime) MinorsStints() []MinorsStint {
+	periods := make([]int, 0, len(p.PeriodHistory))
+	for period := range p.PeriodHistory {
+		periods = append(periods, period)
+	}
+	sort.Ints(periods)
+
+	var stints []MinorsStint
+	for _, period := range periods {
+		if p.PeriodHistory[period].Status != StatusMinors {
+			continue
+		}
+		n := len(stints)
+		if n > 0 && stints[n-1].EndPeriod == period-1 {
+			stints[n-1].EndPeriod = period
+			stints[n-1].Periods++
+			continue
+		}
+		stints = append(stints, MinorsStint{StartPeriod: period, EndPeriod: period, Periods: 1})
+	}
+	if n := len(stints); n > 0 && stints[n-1].EndPeriod == periods[len(periods)-1] {
+		stints[n-1].Ongoing = true
+	}
+	return stints
+}
@@ -0,0 +1,26 @@
+package models
+
+import "fmt"
+
+// ParseWarning describes part of a Fantrax response that could not be parsed. The parsers
+// keep going past such rows and cells, returning what they could read plus the warnings, so
+// callers can tell a partial result from a complete one.
+type ParseWarning struct {
+	Source  string `json:"source"`          // Parser that produced the warning (e.g. "transactions", "roster", "playerPool")
+	Row     int    `json:"row"`             // Zero-based row index within the response table, or -1 if not row-specific
+	Field   string `json:"field,omitempty"` // Cell or column key, if the problem is a single value
+	Value   string `json:"value,omitempty"` // The raw value that failed to parse
+	Message string `json:"message"`
+}
+
+// String formats the warning for logging
+func (w ParseWarning) String() string {
+	location := w.Source
+	if w.Row >= 0 {
+		location += fmt.Sprintf(" row %d", w.Row)
+	}
+	if w.Field != "" {
+		location += fmt.Sprintf(" %s=%q", w.Field, w.Value)
+	}
+	return location + ": " + w.Message
+}
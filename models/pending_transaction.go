@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// PendingTransaction is a claim or trade that has been submitted but not yet processed.
+// The rows Fantrax returns for one pending transaction (a claim and its conditional drops,
+// or each player in a trade) are combined into a single entry.
+type PendingTransaction struct {
+	ID          string    `json:"id"`                  // txSetId shared by every row of the transaction
+	Type        string    `json:"type"`                // "CLAIM", "DROP" or "TRADE"
+	ClaimType   string    `json:"claimType,omitempty"` // "FA" or "WW" for claims
+	TeamID      string    `json:"teamId"`              // Team that submitted the claim (empty for trades)
+	TeamName    string    `json:"teamName"`
+	BidAmount   string    `json:"bidAmount,omitempty"`
+	Priority    string    `json:"priority,omitempty"`
+	ProcessTime time.Time `json:"processTime"` // When the transaction is scheduled to process
+	Period      int       `json:"period,omitempty"`
+	Note        string    `json:"note,omitempty"` // Trade message or commissioner note
+
+	Claims           []PendingTransactionPlayer `json:"claims,omitempty"`           // Players being claimed
+	ConditionalDrops []PendingTransactionPlayer `json:"conditionalDrops,omitempty"` // Players dropped only if the claim succeeds
+	TradePlayers     []PendingTransactionPlayer `json:"tradePlayers,omitempty"`     // Players changing teams in a trade
+}
+
+// PendingTransactionPlayer is one player involved in a pending transaction
+type PendingTransactionPlayer struct {
+	PlayerID       string `json:"playerId"`
+	PlayerName     string `json:"playerName"`
+	PlayerTeam     string `json:"playerTeam"`
+	PlayerPosition string `json:"playerPosition"`
+	FromTeamID     string `json:"fromTeamId,omitempty"` // For trades
+	FromTeamName   string `json:"fromTeamName,omitempty"`
+	ToTeamID       string `json:"toTeamId,omitempty"` // For trades
+	ToTeamName     string `json:"toTeamName,omitempty"`
+}
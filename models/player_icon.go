@@ -0,0 +1,105 @@
+package models
+
+import "strings"
+
+// IconKind is what a player icon means, decoded from its TypeID
+type IconKind string
+
+const (
+	IconKindUnknown        IconKind = ""
+	IconKindInjury         IconKind = "INJURY"          // Day-to-day, injured list, or out indefinitely
+	IconKindNews           IconKind = "NEWS"            // A news blurb, in the tooltip
+	IconKindMinorsEligible IconKind = "MINORS_ELIGIBLE" // Eligible for a minors roster slot
+	IconKindSuspended      IconKind = "SUSPENDED"
+	IconKindProbable       IconKind = "PROBABLE" // Probable starter
+	IconKindMinorLeagues   IconKind = "MINOR_LEAGUES"
+	IconKindFreeAgent      IconKind = "FREE_AGENT" // Not signed to an MLB team
+	IconKindInactive       IconKind = "INACTIVE"   // Inactive or retired
+	IconKindHandedness     IconKind = "HANDEDNESS"
+)
+
+// iconKinds maps each known icon TypeID to its kind
+var iconKinds = map[string]IconKind{
+	IconDayToDay:        IconKindInjury,
+	IconInjuredList:     IconKindInjury,
+	IconOutIndefinitely: IconKindInjury,
+	IconFreeAgent:       IconKindFreeAgent,
+	IconMinorLeagues:    IconKindMinorLeagues,
+	IconSuspended:       IconKindSuspended,
+	IconInactive:        IconKindInactive,
+	IconNewsOld:         IconKindNews,
+	IconNewsRecent:      IconKindNews,
+	IconNewsBreaking:    IconKindNews,
+	IconBatsLeft:        IconKindHandedness,
+	IconBatsRight:       IconKindHandedness,
+	IconSwitchHitter:    IconKindHandedness,
+	IconMinorsEligible:  IconKindMinorsEligible,
+}
+
+// Kind decodes the icon's TypeID. The probable starter icon's TypeID hasn't been seen in a
+// captured response, so icons with an unknown TypeID and a tooltip starting with "Probable"
+// are IconKindProbable.
+func (i PlayerIcon) Kind() IconKind {
+	if kind, ok := iconKinds[i.TypeID]; ok {
+		return kind
+	}
+	if strings.HasPrefix(strings.ToLower(strings.TrimSpace(i.Tooltip)), "probable") {
+		return IconKindProbable
+	}
+	return IconKindUnknown
+}
+
+// FindIcon returns the first icon of a kind
+func FindIcon(icons []PlayerIcon, kind IconKind) (PlayerIcon, bool) {
+	for _, icon := range icons {
+		if icon.Kind() == kind {
+			return icon, true
+		}
+	}
+	return PlayerIcon{}, false
+}
+
+// IconTooltip returns the tooltip of the first icon of a kind (e.g. "Hamstring - Day-to-Day"
+// for IconKindInjury), or "" if there is none
+func IconTooltip(icons []PlayerIcon, kind IconKind) string {
+	icon, _ := FindIcon(icons, kind)
+	return icon.Tooltip
+}
+
+// IconKinds returns the kinds of a player's icons, each once, in icon order. Unknown icons
+// are left out.
+func IconKinds(icons []PlayerIcon) []IconKind {
+	var kinds []IconKind
+	seen := make(map[IconKind]bool)
+	for _, icon := range icons {
+		kind := icon.Kind()
+		if kind == IconKindUnknown || seen[kind] {
+			continue
+		}
+		seen[kind] = true
+		kinds = append(kinds, kind)
+	}
+	return kinds
+}
+
+// HasIconKind reports whether the player has an icon of a kind
+func (p RosterPlayer) HasIconKind(kind IconKind) bool {
+	_, ok := FindIcon(p.Icons, kind)
+	return ok
+}
+
+// IconTooltip returns the tooltip of the player's first icon of a kind, or ""
+func (p RosterPlayer) IconTooltip(kind IconKind) string {
+	return IconTooltip(p.Icons, kind)
+}
+
+// HasIconKind reports whether the player has an icon of a kind
+func (p PoolPlayer) HasIconKind(kind IconKind) bool {
+	_, ok := FindIcon(p.Icons, kind)
+	return ok
+}
+
+// IconTooltip returns the tooltip of the player's first icon of a kind, or ""
+func (p PoolPlayer) IconTooltip(kind IconKind) string {
+	return IconTooltip(p.Icons, kind)
+}
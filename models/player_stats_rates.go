@@ -0,0 +1,252 @@
+package models
+
+import "math"
+
+// Rate stats computed from the counting stats Fantrax reports. Each returns false when a stat
+// it needs is missing or its denominator is zero. The parsed fields are left as Fantrax sent
+// them; these methods only fill in what the tables leave out.
+
+// AVG returns batting average (H / AB)
+func (s *BattingStats) AVG() (float64, bool) {
+	return ratio(s.Hits, s.AtBats)
+}
+
+// PlateAppearances returns AB + BB + HBP. Fantrax's tracked stats have no sacrifices, so this
+// undercounts by the player's sacrifice flies and bunts.
+func (s *BattingStats) PlateAppearances() (int, bool) {
+	ab, ok := intStat(s.AtBats)
+	if !ok {
+		return 0, false
+	}
+	bb, _ := intStat(s.Walks)
+	hbp, _ := intStat(s.HitByPitch)
+	return ab + bb + hbp, true
+}
+
+// OBP returns on-base percentage ((H + BB + HBP) / PA), without sacrifice flies in the
+// denominator (see PlateAppearances)
+func (s *BattingStats) OBP() (float64, bool) {
+	h, ok := intStat(s.Hits)
+	pa, paOK := s.PlateAppearances()
+	if !ok || !paOK || pa == 0 {
+		return 0, false
+	}
+	bb, _ := intStat(s.Walks)
+	hbp, _ := intStat(s.HitByPitch)
+	return float64(h+bb+hbp) / float64(pa), true
+}
+
+// TotalBases returns H + 2B + 2×3B + 3×HR
+func (s *BattingStats) TotalBases() (int, bool) {
+	h, ok := intStat(s.Hits)
+	if !ok {
+		return 0, false
+	}
+	doubles, _ := intStat(s.Doubles)
+	triples, _ := intStat(s.Triples)
+	hr, _ := intStat(s.HomeRuns)
+	return h + doubles + 2*triples + 3*hr, true
+}
+
+// SLG returns slugging percentage (TB / AB)
+func (s *BattingStats) SLG() (float64, bool) {
+	tb, ok := s.TotalBases()
+	if !ok {
+		return 0, false
+	}
+	return ratio(&tb, s.AtBats)
+}
+
+// OPS returns OBP + SLG
+func (s *BattingStats) OPS() (float64, bool) {
+	obp, ok := s.OBP()
+	slg, slgOK := s.SLG()
+	return obp + slg, ok && slgOK
+}
+
+// ISO returns isolated power (SLG - AVG)
+func (s *BattingStats) ISO() (float64, bool) {
+	slg, ok := s.SLG()
+	avg, avgOK := s.AVG()
+	return slg - avg, ok && avgOK
+}
+
+// StrikeoutRate returns strikeouts per plate appearance
+func (s *BattingStats) StrikeoutRate() (float64, bool) {
+	pa, ok := s.PlateAppearances()
+	if !ok {
+		return 0, false
+	}
+	return ratio(s.Strikeouts, &pa)
+}
+
+// WalkRate returns walks per plate appearance
+func (s *BattingStats) WalkRate() (float64, bool) {
+	pa, ok := s.PlateAppearances()
+	if !ok {
+		return 0, false
+	}
+	return ratio(s.Walks, &pa)
+}
+
+// PerGame returns a counting stat divided by games played, e.g. s.PerGame(s.HomeRuns)
+func (s *BattingStats) PerGame(stat *int) (float64, bool) {
+	return ratio(stat, s.GamesPlayed)
+}
+
+// Since returns the counting stats accumulated after an earlier snapshot of the same player's
+// totals, e.g. one scoring period's split from the season totals at the end of two periods.
+// Rates in the result (FP/G) are left nil; compute them from the split with the rate methods.
+func (s *BattingStats) Since(earlier *BattingStats) BattingStats {
+	return BattingStats{
+		AtBats:                diffStat(s.AtBats, earlier.AtBats),
+		Hits:                  diffStat(s.Hits, earlier.Hits),
+		Runs:                  diffStat(s.Runs, earlier.Runs),
+		Doubles:               diffStat(s.Doubles, earlier.Doubles),
+		Triples:               diffStat(s.Triples, earlier.Triples),
+		HomeRuns:              diffStat(s.HomeRuns, earlier.HomeRuns),
+		RBI:                   diffStat(s.RBI, earlier.RBI),
+		Walks:                 diffStat(s.Walks, earlier.Walks),
+		Strikeouts:            diffStat(s.Strikeouts, earlier.Strikeouts),
+		StolenBases:           diffStat(s.StolenBases, earlier.StolenBases),
+		CaughtStealing:        diffStat(s.CaughtStealing, earlier.CaughtStealing),
+		HitByPitch:            diffStat(s.HitByPitch, earlier.HitByPitch),
+		GIDP:                  diffStat(s.GIDP, earlier.GIDP),
+		Errors:                diffStat(s.Errors, earlier.Errors),
+		CaughtStealingAgainst: diffStat(s.CaughtStealingAgainst, earlier.CaughtStealingAgainst),
+		DoublePlays:           diffStat(s.DoublePlays, earlier.DoublePlays),
+		Assists:               diffStat(s.Assists, earlier.Assists),
+		AssistsOutfield:       diffStat(s.AssistsOutfield, earlier.AssistsOutfield),
+		Putouts:               diffStat(s.Putouts, earlier.Putouts),
+		PutoutsOutfield:       diffStat(s.PutoutsOutfield, earlier.PutoutsOutfield),
+		StolenBasesAgainst:    diffStat(s.StolenBasesAgainst, earlier.StolenBasesAgainst),
+		PassedBalls:           diffStat(s.PassedBalls, earlier.PassedBalls),
+		GamesPlayed:           diffStat(s.GamesPlayed, earlier.GamesPlayed),
+	}
+}
+
+// Outs returns the outs recorded. Fantrax reports innings in baseball notation, where 6.1 is
+// six and one-third innings, so the fraction is read as thirds.
+func (s *PitchingStats) Outs() (int, bool) {
+	if s.InningsPitched == nil {
+		return 0, false
+	}
+	whole := math.Floor(*s.InningsPitched)
+	thirds := math.Round((*s.InningsPitched - whole) * 10)
+	return int(whole)*3 + int(thirds), true
+}
+
+// Innings returns innings pitched as a true decimal (6.1 in baseball notation is 6.333…)
+func (s *PitchingStats) Innings() (float64, bool) {
+	outs, ok := s.Outs()
+	return float64(outs) / 3, ok
+}
+
+// EarnedRunAverage returns the ERA Fantrax reported, or computes ER × 9 / IP when it is missing
+func (s *PitchingStats) EarnedRunAverage() (float64, bool) {
+	if s.ERA != nil {
+		return *s.ERA, true
+	}
+	return s.perNine(s.EarnedRuns)
+}
+
+// WHIP returns walks plus hits per inning pitched
+func (s *PitchingStats) WHIP() (float64, bool) {
+	h, ok := intStat(s.HitsAllowed)
+	bb, bbOK := intStat(s.WalksAllowed)
+	ip, ipOK := s.Innings()
+	if !ok || !bbOK || !ipOK || ip == 0 {
+		return 0, false
+	}
+	return float64(h+bb) / ip, true
+}
+
+// KPer9 returns strikeouts per nine innings
+func (s *PitchingStats) KPer9() (float64, bool) {
+	return s.perNine(s.Strikeouts)
+}
+
+// BBPer9 returns walks per nine innings
+func (s *PitchingStats) BBPer9() (float64, bool) {
+	return s.perNine(s.WalksAllowed)
+}
+
+// HPer9 returns hits allowed per nine innings
+func (s *PitchingStats) HPer9() (float64, bool) {
+	return s.perNine(s.HitsAllowed)
+}
+
+// KPerBB returns the strikeout-to-walk ratio
+func (s *PitchingStats) KPerBB() (float64, bool) {
+	return ratio(s.Strikeouts, s.WalksAllowed)
+}
+
+// PerGame returns a counting stat divided by games played, e.g. s.PerGame(s.Strikeouts)
+func (s *PitchingStats) PerGame(stat *int) (float64, bool) {
+	return ratio(stat, s.GamesPlayed)
+}
+
+// Since returns the counting stats accumulated after an earlier snapshot of the same player's
+// totals (see BattingStats.Since). Innings are subtracted in outs and returned in baseball
+// notation; ERA and FP/G are left nil.
+func (s *PitchingStats) Since(earlier *PitchingStats) PitchingStats {
+	split := PitchingStats{
+		QualityStarts: diffStat(s.QualityStarts, earlier.QualityStarts),
+		Saves:         diffStat(s.Saves, earlier.Saves),
+		BlownSaves:    diffStat(s.BlownSaves, earlier.BlownSaves),
+		Holds:         diffStat(s.Holds, earlier.Holds),
+		CompleteGames: diffStat(s.CompleteGames, earlier.CompleteGames),
+		HitsAllowed:   diffStat(s.HitsAllowed, earlier.HitsAllowed),
+		EarnedRuns:    diffStat(s.EarnedRuns, earlier.EarnedRuns),
+		WalksAllowed:  diffStat(s.WalksAllowed, earlier.WalksAllowed),
+		Strikeouts:    diffStat(s.Strikeouts, earlier.Strikeouts),
+		Balks:         diffStat(s.Balks, earlier.Balks),
+		WildPitches:   diffStat(s.WildPitches, earlier.WildPitches),
+		HitBatsmen:    diffStat(s.HitBatsmen, earlier.HitBatsmen),
+		Shutouts:      diffStat(s.Shutouts, earlier.Shutouts),
+		Pickoffs:      diffStat(s.Pickoffs, earlier.Pickoffs),
+		GamesPlayed:   diffStat(s.GamesPlayed, earlier.GamesPlayed),
+	}
+	outs, ok := s.Outs()
+	earlierOuts, earlierOK := earlier.Outs()
+	if ok && earlierOK {
+		diff := outs - earlierOuts
+		ip := float64(diff/3) + float64(diff%3)/10
+		split.InningsPitched = &ip
+	}
+	return split
+}
+
+// perNine scales a counting stat to nine innings
+func (s *PitchingStats) perNine(stat *int) (float64, bool) {
+	n, ok := intStat(stat)
+	ip, ipOK := s.Innings()
+	if !ok || !ipOK || ip == 0 {
+		return 0, false
+	}
+	return float64(n) * 9 / ip, true
+}
+
+func intStat(stat *int) (int, bool) {
+	if stat == nil {
+		return 0, false
+	}
+	return *stat, true
+}
+
+// ratio divides two stats, returning false if either is missing or the denominator is zero
+func ratio(numerator, denominator *int) (float64, bool) {
+	if numerator == nil || denominator == nil || *denominator == 0 {
+		return 0, false
+	}
+	return float64(*numerator) / float64(*denominator), true
+}
+
+// diffStat subtracts an earlier value of a counting stat, or returns nil if either is missing
+func diffStat(later, earlier *int) *int {
+	if later == nil || earlier == nil {
+		return nil
+	}
+	diff := *later - *earlier
+	return &diff
+}
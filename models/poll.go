@@ -0,0 +1,104 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// PollResponse is the raw response from getLeaguePolls
+type PollResponse struct {
+	Responses []struct {
+		Data PollData `json:"data"`
+	} `json:"responses"`
+}
+
+// PollData holds the league's polls
+type PollData struct {
+	Polls []RawPoll `json:"polls"`
+}
+
+// RawPoll is one league poll as Fantrax returns it. The field names follow the poll page and
+// haven't been checked against a captured response; TestGoldenFixtures covers them once a
+// getLeaguePolls fixture is recorded.
+type RawPoll struct {
+	ID            string          `json:"id"`
+	Question      string          `json:"question"`
+	Description   string          `json:"description,omitempty"`
+	Choices       []RawPollChoice `json:"choices"`
+	Closed        bool            `json:"closed"`
+	CloseDate     int64           `json:"closeDate,omitempty"`   // Milliseconds since the epoch
+	CreatedDate   int64           `json:"createdDate,omitempty"` // Milliseconds since the epoch
+	CreatorTeamID string          `json:"creatorTeamId,omitempty"`
+	AllowMultiple bool            `json:"allowMultiple"`
+	Anonymous     bool            `json:"anonymous"`
+	MyChoiceIDs   []string        `json:"myChoiceIds,omitempty"`
+}
+
+// RawPollChoice is one answer of a raw poll
+type RawPollChoice struct {
+	ID           string   `json:"id"`
+	Text         string   `json:"text"`
+	NumVotes     int      `json:"numVotes"`
+	VoterTeamIDs []string `json:"voterTeamIds,omitempty"` // Empty for anonymous polls
+}
+
+// Poll is a league poll, such as a rule-change or trade-veto vote
+type Poll struct {
+	ID            string       `json:"id"`
+	Question      string       `json:"question"`
+	Description   string       `json:"description,omitempty"`
+	Choices       []PollChoice `json:"choices"`
+	Closed        bool         `json:"closed"`
+	Closes        time.Time    `json:"closes,omitempty"` // Zero if the poll has no closing date
+	Created       time.Time    `json:"created,omitempty"`
+	CreatorTeamID string       `json:"creatorTeamId,omitempty"`
+	AllowMultiple bool         `json:"allowMultiple"` // Voters may pick more than one choice
+	Anonymous     bool         `json:"anonymous"`
+	MyVotes       []string     `json:"myVotes,omitempty"` // Choice IDs the authenticated user voted for
+}
+
+// PollChoice is one answer of a poll and its votes
+type PollChoice struct {
+	ID           string   `json:"id"`
+	Text         string   `json:"text"`
+	Votes        int      `json:"votes"`
+	VoterTeamIDs []string `json:"voterTeamIds,omitempty"`
+}
+
+// TotalVotes returns the number of votes cast across all choices
+func (p Poll) TotalVotes() int {
+	total := 0
+	for _, choice := range p.Choices {
+		total += choice.Votes
+	}
+	return total
+}
+
+// Results returns the choices ordered by votes, most first
+func (p Poll) Results() []PollChoice {
+	results := append([]PollChoice(nil), p.Choices...)
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Votes > results[j].Votes })
+	return results
+}
+
+// Leaders returns the choices with the most votes; more than one means a tie. Returns nil
+// if no votes have been cast.
+func (p Poll) Leaders() []PollChoice {
+	var leaders []PollChoice
+	most := 0
+	for _, choice := range p.Choices {
+		switch {
+		case choice.Votes > most:
+			most = choice.Votes
+			leaders = []PollChoice{choice}
+		case choice.Votes == most && most > 0:
+			leaders = append(leaders, choice)
+		}
+	}
+	return leaders
+}
+
+// Open reports whether the poll still accepts votes at now
+func (p Poll) Open(now time.Time) bool {
+	return !p.Closed && (p.Closes.IsZero() || now.Before(p.Closes))
+}
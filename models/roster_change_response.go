@@ -1,5 +1,11 @@
 package models
 
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
 // RosterChangeResponse represents the full API response from confirmOrExecuteTeamRosterChanges
 type RosterChangeResponse struct {
 	Data struct {
@@ -58,4 +64,121 @@ type RosterChangeResult struct {
 	Warnings         []string // Roster validation warnings (can exist even when successful)
 	TotalFee         float64  // Total cost of the changes
 	IsCommissioner   bool     // True if change was made in commissioner mode
+
+	LineupChanges   []LineupChange         // Typed form of Changes (per player when applied through RosterEditor)
+	Fees            FeeBreakdown           // Itemized form of TotalFee
+	IllegalMessages []IllegalRosterMessage // Classified form of Warnings
+}
+
+// FeeBreakdown itemizes the fees charged for a roster change
+type FeeBreakdown struct {
+	Total        float64
+	Claim        float64
+	LineupChange float64
+	Drop         float64
+}
+
+// Fees returns the itemized fees from the roster adjustment info
+func (r RosterAdjustmentInfo) Fees() FeeBreakdown {
+	return FeeBreakdown{
+		Total:        r.TotalFee,
+		Claim:        r.TotalClaimFee,
+		LineupChange: r.TotalLineupChangeFee,
+		Drop:         r.TotalDropFee,
+	}
+}
+
+// LineupChange is a single player's move between roster statuses or active slots.
+// Entries built from the API's summary strings (e.g. "Active to Reserve") carry only the
+// statuses; entries built by RosterEditor also identify the player and slots.
+type LineupChange struct {
+	PlayerID     string
+	PlayerName   string
+	FromStatus   string // "Active", "Reserve", "IR" or "Minors"
+	ToStatus     string
+	FromPosition string // Position short name of the active slot, empty when not active
+	ToPosition   string
+}
+
+// ParseLineupChangeSummary parses an API summary such as "Active to Reserve" into a
+// LineupChange. Returns false if the text is not in "<status> to <status>" form.
+func ParseLineupChangeSummary(summary string) (LineupChange, bool) {
+	parts := strings.SplitN(summary, " to ", 2)
+	if len(parts) != 2 {
+		return LineupChange{}, false
+	}
+	return LineupChange{
+		FromStatus: strings.TrimSpace(parts[0]),
+		ToStatus:   strings.TrimSpace(parts[1]),
+	}, true
+}
+
+// IllegalRosterCode is a machine-readable classification of an illegal roster message
+type IllegalRosterCode string
+
+// Illegal roster message codes
+const (
+	IllegalMaxActiveExceeded   IllegalRosterCode = "MAX_ACTIVE_EXCEEDED"   // Too many active players
+	IllegalMaxReserveExceeded  IllegalRosterCode = "MAX_RESERVE_EXCEEDED"  // Too many reserve players
+	IllegalMaxTotalExceeded    IllegalRosterCode = "MAX_TOTAL_EXCEEDED"    // Too many players overall
+	IllegalMaxPositionExceeded IllegalRosterCode = "MAX_POSITION_EXCEEDED" // Too many active players at one position
+	IllegalMinNotMet           IllegalRosterCode = "MIN_NOT_MET"           // Too few players at a position or status
+	IllegalPositionIneligible  IllegalRosterCode = "POSITION_INELIGIBLE"   // Player is not eligible at the slot they occupy
+	IllegalUnknown             IllegalRosterCode = "UNKNOWN"               // Message text did not match a known pattern
+)
+
+// IllegalRosterMessage is an illegal roster message with its classification
+type IllegalRosterMessage struct {
+	Code     IllegalRosterCode
+	Text     string // The original message text
+	Limit    int    // The limit named in the message, when present
+	Position string // The position or status named in the message, when present
+}
+
+var (
+	illegalMaxRegex = regexp.MustCompile(`(?i)maximum number of (\d+) (.+?) player\(s\)`)
+	illegalMinRegex = regexp.MustCompile(`(?i)minimum number of (\d+) (.+?) player\(s\)`)
+)
+
+// ParseIllegalRosterMessage classifies an illegal roster message such as
+// "The maximum number of 15 active player(s) has been exceeded."
+func ParseIllegalRosterMessage(text string) IllegalRosterMessage {
+	msg := IllegalRosterMessage{Code: IllegalUnknown, Text: text}
+
+	if m := illegalMaxRegex.FindStringSubmatch(text); m != nil {
+		msg.Limit, _ = strconv.Atoi(m[1])
+		msg.Position = m[2]
+		switch strings.ToLower(m[2]) {
+		case "active":
+			msg.Code = IllegalMaxActiveExceeded
+		case "reserve":
+			msg.Code = IllegalMaxReserveExceeded
+		case "total":
+			msg.Code = IllegalMaxTotalExceeded
+		default:
+			msg.Code = IllegalMaxPositionExceeded
+		}
+		return msg
+	}
+
+	if m := illegalMinRegex.FindStringSubmatch(text); m != nil {
+		msg.Limit, _ = strconv.Atoi(m[1])
+		msg.Position = m[2]
+		msg.Code = IllegalMinNotMet
+		return msg
+	}
+
+	if strings.Contains(strings.ToLower(text), "not eligible") {
+		msg.Code = IllegalPositionIneligible
+	}
+	return msg
+}
+
+// ParseIllegalRosterMessages classifies each message in order
+func ParseIllegalRosterMessages(texts []string) []IllegalRosterMessage {
+	messages := make([]IllegalRosterMessage, 0, len(texts))
+	for _, text := range texts {
+		messages = append(messages, ParseIllegalRosterMessage(text))
+	}
+	return messages
 }
@@ -0,0 +1,65 @@
+package models
+
+// Roster violation rule codes
+const (
+	ViolationIRNotInjured        = "IR_NOT_INJURED"         // Player in an IR slot without an Injured List designation
+	ViolationMaxTotalPlayers     = "MAX_TOTAL_PLAYERS"      // More players rostered than the league allows
+	ViolationMaxActivePlayers    = "MAX_ACTIVE_PLAYERS"     // More active players than the league allows
+	ViolationMaxReservePlayers   = "MAX_RESERVE_PLAYERS"    // More reserve players than the league allows
+	ViolationMaxActiveAtPosition = "MAX_ACTIVE_AT_POSITION" // More active players at one position than the league allows
+	ViolationMaxMinorsPlayers    = "MAX_MINORS_PLAYERS"     // More players in minors slots than the league allows
+	ViolationMaxIRPlayers        = "MAX_IR_PLAYERS"         // More players in IR slots than the league allows
+	ViolationMinorsIneligible    = "MINORS_INELIGIBLE"      // Player in a minors slot who has lost minors eligibility
+	ViolationMinorsDaysActive    = "MINORS_DAYS_ACTIVE"     // Player in a minors slot who has spent too many days active
+)
+
+// RosterLimits describes the roster limits a team is checked against
+//
+// A zero value for any maximum means the limit is not enforced.
+type RosterLimits struct {
+	MaxTotalPlayers       int
+	MaxActivePlayers      int
+	MaxReservePlayers     int
+	MaxMinorsPlayers      int
+	MaxIRPlayers          int
+	MaxActiveByPosition   map[string]int // Keyed by position short name (e.g. "C", "OF", "SP")
+	RequireMinorsEligible bool           // Flag minors-slot players whose service time has made them ineligible
+	RequireInjuredListIR  bool           // Flag IR-slot players without an Injured List designation
+
+	// MaxMinorsDaysActive flags minors-slot players who have spent more than this many days
+	// in active slots this season, from the team's service time (0 = not enforced)
+	MaxMinorsDaysActive int
+}
+
+// RosterViolation describes a single league rule that a team's roster is breaking
+type RosterViolation struct {
+	Rule       string // Machine-readable rule code (e.g. ViolationIRNotInjured)
+	TeamID     string
+	TeamName   string
+	PlayerID   string // Empty for violations that are not tied to one player
+	PlayerName string
+	Message    string // Human-readable description of the violation
+}
+
+// TeamRosterViolations groups the violations found on a single team's roster
+type TeamRosterViolations struct {
+	TeamID     string
+	TeamName   string
+	Violations []RosterViolation
+}
+
+// HasViolations returns true if the team has at least one violation
+func (t *TeamRosterViolations) HasViolations() bool {
+	return len(t.Violations) > 0
+}
+
+// ViolationsByRule returns the team's violations for the given rule code
+func (t *TeamRosterViolations) ViolationsByRule(rule string) []RosterViolation {
+	var result []RosterViolation
+	for _, v := range t.Violations {
+		if v.Rule == rule {
+			result = append(result, v)
+		}
+	}
+	return result
+}
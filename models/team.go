@@ -0,0 +1,142 @@
+package models
+
+import "strings"
+
+// Team is the canonical fantasy team. The other team shapes convert to it with their
+// Team method, and APIs that return teams refer to them by ID.
+type Team struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	ShortName    string `json:"shortName"`
+	Division     string `json:"division,omitempty"` // Division name; empty in leagues without divisions
+	Commissioner bool   `json:"commissioner"`       // Owned by the commissioner
+	LogoID       string `json:"logoId,omitempty"`
+	LogoURL128   string `json:"logoUrl128,omitempty"`
+	LogoURL256   string `json:"logoUrl256,omitempty"`
+}
+
+// Team converts the roster response's team to the canonical Team
+func (t FantasyTeam) Team() Team {
+	return Team{
+		ID:           t.ID,
+		Name:         t.Name,
+		ShortName:    t.ShortName,
+		Commissioner: t.Commissioner,
+		LogoID:       t.LogoID,
+		LogoURL128:   t.LogoURL128,
+		LogoURL256:   t.LogoURL256,
+	}
+}
+
+// Team converts the league setup page's team to the canonical Team
+func (t LeagueSetupTeam) Team() Team {
+	team := Team{ID: t.TeamID, Name: t.Name, ShortName: t.ShortName}
+	for _, owner := range t.Owners {
+		if owner.IsCommissioner {
+			team.Commissioner = true
+		}
+	}
+	return team
+}
+
+// TeamRegistry holds a league's teams in league order, looked up by ID
+type TeamRegistry struct {
+	Teams []Team `json:"teams"`
+
+	byID map[string]int
+}
+
+// NewTeamRegistry returns a registry of the teams. A team listed twice keeps its first entry.
+func NewTeamRegistry(teams []Team) *TeamRegistry {
+	r := &TeamRegistry{byID: make(map[string]int, len(teams))}
+	for _, team := range teams {
+		r.Add(team)
+	}
+	return r
+}
+
+// Add registers a team, or fills in the fields missing from the team already registered
+// with its ID
+func (r *TeamRegistry) Add(team Team) {
+	if r.byID == nil {
+		r.reindex()
+	}
+	i, ok := r.byID[team.ID]
+	if !ok {
+		r.byID[team.ID] = len(r.Teams)
+		r.Teams = append(r.Teams, team)
+		return
+	}
+	existing := &r.Teams[i]
+	if existing.Name == "" {
+		existing.Name = team.Name
+	}
+	if existing.ShortName == "" {
+		existing.ShortName = team.ShortName
+	}
+	if existing.Division == "" {
+		existing.Division = team.Division
+	}
+	if existing.LogoID == "" {
+		existing.LogoID = team.LogoID
+	}
+	if existing.LogoURL128 == "" {
+		existing.LogoURL128 = team.LogoURL128
+	}
+	if existing.LogoURL256 == "" {
+		existing.LogoURL256 = team.LogoURL256
+	}
+	existing.Commissioner = existing.Commissioner || team.Commissioner
+}
+
+// Get returns the team with the ID
+func (r *TeamRegistry) Get(id string) (Team, bool) {
+	if r.byID == nil {
+		r.reindex()
+	}
+	i, ok := r.byID[id]
+	if !ok {
+		return Team{}, false
+	}
+	return r.Teams[i], true
+}
+
+// Name returns the team's name, or the ID if the team isn't registered
+func (r *TeamRegistry) Name(id string) string {
+	if team, ok := r.Get(id); ok && team.Name != "" {
+		return team.Name
+	}
+	return id
+}
+
+// Find returns the team whose ID, name, or short name matches, ignoring case
+func (r *TeamRegistry) Find(key string) (Team, bool) {
+	if team, ok := r.Get(key); ok {
+		return team, true
+	}
+	for _, team := range r.Teams {
+		if strings.EqualFold(team.Name, key) || strings.EqualFold(team.ShortName, key) {
+			return team, true
+		}
+	}
+	return Team{}, false
+}
+
+// IDs returns the team IDs in league order
+func (r *TeamRegistry) IDs() []string {
+	ids := make([]string, len(r.Teams))
+	for i, team := range r.Teams {
+		ids[i] = team.ID
+	}
+	return ids
+}
+
+// reindex rebuilds the ID index, e.g. after the registry is unmarshaled
+func (r *TeamRegistry) reindex() {
+	r.byID = make(map[string]int, len(r.Teams))
+	for i, team := range r.Teams {
+		if _, ok := r.byID[team.ID]; !ok {
+			r.byID[team.ID] = i
+		}
+	}
+}
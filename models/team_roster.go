@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 // TeamRoster represents a simplified view of a team's roster
 type TeamRoster struct {
 	TeamInfo              TeamInfo
@@ -12,6 +14,9 @@ type TeamRoster struct {
 	IllegalRoster         bool     // True if the roster is illegal for this period
 	IllegalRosterTitle    string   // Summary message (e.g. "This Team roster for this lineup period is illegal...")
 	IllegalRosterMessages []string // Specific violations (e.g. "The maximum number of 15 active player(s) has been exceeded.")
+	StatsView             string   // Timeframe code of the stats shown with each player (e.g. "YEAR_TO_DATE"); empty if unknown
+
+	ParseWarnings []ParseWarning // Rows and cells that could not be parsed; the roster may be incomplete
 }
 
 // TeamInfo contains basic team information
@@ -48,15 +53,92 @@ type RosterPlayer struct {
 
 // GameInfo represents upcoming game information
 type GameInfo struct {
-	Opponent        string
-	DateTime        string
+	Opponent        string // Opponent as displayed, "@" prefixed for road games (e.g. "@PIT")
+	DateTime        string // Game time as displayed (e.g. "Thu 5:40PM"); the first game of a double-header
 	EventID         string
 	ProbablePitcher *PitcherInfo
+	StartTime       time.Time // Resolved from DateTime in the user's timezone; zero if it could not be determined
+
+	OpponentShortName string // Opponent abbreviation without the road marker (e.g. "PIT")
+	OpponentTeamID    string // Opponent's team ID, when Fantrax includes it in the cell
+	Home              bool   // True for home games
+
+	// Double-headers list both games; the second game's time is in SecondDateTime and
+	// SecondStartTime
+	DoubleHeader    bool
+	SecondDateTime  string
+	SecondStartTime time.Time
 }
 
 // PitcherInfo represents opposing pitcher information
 type PitcherInfo struct {
 	Name      string
 	ShortName string
-	Stats     map[string]string
+	Throws    string               // "R" or "L"; empty if the popover does not say
+	Season    ProbablePitcherStats // Season line from the popover
+	Stats     map[string]string    // Every stat label in the popover with its displayed value
+}
+
+// ProbablePitcherStats is the season line shown for a probable pitcher. Fields the popover
+// does not include are nil.
+type ProbablePitcherStats struct {
+	Wins       *int
+	Losses     *int
+	ERA        *float64
+	WHIP       *float64
+	Strikeouts *int
+}
+
+// AllPlayers returns every rostered player regardless of roster status
+func (r *TeamRoster) AllPlayers() []RosterPlayer {
+	players := make([]RosterPlayer, 0, len(r.ActiveRoster)+len(r.ReserveRoster)+len(r.InjuredReserve)+len(r.MinorsRoster))
+	players = append(players, r.ActiveRoster...)
+	players = append(players, r.ReserveRoster...)
+	players = append(players, r.InjuredReserve...)
+	players = append(players, r.MinorsRoster...)
+	return players
+}
+
+// FindPlayer returns the rostered player with the given ID, or nil if the player is not on this roster
+func (r *TeamRoster) FindPlayer(playerID string) *RosterPlayer {
+	for _, player := range r.AllPlayers() {
+		if player.PlayerID == playerID {
+			p := player
+			return &p
+		}
+	}
+	return nil
+}
+
+// IsLocked returns true if the player's next game has started, which locks them in place
+// for lineup changes. Players with no game or an unknown start time are never locked.
+func (p RosterPlayer) IsLocked(now time.Time) bool {
+	return p.NextGame != nil && !p.NextGame.StartTime.IsZero() && !now.Before(p.NextGame.StartTime)
+}
+
+// LockedPlayers returns the rostered players whose games have started
+func (r *TeamRoster) LockedPlayers(now time.Time) []RosterPlayer {
+	var locked []RosterPlayer
+	for _, player := range r.AllPlayers() {
+		if player.IsLocked(now) {
+			locked = append(locked, player)
+		}
+	}
+	return locked
+}
+
+// EarliestLockTime returns the earliest game start after now among rostered players, i.e.
+// the deadline for lineup changes that involve every player. Returns false if no player
+// has an upcoming game with a known start time.
+func (r *TeamRoster) EarliestLockTime(now time.Time) (time.Time, bool) {
+	var earliest time.Time
+	for _, player := range r.AllPlayers() {
+		if player.NextGame == nil || player.NextGame.StartTime.IsZero() || !player.NextGame.StartTime.After(now) {
+			continue
+		}
+		if earliest.IsZero() || player.NextGame.StartTime.Before(earliest) {
+			earliest = player.NextGame.StartTime
+		}
+	}
+	return earliest, !earliest.IsZero()
 }
@@ -237,6 +237,7 @@ func GetInjuryIcons(icons []PlayerIcon) []PlayerIcon {
 type Cell struct {
 	Content string   `json:"content"`
 	EventID string   `json:"eventId,omitempty"`
+	TeamID  string   `json:"teamId,omitempty"`
 	PopOver *PopOver `json:"popOver,omitempty"`
 }
 
@@ -0,0 +1,48 @@
+package models
+
+// TradeBlockResponse is the raw response from getTradeBlocks
+type TradeBlockResponse struct {
+	Responses []struct {
+		Data TradeBlockData `json:"data"`
+	} `json:"responses"`
+}
+
+// TradeBlockData holds every team's trade block along with the league's teams
+type TradeBlockData struct {
+	TradeBlocks  []RawTradeBlock `json:"tradeBlocks"`
+	FantasyTeams []FantasyTeam   `json:"fantasyTeams,omitempty"`
+}
+
+// RawTradeBlock is one team's trade block as Fantrax returns it
+type RawTradeBlock struct {
+	TeamID           string   `json:"teamId"`
+	Note             string   `json:"note"`
+	PlayersOffered   []Player `json:"playersOffered"`
+	PositionsOffered []string `json:"positionsOffered"` // Position IDs
+	PositionsWanted  []string `json:"positionsWanted"`  // Position IDs
+	LastUpdated      string   `json:"lastUpdated,omitempty"`
+}
+
+// TradeBlock is one team's trade block
+type TradeBlock struct {
+	TeamID           string             `json:"teamId"`
+	TeamName         string             `json:"teamName"`
+	PlayersOffered   []TradeBlockPlayer `json:"playersOffered"`
+	PositionsOffered []string           `json:"positionsOffered"` // Position short names (e.g. "SP")
+	PositionsWanted  []string           `json:"positionsWanted"`  // Position short names
+	Note             string             `json:"note,omitempty"`
+	LastUpdated      string             `json:"lastUpdated,omitempty"` // As displayed by Fantrax
+}
+
+// TradeBlockPlayer is a player a team has put on its trade block
+type TradeBlockPlayer struct {
+	PlayerID      string   `json:"playerId"`
+	Name          string   `json:"name"`
+	TeamShortName string   `json:"teamShortName"` // MLB team
+	Positions     []string `json:"positions"`
+}
+
+// Empty reports whether the team has nothing on its trade block
+func (b TradeBlock) Empty() bool {
+	return len(b.PlayersOffered) == 0 && len(b.PositionsOffered) == 0 && len(b.PositionsWanted) == 0 && b.Note == ""
+}
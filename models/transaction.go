@@ -92,6 +92,8 @@ type TransactionRow struct {
 	TransactionType string            `json:"transactionType"`
 	Deleted         bool              `json:"deleted"`
 	Disabled        bool              `json:"disabled,omitempty"`
+	Note            string            `json:"note,omitempty"` // Commissioner note, when sent on the row
+	Msg             string            `json:"msg,omitempty"`  // Trade message, when sent on the row
 	Cells           []TableCell       `json:"cells"`
 	LinkedRows      []interface{}     `json:"linkedRows,omitempty"`
 }
@@ -136,25 +138,29 @@ type TableCell struct {
 
 // Transaction represents a simplified transaction for easier use
 type Transaction struct {
-	ID             string    `json:"id"`
-	Type           string    `json:"type"`                   // "CLAIM", "DROP", "TRADE"
-	ClaimType      string    `json:"claimType,omitempty"`    // "FA" (Free Agent) or "WW" (Waiver Wire) for CLAIM transactions
-	TeamName       string    `json:"teamName"`               // For CLAIM/DROP transactions
-	TeamID         string    `json:"teamId"`                 // For CLAIM/DROP transactions
-	FromTeamName   string    `json:"fromTeamName,omitempty"` // For TRADE transactions
-	FromTeamID     string    `json:"fromTeamId,omitempty"`   // For TRADE transactions
-	ToTeamName     string    `json:"toTeamName,omitempty"`   // For TRADE transactions
-	ToTeamID       string    `json:"toTeamId,omitempty"`     // For TRADE transactions
-	PlayerName     string    `json:"playerName"`
-	PlayerID       string    `json:"playerId"`
-	PlayerTeam     string    `json:"playerTeam"`
-	PlayerPosition string    `json:"playerPosition"`
-	BidAmount      string    `json:"bidAmount,omitempty"`
-	Priority       string    `json:"priority,omitempty"`
-	ProcessedDate  time.Time `json:"processedDate"`
-	Period         int       `json:"period"`
-	Executed       bool      `json:"executed"`
-	ExecutedBy     string    `json:"executedBy,omitempty"`     // "COMMISSIONER" if commissioner executed
-	TradeGroupID   string    `json:"tradeGroupId,omitempty"`   // txSetId for grouping trade players
-	TradeGroupSize int       `json:"tradeGroupSize,omitempty"` // numInGroup for trades
+	ID             string     `json:"id"`
+	Type           string     `json:"type"`                   // "CLAIM", "DROP", "TRADE"
+	ClaimType      string     `json:"claimType,omitempty"`    // "FA" (Free Agent) or "WW" (Waiver Wire) for CLAIM transactions
+	TeamName       string     `json:"teamName"`               // For CLAIM/DROP transactions
+	TeamID         string     `json:"teamId"`                 // For CLAIM/DROP transactions
+	FromTeamName   string     `json:"fromTeamName,omitempty"` // For TRADE transactions
+	FromTeamID     string     `json:"fromTeamId,omitempty"`   // For TRADE transactions
+	ToTeamName     string     `json:"toTeamName,omitempty"`   // For TRADE transactions
+	ToTeamID       string     `json:"toTeamId,omitempty"`     // For TRADE transactions
+	PlayerName     string     `json:"playerName"`
+	PlayerID       string     `json:"playerId"`
+	PlayerTeam     string     `json:"playerTeam"`
+	PlayerPosition string     `json:"playerPosition"`
+	BidAmount      string     `json:"bidAmount,omitempty"`
+	Priority       string     `json:"priority,omitempty"`
+	ProcessedDate  time.Time  `json:"processedDate"`
+	Period         int        `json:"period"`
+	Executed       bool       `json:"executed"`
+	ExecutedBy     string     `json:"executedBy,omitempty"`     // "COMMISSIONER" if commissioner executed
+	TradeGroupID   string     `json:"tradeGroupId,omitempty"`   // txSetId for grouping trade players
+	TradeGroupSize int        `json:"tradeGroupSize,omitempty"` // numInGroup for trades
+	Fee            float64    `json:"fee,omitempty"`            // Fee charged for this move, in leagues that charge per-move fees
+	FeesUsed       bool       `json:"feesUsed,omitempty"`       // True if the league charged fees on this transaction
+	DraftPick      *DraftPick `json:"draftPick,omitempty"`      // Set for trade rows that move a draft pick instead of a player
+	Note           string     `json:"note,omitempty"`           // Free-text note from the commissioner or trade message
 }
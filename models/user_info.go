@@ -1,6 +1,17 @@
 package models
 
+import "time"
+
 // UserInfo contains detailed user information including timezone data
+//
+// Fields that change client behavior:
+//   - Timezone is sent as the "tz" field of fxpa requests, and transaction times in responses
+//     are interpreted in it
+//   - TimezoneCode (falling back to Timezone) is the Location used for game start times.
+//     Commissioner transaction timestamps are sent in UTC.
+//   - UserID being empty after login means authentication failed
+//
+// The remaining fields are informational.
 type UserInfo struct {
 	LName                       string      `json:"lName"`
 	Country                     string      `json:"country"`
@@ -28,9 +39,55 @@ type UserInfo struct {
 	TimezoneDisplay             string      `json:"timezoneDisplay"` // Display name (e.g., "CDT")
 	ChatNotificationTypeDefault string      `json:"chatNotificationTypeDefault"`
 	Username                    string      `json:"username"`
+
+	// Leagues the user belongs to, when the login response lists them. The field hasn't been
+	// seen in a captured login response, so it may always be empty; GetMyTeamID falls back
+	// to the roster page when it is.
+	Leagues []UserLeague `json:"leagues,omitempty"`
+}
+
+// UserLeague is one league the logged-in user belongs to, along with their team in it
+type UserLeague struct {
+	LeagueID   string `json:"leagueId"`
+	LeagueName string `json:"leagueName"`
+	TeamID     string `json:"teamId,omitempty"`
+	TeamName   string `json:"teamName,omitempty"`
+	Sport      string `json:"sport,omitempty"`
+}
+
+// UTCOffset parses Timezone (e.g. "-0500") into an offset from UTC. ok is false if the
+// offset is missing or malformed.
+func (u *UserInfo) UTCOffset() (offset time.Duration, ok bool) {
+	if u == nil {
+		return 0, false
+	}
+	t, err := time.Parse("-0700", u.Timezone)
+	if err != nil {
+		return 0, false
+	}
+	_, seconds := t.Zone()
+	return time.Duration(seconds) * time.Second, true
+}
+
+// Location returns the user's timezone. TimezoneCode (e.g. "US/Central") is preferred since
+// it tracks daylight saving changes; otherwise the current Timezone offset is used as a fixed
+// zone. Returns UTC when neither is usable, including on a nil UserInfo.
+func (u *UserInfo) Location() *time.Location {
+	if u == nil {
+		return time.UTC
+	}
+	if u.TimezoneCode != "" {
+		if loc, err := time.LoadLocation(u.TimezoneCode); err == nil {
+			return loc
+		}
+	}
+	if offset, ok := u.UTCOffset(); ok {
+		return time.FixedZone(u.Timezone, int(offset/time.Second))
+	}
+	return time.UTC
 }
 
-// LookAndFeel contains UI preferences
+// LookAndFeel contains the user's Fantrax UI preferences. The client does not use them.
 type LookAndFeel struct {
 	UIScalingContent       float64 `json:"uiScalingContent"`
 	UIScalingSpacing       float64 `json:"uiScalingSpacing"`
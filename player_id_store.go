@@ -0,0 +1,216 @@
+package fantrax
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// PlayerIDStore keeps each sport's player ID map on disk. The map is large and rarely
+// changes, so it is reused until it is older than TTL and then refreshed in place.
+//
+// getPlayerIds can't be asked for changed players only, so a refresh downloads the whole map
+// unless Fantrax answers the conditional request with 304 Not Modified. That needs an ETag or
+// Last-Modified header on the previous response; when Fantrax sends neither, every refresh is
+// a full download, and only applying it to the stored map is incremental.
+type PlayerIDStore struct {
+	Dir string
+	TTL time.Duration
+}
+
+// NewPlayerIDStore creates a player ID store in dir
+//
+// Parameters:
+//   - dir: Directory to keep one file per sport in
+//   - ttl: How long a stored map is used before it is refreshed; zero never refreshes it
+func NewPlayerIDStore(dir string, ttl time.Duration) (*PlayerIDStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create player ID store directory: %w", err)
+	}
+	return &PlayerIDStore{Dir: dir, TTL: ttl}, nil
+}
+
+// playerIDSnapshot is the stored form of one sport's player ID map
+type playerIDSnapshot struct {
+	Sport      Sport             `json:"sport"`
+	FetchedAt  time.Time         `json:"fetchedAt"` // Last time the map was downloaded or confirmed unchanged
+	Validators CacheValidators   `json:"validators"`
+	Players    map[string]Player `json:"players"`
+}
+
+func (s *PlayerIDStore) path(sport Sport) string {
+	return filepath.Join(s.Dir, fmt.Sprintf("player-ids-%s.json", sport))
+}
+
+// Load returns the stored player ID map for a sport and when it was last refreshed. found
+// is false if nothing has been stored for the sport yet.
+func (s *PlayerIDStore) Load(sport Sport) (players map[string]Player, fetchedAt time.Time, found bool, err error) {
+	snapshot, err := s.load(sport)
+	if err != nil || snapshot == nil {
+		return nil, time.Time{}, false, err
+	}
+	return snapshot.Players, snapshot.FetchedAt, true, nil
+}
+
+func (s *PlayerIDStore) load(sport Sport) (*playerIDSnapshot, error) {
+	data, err := os.ReadFile(s.path(sport))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read player ID store: %w", err)
+	}
+	var snapshot playerIDSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse player ID store: %w", err)
+	}
+	return &snapshot, nil
+}
+
+func (s *PlayerIDStore) save(snapshot *playerIDSnapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode player ID store: %w", err)
+	}
+	// Write to a temporary file first so a crash never leaves a truncated map behind
+	tmp := s.path(snapshot.Sport) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write player ID store: %w", err)
+	}
+	return os.Rename(tmp, s.path(snapshot.Sport))
+}
+
+func (s *PlayerIDStore) fresh(snapshot *playerIDSnapshot) bool {
+	return snapshot != nil && (s.TTL <= 0 || time.Since(snapshot.FetchedAt) < s.TTL)
+}
+
+// PlayerIDChanges lists the Fantrax IDs that changed in a player ID refresh
+type PlayerIDChanges struct {
+	Sport     Sport    `json:"sport"`
+	Unchanged bool     `json:"unchanged"` // True if the server reported the map unchanged
+	Added     []string `json:"added,omitempty"`
+	Updated   []string `json:"updated,omitempty"`
+	Removed   []string `json:"removed,omitempty"`
+
+	// Revalidates is false when the response had no ETag or Last-Modified header, so the next
+	// refresh will download the full map again
+	Revalidates bool `json:"revalidates"`
+}
+
+// WithPlayerIDStore keeps player ID maps in store. GetPlayerIds and GetTeamRostersDetailed
+// then read from disk until the stored map expires, and refresh it after that (see
+// PlayerIDStore for what a refresh downloads).
+func WithPlayerIDStore(store *PlayerIDStore) ClientOption {
+	return func(c *Client) {
+		c.PlayerIDStore = store
+	}
+}
+
+// RefreshPlayerIds updates the stored player ID map for a sport. The download is skipped
+// when the server confirms the map is unchanged; otherwise the full map is downloaded and
+// only added, changed, and removed players are applied to the stored map. The client must be
+// created with WithPlayerIDStore.
+//
+// Parameters:
+//   - sport: The sport whose map to refresh
+func (c *Client) RefreshPlayerIds(sport Sport) (*PlayerIDChanges, error) {
+	_, changes, err := c.refreshPlayerIds(sport)
+	return changes, err
+}
+
+func (c *Client) refreshPlayerIds(sport Sport) (*playerIDSnapshot, *PlayerIDChanges, error) {
+	if c.PlayerIDStore == nil {
+		return nil, nil, fmt.Errorf("no player ID store configured")
+	}
+
+	snapshot, err := c.PlayerIDStore.load(sport)
+	if err != nil {
+		return nil, nil, err
+	}
+	if snapshot == nil {
+		snapshot = &playerIDSnapshot{Sport: sport, Players: make(map[string]Player)}
+	}
+
+	endpoint := "/general/getPlayerIds"
+	body, validators, notModified, err := c.makeConditionalRequest(endpoint, map[string]string{"sport": string(sport)}, snapshot.Validators)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get player IDs: %w", err)
+	}
+
+	changes := &PlayerIDChanges{Sport: sport, Unchanged: notModified}
+	if !notModified {
+		var latest map[string]Player
+		if err := c.decode(endpoint, body, &latest); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse player IDs: %w", err)
+		}
+		applyPlayerIDChanges(snapshot.Players, latest, changes)
+		snapshot.Validators = validators
+	}
+	changes.Revalidates = !snapshot.Validators.IsZero()
+	snapshot.FetchedAt = time.Now()
+
+	if err := c.PlayerIDStore.save(snapshot); err != nil {
+		return nil, nil, err
+	}
+	return snapshot, changes, nil
+}
+
+// applyPlayerIDChanges updates stored in place to match latest, recording which IDs changed
+func applyPlayerIDChanges(stored, latest map[string]Player, changes *PlayerIDChanges) {
+	for id, player := range latest {
+		previous, ok := stored[id]
+		switch {
+		case !ok:
+			changes.Added = append(changes.Added, id)
+		case !reflect.DeepEqual(previous, player):
+			changes.Updated = append(changes.Updated, id)
+		default:
+			continue
+		}
+		stored[id] = player
+	}
+	for id := range stored {
+		if _, ok := latest[id]; !ok {
+			changes.Removed = append(changes.Removed, id)
+			delete(stored, id)
+		}
+	}
+	sort.Strings(changes.Added)
+	sort.Strings(changes.Updated)
+	sort.Strings(changes.Removed)
+}
+
+// allPlayerIds returns the full player ID map for a sport, including players without a team,
+// from the player ID store when one is configured
+func (c *Client) allPlayerIds(sport Sport) (map[string]Player, error) {
+	if c.PlayerIDStore != nil {
+		snapshot, err := c.PlayerIDStore.load(sport)
+		if err != nil {
+			return nil, err
+		}
+		if !c.PlayerIDStore.fresh(snapshot) {
+			if snapshot, _, err = c.refreshPlayerIds(sport); err != nil {
+				return nil, err
+			}
+		}
+		players := make(map[string]Player, len(snapshot.Players))
+		for id, player := range snapshot.Players {
+			players[id] = player
+		}
+		return players, nil
+	}
+
+	raw, err := c.GetPlayerIdsRaw(sport)
+	if err != nil {
+		return nil, err
+	}
+	var players map[string]Player
+	if err := c.decode("/general/getPlayerIds", raw, &players); err != nil {
+		return nil, fmt.Errorf("failed to parse player IDs: %w", err)
+	}
+	return players, nil
+}
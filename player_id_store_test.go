@@ -0,0 +1,70 @@
+package fantrax
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRefreshPlayerIds(t *testing.T) {
+	body := `{"p1": {"name": "A", "fantraxId": "p1", "team": "NYY", "position": "C"},
+		"p2": {"name": "B", "fantraxId": "p2", "team": "BOS", "position": "1B"}}`
+	etag := `"v1"`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	store, err := NewPlayerIDStore(t.TempDir(), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := NewClient("league", false, WithPlayerIDStore(store))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.BaseURL = server.URL
+
+	changes, err := client.RefreshPlayerIds(MLB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(changes.Added, []string{"p1", "p2"}) || !changes.Revalidates {
+		t.Errorf("first refresh added %v (revalidates=%v), want [p1 p2]", changes.Added, changes.Revalidates)
+	}
+
+	changes, err = client.RefreshPlayerIds(MLB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changes.Unchanged {
+		t.Errorf("second refresh should be a conditional hit, got %+v", changes)
+	}
+
+	body = `{"p1": {"name": "A", "fantraxId": "p1", "team": "LAD", "position": "C"},
+		"p3": {"name": "C", "fantraxId": "p3", "team": "SEA", "position": "SP"}}`
+	etag = `"v2"`
+	changes, err = client.RefreshPlayerIds(MLB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &PlayerIDChanges{Sport: MLB, Added: []string{"p3"}, Updated: []string{"p1"}, Removed: []string{"p2"}, Revalidates: true}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("got %+v, want %+v", changes, want)
+	}
+
+	players, _, found, err := store.Load(MLB)
+	if err != nil || !found {
+		t.Fatalf("Load: found=%v err=%v", found, err)
+	}
+	if len(players) != 2 || players["p1"].Team != "LAD" {
+		t.Errorf("stored map not updated: %+v", players)
+	}
+}
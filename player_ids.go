@@ -1,6 +1,9 @@
 package fantrax
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+)
 
 type Sport string
 
@@ -30,17 +33,28 @@ type Player struct {
 // PlayersResponse represents the response from the getPlayerIds endpoint
 // It's a map of fantraxId to PlayerStatus details
 
-// GetPlayerIds gets the list of all players in the database for a particular sport
-func (c *Client) GetPlayerIds(sport Sport) (*map[string]Player, error) {
+// GetPlayerIdsRaw fetches the getPlayerIds response without parsing it. Unlike GetPlayerIds,
+// players without a team are included.
+func (c *Client) GetPlayerIdsRaw(sport Sport) (json.RawMessage, error) {
 	endpoint := "/general/getPlayerIds"
 	params := map[string]string{"sport": string(sport)}
 
-	var results map[string]Player
-	err := c.fetchWithCache(endpoint, params, &results)
+	var raw json.RawMessage
+	err := c.fetchWithCache(endpoint, params, &raw)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get player IDs: %w", err)
 	}
 
+	return raw, nil
+}
+
+// GetPlayerIds gets the list of all players in the database for a particular sport
+func (c *Client) GetPlayerIds(sport Sport) (*map[string]Player, error) {
+	results, err := c.allPlayerIds(sport)
+	if err != nil {
+		return nil, err
+	}
+
 	// Filter out team aggregate records (Team, Team Pitching, Team Hitting)
 	// These records have an empty "team" field since the API uses "teamName" instead
 	for id, player := range results {
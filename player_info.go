@@ -1,6 +1,9 @@
 package fantrax
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+)
 
 // PlayerInfo represents a player with their Average Draft Position (ADP)
 type PlayerInfo struct {
@@ -54,8 +57,8 @@ func WithShowAllPositions(showAllPositions bool) PlayerInfoOption {
 	}
 }
 
-// GetPlayerInfo gets player info including ADP, and allows for sorting, filtering, and limiting results
-func (c *Client) GetPlayerInfo(sport Sport, opts ...PlayerInfoOption) (*PlayerInfoResponse, error) {
+// GetPlayerInfoRaw fetches the getAdp response without parsing it
+func (c *Client) GetPlayerInfoRaw(sport Sport, opts ...PlayerInfoOption) (json.RawMessage, error) {
 	endpoint := "/general/getAdp"
 	params := map[string]string{"sport": string(sport)}
 
@@ -80,11 +83,26 @@ func (c *Client) GetPlayerInfo(sport Sport, opts ...PlayerInfoOption) (*PlayerIn
 		params["showAllPositions"] = "true"
 	}
 
-	var results PlayerInfoResponse
-	err := c.fetchWithCache(endpoint, params, &results)
+	var raw json.RawMessage
+	err := c.fetchWithCache(endpoint, params, &raw)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get player info: %w", err)
 	}
 
+	return raw, nil
+}
+
+// GetPlayerInfo gets player info including ADP, and allows for sorting, filtering, and limiting results
+func (c *Client) GetPlayerInfo(sport Sport, opts ...PlayerInfoOption) (*PlayerInfoResponse, error) {
+	raw, err := c.GetPlayerInfoRaw(sport, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var results PlayerInfoResponse
+	if err := c.decode("/general/getAdp", raw, &results); err != nil {
+		return nil, fmt.Errorf("failed to parse player info: %w", err)
+	}
+
 	return &results, nil
 }
@@ -0,0 +1,165 @@
+package projections
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pmurley/go-fantrax"
+)
+
+// AuctionSettings describes the league economy used to price players
+type AuctionSettings struct {
+	Teams  int     // Number of teams in the league
+	Budget float64 // Auction budget per team
+	MinBid float64 // Minimum bid (every drafted player is worth at least this much)
+
+	// Slots is the number of active roster slots per team at each position short name
+	Slots map[string]int
+
+	// ReserveSlots is the number of bench slots per team. Bench players are valued against
+	// the best player left undrafted.
+	ReserveSlots int
+}
+
+// AuctionSettingsFromLeagueInfo builds auction settings from the league's teams and roster
+// configuration
+//
+// Parameters:
+//   - info: League settings from GetLeagueInfo
+//   - budget: Auction budget per team (Fantrax does not expose it in league info)
+func AuctionSettingsFromLeagueInfo(info *fantrax.LeagueInfo, budget float64) AuctionSettings {
+	settings := AuctionSettings{
+		Teams:  len(info.TeamInfo),
+		Budget: budget,
+		MinBid: 1,
+		Slots:  make(map[string]int, len(info.RosterInfo.PositionConstraints)),
+	}
+	for pos, constraint := range info.RosterInfo.PositionConstraints {
+		settings.Slots[pos] = constraint.MaxActive
+	}
+	if reserve := info.RosterInfo.MaxTotalPlayers - info.RosterInfo.MaxTotalActivePlayers; reserve > 0 {
+		settings.ReserveSlots = reserve
+	}
+	return settings
+}
+
+// AuctionValue is a player's projected auction price
+type AuctionValue struct {
+	PlayerValue
+	Slot                   string  // Position slot the player fills in the league-wide draft, or "BN" for bench
+	ReplacementPoints      float64 // Projected points of the replacement-level player at Slot
+	PointsAboveReplacement float64
+	Dollars                float64
+}
+
+// CalculateAuctionValues prices players from their projected points
+//
+// Players are assigned, best first, to the first eligible position slot with room across the
+// whole league; once active slots are full the next best players fill the bench. Each slot's
+// replacement level is the best projected player left over who is eligible for it. Surplus
+// dollars (total budget minus a minimum bid per drafted player) are shared out in proportion
+// to points above replacement. Players who would not be drafted are omitted.
+//
+// Parameters:
+//   - values: Ranked player values (e.g. from Value)
+//   - settings: League economy and roster slots
+func CalculateAuctionValues(values []PlayerValue, settings AuctionSettings) []AuctionValue {
+	sorted := make([]PlayerValue, len(values))
+	copy(sorted, values)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ProjectedPoints > sorted[j].ProjectedPoints
+	})
+
+	// Fill scarce slots first so a multi-position player does not take a plentiful slot
+	// another player could have filled
+	slotNames := make([]string, 0, len(settings.Slots))
+	remaining := make(map[string]int, len(settings.Slots))
+	for pos, count := range settings.Slots {
+		slotNames = append(slotNames, pos)
+		remaining[pos] = count * settings.Teams
+	}
+	sort.Slice(slotNames, func(i, j int) bool {
+		if settings.Slots[slotNames[i]] != settings.Slots[slotNames[j]] {
+			return settings.Slots[slotNames[i]] < settings.Slots[slotNames[j]]
+		}
+		return slotNames[i] < slotNames[j]
+	})
+
+	bench := settings.ReserveSlots * settings.Teams
+	var drafted []AuctionValue
+	var undrafted []PlayerValue
+	for _, v := range sorted {
+		eligible := playerPositions(v)
+		slot := ""
+		for _, pos := range slotNames {
+			if remaining[pos] > 0 && eligible[pos] {
+				slot = pos
+				break
+			}
+		}
+		switch {
+		case slot != "":
+			remaining[slot]--
+		case bench > 0:
+			slot = "BN"
+			bench--
+		default:
+			undrafted = append(undrafted, v)
+			continue
+		}
+		drafted = append(drafted, AuctionValue{PlayerValue: v, Slot: slot})
+	}
+
+	// Replacement level: best undrafted player eligible at each slot
+	replacement := make(map[string]float64)
+	for _, v := range undrafted {
+		if _, ok := replacement["BN"]; !ok {
+			replacement["BN"] = v.ProjectedPoints
+		}
+		for pos := range playerPositions(v) {
+			if _, ok := replacement[pos]; !ok {
+				replacement[pos] = v.ProjectedPoints
+			}
+		}
+	}
+
+	totalAbove := 0.0
+	for i := range drafted {
+		d := &drafted[i]
+		d.ReplacementPoints = replacement[d.Slot]
+		d.PointsAboveReplacement = d.ProjectedPoints - d.ReplacementPoints
+		if d.PointsAboveReplacement < 0 {
+			d.PointsAboveReplacement = 0
+		}
+		totalAbove += d.PointsAboveReplacement
+	}
+
+	surplus := float64(settings.Teams)*settings.Budget - float64(len(drafted))*settings.MinBid
+	for i := range drafted {
+		d := &drafted[i]
+		d.Dollars = settings.MinBid
+		if totalAbove > 0 && surplus > 0 {
+			d.Dollars += surplus * d.PointsAboveReplacement / totalAbove
+		}
+	}
+
+	sort.SliceStable(drafted, func(i, j int) bool {
+		return drafted[i].Dollars > drafted[j].Dollars
+	})
+	return drafted
+}
+
+// playerPositions returns the set of position short names a player is eligible at
+func playerPositions(v PlayerValue) map[string]bool {
+	positions := make(map[string]bool)
+	names := v.Player.MultiPositions
+	if names == "" {
+		names = v.Player.PosShortNames
+	}
+	for _, pos := range strings.Split(names, ",") {
+		if pos = strings.TrimSpace(pos); pos != "" {
+			positions[pos] = true
+		}
+	}
+	return positions
+}
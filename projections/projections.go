@@ -0,0 +1,135 @@
+// Package projections loads external stat projections, joins them onto the Fantrax player
+// pool and values players using the league's own scoring settings.
+package projections
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Projection is one player's projected stat line from an external source
+type Projection struct {
+	ID    string             // The source's player ID (may be a Fantrax ID, see ColumnMapping.IDType)
+	Name  string             // Player name as written by the source
+	Team  string             // Professional team abbreviation
+	Stats map[string]float64 // Keyed by Fantrax stat short name (e.g. "HR", "K")
+}
+
+// IDType identifies which ID system a projection file uses
+type IDType string
+
+// Supported projection ID systems
+const (
+	IDTypeFantrax    IDType = "fantrax"
+	IDTypeRotowire   IDType = "rotowire"
+	IDTypeStatsInc   IDType = "statsinc"
+	IDTypeSportRadar IDType = "sportradar"
+	IDTypeNone       IDType = "" // No ID column; players are matched by name and team
+)
+
+// ColumnMapping tells LoadCSV which CSV columns hold which values. Column names are matched
+// case-insensitively against the header row.
+type ColumnMapping struct {
+	ID     string // Column holding the player ID (optional)
+	IDType IDType // ID system used by the ID column
+	Name   string // Column holding the player name
+	Team   string // Column holding the team abbreviation (optional)
+
+	// Stats maps Fantrax stat short names to the CSV column holding that projection
+	// (e.g. {"K": "SO"} when the source calls strikeouts SO)
+	Stats map[string]string
+}
+
+// LoadCSV reads projections from CSV using the given column mapping
+//
+// Empty stat cells are treated as zero; non-numeric stat cells are an error.
+func LoadCSV(r io.Reader, mapping ColumnMapping) ([]Projection, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read projection header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	column := func(name string) (int, error) {
+		if name == "" {
+			return -1, nil
+		}
+		i, ok := columns[strings.ToLower(name)]
+		if !ok {
+			return -1, fmt.Errorf("projection CSV has no %q column", name)
+		}
+		return i, nil
+	}
+
+	idCol, err := column(mapping.ID)
+	if err != nil {
+		return nil, err
+	}
+	nameCol, err := column(mapping.Name)
+	if err != nil {
+		return nil, err
+	}
+	teamCol, err := column(mapping.Team)
+	if err != nil {
+		return nil, err
+	}
+	if idCol < 0 && nameCol < 0 {
+		return nil, fmt.Errorf("projection mapping needs an ID or name column")
+	}
+	statCols := make(map[string]int, len(mapping.Stats))
+	for stat, name := range mapping.Stats {
+		i, err := column(name)
+		if err != nil {
+			return nil, err
+		}
+		statCols[stat] = i
+	}
+
+	var projections []Projection
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read projection line %d: %w", line, err)
+		}
+
+		cell := func(i int) string {
+			if i < 0 || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		p := Projection{
+			ID:    cell(idCol),
+			Name:  cell(nameCol),
+			Team:  cell(teamCol),
+			Stats: make(map[string]float64, len(statCols)),
+		}
+		for stat, i := range statCols {
+			text := strings.ReplaceAll(cell(i), ",", "")
+			if text == "" {
+				continue
+			}
+			value, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, fmt.Errorf("projection line %d: invalid %s value %q", line, stat, cell(i))
+			}
+			p.Stats[stat] = value
+		}
+		projections = append(projections, p)
+	}
+
+	return projections, nil
+}
@@ -0,0 +1,65 @@
+package projections
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pmurley/go-fantrax/auth_client"
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func TestLoadJoinAndValue(t *testing.T) {
+	csvData := "Name,Team,HR,SO\nJosé Ramírez,CLE,30,0\nLuis García Jr.,WSH,10,0\nNobody,FA,1,0\n"
+	projections, err := LoadCSV(strings.NewReader(csvData), ColumnMapping{
+		Name:  "name",
+		Team:  "team",
+		Stats: map[string]string{"HR": "HR", "K": "SO"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(projections) != 3 || projections[0].Stats["HR"] != 30 {
+		t.Fatalf("unexpected projections: %+v", projections)
+	}
+
+	pool := []models.PoolPlayer{
+		{PlayerID: "a", Name: "Jose Ramirez", MLBTeamShortName: "CLE", PosShortNames: "3B"},
+		{PlayerID: "b", Name: "Luis Garcia", MLBTeamShortName: "WSH", PosShortNames: "2B"},
+	}
+	matched, unmatched := Join(projections, pool, nil)
+	if len(matched) != 2 || len(unmatched) != 1 {
+		t.Fatalf("expected 2 matched and 1 unmatched, got %d and %d", len(matched), len(unmatched))
+	}
+
+	scoring := &Scoring{Points: map[string]map[string]float64{"HITTING": {"HR": 4}}}
+	values := Value(pool, matched, scoring)
+	if values[0].Player.PlayerID != "a" || values[0].ProjectedPoints != 120 || values[0].Rank != 1 {
+		t.Errorf("unexpected top value: %+v", values[0])
+	}
+
+	var source auth_client.PlayerValueSource = NewProjectedPoints(values)
+	if points, ok := source.PlayerValue("a"); !ok || points != 120 {
+		t.Errorf("PlayerValue(a) = %v, %v; want 120", points, ok)
+	}
+	if _, ok := source.PlayerValue("nobody"); ok {
+		t.Error("a player without a projection was valued")
+	}
+}
+
+func TestCalculateAuctionValues(t *testing.T) {
+	values := []PlayerValue{
+		{Player: models.PoolPlayer{PlayerID: "c1", PosShortNames: "C"}, ProjectedPoints: 300},
+		{Player: models.PoolPlayer{PlayerID: "c2", PosShortNames: "C"}, ProjectedPoints: 200},
+		{Player: models.PoolPlayer{PlayerID: "c3", PosShortNames: "C"}, ProjectedPoints: 100},
+	}
+	settings := AuctionSettings{Teams: 2, Budget: 10, MinBid: 1, Slots: map[string]int{"C": 1}}
+
+	auction := CalculateAuctionValues(values, settings)
+	if len(auction) != 2 {
+		t.Fatalf("expected 2 drafted players, got %d", len(auction))
+	}
+	// $20 total, $2 in minimum bids, $18 split 200:100 above the 100-point replacement
+	if auction[0].Dollars != 13 || auction[1].Dollars != 7 {
+		t.Errorf("unexpected dollars: %.2f, %.2f", auction[0].Dollars, auction[1].Dollars)
+	}
+}
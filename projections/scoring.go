@@ -0,0 +1,67 @@
+package projections
+
+import (
+	"strings"
+
+	"github.com/pmurley/go-fantrax"
+)
+
+// Scoring holds the points awarded per unit of each stat, by scoring group
+type Scoring struct {
+	// Points is keyed by group code (e.g. "HITTING", "PITCHING") then stat short name
+	Points map[string]map[string]float64
+
+	// GroupFor returns the scoring group for a player given their eligible position short
+	// names. Defaults to DefaultGroupFor.
+	GroupFor func(positions []string) string
+}
+
+// pitcherPositions are the position short names DefaultGroupFor treats as pitchers
+var pitcherPositions = map[string]bool{"SP": true, "RP": true, "P": true}
+
+// DefaultGroupFor puts pitchers (SP, RP, P) in "PITCHING" and everyone else in "HITTING".
+// A two-way player eligible at both is scored as a hitter.
+func DefaultGroupFor(positions []string) string {
+	if len(positions) == 0 {
+		return "HITTING"
+	}
+	for _, pos := range positions {
+		if !pitcherPositions[strings.TrimSpace(pos)] {
+			return "HITTING"
+		}
+	}
+	return "PITCHING"
+}
+
+// ScoringFromLeagueInfo reads the league's points-per-stat settings
+//
+// When a stat has different values for different positions within a group, the first
+// configuration listed is used.
+func ScoringFromLeagueInfo(info *fantrax.LeagueInfo) *Scoring {
+	scoring := &Scoring{
+		Points:   make(map[string]map[string]float64),
+		GroupFor: DefaultGroupFor,
+	}
+	for _, setting := range info.ScoringSystem.ScoringCategorySettings {
+		group := setting.Group.Code
+		if scoring.Points[group] == nil {
+			scoring.Points[group] = make(map[string]float64)
+		}
+		for _, config := range setting.Configs {
+			stat := config.ScoringCategory.ShortName
+			if _, seen := scoring.Points[group][stat]; !seen {
+				scoring.Points[group][stat] = config.Points
+			}
+		}
+	}
+	return scoring
+}
+
+// FantasyPoints returns the projected fantasy points for a stat line in the given group
+func (s *Scoring) FantasyPoints(group string, stats map[string]float64) float64 {
+	total := 0.0
+	for stat, points := range s.Points[group] {
+		total += stats[stat] * points
+	}
+	return total
+}
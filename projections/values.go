@@ -0,0 +1,182 @@
+package projections
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pmurley/go-fantrax"
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// PlayerValue is a pool player joined to their projection and valued with league scoring
+type PlayerValue struct {
+	Player          models.PoolPlayer
+	Projection      Projection
+	Group           string  // Scoring group used (e.g. "HITTING")
+	ProjectedPoints float64 // Projected fantasy points under the league's scoring
+	Rank            int     // 1 = most valuable
+}
+
+// IDMap maps a projection source's player IDs to Fantrax player IDs
+type IDMap map[string]string
+
+// IDMapFromPlayerIds builds an IDMap from the public API's player ID list (GetPlayerIds)
+func IDMapFromPlayerIds(players map[string]fantrax.Player, idType IDType) IDMap {
+	ids := make(IDMap, len(players))
+	for fantraxID, p := range players {
+		switch idType {
+		case IDTypeFantrax:
+			ids[fantraxID] = fantraxID
+		case IDTypeRotowire:
+			if p.RotowireId != nil {
+				ids[strconv.Itoa(*p.RotowireId)] = fantraxID
+			}
+		case IDTypeStatsInc:
+			if p.StatsIncId != nil {
+				ids[strconv.Itoa(*p.StatsIncId)] = fantraxID
+			}
+		case IDTypeSportRadar:
+			if p.SportRadarId != nil {
+				ids[*p.SportRadarId] = fantraxID
+			}
+		}
+	}
+	return ids
+}
+
+// Join matches projections to pool players
+//
+// A projection is matched by ID through ids when possible (pass nil when the projection IDs
+// are already Fantrax IDs or there are none), then by normalized name plus team, then by
+// normalized name alone if that name is unique in the pool. Unmatched projections are
+// returned separately.
+func Join(projections []Projection, pool []models.PoolPlayer, ids IDMap) (matched map[string]Projection, unmatched []Projection) {
+	byID := make(map[string]bool, len(pool))
+	byNameTeam := make(map[string]string, len(pool))
+	byName := make(map[string][]string, len(pool))
+	for _, p := range pool {
+		byID[p.PlayerID] = true
+		name := NormalizeName(p.Name)
+		byNameTeam[name+"|"+strings.ToUpper(p.MLBTeamShortName)] = p.PlayerID
+		byName[name] = append(byName[name], p.PlayerID)
+	}
+
+	matched = make(map[string]Projection)
+	for _, proj := range projections {
+		playerID := ""
+		if proj.ID != "" {
+			if ids != nil {
+				playerID = ids[proj.ID]
+			} else if byID[proj.ID] {
+				playerID = proj.ID
+			}
+		}
+		if playerID == "" && proj.Name != "" {
+			name := NormalizeName(proj.Name)
+			if id, ok := byNameTeam[name+"|"+strings.ToUpper(proj.Team)]; ok {
+				playerID = id
+			} else if candidates := byName[name]; len(candidates) == 1 {
+				playerID = candidates[0]
+			}
+		}
+
+		if playerID == "" || !byID[playerID] {
+			unmatched = append(unmatched, proj)
+			continue
+		}
+		matched[playerID] = proj
+	}
+	return matched, unmatched
+}
+
+// Value computes projected fantasy points for every pool player with a projection and
+// returns them ranked, highest first
+func Value(pool []models.PoolPlayer, matched map[string]Projection, scoring *Scoring) []PlayerValue {
+	groupFor := scoring.GroupFor
+	if groupFor == nil {
+		groupFor = DefaultGroupFor
+	}
+
+	var values []PlayerValue
+	for _, player := range pool {
+		proj, ok := matched[player.PlayerID]
+		if !ok {
+			continue
+		}
+		group := groupFor(strings.Split(player.PosShortNames, ","))
+		values = append(values, PlayerValue{
+			Player:          player,
+			Projection:      proj,
+			Group:           group,
+			ProjectedPoints: scoring.FantasyPoints(group, proj.Stats),
+		})
+	}
+
+	sort.SliceStable(values, func(i, j int) bool {
+		return values[i].ProjectedPoints > values[j].ProjectedPoints
+	})
+	for i := range values {
+		values[i].Rank = i + 1
+	}
+	return values
+}
+
+// ProjectedPoints maps Fantrax player IDs to projected fantasy points. It implements
+// auth_client.PlayerValueSource, so trades can be valued on projections.
+type ProjectedPoints map[string]float64
+
+// NewProjectedPoints builds a ProjectedPoints from the results of Value
+func NewProjectedPoints(values []PlayerValue) ProjectedPoints {
+	points := make(ProjectedPoints, len(values))
+	for _, v := range values {
+		points[v.Player.PlayerID] = v.ProjectedPoints
+	}
+	return points
+}
+
+// PlayerValue returns the player's projected points and whether they have a projection
+func (p ProjectedPoints) PlayerValue(playerID string) (float64, bool) {
+	points, ok := p[playerID]
+	return points, ok
+}
+
+// NormalizeName lowercases a player name and strips accents, punctuation and suffixes such
+// as "Jr." so that names from different sources compare equal
+func NormalizeName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		r = unicode.ToLower(r)
+		if plain, ok := accentFolds[r]; ok {
+			r = plain
+		}
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		case unicode.IsSpace(r) || r == '-':
+			b.WriteRune(' ')
+		}
+	}
+
+	words := strings.Fields(b.String())
+	for len(words) > 1 {
+		switch words[len(words)-1] {
+		case "jr", "sr", "ii", "iii", "iv":
+			words = words[:len(words)-1]
+			continue
+		}
+		break
+	}
+	return strings.Join(words, " ")
+}
+
+// accentFolds maps the accented letters common in player names to their plain forms
+var accentFolds = map[rune]rune{
+	'á': 'a', 'à': 'a', 'â': 'a', 'ä': 'a', 'ã': 'a', 'å': 'a',
+	'é': 'e', 'è': 'e', 'ê': 'e', 'ë': 'e',
+	'í': 'i', 'ì': 'i', 'î': 'i', 'ï': 'i',
+	'ó': 'o', 'ò': 'o', 'ô': 'o', 'ö': 'o', 'õ': 'o', 'ø': 'o',
+	'ú': 'u', 'ù': 'u', 'û': 'u', 'ü': 'u',
+	'ñ': 'n', 'ç': 'c', 'ý': 'y', 'ÿ': 'y', 'š': 's', 'ž': 'z', 'č': 'c', 'ć': 'c',
+}
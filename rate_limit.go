@@ -0,0 +1,56 @@
+package fantrax
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimiter spaces requests at least MinInterval apart. One limiter can be shared by
+// several clients, including auth_client clients, so they stay under a combined rate.
+// A nil *RateLimiter does not limit.
+type RateLimiter struct {
+	MinInterval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// NewRateLimiter creates a limiter allowing one request per minInterval
+func NewRateLimiter(minInterval time.Duration) *RateLimiter {
+	return &RateLimiter{MinInterval: minInterval}
+}
+
+// Wait blocks until the next request may be sent
+func (r *RateLimiter) Wait() {
+	if r == nil || r.MinInterval <= 0 {
+		return
+	}
+	r.mu.Lock()
+	now := time.Now()
+	wait := r.next.Sub(now)
+	if wait < 0 {
+		wait = 0
+	}
+	r.next = now.Add(wait + r.MinInterval)
+	r.mu.Unlock()
+
+	time.Sleep(wait)
+}
+
+// WithRateLimiter makes the client wait on limiter before every request to Fantrax. Cached
+// responses are not limited.
+func WithRateLimiter(limiter *RateLimiter) ClientOption {
+	return func(c *Client) {
+		c.RateLimiter = limiter
+	}
+}
+
+// WithCache enables the response cache in dir with entries kept for ttl, in place of the
+// cacheEnabled default of CachePath and 24 hours
+func WithCache(dir string, ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		c.CacheEnabled = true
+		c.cacheDir = dir
+		c.cacheTTL = ttl
+	}
+}
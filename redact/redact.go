@@ -0,0 +1,158 @@
+// Package redact masks credentials and personal data — Fantrax cookies, passwords, API
+// tokens, and email addresses — in strings, errors, and log entries so they do not end up in
+// logs, error messages, or files written by go-fantrax tools.
+//
+// Text is matched two ways: by pattern (cookie pairs such as FX_RM=..., "password"/"token"
+// style key-value pairs, email addresses), and by value, for secrets registered with
+// AddSecret. The clients register the cookies and credentials they are given, so a secret is
+// masked wherever it appears once a client has used it. The library redacts the errors it
+// logs; everything else logged through logrus is redacted once Install is called.
+package redact
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Mask replaces redacted text
+const Mask = "[REDACTED]"
+
+// minSecretLength keeps short values, which would mask unrelated text, out of the registry
+const minSecretLength = 6
+
+var patterns = []struct {
+	re          *regexp.Regexp
+	replacement string
+}{
+	// Whole Cookie headers, e.g. from a dumped request
+	{regexp.MustCompile(`(?im)^(\s*cookie:\s*).+$`), "${1}" + Mask},
+	// Fantrax session cookies in a Cookie header or cookie cache
+	{regexp.MustCompile(`\b(FX_RM|JSESSIONID)=[^;\s"'&]+`), "${1}=" + Mask},
+	{regexp.MustCompile(`("name"\s*:\s*"(?:FX_RM|JSESSIONID)"\s*,\s*"value"\s*:\s*")[^"]*`), "${1}" + Mask},
+	// Credentials in JSON bodies and form or query strings
+	{regexp.MustCompile(`(?i)("(?:password|passwd|token|apiToken|userSecretId)"\s*:\s*")[^"]*`), "${1}" + Mask},
+	{regexp.MustCompile(`(?i)\b((?:password|passwd|token|apiToken|userSecretId)=)[^&\s"']+`), "${1}" + Mask},
+	// Email addresses, including the URL-encoded form used in league setup form keys
+	{regexp.MustCompile(`[A-Za-z0-9._%+-]+(?:@|%40)[A-Za-z0-9.-]+\.[A-Za-z]{2,}`), Mask},
+}
+
+var (
+	secretsMu sync.RWMutex
+	secrets   []string
+)
+
+// AddSecret registers values to be masked wherever they appear, such as a Cookie header or a
+// password. Empty and very short values are ignored.
+func AddSecret(values ...string) {
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if len(v) < minSecretLength || contains(secrets, v) {
+			continue
+		}
+		secrets = append(secrets, v)
+	}
+	// Longest first, so a secret containing another is masked whole
+	sort.Slice(secrets, func(i, j int) bool { return len(secrets[i]) > len(secrets[j]) })
+}
+
+func contains(values []string, v string) bool {
+	for _, existing := range values {
+		if existing == v {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns s with registered secrets, cookies, credentials, and email addresses masked
+func String(s string) string {
+	secretsMu.RLock()
+	for _, secret := range secrets {
+		s = strings.ReplaceAll(s, secret, Mask)
+	}
+	secretsMu.RUnlock()
+
+	for _, p := range patterns {
+		s = p.re.ReplaceAllString(s, p.replacement)
+	}
+	return s
+}
+
+// redactedError masks the message of an error while keeping it available to errors.Is and
+// errors.As
+type redactedError struct {
+	err error
+}
+
+func (e *redactedError) Error() string {
+	return String(e.err.Error())
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// Error wraps err so its message is redacted. A nil err returns nil.
+func Error(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(*redactedError); ok {
+		return err
+	}
+	return &redactedError{err: err}
+}
+
+// Logf wraps a printf-style logger, such as the one passed to chromedp, so its output is
+// redacted
+func Logf(logf func(format string, args ...interface{})) func(format string, args ...interface{}) {
+	return func(format string, args ...interface{}) {
+		for i, arg := range args {
+			switch v := arg.(type) {
+			case string:
+				args[i] = String(v)
+			case error:
+				args[i] = Error(v)
+			}
+		}
+		logf(String(format), args...)
+	}
+}
+
+// Hook is a logrus hook that redacts the message and string or error fields of every entry
+type Hook struct{}
+
+// Levels returns all log levels
+func (Hook) Levels() []log.Level {
+	return log.AllLevels
+}
+
+// Fire redacts the entry
+func (Hook) Fire(entry *log.Entry) error {
+	entry.Message = String(entry.Message)
+	for key, value := range entry.Data {
+		switch v := value.(type) {
+		case string:
+			entry.Data[key] = String(v)
+		case error:
+			entry.Data[key] = Error(v)
+		}
+	}
+	return nil
+}
+
+var installOnce sync.Once
+
+// Install adds Hook to the standard logrus logger, once. Programs opt in by calling it, or
+// with auth_client.WithLogRedaction, since the hook redacts every entry they log.
+func Install() {
+	installOnce.Do(func() {
+		log.AddHook(Hook{})
+	})
+}
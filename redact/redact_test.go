@@ -0,0 +1,61 @@
+package redact
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Cookie: FX_RM=abc123; other=1", "Cookie: " + Mask},
+		{"sent FX_RM=abc123; JSESSIONID=xyz", "sent FX_RM=" + Mask + "; JSESSIONID=" + Mask},
+		{`[{"name":"FX_RM","value":"abc123"}]`, `[{"name":"FX_RM","value":"` + Mask + `"}]`},
+		{`{"password": "hunter2"}`, `{"password": "` + Mask + `"}`},
+		{"GET /api?leagueId=x&userSecretId=s3cr3t&y=1", "GET /api?leagueId=x&userSecretId=" + Mask + "&y=1"},
+		{"owner jane.doe@example.com joined", "owner " + Mask + " joined"},
+		{"teamOwnerEmail,jane%40example.com,t1", "teamOwnerEmail," + Mask + ",t1"},
+		{"no secrets here", "no secrets here"},
+	}
+	for _, tt := range tests {
+		if got := String(tt.in); got != tt.want {
+			t.Errorf("String(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSecretsAndErrors(t *testing.T) {
+	AddSecret("plain-secret-value", "abc")
+	if got := String("token plain-secret-value and abc"); got != "token "+Mask+" and abc" {
+		t.Errorf("registered secret not masked: %q", got)
+	}
+
+	base := errors.New("request failed")
+	err := Error(errors.Join(base, errors.New("with plain-secret-value")))
+	if strings.Contains(err.Error(), "plain-secret-value") {
+		t.Errorf("error message not redacted: %q", err)
+	}
+	if !errors.Is(err, base) {
+		t.Error("redacted error does not unwrap to the original")
+	}
+	if Error(nil) != nil {
+		t.Error("Error(nil) is not nil")
+	}
+}
+
+func TestHook(t *testing.T) {
+	var out strings.Builder
+	logger := log.New()
+	logger.SetOutput(&out)
+	logger.AddHook(Hook{})
+	logger.WithField("cookie", "FX_RM=abc123").Info("login as jane@example.com")
+
+	if strings.Contains(out.String(), "abc123") || strings.Contains(out.String(), "jane@example.com") {
+		t.Errorf("log entry not redacted: %s", out.String())
+	}
+}
@@ -0,0 +1,124 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/pmurley/go-fantrax"
+	"github.com/pmurley/go-fantrax/config"
+)
+
+// Duration is a time.Duration written in JSON as a string such as "10m" or "24h"
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string such as \"10m\": %w", err)
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// JobConfig configures one scheduled job
+type JobConfig struct {
+	Name     string `json:"name"`
+	Task     string `json:"task"`     // Registered task to run
+	Schedule string `json:"schedule"` // See ParseSchedule
+
+	// MinInterval is the shortest time allowed between two runs of the job, whether
+	// scheduled or started with RunNow
+	MinInterval Duration `json:"minInterval,omitempty"`
+
+	// Timeout cancels the job's context after this long (0 = no timeout)
+	Timeout Duration `json:"timeout,omitempty"`
+
+	Params   json.RawMessage `json:"params,omitempty"` // Task-specific settings, read with Job.Decode
+	Disabled bool            `json:"disabled,omitempty"`
+}
+
+// Config is a scheduler configuration file
+type Config struct {
+	LeagueID   string `json:"leagueId,omitempty"` // Default from the config package
+	Sport      string `json:"sport,omitempty"`
+	Timezone   string `json:"timezone,omitempty"`   // IANA time zone for schedules (default local time)
+	PublicOnly bool   `json:"publicOnly,omitempty"` // Do not log in; tasks get only the public client
+
+	// PlayerIDStore is a directory to keep player ID maps in, shared by the jobs
+	PlayerIDStore    string   `json:"playerIdStore,omitempty"`
+	PlayerIDStoreTTL Duration `json:"playerIdStoreTtl,omitempty"`
+
+	Jobs []JobConfig `json:"jobs"`
+}
+
+// LoadConfig reads a JSON scheduler configuration
+func LoadConfig(r io.Reader) (*Config, error) {
+	var config Config
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&config); err != nil {
+		return nil, fmt.Errorf("failed to parse scheduler config: %w", err)
+	}
+	return &config, nil
+}
+
+// LoadConfigFile reads a JSON scheduler configuration file
+func LoadConfigFile(path string) (*Config, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open scheduler config: %w", err)
+	}
+	defer f.Close()
+	return LoadConfig(f)
+}
+
+// Clients creates the clients shared by the config's jobs, logging in unless PublicOnly is
+// set. Credentials, caching, and rate limits come from the config package (fantrax.json and
+// FANTRAX_* variables); LeagueID and Sport, when set here, take precedence over it.
+func (c *Config) Clients() (*Clients, error) {
+	shared, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	if c.LeagueID != "" {
+		shared.LeagueID = c.LeagueID
+	}
+	if c.Sport != "" {
+		shared.Sport = c.Sport
+	}
+	if shared.LeagueID == "" {
+		return nil, config.ErrNoLeagueID
+	}
+
+	var opts []fantrax.ClientOption
+	if c.PlayerIDStore != "" {
+		store, err := fantrax.NewPlayerIDStore(c.PlayerIDStore, time.Duration(c.PlayerIDStoreTTL))
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, fantrax.WithPlayerIDStore(store))
+	}
+	public, err := shared.PublicClient(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create public client: %w", err)
+	}
+
+	clients := &Clients{Public: public, Sport: fantrax.Sport(shared.Sport)}
+	if !c.PublicOnly {
+		if clients.Auth, err = shared.AuthClient(); err != nil {
+			return nil, fmt.Errorf("failed to create auth client: %w", err)
+		}
+	}
+	return clients, nil
+}
@@ -0,0 +1,189 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/smtp"
+	"os"
+	"strings"
+
+	"github.com/pmurley/go-fantrax/auth_client"
+)
+
+// lineupAlertParams are the lineup-alerts params
+type lineupAlertParams struct {
+	Period      string                       `json:"period"`      // Roster period; empty = current
+	Webhook     string                       `json:"webhook"`     // Webhook every team's alerts are posted to, unless the team has its own
+	Email       *smtpSettings                `json:"email"`       // Mail server; alerts are not emailed without it
+	OwnerEmails bool                         `json:"ownerEmails"` // Email the owners listed on the league setup page (commissioner only)
+	Teams       map[string]teamAlertSettings `json:"teams"`       // Per-team settings by team ID
+}
+
+// teamAlertSettings overrides where one team's alerts go
+type teamAlertSettings struct {
+	OptOut  bool     `json:"optOut"`  // Send this team nothing
+	Webhook string   `json:"webhook"` // In place of the default webhook
+	Email   []string `json:"email"`   // In place of the owners' emails
+}
+
+// smtpSettings is the mail server lineup alerts are sent through
+type smtpSettings struct {
+	Addr        string `json:"addr"` // host:port
+	From        string `json:"from"`
+	Username    string `json:"username"`
+	PasswordEnv string `json:"passwordEnv"` // Environment variable holding the password
+}
+
+// lineupAlert is one team's alert, posted to webhooks as JSON
+type lineupAlert struct {
+	LeagueID string                    `json:"leagueId"`
+	TeamID   string                    `json:"teamId"`
+	TeamName string                    `json:"teamName"`
+	Issues   []auth_client.LineupIssue `json:"issues"`
+	Text     string                    `json:"text"` // The issues as plain text, for chat webhooks
+}
+
+// lineupDelivery is where one team's alert goes
+type lineupDelivery struct {
+	Alert   lineupAlert
+	Webhook string
+	Emails  []string
+}
+
+func sendLineupAlerts(ctx context.Context, job *Job) error {
+	if job.Clients.Auth == nil {
+		return errNoAuthClient
+	}
+	var params lineupAlertParams
+	if err := job.Decode(&params); err != nil {
+		return err
+	}
+
+	report, err := job.Clients.Auth.GetOutstandingLineupIssues(params.Period)
+	if err != nil {
+		return err
+	}
+	var owners map[string][]string
+	if params.OwnerEmails {
+		list, err := job.Clients.Auth.GetLeagueOwners()
+		if err != nil {
+			return fmt.Errorf("failed to get owner emails: %w", err)
+		}
+		owners = make(map[string][]string)
+		for _, owner := range list {
+			if owner.Email != "" {
+				owners[owner.TeamID] = append(owners[owner.TeamID], owner.Email)
+			}
+		}
+	}
+
+	deliveries := planLineupAlerts(job.Clients.Auth.LeagueID, report, owners, params)
+	var errs []error
+	for _, d := range deliveries {
+		if d.Webhook != "" {
+			if err := postLineupAlert(ctx, d.Webhook, d.Alert); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", d.Alert.TeamName, err))
+			}
+		}
+		if len(d.Emails) > 0 {
+			if params.Email == nil {
+				job.Log.Warnf("%s: no mail server configured; not emailing %s", d.Alert.TeamName, strings.Join(d.Emails, ", "))
+			} else if err := emailLineupAlert(params.Email, d.Emails, d.Alert); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", d.Alert.TeamName, err))
+			}
+		}
+	}
+	job.Log.Infof("lineup alerts sent for %d of %d teams", len(deliveries), len(report))
+	return errors.Join(errs...)
+}
+
+// planLineupAlerts decides which teams are alerted and where. Teams without issues, teams that
+// opted out, and teams with nowhere to send to are skipped.
+func planLineupAlerts(leagueID string, report []auth_client.TeamLineupIssues, owners map[string][]string, params lineupAlertParams) []lineupDelivery {
+	var deliveries []lineupDelivery
+	for _, team := range report {
+		settings := params.Teams[team.TeamID]
+		if len(team.Issues) == 0 || settings.OptOut {
+			continue
+		}
+		d := lineupDelivery{Webhook: params.Webhook, Emails: owners[team.TeamID]}
+		if settings.Webhook != "" {
+			d.Webhook = settings.Webhook
+		}
+		if len(settings.Email) > 0 {
+			d.Emails = settings.Email
+		}
+		if d.Webhook == "" && len(d.Emails) == 0 {
+			continue
+		}
+		d.Alert = lineupAlert{
+			LeagueID: leagueID,
+			TeamID:   team.TeamID,
+			TeamName: team.TeamName,
+			Issues:   team.Issues,
+			Text:     lineupAlertText(team),
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries
+}
+
+// lineupAlertText lists a team's issues one per line
+func lineupAlertText(team auth_client.TeamLineupIssues) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Lineup issues for %s:\n", team.TeamName)
+	for _, issue := range team.Issues {
+		fmt.Fprintf(&sb, "- %s\n", issue.Message)
+	}
+	return sb.String()
+}
+
+// postLineupAlert posts an alert to a webhook as JSON
+func postLineupAlert(ctx context.Context, url string, alert lineupAlert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("failed to marshal lineup alert: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// emailLineupAlert emails an alert as plain text
+func emailLineupAlert(settings *smtpSettings, to []string, alert lineupAlert) error {
+	host := settings.Addr
+	if i := strings.LastIndex(host, ":"); i >= 0 {
+		host = host[:i]
+	}
+	var auth smtp.Auth
+	if settings.Username != "" {
+		auth = smtp.PlainAuth("", settings.Username, os.Getenv(settings.PasswordEnv), host)
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", settings.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: Lineup issues for %s\r\n", alert.TeamName)
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(alert.Text, "\n", "\r\n"))
+
+	if err := smtp.SendMail(settings.Addr, auth, settings.From, to, []byte(msg.String())); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
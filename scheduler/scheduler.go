@@ -0,0 +1,330 @@
+// Package scheduler runs recurring league tasks — refreshing caches, saving snapshots,
+// checking rosters, processing keeper deadlines, or any task you register — on cron-like
+// schedules, sharing one set of Fantrax clients. Jobs are usually read from a JSON file:
+//
+//	{
+//	  "leagueId": "abc123",
+//	  "sport": "MLB",
+//	  "timezone": "America/New_York",
+//	  "jobs": [
+//	    {"name": "ids", "task": "refresh-player-ids", "schedule": "@daily"},
+//	    {"name": "snapshot", "task": "snapshot", "schedule": "*/30 * * * *",
+//	     "params": {"path": "league.json"}},
+//	    {"name": "recap", "task": "post-recap", "schedule": "0 9 * * 1", "minInterval": "24h"}
+//	  ]
+//	}
+//
+// and run with:
+//
+//	config, err := scheduler.LoadConfigFile("jobs.json")
+//	clients, err := config.Clients()
+//	s := scheduler.New(clients)
+//	s.Register("post-recap", postRecap)
+//	err = s.Configure(config)
+//	err = s.Run(ctx)
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pmurley/go-fantrax"
+	"github.com/pmurley/go-fantrax/auth_client"
+	"github.com/pmurley/go-fantrax/redact"
+	log "github.com/sirupsen/logrus"
+)
+
+// Clients are the Fantrax clients shared by every job
+type Clients struct {
+	Public *fantrax.Client
+	Auth   *auth_client.Client // Nil when running without logging in
+	Sport  fantrax.Sport
+}
+
+// Job is what a task receives when it runs
+type Job struct {
+	Name    string
+	Params  json.RawMessage
+	Clients *Clients
+	Log     *log.Entry
+}
+
+// Decode unmarshals the job's params into v. Jobs without params leave v unchanged.
+func (j *Job) Decode(v interface{}) error {
+	if len(j.Params) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(j.Params, v); err != nil {
+		return fmt.Errorf("invalid params for job %s: %w", j.Name, err)
+	}
+	return nil
+}
+
+// TaskFunc is the code a job runs
+type TaskFunc func(ctx context.Context, job *Job) error
+
+var (
+	// ErrRateLimited is returned by RunNow when a job ran more recently than its MinInterval
+	ErrRateLimited = errors.New("job ran too recently")
+
+	// ErrJobRunning is returned by RunNow when the job is already running
+	ErrJobRunning = errors.New("job is already running")
+)
+
+// job is a configured job and its run state
+type job struct {
+	config   JobConfig
+	schedule Schedule
+	task     TaskFunc
+
+	mu      sync.Mutex
+	next    time.Time
+	running bool
+	lastRun time.Time
+	lastErr error
+	runs    int
+}
+
+// JobStatus reports a job's run history
+type JobStatus struct {
+	Name     string    `json:"name"`
+	Task     string    `json:"task"`
+	Next     time.Time `json:"next"`
+	Running  bool      `json:"running"`
+	LastRun  time.Time `json:"lastRun"`
+	LastErr  string    `json:"lastError,omitempty"`
+	RunCount int       `json:"runCount"`
+}
+
+// Scheduler runs jobs on their schedules
+type Scheduler struct {
+	Clients  *Clients
+	Location *time.Location // Time zone schedules are evaluated in (default local time)
+
+	mu    sync.Mutex
+	tasks map[string]TaskFunc
+	jobs  map[string]*job
+	wg    sync.WaitGroup
+	now   func() time.Time
+}
+
+// New creates a scheduler with the built-in tasks registered
+//
+// Parameters:
+//   - clients: The clients shared by every job
+func New(clients *Clients) *Scheduler {
+	s := &Scheduler{
+		Clients:  clients,
+		Location: time.Local,
+		tasks:    make(map[string]TaskFunc),
+		jobs:     make(map[string]*job),
+		now:      time.Now,
+	}
+	registerBuiltins(s)
+	return s
+}
+
+// Register adds or replaces a task that jobs can name
+func (s *Scheduler) Register(task string, fn TaskFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tasks[task] = fn
+}
+
+// AddJob schedules a job. The job's task must already be registered.
+func (s *Scheduler) AddJob(config JobConfig) error {
+	if config.Name == "" {
+		return fmt.Errorf("job has no name")
+	}
+	schedule, err := ParseSchedule(config.Schedule)
+	if err != nil {
+		return fmt.Errorf("job %s: %w", config.Name, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	task, ok := s.tasks[config.Task]
+	if !ok {
+		return fmt.Errorf("job %s: unknown task %q", config.Name, config.Task)
+	}
+	if _, exists := s.jobs[config.Name]; exists {
+		return fmt.Errorf("job %s is defined twice", config.Name)
+	}
+	s.jobs[config.Name] = &job{
+		config:   config,
+		schedule: schedule,
+		task:     task,
+		next:     schedule.Next(s.now().In(s.Location)),
+	}
+	return nil
+}
+
+// Configure applies a config's time zone and adds its enabled jobs
+func (s *Scheduler) Configure(config *Config) error {
+	if config.Timezone != "" {
+		location, err := time.LoadLocation(config.Timezone)
+		if err != nil {
+			return fmt.Errorf("invalid timezone: %w", err)
+		}
+		s.Location = location
+	}
+	for _, jc := range config.Jobs {
+		if jc.Disabled {
+			continue
+		}
+		if err := s.AddJob(jc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run starts jobs as they come due until ctx is cancelled, then waits for running jobs to
+// finish. A job that is still running when it comes due again is skipped for that run.
+func (s *Scheduler) Run(ctx context.Context) error {
+	for {
+		due, wait := s.due()
+		for _, j := range due {
+			s.start(ctx, j)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			s.wg.Wait()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// due returns the jobs whose run time has passed, advancing their next run, and how long to
+// wait before checking again
+func (s *Scheduler) due() ([]*job, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now().In(s.Location)
+	wait := time.Minute
+	var due []*job
+	for _, j := range s.jobs {
+		j.mu.Lock()
+		if !j.next.IsZero() && !now.Before(j.next) {
+			due = append(due, j)
+			j.next = j.schedule.Next(now)
+		}
+		if !j.next.IsZero() {
+			if until := j.next.Sub(now); until < wait {
+				wait = until
+			}
+		}
+		j.mu.Unlock()
+	}
+	return due, wait
+}
+
+// start runs a scheduled job in the background unless it is running or rate limited
+func (s *Scheduler) start(ctx context.Context, j *job) {
+	if err := s.claim(j); err != nil {
+		log.Infof("skipping job %s: %v", j.config.Name, redact.Error(err))
+		return
+	}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.execute(ctx, j)
+	}()
+}
+
+// RunNow runs a job immediately and waits for it to finish. It returns ErrJobRunning or
+// ErrRateLimited instead of running the job when the job is busy or ran too recently.
+func (s *Scheduler) RunNow(ctx context.Context, name string) error {
+	s.mu.Lock()
+	j, ok := s.jobs[name]
+	s.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("unknown job %q", name)
+	}
+	if err := s.claim(j); err != nil {
+		return fmt.Errorf("job %s: %w", name, err)
+	}
+	return s.execute(ctx, j)
+}
+
+// claim marks a job as running, enforcing its MinInterval
+func (s *Scheduler) claim(j *job) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.running {
+		return ErrJobRunning
+	}
+	if interval := time.Duration(j.config.MinInterval); interval > 0 && !j.lastRun.IsZero() &&
+		s.now().Sub(j.lastRun) < interval {
+		return ErrRateLimited
+	}
+	j.running = true
+	j.lastRun = s.now()
+	return nil
+}
+
+// execute runs a claimed job and records the outcome
+func (s *Scheduler) execute(ctx context.Context, j *job) error {
+	if timeout := time.Duration(j.config.Timeout); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	entry := log.WithField("job", j.config.Name)
+	entry.Info("running job")
+	start := time.Now()
+	err := j.task(ctx, &Job{
+		Name:    j.config.Name,
+		Params:  j.config.Params,
+		Clients: s.Clients,
+		Log:     entry,
+	})
+	if err != nil {
+		entry.Errorf("job failed after %s: %v", time.Since(start).Round(time.Millisecond), err)
+	} else {
+		entry.Infof("job finished in %s", time.Since(start).Round(time.Millisecond))
+	}
+
+	j.mu.Lock()
+	j.running = false
+	j.lastErr = err
+	j.runs++
+	j.mu.Unlock()
+	return err
+}
+
+// Status returns every job's state, sorted by name
+func (s *Scheduler) Status() []JobStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	statuses := make([]JobStatus, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		j.mu.Lock()
+		status := JobStatus{
+			Name:     j.config.Name,
+			Task:     j.config.Task,
+			Next:     j.next,
+			Running:  j.running,
+			LastRun:  j.lastRun,
+			RunCount: j.runs,
+		}
+		if j.lastErr != nil {
+			status.LastErr = j.lastErr.Error()
+		}
+		j.mu.Unlock()
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(a, b int) bool { return statuses[a].Name < statuses[b].Name })
+	return statuses
+}
@@ -0,0 +1,152 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pmurley/go-fantrax/auth_client"
+)
+
+func TestParseSchedule(t *testing.T) {
+	from := time.Date(2025, 6, 6, 10, 7, 30, 0, time.UTC) // A Friday
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"*/15 * * * *", time.Date(2025, 6, 6, 10, 15, 0, 0, time.UTC)},
+		{"0 9 * * 1", time.Date(2025, 6, 9, 9, 0, 0, 0, time.UTC)},
+		{"30 8-17 * * 1-5", time.Date(2025, 6, 6, 10, 30, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 12 * * 7", time.Date(2025, 6, 8, 12, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2025, 6, 7, 0, 0, 0, 0, time.UTC)},
+		{"@every 90m", from.Add(90 * time.Minute)},
+	}
+	for _, tt := range tests {
+		schedule, err := ParseSchedule(tt.spec)
+		if err != nil {
+			t.Errorf("ParseSchedule(%q): %v", tt.spec, err)
+			continue
+		}
+		if got := schedule.Next(from); !got.Equal(tt.want) {
+			t.Errorf("%q: next run %v, want %v", tt.spec, got, tt.want)
+		}
+	}
+
+	for _, spec := range []string{"* * * *", "60 * * * *", "*/0 * * * *", "@every 10s", "5-1 * * * *"} {
+		if _, err := ParseSchedule(spec); err == nil {
+			t.Errorf("ParseSchedule(%q) succeeded, want an error", spec)
+		}
+	}
+}
+
+func TestRunNowRateLimit(t *testing.T) {
+	s := New(&Clients{})
+	now := time.Date(2025, 6, 6, 10, 0, 0, 0, time.UTC)
+	s.Location = time.UTC
+	s.now = func() time.Time { return now }
+
+	runs := 0
+	s.Register("count", func(ctx context.Context, job *Job) error {
+		var params struct{ Fail bool }
+		if err := job.Decode(&params); err != nil {
+			return err
+		}
+		runs++
+		return nil
+	})
+	config, err := LoadConfig(strings.NewReader(`{"leagueId": "x", "jobs": [
+		{"name": "counter", "task": "count", "schedule": "@hourly", "minInterval": "10m", "params": {"fail": false}}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Configure(config); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.RunNow(context.Background(), "counter"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RunNow(context.Background(), "counter"); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("second run within the interval: got %v, want ErrRateLimited", err)
+	}
+	now = now.Add(11 * time.Minute)
+	if err := s.RunNow(context.Background(), "counter"); err != nil {
+		t.Errorf("run after the interval: %v", err)
+	}
+	if runs != 2 {
+		t.Errorf("task ran %d times, want 2", runs)
+	}
+
+	status := s.Status()
+	if len(status) != 1 || status[0].RunCount != 2 || !status[0].Next.Equal(time.Date(2025, 6, 6, 11, 0, 0, 0, time.UTC)) {
+		t.Errorf("status = %+v", status)
+	}
+
+	if err := s.AddJob(JobConfig{Name: "bad", Task: "missing", Schedule: "@daily"}); err == nil {
+		t.Error("job with an unknown task was added")
+	}
+}
+
+func TestPlanLineupAlerts(t *testing.T) {
+	issue := []auth_client.LineupIssue{{Kind: auth_client.LineupIssueIdleStarter, Message: "Idle has no game at 1B"}}
+	report := []auth_client.TeamLineupIssues{
+		{TeamID: "a", TeamName: "Aces", Issues: issue},
+		{TeamID: "b", TeamName: "Bats", Issues: issue},
+		{TeamID: "c", TeamName: "Cats"},
+		{TeamID: "d", TeamName: "Dogs", Issues: issue},
+	}
+	var params lineupAlertParams
+	if err := json.Unmarshal([]byte(`{"webhook": "https://hooks.example/league", "teams": {
+		"b": {"optOut": true},
+		"d": {"webhook": "https://hooks.example/dogs", "email": ["dogs@example.com"]}
+	}}`), &params); err != nil {
+		t.Fatal(err)
+	}
+	owners := map[string][]string{"a": {"aces@example.com"}, "d": {"owner@example.com"}}
+
+	deliveries := planLineupAlerts("L1", report, owners, params)
+	if len(deliveries) != 2 {
+		t.Fatalf("got %d deliveries, want Aces and Dogs: %+v", len(deliveries), deliveries)
+	}
+	aces, dogs := deliveries[0], deliveries[1]
+	if aces.Alert.TeamID != "a" || aces.Webhook != "https://hooks.example/league" || aces.Emails[0] != "aces@example.com" {
+		t.Errorf("Aces delivery = %+v", aces)
+	}
+	if dogs.Webhook != "https://hooks.example/dogs" || len(dogs.Emails) != 1 || dogs.Emails[0] != "dogs@example.com" {
+		t.Errorf("Dogs delivery = %+v", dogs)
+	}
+	if !strings.Contains(aces.Alert.Text, "- Idle has no game at 1B") {
+		t.Errorf("alert text = %q", aces.Alert.Text)
+	}
+}
+
+func TestKeeperSkipReason(t *testing.T) {
+	deadline := time.Date(2025, 3, 20, 12, 0, 0, 0, time.UTC)
+	params := keeperParams{Selections: filepath.Join(t.TempDir(), "keepers.csv"), Deadline: deadline}
+	if !(keeperParams{}).dryRun() {
+		t.Error("keeper runs should default to dry runs")
+	}
+
+	if reason := keeperSkipReason(keeperParams{Selections: params.Selections}, deadline); reason == "" {
+		t.Error("real run without a deadline was not skipped")
+	}
+	if reason := keeperSkipReason(params, deadline.Add(-time.Hour)); reason == "" {
+		t.Error("run before the deadline was not skipped")
+	}
+	if reason := keeperSkipReason(params, deadline.Add(time.Hour)); reason != "" {
+		t.Errorf("run after the deadline was skipped: %s", reason)
+	}
+	if err := os.WriteFile(params.doneFile(), []byte("processed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if reason := keeperSkipReason(params, deadline.Add(2*time.Hour)); reason == "" {
+		t.Error("second run after processing was not skipped")
+	}
+}
@@ -0,0 +1,218 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pmurley/go-fantrax/auth_client"
+	"github.com/pmurley/go-fantrax/snapshot"
+)
+
+// Built-in task names
+const (
+	TaskRefreshPlayerIDs = "refresh-player-ids" // Refresh the stored player ID map; needs a player ID store
+	TaskSnapshot         = "snapshot"           // Save a league snapshot; params: {"path": "...", "transactions": 100}
+	TaskCheckRosters     = "check-rosters"      // Log roster limit violations
+	TaskProcessKeepers   = "process-keepers"    // Release unkept players once after a deadline; params: see keeperParams
+	TaskTradeDeadline    = "trade-deadline"     // Log pending trades processing after the deadline; params: {"deadline": RFC 3339 time, "grace": "48h"}
+	TaskLineupAlerts     = "lineup-alerts"      // Send each team its lineup issues by webhook or email; params: see lineupAlertParams
+)
+
+var errNoAuthClient = errors.New("task needs a logged-in client")
+
+func registerBuiltins(s *Scheduler) {
+	s.Register(TaskRefreshPlayerIDs, refreshPlayerIDs)
+	s.Register(TaskSnapshot, saveSnapshot)
+	s.Register(TaskCheckRosters, checkRosters)
+	s.Register(TaskProcessKeepers, processKeepers)
+	s.Register(TaskTradeDeadline, flagLateTrades)
+	s.Register(TaskLineupAlerts, sendLineupAlerts)
+}
+
+func refreshPlayerIDs(ctx context.Context, job *Job) error {
+	changes, err := job.Clients.Public.RefreshPlayerIds(job.Clients.Sport)
+	if err != nil {
+		return err
+	}
+	if changes.Unchanged {
+		job.Log.Info("player IDs unchanged")
+		return nil
+	}
+	job.Log.Infof("player IDs: %d added, %d updated, %d removed",
+		len(changes.Added), len(changes.Updated), len(changes.Removed))
+	return nil
+}
+
+func saveSnapshot(ctx context.Context, job *Job) error {
+	if job.Clients.Auth == nil {
+		return errNoAuthClient
+	}
+	params := struct {
+		Path         string `json:"path"`
+		Transactions int    `json:"transactions"`
+	}{Path: "league-snapshot.json", Transactions: 100}
+	if err := job.Decode(&params); err != nil {
+		return err
+	}
+
+	snap, err := snapshot.Take(job.Clients.Auth, snapshot.WithTransactionLimit(params.Transactions))
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(params.Path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create snapshot directory: %w", err)
+		}
+	}
+	return snap.Save(params.Path)
+}
+
+func checkRosters(ctx context.Context, job *Job) error {
+	if job.Clients.Auth == nil {
+		return errNoAuthClient
+	}
+	violations, err := job.Clients.Auth.CheckLeagueRosterCompliance()
+	if err != nil {
+		return err
+	}
+	for _, team := range violations {
+		for _, v := range team.Violations {
+			job.Log.Warnf("%s: %s", team.TeamName, v.Message)
+		}
+	}
+	if len(violations) == 0 {
+		job.Log.Info("all rosters are legal")
+	}
+	return nil
+}
+
+// keeperParams are the process-keepers params
+//
+// Runs are dry runs unless dryRun is set to false. A real run also needs a deadline: runs
+// before it are skipped, and the first run after it releases players once and writes a
+// marker file (selections file + ".done"), so later runs of a recurring job don't drop
+// players added since.
+type keeperParams struct {
+	Selections    string    `json:"selections"` // CSV or JSON keeper selections file
+	Deadline      time.Time `json:"deadline"`   // RFC 3339 time keepers are due
+	Period        int       `json:"period"`
+	MaxKeepers    int       `json:"maxKeepers"`
+	DryRun        *bool     `json:"dryRun"` // Default true
+	DropToWaivers bool      `json:"dropToWaivers"`
+}
+
+// dryRun reports whether the params leave rosters unchanged
+func (p keeperParams) dryRun() bool {
+	return p.DryRun == nil || *p.DryRun
+}
+
+// doneFile is the marker written after keepers are processed
+func (p keeperParams) doneFile() string {
+	return p.Selections + ".done"
+}
+
+// keeperSkipReason returns why a real keeper run shouldn't release players at now, or ""
+func keeperSkipReason(params keeperParams, now time.Time) string {
+	if params.Deadline.IsZero() {
+		return "no deadline set"
+	}
+	if now.Before(params.Deadline) {
+		return fmt.Sprintf("deadline %s has not passed", params.Deadline.Format(time.RFC3339))
+	}
+	if _, err := os.Stat(params.doneFile()); err == nil {
+		return fmt.Sprintf("already processed (%s exists)", params.doneFile())
+	}
+	return ""
+}
+
+func processKeepers(ctx context.Context, job *Job) error {
+	if job.Clients.Auth == nil {
+		return errNoAuthClient
+	}
+	var params keeperParams
+	if err := job.Decode(&params); err != nil {
+		return err
+	}
+	if params.Selections == "" {
+		return fmt.Errorf("process-keepers needs a selections file")
+	}
+	dryRun := params.dryRun()
+	if !dryRun {
+		if reason := keeperSkipReason(params, time.Now()); reason != "" {
+			job.Log.Infof("not releasing players: %s", reason)
+			return nil
+		}
+	}
+
+	f, err := os.Open(params.Selections)
+	if err != nil {
+		return fmt.Errorf("failed to open keeper selections: %w", err)
+	}
+	defer f.Close()
+	var selections auth_client.KeeperSelections
+	if strings.EqualFold(filepath.Ext(params.Selections), ".json") {
+		selections, err = auth_client.LoadKeeperSelectionsJSON(f)
+	} else {
+		selections, err = auth_client.LoadKeeperSelectionsCSV(f)
+	}
+	if err != nil {
+		return err
+	}
+
+	plan, err := job.Clients.Auth.ProcessKeepers(selections, auth_client.KeeperOptions{
+		Period:        params.Period,
+		MaxKeepers:    params.MaxKeepers,
+		DryRun:        dryRun,
+		DropToWaivers: params.DropToWaivers,
+	})
+	if plan != nil {
+		job.Log.Infof("keeper plan: %d teams, %d drops (dry run: %v)", len(plan.Teams), plan.TotalDrops(), dryRun)
+	}
+	if dryRun || plan == nil || errors.Is(err, auth_client.ErrInvalidKeeperPlan) {
+		return err
+	}
+
+	// Drops were attempted, so mark the deadline handled even if one failed; finishing a
+	// partial run is left to a person rather than a re-plan against changed rosters
+	marker := fmt.Sprintf("processed %s\n", time.Now().Format(time.RFC3339))
+	if err != nil {
+		marker += fmt.Sprintf("stopped: %v\n", err)
+	}
+	if writeErr := os.WriteFile(params.doneFile(), []byte(marker), 0644); writeErr != nil {
+		job.Log.Errorf("failed to write %s: %v", params.doneFile(), writeErr)
+	}
+	return err
+}
+
+func flagLateTrades(ctx context.Context, job *Job) error {
+	if job.Clients.Auth == nil {
+		return errNoAuthClient
+	}
+	var params struct {
+		Deadline time.Time `json:"deadline"`
+		Grace    Duration  `json:"grace"`
+	}
+	if err := job.Decode(&params); err != nil {
+		return err
+	}
+	if params.Deadline.IsZero() {
+		return fmt.Errorf("trade-deadline needs a deadline")
+	}
+
+	late, err := job.Clients.Auth.CheckTradeDeadline(auth_client.TradeDeadlinePolicy{
+		Deadline: params.Deadline,
+		Grace:    time.Duration(params.Grace),
+	})
+	if err != nil {
+		return err
+	}
+	for _, trade := range late {
+		job.Log.Warn(trade.Description())
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+package fantrax
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// DriftKind classifies a difference between a Fantrax response and the Go type it decodes into
+type DriftKind string
+
+const (
+	// DriftUnknownField is a key in the response that no struct field maps to
+	DriftUnknownField DriftKind = "unknown_field"
+	// DriftMissingField is a struct field (without omitempty) whose key is absent from the response
+	DriftMissingField DriftKind = "missing_field"
+	// DriftTypeMismatch is a value whose JSON type does not match the Go field type
+	DriftTypeMismatch DriftKind = "type_mismatch"
+)
+
+// SchemaDrift describes one way a response differs from the structure this package expects
+type SchemaDrift struct {
+	Endpoint string    `json:"endpoint"` // Endpoint or fxpa method the response came from
+	Path     string    `json:"path"`     // JSON path, with [] for array elements and {} for map values
+	Kind     DriftKind `json:"kind"`
+	Detail   string    `json:"detail,omitempty"`
+}
+
+// String formats the drift for logging
+func (d SchemaDrift) String() string {
+	s := fmt.Sprintf("%s: %s at %s", d.Endpoint, d.Kind, d.Path)
+	if d.Detail != "" {
+		s += " (" + d.Detail + ")"
+	}
+	return s
+}
+
+// DetectSchemaDrift compares a JSON document against the Go type of target (a value or pointer
+// to the struct the document is decoded into) and reports unknown keys, missing keys, and type
+// mismatches. Each path is reported once even if it occurs in many array elements. Fields
+// typed interface{} or json.RawMessage are not inspected.
+//
+// Parameters:
+//   - endpoint: Recorded on each SchemaDrift to say where the response came from
+//   - data: The raw JSON response
+//   - target: A value of the type the response decodes into (e.g. &LeagueInfo{})
+func DetectSchemaDrift(endpoint string, data []byte, target interface{}) ([]SchemaDrift, error) {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode response for schema check: %w", err)
+	}
+
+	d := &driftDetector{endpoint: endpoint, seen: make(map[string]bool)}
+	d.walk("$", doc, reflect.TypeOf(target))
+
+	sort.SliceStable(d.drifts, func(i, j int) bool {
+		return d.drifts[i].Path < d.drifts[j].Path
+	})
+	return d.drifts, nil
+}
+
+type driftDetector struct {
+	endpoint string
+	drifts   []SchemaDrift
+	seen     map[string]bool
+}
+
+var (
+	rawMessageType  = reflect.TypeOf(json.RawMessage{})
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+func (d *driftDetector) report(path string, kind DriftKind, detail string) {
+	key := string(kind) + "|" + path
+	if d.seen[key] {
+		return
+	}
+	d.seen[key] = true
+	d.drifts = append(d.drifts, SchemaDrift{Endpoint: d.endpoint, Path: path, Kind: kind, Detail: detail})
+}
+
+func (d *driftDetector) walk(path string, value interface{}, t reflect.Type) {
+	if t == nil {
+		return
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if value == nil || t.Kind() == reflect.Interface || t == rawMessageType {
+		return
+	}
+	// Types with custom decoding define their own shape
+	if reflect.PointerTo(t).Implements(unmarshalerType) {
+		return
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		switch t.Kind() {
+		case reflect.Struct:
+			d.walkStruct(path, v, t)
+		case reflect.Map:
+			for _, elem := range v {
+				d.walk(path+".{}", elem, t.Elem())
+			}
+		default:
+			d.report(path, DriftTypeMismatch, fmt.Sprintf("got object, want %s", t.Kind()))
+		}
+	case []interface{}:
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+			d.report(path, DriftTypeMismatch, fmt.Sprintf("got array, want %s", t.Kind()))
+			return
+		}
+		for _, elem := range v {
+			d.walk(path+"[]", elem, t.Elem())
+		}
+	case string:
+		if t.Kind() != reflect.String {
+			d.report(path, DriftTypeMismatch, fmt.Sprintf("got string, want %s", t.Kind()))
+		}
+	case float64:
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+		default:
+			d.report(path, DriftTypeMismatch, fmt.Sprintf("got number, want %s", t.Kind()))
+		}
+	case bool:
+		if t.Kind() != reflect.Bool {
+			d.report(path, DriftTypeMismatch, fmt.Sprintf("got bool, want %s", t.Kind()))
+		}
+	}
+}
+
+// driftField is a struct field as encoding/json sees it
+type driftField struct {
+	name      string
+	typ       reflect.Type
+	optional  bool
+	stringOpt bool
+}
+
+func (d *driftDetector) walkStruct(path string, obj map[string]interface{}, t reflect.Type) {
+	fields := jsonFields(t)
+
+	matched := make(map[string]bool, len(fields))
+	for key, value := range obj {
+		field, ok := matchField(fields, key)
+		if !ok {
+			d.report(path+"."+key, DriftUnknownField, "")
+			continue
+		}
+		matched[field.name] = true
+		if field.stringOpt {
+			// ",string" fields carry numbers and bools inside a JSON string
+			if _, isString := value.(string); !isString && value != nil {
+				d.report(path+"."+key, DriftTypeMismatch, "want quoted value")
+			}
+			continue
+		}
+		d.walk(path+"."+key, value, field.typ)
+	}
+
+	for _, field := range fields {
+		if !field.optional && !matched[field.name] {
+			d.report(path+"."+field.name, DriftMissingField, "")
+		}
+	}
+}
+
+// matchField finds the field for a JSON key, preferring an exact name and falling back to
+// the case-insensitive match encoding/json also accepts
+func matchField(fields []driftField, key string) (driftField, bool) {
+	for _, f := range fields {
+		if f.name == key {
+			return f, true
+		}
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.name, key) {
+			return f, true
+		}
+	}
+	return driftField{}, false
+}
+
+// jsonFields lists the JSON-visible fields of a struct type, flattening embedded structs
+func jsonFields(t reflect.Type) []driftField {
+	var fields []driftField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if sf.Anonymous && name == "" {
+			ft := sf.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				fields = append(fields, jsonFields(ft)...)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		fields = append(fields, driftField{
+			name:      name,
+			typ:       sf.Type,
+			optional:  strings.Contains(opts, "omitempty") || strings.Contains(opts, "omitzero"),
+			stringOpt: strings.Contains(opts, "string"),
+		})
+	}
+	return fields
+}
@@ -0,0 +1,34 @@
+package fantrax
+
+import "testing"
+
+func TestDetectSchemaDrift(t *testing.T) {
+	data := []byte(`{
+		"period": "7",
+		"rosters": {
+			"t1": {"teamName": "A", "rosterItems": [{"id": "p1", "position": "C", "status": "ACTIVE", "salary": 3}]},
+			"t2": {"rosterItems": [{"id": "p2", "position": "1B", "status": "ACTIVE"}]}
+		},
+		"lastUpdated": 1700000000
+	}`)
+
+	drifts, err := DetectSchemaDrift("/general/getTeamRosters", data, &LeagueRosters{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]DriftKind{
+		"$.lastUpdated":                     DriftUnknownField,
+		"$.period":                          DriftTypeMismatch,
+		"$.rosters.{}.rosterItems[].salary": DriftUnknownField,
+		"$.rosters.{}.teamName":             DriftMissingField,
+	}
+	if len(drifts) != len(want) {
+		t.Fatalf("got %d drifts, want %d: %v", len(drifts), len(want), drifts)
+	}
+	for _, d := range drifts {
+		if want[d.Path] != d.Kind {
+			t.Errorf("unexpected drift %s", d)
+		}
+	}
+}
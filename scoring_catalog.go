@@ -0,0 +1,176 @@
+package fantrax
+
+import (
+	"sort"
+	"strings"
+)
+
+// ScoringCategoryInfo describes one scoring category of a league along with its point values
+type ScoringCategoryInfo struct {
+	ID         string             `json:"id"`
+	Code       string             `json:"code"`
+	Name       string             `json:"name"`      // e.g. "Home Runs"
+	ShortName  string             `json:"shortName"` // e.g. "HR"
+	Group      string             `json:"group"`     // Group code, e.g. "HITTING"
+	GroupName  string             `json:"groupName"` // e.g. "Hitting"
+	Cumulative bool               `json:"cumulative"`
+	Points     map[string]float64 `json:"points,omitempty"` // Keyed by position short name; empty in category leagues
+}
+
+// PointsFor returns the points awarded for the category at a position, falling back to the
+// first configured value when the position has no specific setting
+func (s ScoringCategoryInfo) PointsFor(position string) (float64, bool) {
+	if points, ok := s.Points[position]; ok {
+		return points, true
+	}
+	if len(s.Points) == 0 {
+		return 0, false
+	}
+	positions := make([]string, 0, len(s.Points))
+	for pos := range s.Points {
+		positions = append(positions, pos)
+	}
+	sort.Strings(positions)
+	return s.Points[positions[0]], true
+}
+
+// ScoringCatalog is the typed list of a league's scoring categories
+type ScoringCatalog struct {
+	Categories []ScoringCategoryInfo
+}
+
+// ScoringCatalog builds the league's scoring category catalog from both the category list
+// and the per-position scoring settings. Categories are sorted by group, then short name.
+func (i *LeagueInfo) ScoringCatalog() *ScoringCatalog {
+	return i.ScoringSystem.Catalog()
+}
+
+// Catalog builds the scoring category catalog for the scoring system
+func (s ScoringSystem) Catalog() *ScoringCatalog {
+	byKey := make(map[string]*ScoringCategoryInfo)
+	var order []string
+
+	add := func(group, groupName string, category ScoringCategory, id string) *ScoringCategoryInfo {
+		if category.ID != "" {
+			id = category.ID
+		}
+		key := group + "/" + id
+		info, ok := byKey[key]
+		if !ok {
+			info = &ScoringCategoryInfo{ID: id, Group: group, GroupName: groupName}
+			byKey[key] = info
+			order = append(order, key)
+		}
+		if info.Code == "" {
+			info.Code = category.Code
+		}
+		if info.Name == "" {
+			info.Name = category.Name
+		}
+		if info.ShortName == "" {
+			info.ShortName = category.ShortName
+		}
+		if info.GroupName == "" {
+			info.GroupName = groupName
+		}
+		return info
+	}
+
+	for _, setting := range s.ScoringCategorySettings {
+		for _, config := range setting.Configs {
+			info := add(setting.Group.Code, setting.Group.Name, config.ScoringCategory, "")
+			info.Cumulative = config.Cumulative
+			if info.Points == nil {
+				info.Points = make(map[string]float64)
+			}
+			if _, seen := info.Points[config.Position.ShortName]; !seen {
+				info.Points[config.Position.ShortName] = config.Points
+			}
+		}
+	}
+	for id, category := range s.ScoringCategories.HITTING {
+		add("HITTING", "", category, id)
+	}
+	for id, category := range s.ScoringCategories.PITCHING {
+		add("PITCHING", "", category, id)
+	}
+
+	catalog := &ScoringCatalog{Categories: make([]ScoringCategoryInfo, 0, len(order))}
+	for _, key := range order {
+		catalog.Categories = append(catalog.Categories, *byKey[key])
+	}
+	sort.SliceStable(catalog.Categories, func(a, b int) bool {
+		ca, cb := catalog.Categories[a], catalog.Categories[b]
+		if ca.Group != cb.Group {
+			return ca.Group < cb.Group
+		}
+		return ca.ShortName < cb.ShortName
+	})
+	return catalog
+}
+
+// ByID returns the category with the given ID. When the same ID appears in more than one
+// group, the first match is returned.
+func (c *ScoringCatalog) ByID(id string) (ScoringCategoryInfo, bool) {
+	for _, category := range c.Categories {
+		if category.ID == id {
+			return category, true
+		}
+	}
+	return ScoringCategoryInfo{}, false
+}
+
+// ByShortName returns the category with the given short name (e.g. "HR") within a group.
+// Matching is case-insensitive; pass an empty group to search all groups.
+func (c *ScoringCatalog) ByShortName(group, shortName string) (ScoringCategoryInfo, bool) {
+	for _, category := range c.Categories {
+		if group != "" && category.Group != group {
+			continue
+		}
+		if strings.EqualFold(category.ShortName, shortName) {
+			return category, true
+		}
+	}
+	return ScoringCategoryInfo{}, false
+}
+
+// Lookup finds a category by ID, short name, or full name, in that order
+func (c *ScoringCatalog) Lookup(key string) (ScoringCategoryInfo, bool) {
+	if category, ok := c.ByID(key); ok {
+		return category, true
+	}
+	if category, ok := c.ByShortName("", key); ok {
+		return category, true
+	}
+	for _, category := range c.Categories {
+		if strings.EqualFold(category.Name, key) {
+			return category, true
+		}
+	}
+	return ScoringCategoryInfo{}, false
+}
+
+// InGroup returns the categories in a scoring group (e.g. "PITCHING")
+func (c *ScoringCatalog) InGroup(group string) []ScoringCategoryInfo {
+	var categories []ScoringCategoryInfo
+	for _, category := range c.Categories {
+		if category.Group == group {
+			categories = append(categories, category)
+		}
+	}
+	return categories
+}
+
+// Groups returns the sorted scoring group codes present in the catalog
+func (c *ScoringCatalog) Groups() []string {
+	seen := make(map[string]bool)
+	var groups []string
+	for _, category := range c.Categories {
+		if !seen[category.Group] {
+			seen[category.Group] = true
+			groups = append(groups, category.Group)
+		}
+	}
+	sort.Strings(groups)
+	return groups
+}
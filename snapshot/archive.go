@@ -0,0 +1,222 @@
+package snapshot
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pmurley/go-fantrax"
+	"github.com/pmurley/go-fantrax/auth_client"
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// ArchiveFormatVersion identifies the layout of Archive. It is bumped whenever a field is
+// renamed or removed so older bundles can be detected.
+const ArchiveFormatVersion = "1"
+
+// Archive is a whole season of a league: settings, every team's roster in each period, the
+// schedule with results, the final standings, and the full transaction log. It keeps
+// league history available offline even if the Fantrax league is deleted.
+type Archive struct {
+	FormatVersion string                                `json:"formatVersion"`
+	LeagueID      string                                `json:"leagueId"`
+	CreatedAt     time.Time                             `json:"createdAt"`
+	Settings      *fantrax.LeagueInfo                   `json:"settings"`
+	Standings     *auth_client.LeagueStandings          `json:"standings"`
+	Matchups      *auth_client.AllMatchupsResult        `json:"matchups"`
+	Teams         []models.FantasyTeam                  `json:"teams"`   // In display order
+	Rosters       map[int]map[string]*models.TeamRoster `json:"rosters"` // Period -> team ID -> roster
+	Transactions  []models.Transaction                  `json:"transactions"`
+}
+
+type archiveOptions struct {
+	firstPeriod, lastPeriod int
+	progress                func(period int)
+}
+
+// ArchiveOption configures ExportSeasonArchive
+type ArchiveOption func(*archiveOptions)
+
+// WithArchivePeriods limits the archived rosters to periods first through last. By default
+// every period in the schedule is archived.
+func WithArchivePeriods(first, last int) ArchiveOption {
+	return func(o *archiveOptions) {
+		o.firstPeriod, o.lastPeriod = first, last
+	}
+}
+
+// WithArchiveProgress is called before each period's rosters are fetched
+func WithArchiveProgress(progress func(period int)) ArchiveOption {
+	return func(o *archiveOptions) {
+		o.progress = progress
+	}
+}
+
+// BuildSeasonArchive fetches a season of the client's league. Every team's roster is
+// fetched for every period, so an archive costs one request per team per period plus a few
+// more.
+//
+// Parameters:
+//   - client: An authenticated client for the league
+//   - opts: Optional WithArchivePeriods and WithArchiveProgress
+func BuildSeasonArchive(client *auth_client.Client, opts ...ArchiveOption) (*Archive, error) {
+	options := &archiveOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	archive := &Archive{
+		FormatVersion: ArchiveFormatVersion,
+		LeagueID:      client.LeagueID,
+		CreatedAt:     time.Now(),
+		Rosters:       make(map[int]map[string]*models.TeamRoster),
+	}
+
+	publicClient, err := fantrax.NewClient(client.LeagueID, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create public client: %w", err)
+	}
+	if archive.Settings, err = publicClient.GetLeagueInfo(client.LeagueID); err != nil {
+		return nil, fmt.Errorf("failed to get league settings: %w", err)
+	}
+	if archive.Standings, err = client.GetStandings(); err != nil {
+		return nil, fmt.Errorf("failed to get standings: %w", err)
+	}
+	if archive.Matchups, err = client.GetAllMatchups(); err != nil {
+		return nil, fmt.Errorf("failed to get matchups: %w", err)
+	}
+	if archive.Transactions, err = client.GetAllTransactionsIncludingTrades(); err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+
+	first, last := options.firstPeriod, options.lastPeriod
+	if first <= 0 {
+		first = 1
+	}
+	if last <= 0 {
+		for _, m := range archive.Matchups.Matchups {
+			if m.ScoringPeriod > last {
+				last = m.ScoringPeriod
+			}
+		}
+	}
+	for period := first; period <= last; period++ {
+		if options.progress != nil {
+			options.progress(period)
+		}
+		rosters, teams, err := client.GetAllTeamRosters(strconv.Itoa(period))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get rosters for period %d: %w", period, err)
+		}
+		archive.Rosters[period] = rosters
+		archive.Teams = teams
+	}
+	return archive, nil
+}
+
+// ExportSeasonArchive builds a season archive of the client's league and writes it to path
+// as gzip-compressed JSON
+//
+// Parameters:
+//   - client: An authenticated client for the league
+//   - path: The bundle file to write
+//   - opts: Optional WithArchivePeriods and WithArchiveProgress
+func ExportSeasonArchive(client *auth_client.Client, path string, opts ...ArchiveOption) (*Archive, error) {
+	archive, err := BuildSeasonArchive(client, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := archive.Save(path); err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
+
+// ImportSeasonArchive reads a bundle written by ExportSeasonArchive or Archive.Save
+func ImportSeasonArchive(path string) (*Archive, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open season archive: %w", err)
+	}
+	defer f.Close()
+	return ReadArchive(f)
+}
+
+// Save writes the archive to path as gzip-compressed JSON
+func (a *Archive) Save(path string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to create season archive: %w", err)
+	}
+	if err := a.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write season archive: %w", err)
+	}
+	return nil
+}
+
+// Write writes the archive to w as gzip-compressed JSON
+func (a *Archive) Write(w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	if err := json.NewEncoder(gz).Encode(a); err != nil {
+		return fmt.Errorf("failed to encode season archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to compress season archive: %w", err)
+	}
+	return nil
+}
+
+// ReadArchive reads a gzip-compressed archive from r
+func ReadArchive(r io.Reader) (*Archive, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress season archive: %w", err)
+	}
+	defer gz.Close()
+
+	var archive Archive
+	if err := json.NewDecoder(gz).Decode(&archive); err != nil {
+		return nil, fmt.Errorf("failed to parse season archive: %w", err)
+	}
+	if archive.FormatVersion != ArchiveFormatVersion {
+		return nil, fmt.Errorf("unsupported season archive format %q (expected %q)", archive.FormatVersion, ArchiveFormatVersion)
+	}
+	return &archive, nil
+}
+
+// Periods returns the archived roster periods in order
+func (a *Archive) Periods() []int {
+	periods := make([]int, 0, len(a.Rosters))
+	for period := range a.Rosters {
+		periods = append(periods, period)
+	}
+	sort.Ints(periods)
+	return periods
+}
+
+// Snapshot returns the archive as a Snapshot with the rosters of one period, so the
+// snapshot queries can run against archived data
+func (a *Archive) Snapshot(period int) (*Snapshot, error) {
+	rosters, ok := a.Rosters[period]
+	if !ok {
+		return nil, fmt.Errorf("period %d is not in the archive", period)
+	}
+	return &Snapshot{
+		LeagueID:     a.LeagueID,
+		TakenAt:      a.CreatedAt,
+		Standings:    a.Standings,
+		Rosters:      rosters,
+		Teams:        a.Teams,
+		Matchups:     a.Matchups,
+		Transactions: a.Transactions,
+	}, nil
+}
@@ -0,0 +1,262 @@
+package snapshot
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pmurley/go-fantrax/auth_client"
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// Query is a lazily filtered, sorted, and limited list of rows. Each method returns a new
+// query and leaves the receiver unchanged.
+type Query[T any] struct {
+	rows    []T
+	filters []func(T) bool
+	less    func(a, b T) bool
+	limit   int
+}
+
+// From starts a query over rows
+func From[T any](rows []T) *Query[T] {
+	return &Query[T]{rows: rows}
+}
+
+func (q *Query[T]) clone() *Query[T] {
+	c := *q
+	c.filters = append([]func(T) bool(nil), q.filters...)
+	return &c
+}
+
+// Where keeps rows for which every predicate returns true
+func (q *Query[T]) Where(predicates ...func(T) bool) *Query[T] {
+	c := q.clone()
+	c.filters = append(c.filters, predicates...)
+	return c
+}
+
+// OrderBy sorts the rows; ties keep their original order
+func (q *Query[T]) OrderBy(less func(a, b T) bool) *Query[T] {
+	c := q.clone()
+	c.less = less
+	return c
+}
+
+// Limit keeps at most n rows after sorting (0 = no limit)
+func (q *Query[T]) Limit(n int) *Query[T] {
+	c := q.clone()
+	c.limit = n
+	return c
+}
+
+// All runs the query
+func (q *Query[T]) All() []T {
+	var rows []T
+rows:
+	for _, row := range q.rows {
+		for _, keep := range q.filters {
+			if !keep(row) {
+				continue rows
+			}
+		}
+		rows = append(rows, row)
+	}
+	if q.less != nil {
+		sort.SliceStable(rows, func(i, j int) bool { return q.less(rows[i], rows[j]) })
+	}
+	if q.limit > 0 && len(rows) > q.limit {
+		rows = rows[:q.limit]
+	}
+	return rows
+}
+
+// First returns the first row, if any
+func (q *Query[T]) First() (T, bool) {
+	rows := q.Limit(1).All()
+	if len(rows) == 0 {
+		var zero T
+		return zero, false
+	}
+	return rows[0], true
+}
+
+// Count returns the number of rows the query yields
+func (q *Query[T]) Count() int {
+	return len(q.All())
+}
+
+// Select runs a query and maps each row to another type
+func Select[T, U any](q *Query[T], f func(T) U) []U {
+	rows := q.All()
+	out := make([]U, len(rows))
+	for i, row := range rows {
+		out[i] = f(row)
+	}
+	return out
+}
+
+// Roster groups reported in PlayerRow.Group
+const (
+	GroupActive  = "Active"
+	GroupReserve = "Reserve"
+	GroupIR      = "IR"
+	GroupMinors  = "Minors"
+)
+
+// PlayerRow is a rostered player joined with their fantasy team
+type PlayerRow struct {
+	Player   models.RosterPlayer       `json:"player"`
+	Group    string                    `json:"group"` // Roster group, e.g. GroupActive
+	TeamID   string                    `json:"teamId"`
+	TeamName string                    `json:"teamName"`
+	Standing *auth_client.TeamStanding `json:"standing,omitempty"` // Nil if the team is missing from the standings
+}
+
+// TeamRow is a fantasy team joined with its standing, roster, and matchups
+type TeamRow struct {
+	TeamID   string                    `json:"teamId"`
+	Name     string                    `json:"name"`
+	Standing *auth_client.TeamStanding `json:"standing,omitempty"`
+	Roster   *models.TeamRoster        `json:"roster,omitempty"`
+	Matchups []auth_client.Matchup     `json:"matchups,omitempty"` // Every matchup the team plays in
+}
+
+// QueryPlayers queries every rostered player in the league
+func (s *Snapshot) QueryPlayers() *Query[PlayerRow] {
+	teamIDs := make([]string, 0, len(s.Rosters))
+	for id := range s.Rosters {
+		teamIDs = append(teamIDs, id)
+	}
+	sort.Strings(teamIDs)
+
+	var rows []PlayerRow
+	for _, teamID := range teamIDs {
+		roster := s.Rosters[teamID]
+		groups := []struct {
+			name    string
+			players []models.RosterPlayer
+		}{
+			{GroupActive, roster.ActiveRoster},
+			{GroupReserve, roster.ReserveRoster},
+			{GroupIR, roster.InjuredReserve},
+			{GroupMinors, roster.MinorsRoster},
+		}
+		for _, group := range groups {
+			for _, player := range group.players {
+				rows = append(rows, PlayerRow{
+					Player:   player,
+					Group:    group.name,
+					TeamID:   teamID,
+					TeamName: s.teamName(teamID),
+					Standing: s.standing(teamID),
+				})
+			}
+		}
+	}
+	return From(rows)
+}
+
+// QueryTeams queries the league's fantasy teams
+func (s *Snapshot) QueryTeams() *Query[TeamRow] {
+	ids := make([]string, 0, len(s.Teams))
+	seen := make(map[string]bool)
+	for _, team := range s.Teams {
+		ids = append(ids, team.ID)
+		seen[team.ID] = true
+	}
+	if s.Standings != nil {
+		for _, standing := range s.Standings.Teams {
+			if !seen[standing.TeamID] {
+				ids = append(ids, standing.TeamID)
+				seen[standing.TeamID] = true
+			}
+		}
+	}
+
+	rows := make([]TeamRow, 0, len(ids))
+	for _, id := range ids {
+		row := TeamRow{
+			TeamID:   id,
+			Name:     s.teamName(id),
+			Standing: s.standing(id),
+			Roster:   s.Rosters[id],
+		}
+		if s.Matchups != nil {
+			for _, m := range s.Matchups.Matchups {
+				if m.AwayTeam.TeamID == id || m.HomeTeam.TeamID == id {
+					row.Matchups = append(row.Matchups, m)
+				}
+			}
+		}
+		rows = append(rows, row)
+	}
+	return From(rows)
+}
+
+// QueryTransactions queries the captured transactions
+func (s *Snapshot) QueryTransactions() *Query[models.Transaction] {
+	return From(s.Transactions)
+}
+
+// AgeUnder keeps players younger than age. Players whose age is unknown are dropped.
+func AgeUnder(age int) func(PlayerRow) bool {
+	return func(p PlayerRow) bool {
+		return p.Player.Age > 0 && p.Player.Age < age
+	}
+}
+
+// AtPosition keeps players eligible at a position (e.g. "SS")
+func AtPosition(position string) func(PlayerRow) bool {
+	return func(p PlayerRow) bool {
+		for _, pos := range p.Player.Positions {
+			if strings.EqualFold(pos, position) {
+				return true
+			}
+		}
+		return strings.EqualFold(p.Player.PrimaryPosition, position)
+	}
+}
+
+// OnTeam keeps players on a fantasy team, matched by team ID or case-insensitive name
+func OnTeam(team string) func(PlayerRow) bool {
+	return func(p PlayerRow) bool {
+		return p.TeamID == team || strings.EqualFold(p.TeamName, team)
+	}
+}
+
+// InGroup keeps players in a roster group (e.g. GroupMinors)
+func InGroup(group string) func(PlayerRow) bool {
+	return func(p PlayerRow) bool {
+		return strings.EqualFold(p.Group, group)
+	}
+}
+
+// TeamRankAtMost keeps players whose fantasy team is ranked rank or better
+func TeamRankAtMost(rank int) func(PlayerRow) bool {
+	return func(p PlayerRow) bool {
+		return p.Standing != nil && p.Standing.Rank > 0 && p.Standing.Rank <= rank
+	}
+}
+
+// ByAge orders players youngest first
+func ByAge(a, b PlayerRow) bool {
+	return a.Player.Age < b.Player.Age
+}
+
+// ByTeamRank orders players by their fantasy team's rank, best first
+func ByTeamRank(a, b PlayerRow) bool {
+	return rankOf(a.Standing) < rankOf(b.Standing)
+}
+
+// ByRank orders teams by rank, best first
+func ByRank(a, b TeamRow) bool {
+	return rankOf(a.Standing) < rankOf(b.Standing)
+}
+
+// rankOf sorts unranked teams last
+func rankOf(standing *auth_client.TeamStanding) int {
+	if standing == nil || standing.Rank <= 0 {
+		return int(^uint(0) >> 1)
+	}
+	return standing.Rank
+}
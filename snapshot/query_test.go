@@ -0,0 +1,111 @@
+package snapshot
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pmurley/go-fantrax/auth_client"
+	"github.com/pmurley/go-fantrax/models"
+)
+
+func testSnapshot() *Snapshot {
+	return &Snapshot{
+		Standings: &auth_client.LeagueStandings{Teams: []auth_client.TeamStanding{
+			{TeamID: "t1", Name: "Aces", Rank: 2},
+			{TeamID: "t2", Name: "Bats", Rank: 1},
+		}},
+		Teams: []models.FantasyTeam{{ID: "t1", Name: "Aces"}, {ID: "t2", Name: "Bats"}},
+		Rosters: map[string]*models.TeamRoster{
+			"t1": {
+				ActiveRoster: []models.RosterPlayer{{Name: "Young Star", Age: 21, Positions: []string{"SS"}}},
+				MinorsRoster: []models.RosterPlayer{{Name: "Prospect", Age: 19, Positions: []string{"OF"}}},
+			},
+			"t2": {
+				ActiveRoster:  []models.RosterPlayer{{Name: "Veteran", Age: 34, Positions: []string{"SS", "2B"}}},
+				ReserveRoster: []models.RosterPlayer{{Name: "Rookie", Age: 22, Positions: []string{"C"}}},
+			},
+		},
+		Matchups: &auth_client.AllMatchupsResult{Matchups: []auth_client.Matchup{
+			{ScoringPeriod: 1, AwayTeam: auth_client.MatchTeam{TeamID: "t1"}, HomeTeam: auth_client.MatchTeam{TeamID: "t2"}},
+		}},
+	}
+}
+
+func TestPlayersQuery(t *testing.T) {
+	snap := testSnapshot()
+
+	young := snap.QueryPlayers().Where(AgeUnder(23)).OrderBy(ByAge).All()
+	if len(young) != 3 {
+		t.Fatalf("got %d players under 23, want 3", len(young))
+	}
+	if young[0].Player.Name != "Prospect" || young[0].Group != GroupMinors || young[0].TeamName != "Aces" {
+		t.Errorf("youngest = %+v", young[0])
+	}
+	if young[0].Standing == nil || young[0].Standing.Rank != 2 {
+		t.Errorf("youngest player's team standing = %+v", young[0].Standing)
+	}
+
+	best, ok := snap.QueryPlayers().Where(AtPosition("ss")).OrderBy(ByTeamRank).First()
+	if !ok || best.Player.Name != "Veteran" {
+		t.Errorf("shortstop on the best team = %+v, %v", best, ok)
+	}
+
+	if n := snap.QueryPlayers().Where(OnTeam("bats"), InGroup(GroupReserve)).Count(); n != 1 {
+		t.Errorf("Bats reserves = %d, want 1", n)
+	}
+
+	names := Select(snap.QueryTeams().OrderBy(ByRank), func(team TeamRow) string { return team.Name })
+	if len(names) != 2 || names[0] != "Bats" {
+		t.Errorf("teams by rank = %v", names)
+	}
+	if team, _ := snap.QueryTeams().First(); len(team.Matchups) != 1 {
+		t.Errorf("team matchups = %v", team.Matchups)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	if err := testSnapshot().Save(path); err != nil {
+		t.Fatal(err)
+	}
+	snap, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := snap.QueryPlayers().Count(); n != 4 {
+		t.Errorf("loaded snapshot has %d players, want 4", n)
+	}
+}
+
+func TestSeasonArchiveRoundTrip(t *testing.T) {
+	snap := testSnapshot()
+	archive := &Archive{
+		FormatVersion: ArchiveFormatVersion,
+		Standings:     snap.Standings,
+		Teams:         snap.Teams,
+		Matchups:      snap.Matchups,
+		Rosters:       map[int]map[string]*models.TeamRoster{2: snap.Rosters, 1: {"t1": snap.Rosters["t1"]}},
+	}
+	path := filepath.Join(t.TempDir(), "season.json.gz")
+	if err := archive.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := ImportSeasonArchive(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if periods := loaded.Periods(); len(periods) != 2 || periods[0] != 1 || periods[1] != 2 {
+		t.Errorf("periods = %v", periods)
+	}
+	period2, err := loaded.Snapshot(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := period2.QueryPlayers().Count(); n != 4 {
+		t.Errorf("period 2 has %d players, want 4", n)
+	}
+	if _, err := loaded.Snapshot(3); err == nil {
+		t.Error("expected an error for a period that wasn't archived")
+	}
+}
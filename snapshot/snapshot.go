@@ -0,0 +1,138 @@
+// Package snapshot captures a league's standings, rosters, matchups, and transactions at one
+// point in time and answers typed queries that join them, such as every rostered player
+// under 23 together with their fantasy team's standing:
+//
+//	snap, err := snapshot.Take(client)
+//	young := snap.QueryPlayers().Where(snapshot.AgeUnder(23)).OrderBy(snapshot.ByAge).All()
+//	for _, p := range young {
+//		fmt.Println(p.Player.Name, p.Player.Age, p.TeamName, p.Standing.Rank)
+//	}
+//
+// Snapshots can be saved to and loaded from JSON, so queries can run offline. A whole season,
+// with every period's rosters, can be bundled with ExportSeasonArchive and read back with
+// ImportSeasonArchive.
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/pmurley/go-fantrax/auth_client"
+	"github.com/pmurley/go-fantrax/models"
+)
+
+// Snapshot is a league's data as of TakenAt
+type Snapshot struct {
+	LeagueID     string                         `json:"leagueId"`
+	TakenAt      time.Time                      `json:"takenAt"`
+	Standings    *auth_client.LeagueStandings   `json:"standings"`
+	Rosters      map[string]*models.TeamRoster  `json:"rosters"` // Keyed by team ID
+	Teams        []models.FantasyTeam           `json:"teams"`   // In display order
+	Matchups     *auth_client.AllMatchupsResult `json:"matchups"`
+	Transactions []models.Transaction           `json:"transactions"`
+}
+
+type takeOptions struct {
+	transactions int
+	period       string
+}
+
+// TakeOption configures Take
+type TakeOption func(*takeOptions)
+
+// WithTransactionLimit sets how many recent transactions are captured (default 100)
+func WithTransactionLimit(n int) TakeOption {
+	return func(o *takeOptions) {
+		o.transactions = n
+	}
+}
+
+// WithRosterPeriod captures rosters for a specific period instead of the current one
+func WithRosterPeriod(period int) TakeOption {
+	return func(o *takeOptions) {
+		o.period = strconv.Itoa(period)
+	}
+}
+
+// Take fetches a snapshot of the client's league. Every team's roster is fetched in turn, so
+// a snapshot costs one request per team plus a few more.
+//
+// Parameters:
+//   - client: An authenticated client for the league
+//   - opts: Optional WithTransactionLimit and WithRosterPeriod
+func Take(client *auth_client.Client, opts ...TakeOption) (*Snapshot, error) {
+	options := &takeOptions{transactions: 100}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	snap := &Snapshot{LeagueID: client.LeagueID, TakenAt: time.Now()}
+	var err error
+	if snap.Standings, err = client.GetStandings(); err != nil {
+		return nil, fmt.Errorf("failed to get standings: %w", err)
+	}
+	if snap.Rosters, snap.Teams, err = client.GetAllTeamRosters(options.period); err != nil {
+		return nil, fmt.Errorf("failed to get rosters: %w", err)
+	}
+	if snap.Matchups, err = client.GetAllMatchups(); err != nil {
+		return nil, fmt.Errorf("failed to get matchups: %w", err)
+	}
+	if snap.Transactions, err = client.GetTransactionHistory(strconv.Itoa(options.transactions)); err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	return snap, nil
+}
+
+// Save writes the snapshot to a JSON file
+func (s *Snapshot) Save(path string) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// Load reads a snapshot written by Save
+func Load(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot: %w", err)
+	}
+	return &snap, nil
+}
+
+// standing returns the standings entry for a team, or nil
+func (s *Snapshot) standing(teamID string) *auth_client.TeamStanding {
+	if s.Standings == nil {
+		return nil
+	}
+	for i := range s.Standings.Teams {
+		if s.Standings.Teams[i].TeamID == teamID {
+			return &s.Standings.Teams[i]
+		}
+	}
+	return nil
+}
+
+// teamName returns a team's name from the team list or standings, falling back to its ID
+func (s *Snapshot) teamName(teamID string) string {
+	for _, team := range s.Teams {
+		if team.ID == teamID {
+			return team.Name
+		}
+	}
+	if standing := s.standing(teamID); standing != nil {
+		return standing.Name
+	}
+	return teamID
+}
@@ -1,6 +1,9 @@
 package fantrax
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+)
 
 // LeagueRosters represents the top-level response from the team rosters endpoint
 type LeagueRosters struct {
@@ -44,8 +47,8 @@ func WithPeriod(period int) TeamRosterOption {
 	}
 }
 
-// GetTeamRosters gets all team rosters for a specific league and period
-func (c *Client) GetTeamRosters(opts ...TeamRosterOption) (*LeagueRosters, error) {
+// GetTeamRostersRaw fetches the getTeamRosters response without parsing it
+func (c *Client) GetTeamRostersRaw(opts ...TeamRosterOption) (json.RawMessage, error) {
 	endpoint := "/general/getTeamRosters"
 	params := map[string]string{"leagueId": c.LeagueId}
 
@@ -59,11 +62,108 @@ func (c *Client) GetTeamRosters(opts ...TeamRosterOption) (*LeagueRosters, error
 		params["period"] = fmt.Sprintf("%d", teamRosterOptions.period)
 	}
 
-	var results LeagueRosters
-	err := c.fetchWithCache(endpoint, params, &results)
+	var raw json.RawMessage
+	err := c.fetchWithCache(endpoint, params, &raw)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get team rosters: %w", err)
 	}
 
+	return raw, nil
+}
+
+// GetTeamRosters gets all team rosters for a specific league and period
+func (c *Client) GetTeamRosters(opts ...TeamRosterOption) (*LeagueRosters, error) {
+	raw, err := c.GetTeamRostersRaw(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var results LeagueRosters
+	if err := c.decode("/general/getTeamRosters", raw, &results); err != nil {
+		return nil, fmt.Errorf("failed to parse team rosters: %w", err)
+	}
+
 	return &results, nil
 }
+
+// DetailedLeagueRosters is LeagueRosters with each roster entry joined to its player record
+type DetailedLeagueRosters struct {
+	Period  int                           `json:"period"`
+	Rosters map[string]DetailedTeamRoster `json:"rosters"` // Keyed by team ID
+}
+
+// DetailedTeamRoster is one team's roster with player details
+type DetailedTeamRoster struct {
+	TeamID   string               `json:"teamId"`
+	TeamName string               `json:"teamName"`
+	Players  []DetailedRosterItem `json:"players"`
+}
+
+// DetailedRosterItem is a roster entry with the player's name and team from GetPlayerIds.
+// The public API does not expose player ages.
+type DetailedRosterItem struct {
+	RosterItem
+	Name           string  `json:"name"`
+	Team           string  `json:"team"`           // Professional team abbreviation
+	PlayerPosition string  `json:"playerPosition"` // The player's listed position(s), as opposed to the roster slot
+	RotowireId     *int    `json:"rotowireId,omitempty"`
+	SportRadarId   *string `json:"sportRadarId,omitempty"`
+	Found          bool    `json:"found"` // False if the player ID was missing from the ID map
+}
+
+// GetTeamRostersDetailed gets all team rosters with player names, teams, and positions joined
+// from the sport's player ID map, saving callers the GetPlayerIds lookup.
+//
+// Parameters:
+//   - sport: The league's sport, used to fetch the player ID map
+//   - opts: Optional WithPeriod to fetch rosters for a specific period
+func (c *Client) GetTeamRostersDetailed(sport Sport, opts ...TeamRosterOption) (*DetailedLeagueRosters, error) {
+	rosters, err := c.GetTeamRosters(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use the unfiltered map so every roster entry can be matched
+	players, err := c.allPlayerIds(sport)
+	if err != nil {
+		return nil, err
+	}
+
+	return JoinRosterPlayers(rosters, players), nil
+}
+
+// JoinRosterPlayers joins roster entries to player records by Fantrax ID. Entries with no
+// matching player have Found set to false.
+//
+// Parameters:
+//   - rosters: Rosters from GetTeamRosters
+//   - players: Player records keyed by Fantrax ID, as from GetPlayerIds
+func JoinRosterPlayers(rosters *LeagueRosters, players map[string]Player) *DetailedLeagueRosters {
+	detailed := &DetailedLeagueRosters{
+		Period:  rosters.Period,
+		Rosters: make(map[string]DetailedTeamRoster, len(rosters.Rosters)),
+	}
+
+	for teamID, roster := range rosters.Rosters {
+		team := DetailedTeamRoster{
+			TeamID:   teamID,
+			TeamName: roster.TeamName,
+			Players:  make([]DetailedRosterItem, 0, len(roster.RosterItems)),
+		}
+		for _, item := range roster.RosterItems {
+			entry := DetailedRosterItem{RosterItem: item}
+			if player, ok := players[item.ID]; ok {
+				entry.Name = player.Name
+				entry.Team = player.Team
+				entry.PlayerPosition = player.Position
+				entry.RotowireId = player.RotowireId
+				entry.SportRadarId = player.SportRadarId
+				entry.Found = true
+			}
+			team.Players = append(team.Players, entry)
+		}
+		detailed.Rosters[teamID] = team
+	}
+
+	return detailed
+}
@@ -0,0 +1,53 @@
+package fantrax
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Connection pool and timeout settings of the transport from NewTransport
+const (
+	// MaxIdleConnsPerHost keeps enough idle connections to fantrax.com for concurrent page
+	// fetches (see auth_client.WithConcurrency) to reuse them
+	MaxIdleConnsPerHost = 16
+
+	// ResponseHeaderTimeout bounds the wait for Fantrax to start answering. Large player pool
+	// pages can take several seconds to build, so it is generous.
+	ResponseHeaderTimeout = 60 * time.Second
+)
+
+var (
+	sharedTransport     *http.Transport
+	sharedTransportOnce sync.Once
+)
+
+// NewTransport returns an HTTP transport tuned for Fantrax: keep-alives and HTTP/2 so requests
+// reuse connections, an idle pool sized for concurrent fetches, and timeouts for dialing, the
+// TLS handshake, and the response headers so a stalled connection fails instead of hanging
+func NewTransport() *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   10 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   MaxIdleConnsPerHost,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: ResponseHeaderTimeout,
+		ExpectContinueTimeout: time.Second,
+	}
+}
+
+// SharedTransport returns the transport shared by every client that doesn't set its own, so
+// the public and authenticated clients draw on one connection pool
+func SharedTransport() *http.Transport {
+	sharedTransportOnce.Do(func() {
+		sharedTransport = NewTransport()
+	})
+	return sharedTransport
+}
@@ -0,0 +1,37 @@
+package fantrax
+
+import "strings"
+
+// DefaultUserAgent is the User-Agent sent to Fantrax by both clients and the browser used to
+// log in, unless a client sets its own
+const DefaultUserAgent = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36"
+
+// UserAgent builds the User-Agent header for a client
+//
+// Parameters:
+//   - userAgent: The base User-Agent; DefaultUserAgent when empty
+//   - appID: An identifier for the tool making requests (e.g. "league-bot/1.2"), appended as
+//     a product token so Fantrax can tell the tool's traffic apart; may be empty
+func UserAgent(userAgent, appID string) string {
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
+	if appID = strings.TrimSpace(appID); appID != "" {
+		userAgent += " " + appID
+	}
+	return userAgent
+}
+
+// WithUserAgent replaces the User-Agent sent with every request
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		c.UserAgent = userAgent
+	}
+}
+
+// WithAppID identifies the tool making requests by appending appID to the User-Agent
+func WithAppID(appID string) ClientOption {
+	return func(c *Client) {
+		c.AppID = appID
+	}
+}
@@ -0,0 +1,33 @@
+package fantrax
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUserAgentHeader(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("User-Agent")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClient("league", false, WithAppID("league-bot/1.2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.BaseURL = server.URL
+	var result map[string]interface{}
+	if err := c.makeRequest("/general/getLeagueInfo", nil, &result); err != nil {
+		t.Fatal(err)
+	}
+	if want := DefaultUserAgent + " league-bot/1.2"; got != want {
+		t.Errorf("User-Agent = %q, want %q", got, want)
+	}
+
+	if ua := UserAgent("my-tool/0.1", ""); ua != "my-tool/0.1" {
+		t.Errorf("custom User-Agent = %q", ua)
+	}
+}